@@ -0,0 +1,153 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"strconv"
+	"strings"
+)
+
+// Aria2cOptions configures aria2c as the external downloader, see
+// [Command.UseAria2c].
+type Aria2cOptions struct {
+	// Executable is the name or path of the aria2c executable. Defaults to
+	// "aria2c" (resolved by yt-dlp from PATH). See [InstallAria2c].
+	Executable string
+
+	// Protocols are the protocols to use aria2c for (e.g. "http", "ftp", "dash",
+	// "m3u8"). If empty, aria2c is used for all protocols it supports.
+	Protocols []string
+
+	// Connections is the maximum number of connections per server (-x). 0 uses
+	// the aria2c default.
+	Connections int
+
+	// Split is the number of connections used to download each file (-s). 0
+	// uses the aria2c default.
+	Split int
+
+	// MinSplitSize is the minimum size of each split (-k), e.g. 1*[Mebibyte]. 0
+	// uses the aria2c default.
+	MinSplitSize ByteSize
+
+	// MaxTries is the number of tries for each download (-m). 0 uses the aria2c
+	// default.
+	MaxTries int
+
+	// Args are additional arguments passed to aria2c.
+	Args []string
+}
+
+// args returns the arguments passed to aria2c.
+func (o *Aria2cOptions) args() []string {
+	var args []string
+
+	if o.Connections > 0 {
+		args = append(args, "-x", strconv.Itoa(o.Connections))
+	}
+
+	if o.Split > 0 {
+		args = append(args, "-s", strconv.Itoa(o.Split))
+	}
+
+	if o.MinSplitSize > 0 {
+		args = append(args, "-k", aria2cSize(o.MinSplitSize))
+	}
+
+	if o.MaxTries > 0 {
+		args = append(args, "-m", strconv.Itoa(o.MaxTries))
+	}
+
+	return append(args, o.Args...)
+}
+
+// aria2cSize formats size for aria2c, which only accepts "K" and "M" suffixes.
+func aria2cSize(size ByteSize) string {
+	switch {
+	case size%Mebibyte == 0:
+		return strconv.FormatInt(int64(size/Mebibyte), 10) + "M"
+	case size%Kibibyte == 0:
+		return strconv.FormatInt(int64(size/Kibibyte), 10) + "K"
+	default:
+		return strconv.FormatInt(int64(size), 10)
+	}
+}
+
+// DownloaderArgs returns the value for [Command.DownloaderArgs], which passes
+// args to the named external downloader, quoting each argument as necessary.
+func DownloaderArgs(name string, args ...string) string {
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = shellQuote(arg)
+	}
+
+	return name + ":" + strings.Join(quoted, " ")
+}
+
+// UseAria2c uses aria2c as the external downloader (see [Command.Downloader]),
+// which can significantly improve throughput, especially for fragmented formats.
+// Any previously set downloaders and downloader args are replaced. opts may be
+// nil to use the defaults.
+//   - See [Command.UnsetDownloader] and [Command.UnsetDownloaderArgs], for
+//     unsetting the flags.
+func (c *Command) UseAria2c(opts *Aria2cOptions) *Command {
+	if opts == nil {
+		opts = &Aria2cOptions{}
+	}
+
+	exe := opts.Executable
+	if exe == "" {
+		exe = "aria2c"
+	}
+
+	c.UnsetDownloader().UnsetDownloaderArgs()
+
+	if len(opts.Protocols) == 0 {
+		c.Downloader(exe)
+	} else {
+		c.Downloader(strings.Join(opts.Protocols, ",") + ":" + exe)
+	}
+
+	if args := opts.args(); len(args) > 0 {
+		c.DownloaderArgs(DownloaderArgs("aria2c", args...))
+	}
+
+	return c
+}
+
+// aria2cTool is the default install spec for aria2c, used by [InstallAria2c]
+// unless a tool named "aria2c" is registered with [RegisterTool]. Official aria2c
+// builds are only published for Windows, as zip archives.
+var aria2cTool = &registeredTool{
+	name: "aria2c",
+	spec: InstallSpec{
+		Version:    "1.37.0",
+		Executable: "aria2c",
+		URLs: map[string]string{
+			"windows_386":   "https://github.com/aria2/aria2/releases/download/release-1.37.0/aria2-1.37.0-win-32bit-build1.zip",
+			"windows_amd64": "https://github.com/aria2/aria2/releases/download/release-1.37.0/aria2-1.37.0-win-64bit-build1.zip",
+		},
+		ArchivePaths: map[string]string{
+			"windows_386":   "aria2-1.37.0-win-32bit-build1/aria2c.exe",
+			"windows_amd64": "aria2-1.37.0-win-64bit-build1/aria2c.exe",
+		},
+	},
+}
+
+// InstallAria2c resolves aria2c from the go-ytdlp cache or PATH, and if not found,
+// installs the official aria2c build for the current platform (see
+// [InstallTool]). Official builds are only available for Windows, so on other
+// platforms, [ErrUnsupportedPlatform] is returned if aria2c isn't on PATH (e.g.
+// installed with the system package manager). If a tool named "aria2c" is
+// registered with [RegisterTool], it's used instead. The resolved executable
+// can be used with [Aria2cOptions.Executable].
+func InstallAria2c(ctx context.Context, opts *InstallOptions) (*ResolvedInstall, error) {
+	if _, err := getTool("aria2c"); err == nil {
+		return InstallTool(ctx, "aria2c", opts)
+	}
+
+	return aria2cTool.install(ctx, opts)
+}
@@ -0,0 +1,80 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"runtime"
+	"slices"
+	"testing"
+)
+
+func TestCommand_UseAria2c(t *testing.T) {
+	t.Parallel()
+
+	cmd := New().Downloader("curl").UseAria2c(&Aria2cOptions{
+		Executable:   "/opt/aria2/aria2c",
+		Protocols:    []string{"http", "dash"},
+		Connections:  16,
+		Split:        8,
+		MinSplitSize: Mebibyte,
+		Args:         []string{"--console-log-level=warn", "--header=X-Test: a b"},
+	})
+
+	var args []string
+	for _, f := range cmd.flags {
+		args = append(args, f.Raw()...)
+	}
+
+	want := []string{
+		"--downloader", "http,dash:/opt/aria2/aria2c",
+		"--downloader-args", "aria2c:-x 16 -s 8 -k 1M --console-log-level=warn '--header=X-Test: a b'",
+	}
+
+	if !slices.Equal(args, want) {
+		t.Fatalf("expected args %q, got %q", want, args)
+	}
+
+	args = nil
+	for _, f := range New().UseAria2c(nil).flags {
+		args = append(args, f.Raw()...)
+	}
+
+	if !slices.Equal(args, []string{"--downloader", "aria2c"}) {
+		t.Fatalf("unexpected default args: %q", args)
+	}
+}
+
+func TestAria2cSize(t *testing.T) {
+	t.Parallel()
+
+	tests := map[ByteSize]string{
+		Mebibyte:        "1M",
+		2 * Gibibyte:    "2048M",
+		512 * Kibibyte:  "512K",
+		1536 * Kibibyte: "1536K",
+		1000 * Byte:     "1000",
+		20 * Mebibyte:   "20M",
+	}
+
+	for size, want := range tests {
+		if got := aria2cSize(size); got != want {
+			t.Fatalf("aria2cSize(%d): expected %q, got %q", size, want, got)
+		}
+	}
+}
+
+func TestInstallAria2c_Unsupported(t *testing.T) { //nolint:paralleltest
+	if runtime.GOOS == "windows" {
+		t.Skip("official aria2c builds are available for windows")
+	}
+
+	t.Setenv("PATH", t.TempDir())
+
+	_, err := InstallAria2c(context.Background(), &InstallOptions{CacheDir: t.TempDir()})
+	if !IsUnsupportedPlatformError(err) {
+		t.Fatalf("expected unsupported platform error, got %v", err)
+	}
+}
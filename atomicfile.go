@@ -0,0 +1,67 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// writeFileAtomic writes data to path (with the provided permissions, minus the
+// process umask, the same as [os.WriteFile]), by first writing it to a temporary
+// file in the same directory, then renaming it over path, so path is never left
+// partially written.
+func writeFileAtomic(path string, data []byte, perm os.FileMode) error {
+	return writeFileAtomicFunc(path, perm, func(w io.Writer) error {
+		_, err := w.Write(data)
+		return err
+	})
+}
+
+// writeFileAtomicFunc is the same as writeFileAtomic, however the contents are
+// written by fn. If fn returns an error, path isn't modified.
+func writeFileAtomicFunc(path string, perm os.FileMode, fn func(w io.Writer) error) error {
+	f, err := createTempFile(path, perm)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name()) //nolint:errcheck
+
+	err = fn(f)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+
+	if err == nil {
+		err = os.Rename(f.Name(), path)
+	}
+
+	return err
+}
+
+// createTempFile creates a new temporary file next to path. Unlike
+// [os.CreateTemp], the file is created with perm, so the process umask is
+// applied, rather than always being private to the owner.
+func createTempFile(path string, perm os.FileMode) (*os.File, error) {
+	b := make([]byte, 8) //nolint:gomnd
+
+	for range 10 {
+		_, _ = rand.Read(b)
+
+		name := filepath.Join(filepath.Dir(path), "."+filepath.Base(path)+"-"+hex.EncodeToString(b)+".tmp")
+
+		f, err := os.OpenFile(name, os.O_RDWR|os.O_CREATE|os.O_EXCL, perm)
+		if !errors.Is(err, os.ErrExist) {
+			return f, err
+		}
+	}
+
+	return nil, fmt.Errorf("unable to create temporary file for %q", path)
+}
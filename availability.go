@@ -0,0 +1,91 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"errors"
+)
+
+// Availability is the classified availability of a video, as returned by
+// [CheckAvailability].
+type Availability string
+
+const (
+	AvailabilityUnknown        Availability = "unknown"
+	AvailabilityPublic         Availability = Availability(ExtractedAvailabilityPublic)
+	AvailabilityUnlisted       Availability = Availability(ExtractedAvailabilityUnlisted)
+	AvailabilityPrivate        Availability = Availability(ExtractedAvailabilityPrivate)
+	AvailabilityPremiumOnly    Availability = Availability(ExtractedAvailabilityPremiumOnly)
+	AvailabilitySubscriberOnly Availability = Availability(ExtractedAvailabilitySubscriberOnly)
+	AvailabilityNeedsAuth      Availability = Availability(ExtractedAvailabilityNeedsAuth) // Includes age-restricted videos.
+	AvailabilityGeoBlocked     Availability = "geo_blocked"
+	AvailabilityRemoved        Availability = "removed" // Removed, deleted, terminated or otherwise doesn't exist.
+)
+
+// IsAvailable returns true if the video can be downloaded without additional
+// authentication.
+func (a Availability) IsAvailable() bool {
+	return a == AvailabilityPublic || a == AvailabilityUnlisted
+}
+
+// classifyAvailability classifies the yt-dlp error output, using [errorPatterns].
+func classifyAvailability(stderr string) Availability {
+	for _, p := range errorPatterns {
+		if p.availability != AvailabilityUnknown && p.re.MatchString(stderr) {
+			return p.availability
+		}
+	}
+
+	return AvailabilityUnknown
+}
+
+// CheckAvailability classifies the availability of the provided video URL (e.g.
+// public, private, geo-blocked, removed) using a lightweight extraction (without
+// downloading), which is useful for link-health checkers. Any flags already set
+// on the command are also used (e.g. cookies, which affect availability), and
+// the command itself is not modified.
+//
+// If the video is unavailable for a known reason, the classification is returned
+// without an error. If yt-dlp fails for an unknown reason, [AvailabilityUnknown]
+// is returned along with the error.
+func (c *Command) CheckAvailability(ctx context.Context, url string) (Availability, error) {
+	result, err := c.clone().
+		SkipDownload().
+		NoPlaylist().
+		IgnoreNoFormatsError().
+		DumpJSON().
+		Run(ctx, url)
+	if err != nil {
+		if result != nil && !IsMisconfigError(err) {
+			if a := classifyAvailability(result.Stderr); a != AvailabilityUnknown {
+				return a, nil
+			}
+		}
+
+		return AvailabilityUnknown, err
+	}
+
+	infos, err := result.GetExtractedInfo()
+	if err != nil {
+		return AvailabilityUnknown, err
+	}
+
+	if len(infos) == 0 {
+		return AvailabilityUnknown, errors.New("unable to check availability: no info returned")
+	}
+
+	if infos[0].Availability == nil {
+		return AvailabilityPublic, nil // Extraction succeeded, with no restrictions reported.
+	}
+
+	return Availability(*infos[0].Availability), nil
+}
+
+// CheckAvailability is the same as [Command.CheckAvailability], using a new
+// command with no additional flags.
+func CheckAvailability(ctx context.Context, url string) (Availability, error) {
+	return New().CheckAvailability(ctx, url)
+}
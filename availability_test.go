@@ -0,0 +1,34 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"testing"
+)
+
+func TestAvailability_Classify(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]Availability{
+		"ERROR: [youtube] abc: Private video. Sign in if you've been granted access to this video":                                  AvailabilityPrivate,
+		"ERROR: [youtube] abc: Join this channel to get access to members-only content like this video, and other exclusive perks.": AvailabilitySubscriberOnly,
+		"ERROR: [youtube] abc: This video requires payment to watch.":                                                               AvailabilityPremiumOnly,
+		"ERROR: [youtube] abc: The uploader has not made this video available in your country":                                      AvailabilityGeoBlocked,
+		"ERROR: [youtube] abc: Sign in to confirm your age. This video may be inappropriate for some users.":                        AvailabilityNeedsAuth,
+		"ERROR: [youtube] abc: Video unavailable. This video has been removed by the uploader":                                      AvailabilityRemoved,
+		"ERROR: [generic] Unable to download webpage: HTTP Error 404: Not Found":                                                    AvailabilityRemoved,
+		"ERROR: [generic] Unable to download webpage: <urlopen error timed out>":                                                    AvailabilityUnknown,
+	}
+
+	for stderr, expected := range tests {
+		if a := classifyAvailability(stderr); a != expected {
+			t.Fatalf("expected %q to be classified as %q, got %q", stderr, expected, a)
+		}
+	}
+
+	if !AvailabilityUnlisted.IsAvailable() || AvailabilityGeoBlocked.IsAvailable() {
+		t.Fatal("unexpected IsAvailable result")
+	}
+}
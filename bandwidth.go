@@ -0,0 +1,158 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// BytesCategory is the category of downloaded bytes, see
+// [RunStats.BytesByCategory].
+type BytesCategory string
+
+const (
+	BytesVideo     BytesCategory = "video"     // Formats with video (including those with audio).
+	BytesAudio     BytesCategory = "audio"     // Audio-only formats.
+	BytesSubtitle  BytesCategory = "subtitle"  // Subtitles.
+	BytesThumbnail BytesCategory = "thumbnail" // Thumbnails.
+	BytesOther     BytesCategory = "other"     // Downloads which couldn't be categorized.
+)
+
+// subtitleExtensions are the extensions of subtitle formats supported by yt-dlp.
+var subtitleExtensions = []string{
+	"vtt", "srt", "ass", "ssa", "ttml", "dfxp", "lrc", "json3", "srv1", "srv2", "srv3",
+}
+
+// "[info] Writing video thumbnail 0 to: <file>".
+var reThumbnailDestination = regexp.MustCompile(`^\[info\] Writing (?:video|playlist) thumbnail (?:\S+ )?to: (.+)$`)
+
+// TrackBandwidth enables accounting of the bytes downloaded by each invocation,
+// by category (see [BytesCategory]), which is available via [Result.Stats] and
+// the [StatsRecorder] set with [Command.SetStatsRecorder] (e.g. to meter usage
+// per tenant, by using a separate recorder for each). Bytes are tracked from the
+// progress updates of each download (which includes all fragments), and the
+// size of written thumbnails. Files which were already downloaded aren't
+// counted, however the bytes of resumed downloads (see [Command.Continue])
+// include those downloaded previously.
+//   - See [Command.UnsetTrackBandwidth], for disabling it.
+func (c *Command) TrackBandwidth() *Command {
+	if len(c.getFlagsByID("progress_template")) == 0 {
+		c.setProgressFlags(time.Second)
+	}
+
+	c.mu.Lock()
+	c.bandwidth = true
+	c.mu.Unlock()
+
+	return c
+}
+
+// UnsetTrackBandwidth disables bandwidth accounting, previously enabled with
+// [Command.TrackBandwidth].
+func (c *Command) UnsetTrackBandwidth() *Command {
+	c.mu.Lock()
+	c.bandwidth = false
+	c.mu.Unlock()
+
+	return c
+}
+
+// bandwidthTracker tracks the bytes downloaded by a single invocation.
+type bandwidthTracker struct {
+	mu    sync.Mutex
+	files map[string]*trackedDownload // Keyed by filename.
+}
+
+type trackedDownload struct {
+	category BytesCategory
+	bytes    int
+}
+
+func newBandwidthTracker() *bandwidthTracker {
+	return &bandwidthTracker{files: make(map[string]*trackedDownload)}
+}
+
+// record records a progress update.
+func (t *bandwidthTracker) record(update ProgressUpdate) {
+	if update.Status != ProgressStatusDownloading && update.Status != ProgressStatusFinished {
+		return
+	}
+
+	if update.Filename == "" {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	f, ok := t.files[update.Filename]
+	if !ok {
+		f = &trackedDownload{category: downloadCategory(update)}
+		t.files[update.Filename] = f
+	}
+
+	f.bytes = max(f.bytes, update.DownloadedBytes)
+}
+
+// downloadCategory returns the category of the download of a progress update.
+func downloadCategory(update ProgressUpdate) BytesCategory {
+	ext := strings.TrimPrefix(filepath.Ext(update.Filename), ".")
+	if slices.Contains(subtitleExtensions, ext) {
+		return BytesSubtitle
+	}
+
+	if update.Info == nil || update.Info.ExtractedFormat == nil {
+		return BytesOther
+	}
+
+	vcodec, acodec := update.Info.VCodec, update.Info.ACodec
+
+	switch {
+	case vcodec != nil && *vcodec != "none":
+		return BytesVideo
+	case acodec != nil && *acodec != "none":
+		return BytesAudio
+	default:
+		return BytesOther
+	}
+}
+
+// bytesByCategory returns the tracked bytes by category, including thumbnails
+// written according to the output logs (relative to dir).
+func (t *bandwidthTracker) bytesByCategory(logs []*ResultLog, dir string) map[BytesCategory]int64 {
+	out := make(map[BytesCategory]int64)
+
+	t.mu.Lock()
+	for _, f := range t.files {
+		if f.bytes > 0 {
+			out[f.category] += int64(f.bytes)
+		}
+	}
+	t.mu.Unlock()
+
+	for _, log := range logs {
+		m := reThumbnailDestination.FindStringSubmatch(strings.TrimSpace(log.Line))
+		if m == nil {
+			continue
+		}
+
+		path := m[1]
+		if !filepath.IsAbs(path) && dir != "" {
+			path = filepath.Join(dir, path)
+		}
+
+		if stat, err := os.Stat(path); err == nil {
+			out[BytesThumbnail] += stat.Size()
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,62 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"testing"
+)
+
+// fakeBandwidthScript emulates yt-dlp downloading a video and audio format (the
+// latter in fragments), a subtitle, and a thumbnail.
+const fakeBandwidthScript = "#!/bin/sh\n" + fakeProgressPreamble + `p() { echo "$PROGRESS{\"info\":{\"id\":\"abc\",\"vcodec\":\"$1\",\"acodec\":\"$2\"},\"progress\":{\"status\":\"$3\",\"filename\":\"$4\",\"downloaded_bytes\":$5}}"; }
+p avc1 none downloading abc.f137.mp4 500
+p avc1 none finished abc.f137.mp4 1000
+p none mp4a downloading abc.f140.m4a 100
+p none mp4a downloading abc.f140.m4a 300
+p none mp4a finished abc.f140.m4a 300
+p "" "" finished abc.en.vtt 50
+echo "$PROGRESS{\"info\":{\"id\":\"abc\"},\"progress\":{\"status\":\"finished\",\"filename\":\"abc.mp4\",\"total_bytes\":1300}}"
+printf '0123456789' > abc.webp
+echo "[info] Writing video thumbnail 0 to: abc.webp"
+`
+
+func TestCommand_TrackBandwidth(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	bin := writeFakeExecutable(t, dir, "yt-dlp.sh", fakeBandwidthScript)
+
+	var recorded *RunStats
+
+	result, err := New().
+		SetExecutable(bin).
+		SetWorkDir(dir).
+		TrackBandwidth().
+		SetStatsRecorder(StatsRecorderFunc(func(stats *RunStats) { recorded = stats })).
+		Run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[BytesCategory]int64{
+		BytesVideo:     1000,
+		BytesAudio:     300,
+		BytesSubtitle:  50,
+		BytesThumbnail: 10,
+	}
+
+	for _, stats := range []*RunStats{result.Stats(), recorded} {
+		if stats == nil || stats.Bytes != 1360 || len(stats.BytesByCategory) != len(expected) {
+			t.Fatalf("unexpected stats: %+v", stats)
+		}
+
+		for category, n := range expected {
+			if stats.BytesByCategory[category] != n {
+				t.Fatalf("expected %d %s bytes, got %d", n, category, stats.BytesByCategory[category])
+			}
+		}
+	}
+}
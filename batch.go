@@ -0,0 +1,181 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// batchCommentPrefixes are the prefixes of lines which yt-dlp ignores in batch
+// files.
+const batchCommentPrefixes = "#;]"
+
+// reBatchInlineComment matches inline comments in batch files, which must follow
+// whitespace (as "#" is otherwise valid within URLs).
+var reBatchInlineComment = regexp.MustCompile(`\s#`)
+
+// BatchFileOptions configures [WriteBatchFile].
+type BatchFileOptions struct {
+	// Header is an optional comment written at the top of the file. Multiple
+	// lines are supported.
+	Header string
+
+	// Info maps URLs to their extracted info (e.g. from [Command.RunWithInfo]),
+	// used to annotate each URL with a comment containing its title.
+	Info map[string]*ExtractedInfo
+
+	// Append appends to the file (if it exists) rather than replacing it. URLs
+	// which already exist in the file are skipped.
+	Append bool
+}
+
+// ReadBatchFile reads the URLs from a batch file (see [Command.BatchFile]),
+// ignoring comments and empty lines, using the same rules as yt-dlp.
+func ReadBatchFile(path string) ([]string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read batch file: %w", err)
+	}
+
+	return parseBatchFile(b)
+}
+
+// parseBatchFile parses the URLs from the contents of a batch file.
+func parseBatchFile(b []byte) (urls []string, err error) {
+	scanner := bufio.NewScanner(bytes.NewReader(bytes.TrimPrefix(b, []byte("\ufeff"))))
+	scanner.Buffer(nil, 1024*1024) //nolint:gomnd
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.ContainsAny(line[:1], batchCommentPrefixes) {
+			continue
+		}
+
+		urls = append(urls, strings.TrimRightFunc(reBatchInlineComment.Split(line, 2)[0], unicode.IsSpace))
+	}
+
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read batch file: %w", err)
+	}
+
+	return urls, nil
+}
+
+// validateBatchURL ensures the URL can be represented in a batch file.
+func validateBatchURL(u string) error {
+	switch {
+	case u == "":
+		return errors.New("empty url")
+	case strings.ContainsAny(u[:1], batchCommentPrefixes):
+		return fmt.Errorf("url %q starts with a comment character", u)
+	case strings.ContainsAny(u, "\r\n"):
+		return fmt.Errorf("url %q contains a newline", u)
+	case reBatchInlineComment.MatchString(u):
+		return fmt.Errorf("url %q contains an inline comment", u)
+	}
+
+	return nil
+}
+
+// batchComment formats s as batch file comment lines.
+func batchComment(s string) string {
+	var out strings.Builder
+
+	for _, line := range strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n") {
+		out.WriteString(strings.TrimRightFunc("# "+line, unicode.IsSpace) + "\n")
+	}
+
+	return out.String()
+}
+
+// WriteBatchFile writes the URLs to a batch file (see [Command.BatchFile]), which
+// yt-dlp can use to download large lists of URLs. Surrounding whitespace is
+// trimmed, duplicate URLs are skipped, and each URL is validated (returning an
+// error without writing anything, if any are invalid). If info is available for
+// a URL (see [BatchFileOptions.Info]), a comment with its title is written above
+// it. The file is replaced atomically. Returns the number of URLs written.
+func WriteBatchFile(path string, urls []string, opts *BatchFileOptions) (written int, err error) {
+	if opts == nil {
+		opts = &BatchFileOptions{}
+	}
+
+	var existing []byte
+
+	if opts.Append {
+		existing, err = os.ReadFile(path)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return 0, fmt.Errorf("unable to read batch file: %w", err)
+		}
+	}
+
+	seen := make(map[string]bool)
+
+	existingURLs, err := parseBatchFile(existing)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, u := range existingURLs {
+		seen[u] = true
+	}
+
+	var errs []error
+	var out bytes.Buffer
+
+	out.Write(existing)
+
+	if len(existing) > 0 && !bytes.HasSuffix(existing, []byte("\n")) {
+		out.WriteString("\n")
+	}
+
+	if opts.Header != "" && len(existing) == 0 {
+		out.WriteString(batchComment(opts.Header))
+	}
+
+	for _, u := range urls {
+		u = strings.TrimSpace(u)
+
+		if err = validateBatchURL(u); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		if seen[u] {
+			continue
+		}
+
+		seen[u] = true
+
+		if info := opts.Info[u]; info != nil && info.Title != nil && *info.Title != "" {
+			out.WriteString(batchComment(*info.Title))
+		}
+
+		out.WriteString(u + "\n")
+		written++
+	}
+
+	if len(errs) > 0 {
+		return 0, fmt.Errorf("invalid batch urls: %w", errors.Join(errs...))
+	}
+
+	if err = os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return 0, fmt.Errorf("unable to create batch file directory: %w", err)
+	}
+
+	if err = writeFileAtomic(path, out.Bytes(), 0o600); err != nil {
+		return 0, fmt.Errorf("unable to write batch file: %w", err)
+	}
+
+	return written, nil
+}
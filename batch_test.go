@@ -0,0 +1,90 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestWriteBatchFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "batch", "urls.txt")
+	title := "Some Video\nWith Newline"
+
+	written, err := WriteBatchFile(path, []string{
+		"https://example.com/a",
+		"  https://example.com/b#t=10  ",
+		"https://example.com/a",
+		"ytsearch5:some query",
+	}, &BatchFileOptions{
+		Header: "generated",
+		Info:   map[string]*ExtractedInfo{"https://example.com/a": {Title: &title}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if written != 3 {
+		t.Fatalf("expected 3 urls to be written, got %d", written)
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "# generated\n# Some Video\n# With Newline\nhttps://example.com/a\nhttps://example.com/b#t=10\nytsearch5:some query\n"
+	if string(b) != expected {
+		t.Fatalf("expected:\n%s\ngot:\n%s", expected, b)
+	}
+
+	written, err = WriteBatchFile(path, []string{"https://example.com/b#t=10", "https://example.com/c"}, &BatchFileOptions{Append: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if written != 1 {
+		t.Fatalf("expected 1 url to be appended, got %d", written)
+	}
+
+	urls, err := ReadBatchFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(urls, []string{"https://example.com/a", "https://example.com/b#t=10", "ytsearch5:some query", "https://example.com/c"}) {
+		t.Fatalf("unexpected urls: %q", urls)
+	}
+
+	for _, invalid := range []string{"", "# comment", "https://example.com/d #comment", "https://example.com/e\nhttps://example.com/f"} {
+		if _, err = WriteBatchFile(path, []string{invalid}, nil); err == nil {
+			t.Fatalf("expected error for invalid url %q", invalid)
+		}
+	}
+}
+
+func TestReadBatchFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "urls.txt")
+
+	err := os.WriteFile(path, []byte("\ufeffhttps://example.com/a\n\n; comment\n] comment\n  https://example.com/b  # inline\nhttps://example.com/c#fragment\n"), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	urls, err := ReadBatchFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(urls, []string{"https://example.com/a", "https://example.com/b", "https://example.com/c#fragment"}) {
+		t.Fatalf("unexpected urls: %q", urls)
+	}
+}
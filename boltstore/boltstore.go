@@ -0,0 +1,149 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+// Package boltstore contains a [ytdlp.JobStore] backed by a Bolt (bbolt)
+// database, for persisting jobs run with [ytdlp.Command.RunJobs]. It's a separate
+// module, so the bbolt dependency is only required by consumers which use it:
+//
+//	go get github.com/lrstanley/go-ytdlp/boltstore
+package boltstore
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/lrstanley/go-ytdlp"
+	bolt "go.etcd.io/bbolt"
+)
+
+// bucket is the bucket which jobs are stored in, keyed by ID.
+var bucket = []byte("jobs")
+
+// Store is a [ytdlp.JobStore] which stores jobs within a Bolt database. Bolt
+// only allows a database to be opened by one process at once, so a store can't
+// be shared between processes, however claims are atomic for all users of the
+// store within the process.
+type Store struct {
+	db *bolt.DB
+}
+
+var _ ytdlp.JobStore = (*Store)(nil)
+
+// Open opens (creating if necessary) the Bolt database at path. If the database
+// is opened by another process, Open waits up to timeout (0 means wait
+// indefinitely) for it to be closed. The store should be closed with
+// [Store.Close] once no longer needed.
+func Open(path string, timeout time.Duration) (*Store, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: timeout})
+	if err != nil {
+		return nil, fmt.Errorf("unable to open job database: %w", err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(bucket)
+		return err
+	})
+	if err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("unable to create jobs bucket: %w", err)
+	}
+
+	return &Store{db: db}, nil
+}
+
+// Close closes the database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// putJob encodes and stores the job within the bucket.
+func putJob(b *bolt.Bucket, job *ytdlp.Job) error {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("unable to encode job: %w", err)
+	}
+
+	return b.Put([]byte(job.ID), data)
+}
+
+// SaveJob implements [ytdlp.JobStore].
+func (s *Store) SaveJob(_ context.Context, job *ytdlp.Job) error {
+	if job.ID == "" {
+		return errors.New("invalid job id: empty")
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return putJob(tx.Bucket(bucket), job)
+	})
+}
+
+// ClaimJob implements [ytdlp.JobStore].
+func (s *Store) ClaimJob(_ context.Context, id, owner string, lease time.Duration) (*ytdlp.Job, error) {
+	var job *ytdlp.Job
+
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(bucket)
+
+		data := b.Get([]byte(id))
+		if data == nil {
+			return ytdlp.ErrJobUnavailable
+		}
+
+		job = &ytdlp.Job{}
+
+		if err := json.Unmarshal(data, job); err != nil {
+			return fmt.Errorf("unable to parse job %q: %w", id, err)
+		}
+
+		if err := job.Claim(owner, lease); err != nil {
+			return err
+		}
+
+		return putJob(b, job)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// Jobs implements [ytdlp.JobStore].
+func (s *Store) Jobs(_ context.Context) ([]*ytdlp.Job, error) {
+	var jobs []*ytdlp.Job
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucket).ForEach(func(k, v []byte) error {
+			job := &ytdlp.Job{}
+
+			if err := json.Unmarshal(v, job); err != nil {
+				return fmt.Errorf("unable to parse job %q: %w", k, err)
+			}
+
+			jobs = append(jobs, job)
+
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortStableFunc(jobs, func(a, b *ytdlp.Job) int {
+		return a.Created.Compare(b.Created)
+	})
+
+	return jobs, nil
+}
+
+// DeleteJob implements [ytdlp.JobStore].
+func (s *Store) DeleteJob(_ context.Context, id string) error {
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucket).Delete([]byte(id))
+	})
+}
@@ -0,0 +1,107 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package boltstore
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lrstanley/go-ytdlp"
+)
+
+func openStore(t *testing.T) *Store {
+	t.Helper()
+
+	s, err := Open(filepath.Join(t.TempDir(), "jobs.db"), time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { _ = s.Close() })
+
+	return s
+}
+
+func TestStore(t *testing.T) {
+	t.Parallel()
+
+	s := openStore(t)
+	ctx := context.Background()
+
+	first, err := ytdlp.EnqueueJob(ctx, s, "https://example.com/1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = ytdlp.EnqueueJob(ctx, s, "https://example.com/2"); err != nil {
+		t.Fatal(err)
+	}
+
+	jobs, err := s.Jobs(ctx)
+	if err != nil || len(jobs) != 2 || jobs[0].ID != first.ID {
+		t.Fatalf("expected 2 jobs in created order, got %+v (err: %v)", jobs, err)
+	}
+
+	job, err := s.ClaimJob(ctx, first.ID, "a", time.Hour)
+	if err != nil || job.Status != ytdlp.JobRunning || job.Owner != "a" {
+		t.Fatalf("expected job to be claimed: %+v (err: %v)", job, err)
+	}
+
+	if _, err = s.ClaimJob(ctx, first.ID, "b", time.Hour); !errors.Is(err, ytdlp.ErrJobUnavailable) {
+		t.Fatalf("expected claimed job to be unavailable, got %v", err)
+	}
+
+	if _, err = s.ClaimJob(ctx, "missing", "a", time.Hour); !errors.Is(err, ytdlp.ErrJobUnavailable) {
+		t.Fatalf("expected missing job to be unavailable, got %v", err)
+	}
+
+	if err = s.DeleteJob(ctx, first.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if jobs, err = s.Jobs(ctx); err != nil || len(jobs) != 1 {
+		t.Fatalf("expected 1 job after deletion, got %d (err: %v)", len(jobs), err)
+	}
+}
+
+func TestStore_RunJobs(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("requires a posix shell")
+	}
+
+	bin := filepath.Join(t.TempDir(), "yt-dlp")
+
+	if err := os.WriteFile(bin, []byte("#!/bin/sh\necho \"$*\"\n"), 0o755); err != nil { //nolint:gosec
+		t.Fatal(err)
+	}
+
+	s := openStore(t)
+	ctx := context.Background()
+
+	if _, err := ytdlp.EnqueueJob(ctx, s, "https://example.com/1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ytdlp.New().SetExecutable(bin).RunJobs(ctx, s, ytdlp.Concurrency{}); err != nil {
+		t.Fatal(err)
+	}
+
+	jobs, err := s.Jobs(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(jobs) != 1 || jobs[0].Status != ytdlp.JobFinished || jobs[0].Result == nil || !strings.HasSuffix(jobs[0].Result.Stdout, "https://example.com/1") {
+		t.Fatalf("expected job to be finished: %+v", jobs)
+	}
+}
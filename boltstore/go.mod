@@ -0,0 +1,19 @@
+module github.com/lrstanley/go-ytdlp/boltstore
+
+go 1.22.0
+
+require (
+	github.com/lrstanley/go-ytdlp v0.0.0-00010101000000-000000000000
+	go.etcd.io/bbolt v1.3.11
+)
+
+require (
+	github.com/ProtonMail/go-crypto v1.1.3 // indirect
+	github.com/cloudflare/circl v1.5.0 // indirect
+	golang.org/x/crypto v0.31.0 // indirect
+	golang.org/x/sync v0.10.0 // indirect
+	golang.org/x/sys v0.28.0 // indirect
+)
+
+// Kept in sync with the parent module during development.
+replace github.com/lrstanley/go-ytdlp => ../
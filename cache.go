@@ -0,0 +1,97 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+)
+
+// CacheDirEnv is the environment variable which, if set, overrides the directory
+// returned by [CacheDir].
+const CacheDirEnv = "YTDLP_GO_CACHE_DIR"
+
+// cacheDirOverride is the process-wide cache directory, see [SetCacheDir].
+var cacheDirOverride atomic.Pointer[string]
+
+// SetCacheDir overrides the directory returned by [CacheDir] for the process,
+// e.g. to relocate the cache to a persistent volume, or a writable directory in
+// containers where [os.UserCacheDir] isn't writable. Relative paths are resolved
+// against the current working directory. Pass an empty string to restore the
+// default. Commands and installs which already resolved the directory aren't
+// affected.
+func SetCacheDir(dir string) error {
+	if dir == "" {
+		cacheDirOverride.Store(nil)
+		return nil
+	}
+
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return fmt.Errorf("unable to resolve cache dir: %w", err)
+	}
+
+	cacheDirOverride.Store(&dir)
+	return nil
+}
+
+// CacheDir returns the directory where go-ytdlp caches executables. In order of
+// precedence:
+//
+//   - The directory set with [SetCacheDir].
+//   - The value of the [CacheDirEnv] environment variable, used as-is.
+//   - "$XDG_CACHE_HOME/go-ytdlp", if XDG_CACHE_HOME is set to an absolute path
+//     (on all platforms, not just those where [os.UserCacheDir] respects it).
+//   - "go-ytdlp" within [os.UserCacheDir].
+func CacheDir() (string, error) {
+	if dir := cacheDirOverride.Load(); dir != nil {
+		return *dir, nil
+	}
+
+	if dir := os.Getenv(CacheDirEnv); dir != "" {
+		return filepath.Clean(dir), nil
+	}
+
+	if dir := os.Getenv("XDG_CACHE_HOME"); dir != "" && filepath.IsAbs(dir) {
+		return filepath.Join(dir, xdgCacheDir), nil
+	}
+
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("unable to get user cache dir: %w", err)
+	}
+
+	return filepath.Join(dir, xdgCacheDir), nil
+}
+
+// ScopedCacheDir returns a directory within [CacheDir], specific to the provided
+// application name, which can be used with [InstallOptions.CacheDir] so that
+// multiple applications (potentially using different versions of go-ytdlp)
+// don't share the same cached executables.
+func ScopedCacheDir(app string) (string, error) {
+	app = strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			return r
+		default:
+			return '_'
+		}
+	}, app)
+
+	if strings.Trim(app, "._") == "" {
+		return "", errors.New("invalid application name for cache dir")
+	}
+
+	dir, err := CacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "apps", app), nil
+}
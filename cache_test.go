@@ -0,0 +1,93 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCacheDir(t *testing.T) {
+	xdg := t.TempDir()
+	override := t.TempDir()
+
+	t.Setenv(CacheDirEnv, "")
+	t.Setenv("XDG_CACHE_HOME", xdg)
+
+	dir, err := CacheDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if dir != filepath.Join(xdg, xdgCacheDir) {
+		t.Fatalf("expected XDG_CACHE_HOME to be respected, got %q", dir)
+	}
+
+	t.Setenv(CacheDirEnv, override)
+
+	dir, err = CacheDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if dir != override {
+		t.Fatalf("expected %s to take precedence, got %q", CacheDirEnv, dir)
+	}
+
+	dir, err = ScopedCacheDir("my app/../v2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if dir != filepath.Join(override, "apps", "my_app_.._v2") {
+		t.Fatalf("unexpected scoped cache dir: %q", dir)
+	}
+
+	for _, app := range []string{"", "..", "/"} {
+		if _, err = ScopedCacheDir(app); err == nil {
+			t.Fatalf("expected error for app name %q", app)
+		}
+	}
+}
+
+func TestSetCacheDir(t *testing.T) { //nolint:paralleltest
+	override := t.TempDir()
+	t.Setenv(CacheDirEnv, t.TempDir())
+
+	if err := SetCacheDir(override); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = SetCacheDir("") })
+
+	dir, err := CacheDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if dir != override {
+		t.Fatalf("expected SetCacheDir to take precedence, got %q", dir)
+	}
+
+	if dir, err = ScopedCacheDir("app"); err != nil || dir != filepath.Join(override, "apps", "app") {
+		t.Fatalf("expected scoped cache dir within override, got %q (err: %v)", dir, err)
+	}
+
+	if err = SetCacheDir("relative"); err != nil {
+		t.Fatal(err)
+	}
+
+	if dir, _ = CacheDir(); !filepath.IsAbs(dir) || filepath.Base(dir) != "relative" {
+		t.Fatalf("expected relative dir to be made absolute, got %q", dir)
+	}
+
+	if err = SetCacheDir(""); err != nil {
+		t.Fatal(err)
+	}
+
+	if dir, _ = CacheDir(); dir != os.Getenv(CacheDirEnv) {
+		t.Fatalf("expected default to be restored, got %q", dir)
+	}
+}
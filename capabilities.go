@@ -0,0 +1,206 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// Capabilities are the features of a yt-dlp executable which go-ytdlp relies on,
+// as detected by [Command.Capabilities].
+type Capabilities struct {
+	// ProgressTemplate is true if --progress-template is supported, which is
+	// required by [Command.ProgressFunc] (and similar).
+	ProgressTemplate bool `json:"progress_template"`
+
+	// PrintHooks is true if --print and --print-to-file support the "WHEN:"
+	// prefix (e.g. "after_move:"), which is required by [Workspace],
+	// [Command.VerifySponsorBlock] and [Command.VerifyIntegrity] (and similar).
+	PrintHooks bool `json:"print_hooks"`
+
+	// Impersonate is true if --impersonate is supported.
+	Impersonate bool `json:"impersonate"`
+
+	// JSRuntimes is true if --js-runtimes is supported. See [JSRuntimeRemediation].
+	JSRuntimes bool `json:"js_runtimes"`
+}
+
+// printHooks are the "WHEN:" stages supported by --print and --print-to-file.
+var printHooks = []string{
+	"pre_process", "after_filter", "video", "before_dl", "post_process", "after_move", "after_video", "playlist",
+}
+
+// capabilityChecks map the flags used by an invocation to the capability they
+// require. Flags are checked by ID, unless the ID is empty (raw flags), in which
+// case the flag itself is matched.
+var capabilityChecks = []struct {
+	name      string
+	supported func(caps *Capabilities) bool
+	uses      func(f *Flag) bool
+}{
+	{
+		name:      "--progress-template",
+		supported: func(caps *Capabilities) bool { return caps.ProgressTemplate },
+		uses:      func(f *Flag) bool { return f.ID == "progress_template" },
+	},
+	{
+		name:      "--print/--print-to-file hooks (WHEN:TEMPLATE)",
+		supported: func(caps *Capabilities) bool { return caps.PrintHooks },
+		uses: func(f *Flag) bool {
+			if (f.ID != "forceprint" && f.ID != "print_to_file") || len(f.Args) == 0 {
+				return false
+			}
+
+			when, _, ok := strings.Cut(f.Args[0], ":")
+			return ok && slices.Contains(printHooks, when)
+		},
+	},
+	{
+		name:      "--impersonate",
+		supported: func(caps *Capabilities) bool { return caps.Impersonate },
+		uses:      func(f *Flag) bool { return f.ID == "impersonate" },
+	},
+	{
+		name:      "--js-runtimes",
+		supported: func(caps *Capabilities) bool { return caps.JSRuntimes },
+		uses: func(f *Flag) bool {
+			return f.ID == rawFlagID && (f.Flag == "--js-runtimes" || strings.HasPrefix(f.Flag, "--js-runtimes="))
+		},
+	},
+}
+
+// capabilitiesCache caches detected capabilities, keyed by capabilitiesKey.
+var capabilitiesCache sync.Map
+
+// capabilitiesKey returns the cache key for the provided executable path.
+func capabilitiesKey(path string, python *pythonModule) string {
+	if python != nil {
+		return path + "\x00" + python.repo
+	}
+
+	return path
+}
+
+// ErrMissingCapability is returned when an invocation uses a flag which the
+// yt-dlp executable doesn't support, as detected by [Command.Capabilities].
+type ErrMissingCapability struct {
+	Executable string
+	Capability string
+}
+
+func (e *ErrMissingCapability) Error() string {
+	return fmt.Sprintf(
+		"yt-dlp executable %q does not support %s, which is required by the flags in use (update yt-dlp)",
+		e.Executable,
+		e.Capability,
+	)
+}
+
+// IsMissingCapabilityError returns true when an invocation uses a flag which the
+// yt-dlp executable doesn't support.
+func IsMissingCapabilityError(err error) bool {
+	var e *ErrMissingCapability
+	return errors.As(err, &e)
+}
+
+// Capabilities detects which of the features go-ytdlp relies on are supported
+// by the yt-dlp executable, by invoking it once with --help (using the same
+// executable, environment, working directory and python settings as the command,
+// but none of its flags, output limits, writers or callbacks). Results are cached
+// per executable for the lifetime of the process, unless detection fails.
+//
+// Once capabilities have been detected for an executable, all invocations using
+// it are checked against them before yt-dlp is started, and fail with
+// [ErrMissingCapability] if a flag in use isn't supported, rather than with a
+// less clear parsing error (or silently missing output).
+func (c *Command) Capabilities(ctx context.Context) (*Capabilities, error) {
+	cc := c.probeCommand()
+	cc.addFlag(&Flag{ID: "", Flag: "--help", Args: nil})
+
+	cmd := cc.buildCommand(ctx)
+	if cmd.Err != nil {
+		return nil, cmd.Err
+	}
+
+	key := capabilitiesKey(cmd.Path, cc.python)
+
+	if caps, ok := capabilitiesCache.Load(key); ok {
+		return caps.(*Capabilities), nil //nolint:forcetypeassert
+	}
+
+	result, err := cc.runWithResult(ctx, cmd)
+	if err != nil {
+		return nil, fmt.Errorf("unable to detect capabilities: %w", err)
+	}
+
+	// Partial output would be cached as missing capabilities.
+	if result.StdoutTruncated || strings.TrimSpace(result.Stdout) == "" {
+		return nil, errors.New("unable to detect capabilities: incomplete --help output")
+	}
+
+	caps := parseCapabilities(result.Stdout)
+	capabilitiesCache.Store(key, caps)
+
+	return caps, nil
+}
+
+// probeCommand returns a bare command, with only the executable, environment,
+// working directory and python settings of the command, used to inspect the
+// yt-dlp executable itself (e.g. [Command.Capabilities]).
+func (c *Command) probeCommand() *Command {
+	cc := New()
+
+	c.mu.RLock()
+	cc.executable = c.executable
+	cc.directory = c.directory
+	maps.Copy(cc.env, c.env)
+	cc.python = c.python
+	cc.resolver = c.resolver
+	cc.ytdlpVersion = c.ytdlpVersion
+	c.mu.RUnlock()
+
+	return cc
+}
+
+// parseCapabilities parses the capabilities from the --help output of yt-dlp.
+func parseCapabilities(help string) *Capabilities {
+	return &Capabilities{
+		ProgressTemplate: strings.Contains(help, "--progress-template"),
+		PrintHooks:       strings.Contains(help, "[WHEN:]TEMPLATE"),
+		Impersonate:      strings.Contains(help, "--impersonate"),
+		JSRuntimes:       strings.Contains(help, "--js-runtimes"),
+	}
+}
+
+// checkCapabilities ensures the flags are supported by the executable, if its
+// capabilities have previously been detected.
+func checkCapabilities(path string, python *pythonModule, flags []*Flag) error {
+	v, ok := capabilitiesCache.Load(capabilitiesKey(path, python))
+	if !ok {
+		return nil
+	}
+
+	caps := v.(*Capabilities) //nolint:forcetypeassert
+
+	for _, check := range capabilityChecks {
+		if check.supported(caps) {
+			continue
+		}
+
+		for _, f := range flags {
+			if check.uses(f) {
+				return &ErrMissingCapability{Executable: path, Capability: check.name}
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,98 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"bytes"
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeCapabilitiesScript emulates an old yt-dlp version, which only supports
+// --progress-template.
+const fakeCapabilitiesScript = `#!/bin/sh
+if [ "$1" = "--help" ]; then
+	echo "Usage: yt-dlp [OPTIONS] URL [URL...]"
+	echo "    --progress-template [TYPES:]TEMPLATE"
+	echo "    --print TEMPLATE"
+	echo "    --print-to-file TEMPLATE FILE"
+fi
+exit 0
+`
+
+func TestCommand_Capabilities(t *testing.T) {
+	t.Parallel()
+
+	bin := writeFakeYtdlp(t, fakeCapabilitiesScript)
+
+	// Not checked until capabilities have been detected.
+	if _, err := New().SetExecutable(bin).Impersonate("chrome").Run(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	caps, err := New().SetExecutable(bin).Impersonate("chrome").Capabilities(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !caps.ProgressTemplate || caps.PrintHooks || caps.Impersonate || caps.JSRuntimes {
+		t.Fatalf("unexpected capabilities: %+v", caps)
+	}
+
+	tests := []struct {
+		cmd     *Command
+		missing bool
+	}{
+		{cmd: New().ProgressFunc(time.Second, func(_ ProgressUpdate) {})},
+		{cmd: New().Print("%(id)s:%(title)s")},
+		{cmd: New().PrintToFile("after_move:%(filepath)s", "files.txt"), missing: true},
+		{cmd: New().Impersonate("chrome"), missing: true},
+		{cmd: New().AddRawFlags("--js-runtimes=deno"), missing: true},
+	}
+
+	for _, tt := range tests {
+		_, err = tt.cmd.SetExecutable(bin).Run(context.Background())
+
+		if IsMissingCapabilityError(err) != tt.missing {
+			t.Fatalf("%v: expected missing capability error to be %v, got %v", tt.cmd.flags, tt.missing, err)
+		}
+	}
+}
+
+func TestCommand_Capabilities_Bare(t *testing.T) {
+	t.Parallel()
+
+	bin := writeFakeYtdlp(t, fakeCapabilitiesScript)
+
+	var stdout bytes.Buffer
+	var logs atomic.Int64
+
+	caps, err := New().
+		SetExecutable(bin).
+		SetMaxCaptureBytes(10, 10).
+		SetStdout(&stdout).
+		LogFunc(func(_ ResultLog) { logs.Add(1) }).
+		Capabilities(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Output limits, writers and callbacks of the command don't apply to the probe.
+	if !caps.ProgressTemplate || stdout.Len() > 0 || logs.Load() > 0 {
+		t.Fatalf("unexpected capabilities/output: %+v, %q, %d logs", caps, stdout.String(), logs.Load())
+	}
+
+	// Failed probes aren't cached.
+	failing := writeFakeYtdlp(t, "#!/bin/sh\nexit 0\n")
+	if _, err = New().SetExecutable(failing).Capabilities(context.Background()); err == nil {
+		t.Fatal("expected error for empty --help output")
+	}
+
+	if _, ok := capabilitiesCache.Load(capabilitiesKey(failing, nil)); ok {
+		t.Fatal("expected failed probe not to be cached")
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// SegmentCheckpoint is emitted when a range of fragments of a fragmented download
+// (e.g. a live stream recorded with [Command.LiveFromStart]) has been completed,
+// and flushed to disk. The range [Offset, Offset+Length) of the file at Path is
+// final, and can be processed (e.g. uploaded) while the download continues.
+type SegmentCheckpoint struct {
+	Info *ExtractedInfo `json:"info"`
+
+	// Filename is the destination filename of the download.
+	Filename string `json:"filename"`
+
+	// Path is the path of the file which is being written to (usually the
+	// temporary ".part" file), or the destination file once Final is true.
+	Path string `json:"path"`
+
+	// FragmentIndex is the number of fragments which have been completed so far.
+	FragmentIndex int `json:"fragment_index"`
+
+	// FragmentCount is the total number of fragments, or 0 if unknown (e.g. while
+	// a live stream is still in progress).
+	FragmentCount int `json:"fragment_count,omitempty"`
+
+	// Offset is the byte offset of the newly flushed range.
+	Offset int64 `json:"offset"`
+
+	// Length is the number of bytes in the newly flushed range.
+	Length int64 `json:"length"`
+
+	// Final is true when the download has finished, and this is the last
+	// checkpoint for the file.
+	Final bool `json:"final"`
+}
+
+// SegmentCheckpointCallbackFunc is a callback function that is called when a range
+// of fragments has been flushed to disk.
+type SegmentCheckpointCallbackFunc func(checkpoint SegmentCheckpoint)
+
+// segmentState is the checkpoint state of a single download.
+type segmentState struct {
+	index  int
+	offset int64
+}
+
+// checkpointTracker tracks fragment progress for all downloads of a single
+// invocation, and emits checkpoints when fragments have been flushed.
+type checkpointTracker struct {
+	fn  SegmentCheckpointCallbackFunc
+	dir string // Working directory, which relative filenames are resolved against.
+
+	mu     sync.Mutex
+	states map[string]*segmentState
+}
+
+func newCheckpointTracker(fn SegmentCheckpointCallbackFunc, dir string) *checkpointTracker {
+	return &checkpointTracker{fn: fn, dir: dir, states: make(map[string]*segmentState)}
+}
+
+func (t *checkpointTracker) path(name string) string {
+	if name == "" || filepath.IsAbs(name) || t.dir == "" {
+		return name
+	}
+
+	return filepath.Join(t.dir, name)
+}
+
+// record records a progress update, emitting a checkpoint if new fragments were
+// completed. The size of the file on disk is used, rather than the reported
+// downloaded bytes, as the latter includes partially downloaded fragments.
+func (t *checkpointTracker) record(update ProgressUpdate) {
+	if update.FragmentIndex == 0 && update.FragmentCount == 0 {
+		return // Not a fragmented download.
+	}
+
+	final := update.Status == ProgressStatusFinished
+
+	t.mu.Lock()
+	state, ok := t.states[update.Filename]
+	if !ok {
+		state = &segmentState{}
+		t.states[update.Filename] = state
+	}
+
+	if !final && update.FragmentIndex <= state.index {
+		t.mu.Unlock()
+		return
+	}
+
+	path := update.Filename
+	if !final && update.tmpFilename != "" {
+		path = update.tmpFilename
+	}
+
+	stat, err := os.Stat(t.path(path))
+	if err != nil || (stat.Size() <= state.offset && !final) {
+		t.mu.Unlock()
+		return
+	}
+
+	checkpoint := SegmentCheckpoint{
+		Info:          update.Info,
+		Filename:      update.Filename,
+		Path:          t.path(path),
+		FragmentIndex: max(update.FragmentIndex, state.index),
+		FragmentCount: update.FragmentCount,
+		Offset:        state.offset,
+		Length:        max(stat.Size()-state.offset, 0),
+		Final:         final,
+	}
+
+	state.index = checkpoint.FragmentIndex
+	state.offset = stat.Size()
+
+	if final {
+		delete(t.states, update.Filename)
+	}
+	t.mu.Unlock()
+
+	t.fn(checkpoint)
+}
+
+// SegmentCheckpointFunc can be used to register a callback function that will be
+// called each time a range of fragments of a fragmented download has been
+// completed and flushed to disk, with the byte offsets of the newly flushed
+// range. This is primarily useful for live streams (see [Command.LiveFromStart]),
+// to begin processing (or uploading) earlier parts of a stream while it's still
+// being recorded.
+//
+// Checkpoints are based on progress updates from yt-dlp (progress reporting is
+// enabled if [Command.ProgressFunc] wasn't already used), and are only emitted for
+// downloads which are written sequentially to a single file, which is the case
+// for most fragmented (e.g. DASH and HLS) downloads, unless
+// [Command.ConcurrentFragments] is used. As checkpoints are based on the size of
+// the file on disk when the progress update is processed, a single checkpoint may
+// cover multiple fragments.
+//   - See [Command.UnsetSegmentCheckpointFunc], for unsetting the function.
+func (c *Command) SegmentCheckpointFunc(fn SegmentCheckpointCallbackFunc) *Command {
+	if len(c.getFlagsByID("progress_template")) == 0 {
+		c.setProgressFlags(100 * time.Millisecond)
+	}
+
+	c.mu.Lock()
+	c.checkpointFn = fn
+	c.mu.Unlock()
+
+	return c
+}
+
+// UnsetSegmentCheckpointFunc can be used to unset the function that was previously
+// set with [Command.SegmentCheckpointFunc].
+func (c *Command) UnsetSegmentCheckpointFunc() *Command {
+	c.mu.Lock()
+	c.checkpointFn = nil
+	c.mu.Unlock()
+
+	return c
+}
@@ -0,0 +1,76 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestCommand_SegmentCheckpointFunc(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	progress := func(status string, index, count int) string {
+		return `echo "$PROGRESS"'{"info":{"id":"v","_type":"video"},"progress":{"status":"` + status +
+			`","filename":"v.mp4","tmpfilename":"v.mp4.part","fragment_index":` + strconv.Itoa(index) +
+			`,"fragment_count":` + strconv.Itoa(count) + `}}'` + "\n"
+	}
+
+	// Wait for each checkpoint to be acknowledged, as the output is processed
+	// asynchronously.
+	wait := func(n int) string {
+		return "while [ ! -f ack" + strconv.Itoa(n) + " ]; do sleep 0.01; done\n"
+	}
+
+	script := "#!/bin/sh\n" + fakeProgressPreamble +
+		"printf aaaa >> v.mp4.part\n" + progress("downloading", 1, 0) + wait(1) + progress("downloading", 1, 0) +
+		"printf bbbbbb >> v.mp4.part\n" + progress("downloading", 2, 0) + wait(2) +
+		"mv v.mp4.part v.mp4\n" + progress("finished", 2, 2)
+
+	bin := writeFakeExecutable(t, dir, "live.sh", script)
+
+	var checkpoints []SegmentCheckpoint
+	var updates int
+
+	_, err := New().
+		SetExecutable(bin).
+		SetWorkDir(dir).
+		ProgressFunc(100*time.Millisecond, func(_ ProgressUpdate) { updates++ }).
+		SegmentCheckpointFunc(func(cp SegmentCheckpoint) {
+			checkpoints = append(checkpoints, cp)
+			_ = os.WriteFile(filepath.Join(dir, "ack"+strconv.Itoa(len(checkpoints))), nil, 0o600)
+		}).
+		Run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if updates != 4 {
+		t.Fatalf("expected progress func to still receive 4 updates, got %d", updates)
+	}
+
+	expected := []SegmentCheckpoint{
+		{Filename: "v.mp4", Path: filepath.Join(dir, "v.mp4.part"), FragmentIndex: 1, Offset: 0, Length: 4},
+		{Filename: "v.mp4", Path: filepath.Join(dir, "v.mp4.part"), FragmentIndex: 2, Offset: 4, Length: 6},
+		{Filename: "v.mp4", Path: filepath.Join(dir, "v.mp4"), FragmentIndex: 2, FragmentCount: 2, Offset: 10, Length: 0, Final: true},
+	}
+
+	if len(checkpoints) != len(expected) {
+		t.Fatalf("expected %d checkpoints, got %d: %+v", len(expected), len(checkpoints), checkpoints)
+	}
+
+	for i, cp := range checkpoints {
+		cp.Info = nil
+
+		if cp != expected[i] {
+			t.Fatalf("unexpected checkpoint %d: got %+v, expected %+v", i, cp, expected[i])
+		}
+	}
+}
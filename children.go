@@ -0,0 +1,103 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"time"
+)
+
+// ChildProcess is a descendant process of a running yt-dlp process, e.g. ffmpeg
+// while merging or re-encoding. See [RunHandle.Children].
+type ChildProcess struct {
+	PID     int           // Process ID.
+	PPID    int           // Parent process ID.
+	Name    string        // Executable name, e.g. "ffmpeg".
+	Args    []string      // Command line arguments, including the executable (only the executable on Windows).
+	CPUTime time.Duration // Total CPU time (user and system) consumed so far.
+}
+
+// descendants returns all descendants of the root process, ordered by PID.
+func descendants(root int, procs []*ChildProcess) []*ChildProcess {
+	parents := map[int]bool{root: true}
+	var children []*ChildProcess
+
+	// Iterate until no new descendants are found, as parents may have a higher
+	// PID than their children (e.g. due to PID wrap-around).
+	for found := true; found; {
+		found = false
+
+		for _, p := range procs {
+			if p.PID != root && !parents[p.PID] && parents[p.PPID] {
+				parents[p.PID] = true
+				children = append(children, p)
+				found = true
+			}
+		}
+	}
+
+	slices.SortFunc(children, func(a, b *ChildProcess) int {
+		return a.PID - b.PID
+	})
+
+	return children
+}
+
+// setPID sets the PID of the currently running yt-dlp process, or 0 if none.
+func (h *RunHandle) setPID(pid int) {
+	h.mu.Lock()
+	h.pid = pid
+	h.mu.Unlock()
+}
+
+// Children returns all descendant processes of the running yt-dlp process (e.g.
+// ffmpeg instances used for merging or post-processing), which can be used to
+// attribute CPU usage to the post-processing phase. If yt-dlp isn't running
+// (yet), nil is returned. Only supported on Linux and Windows, otherwise
+// [errors.ErrUnsupported] is returned.
+func (h *RunHandle) Children() ([]*ChildProcess, error) {
+	h.mu.Lock()
+	pid := h.pid
+	h.mu.Unlock()
+
+	if pid == 0 {
+		return nil, nil
+	}
+
+	procs, err := listProcesses()
+	if err != nil {
+		return nil, fmt.Errorf("unable to list child processes: %w", err)
+	}
+
+	return descendants(pid, procs), nil
+}
+
+// KillChild kills a descendant process of the running yt-dlp process (see
+// [RunHandle.Children]), e.g. a stuck ffmpeg instance. yt-dlp itself is not
+// killed, and will usually fail the post-processing step. An error is returned
+// if the process is not a descendant of yt-dlp.
+func (h *RunHandle) KillChild(pid int) error {
+	children, err := h.Children()
+	if err != nil {
+		return err
+	}
+
+	if !slices.ContainsFunc(children, func(p *ChildProcess) bool { return p.PID == pid }) {
+		return fmt.Errorf("unable to kill process %d: not a child of yt-dlp", pid)
+	}
+
+	proc, err := os.FindProcess(pid)
+	if err != nil {
+		return fmt.Errorf("unable to kill process %d: %w", pid, err)
+	}
+
+	if err = proc.Kill(); err != nil {
+		return fmt.Errorf("unable to kill process %d: %w", pid, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,80 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+//go:build linux
+
+package ytdlp
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// clockTicks is the number of clock ticks per second (USER_HZ), which is 100 on
+// all supported architectures.
+const clockTicks = 100
+
+// listProcesses returns all processes, from /proc.
+func listProcesses() ([]*ChildProcess, error) {
+	entries, err := os.ReadDir("/proc")
+	if err != nil {
+		return nil, err
+	}
+
+	var procs []*ChildProcess
+
+	for _, e := range entries {
+		pid, err := strconv.Atoi(e.Name())
+		if err != nil {
+			continue // Not a process.
+		}
+
+		if p := readProcess(pid); p != nil {
+			procs = append(procs, p)
+		}
+	}
+
+	return procs, nil
+}
+
+// readProcess returns the process information from /proc/<pid>, or nil if the
+// process has exited (or is otherwise not readable).
+func readProcess(pid int) *ChildProcess {
+	dir := filepath.Join("/proc", strconv.Itoa(pid))
+
+	stat, err := os.ReadFile(filepath.Join(dir, "stat"))
+	if err != nil {
+		return nil
+	}
+
+	// Format is "<pid> (<comm>) <state> <ppid> ...", where comm may contain spaces
+	// and parentheses, so use the last closing parenthesis.
+	start := bytes.IndexByte(stat, '(')
+	end := bytes.LastIndexByte(stat, ')')
+	if start < 0 || end < start {
+		return nil
+	}
+
+	fields := strings.Fields(string(stat[end+1:]))
+	if len(fields) < 13 { //nolint:gomnd
+		return nil
+	}
+
+	p := &ChildProcess{PID: pid, Name: string(stat[start+1 : end])}
+	p.PPID, _ = strconv.Atoi(fields[1])
+
+	utime, _ := strconv.ParseInt(fields[11], 10, 64)
+	stime, _ := strconv.ParseInt(fields[12], 10, 64)
+	p.CPUTime = time.Duration(utime+stime) * time.Second / clockTicks
+
+	if cmdline, err := os.ReadFile(filepath.Join(dir, "cmdline")); err == nil && len(cmdline) > 0 {
+		p.Args = strings.Split(strings.TrimRight(string(cmdline), "\x00"), "\x00")
+	}
+
+	return p
+}
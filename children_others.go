@@ -0,0 +1,16 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+//go:build !linux && !windows
+
+package ytdlp
+
+import (
+	"errors"
+)
+
+// listProcesses returns all processes.
+func listProcesses() ([]*ChildProcess, error) {
+	return nil, errors.ErrUnsupported
+}
@@ -0,0 +1,87 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"os"
+	"runtime"
+	"testing"
+	"time"
+)
+
+func TestDescendants(t *testing.T) {
+	t.Parallel()
+
+	procs := []*ChildProcess{
+		{PID: 1, PPID: 0},
+		{PID: 10, PPID: 1},
+		{PID: 5, PPID: 12}, // Grandchild, with a lower PID than its parent.
+		{PID: 12, PPID: 10},
+		{PID: 13, PPID: 10},
+		{PID: 20, PPID: 1},
+	}
+
+	children := descendants(10, procs)
+
+	if len(children) != 3 || children[0].PID != 5 || children[1].PID != 12 || children[2].PID != 13 {
+		t.Fatalf("unexpected descendants: %+v", children)
+	}
+}
+
+func TestRunHandle_Children(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS != "linux" {
+		t.Skip("requires linux")
+	}
+
+	bin := writeFakeYtdlp(t, "#!/bin/sh\nsleep 30\necho done\n")
+
+	h := New().SetExecutable(bin).Start(context.Background())
+	defer h.Cancel()
+
+	var child *ChildProcess
+
+	for deadline := time.Now().Add(5 * time.Second); child == nil && time.Now().Before(deadline); {
+		children, err := h.Children()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, c := range children {
+			if c.Name == "sleep" {
+				child = c
+			}
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if child == nil || len(child.Args) != 2 || child.Args[1] != "30" {
+		t.Fatalf("expected sleep child process, got %+v", child)
+	}
+
+	if err := h.KillChild(os.Getpid()); err == nil {
+		t.Fatal("expected error killing non-child process")
+	}
+
+	if err := h.KillChild(child.PID); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := h.Wait()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Stdout != "done" {
+		t.Fatalf("expected script to continue after killing child, got %q", result.Stdout)
+	}
+
+	if children, _ := h.Children(); children != nil {
+		t.Fatal("expected no children after exit")
+	}
+}
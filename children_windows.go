@@ -0,0 +1,72 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+//go:build windows
+
+package ytdlp
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// listProcesses returns all processes, from a Toolhelp32 snapshot. Command line
+// arguments aren't available on Windows, so only the executable is included.
+func listProcesses() ([]*ChildProcess, error) {
+	snapshot, err := windows.CreateToolhelp32Snapshot(windows.TH32CS_SNAPPROCESS, 0)
+	if err != nil {
+		return nil, err
+	}
+	defer windows.CloseHandle(snapshot) //nolint:errcheck
+
+	var procs []*ChildProcess
+
+	entry := windows.ProcessEntry32{Size: uint32(unsafe.Sizeof(windows.ProcessEntry32{}))}
+
+	for err = windows.Process32First(snapshot, &entry); err == nil; err = windows.Process32Next(snapshot, &entry) {
+		exe := windows.UTF16ToString(entry.ExeFile[:])
+
+		procs = append(procs, &ChildProcess{
+			PID:     int(entry.ProcessID),
+			PPID:    int(entry.ParentProcessID),
+			Name:    strings.TrimSuffix(exe, ".exe"),
+			Args:    []string{exe},
+			CPUTime: processCPUTime(entry.ProcessID),
+		})
+	}
+
+	if !errors.Is(err, windows.ERROR_NO_MORE_FILES) {
+		return nil, err
+	}
+
+	return procs, nil
+}
+
+// processCPUTime returns the total CPU time (user and kernel) of the process, or
+// 0 if the process has exited (or is otherwise not accessible).
+func processCPUTime(pid uint32) time.Duration {
+	h, err := windows.OpenProcess(windows.PROCESS_QUERY_LIMITED_INFORMATION, false, pid)
+	if err != nil {
+		return 0
+	}
+	defer windows.CloseHandle(h) //nolint:errcheck
+
+	var creation, exit, kernel, user windows.Filetime
+
+	if err = windows.GetProcessTimes(h, &creation, &exit, &kernel, &user); err != nil {
+		return 0
+	}
+
+	return filetimeDuration(kernel) + filetimeDuration(user)
+}
+
+// filetimeDuration converts a FILETIME interval (in 100-nanosecond units) to a
+// duration.
+func filetimeDuration(ft windows.Filetime) time.Duration {
+	return time.Duration(uint64(ft.HighDateTime)<<32|uint64(ft.LowDateTime)) * 100 //nolint:gomnd
+}
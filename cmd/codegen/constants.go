@@ -92,6 +92,25 @@ var linkableFlags = map[string][]OptionURL{
 	"--video-multistreams": {{Name: "Format Selection", URL: "https://github.com/yt-dlp/yt-dlp/blob/{version}/README.md#format-selection"}},
 }
 
+// extractorURLTemplates are URL templates for extractors (by name), which can be
+// used to build a canonical URL from a bare ID. "{id}" is replaced with the ID.
+var extractorURLTemplates = map[string]string{
+	"archive.org":  "https://archive.org/details/{id}",
+	"BiliBili":     "https://www.bilibili.com/video/{id}",
+	"BitChute":     "https://www.bitchute.com/video/{id}/",
+	"dailymotion":  "https://www.dailymotion.com/video/{id}",
+	"Instagram":    "https://www.instagram.com/p/{id}/",
+	"kick:vod":     "https://kick.com/video/{id}",
+	"niconico":     "https://www.nicovideo.jp/watch/{id}",
+	"Streamable":   "https://streamable.com/{id}",
+	"TikTok":       "https://www.tiktok.com/embed/{id}",
+	"twitch:clips": "https://clips.twitch.tv/{id}",
+	"twitch:vod":   "https://www.twitch.tv/videos/{id}",
+	"twitter":      "https://twitter.com/i/web/status/{id}",
+	"vimeo":        "https://vimeo.com/{id}",
+	"youtube":      "https://www.youtube.com/watch?v={id}",
+}
+
 // knownExecutable are dest or flag names that are executable (override the default url input).
 var knownExecutable = []string{
 	"--update-to",
@@ -0,0 +1,153 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// advancedGroups are option groups which are considered advanced, and should be
+// hidden by default in UIs.
+var advancedGroups = []string{
+	"Workarounds",
+	"Extractor",
+	"Internet Shortcut",
+	"Verbosity Simulation",
+}
+
+// labelAcronyms are words which should be uppercased when generating labels.
+var labelAcronyms = []string{
+	"api", "ascii", "cpu", "dns", "ffmpeg", "hls", "html", "http", "https", "id",
+	"ip", "ipv4", "ipv6", "json", "mpd", "tls", "uid", "url", "urls", "xff",
+}
+
+// FormField is the UI metadata for a single option, used to build forms for
+// download options.
+type FormField struct {
+	ID          string   `json:"id,omitempty"`
+	Flag        string   `json:"flag"`
+	Method      string   `json:"method"`
+	Label       string   `json:"label"`
+	Help        string   `json:"help,omitempty"`
+	Group       string   `json:"group"`
+	Input       string   `json:"input"`
+	Type        string   `json:"type"`
+	Choices     []string `json:"choices,omitempty"`
+	ArgNames    []string `json:"arg_names,omitempty"`
+	NArgs       int      `json:"nargs"`
+	Advanced    bool     `json:"advanced"`
+	Hidden      bool     `json:"hidden"`
+	Deprecated  string   `json:"deprecated,omitempty"`
+	Conflicting []string `json:"conflicting,omitempty"` // Methods which share the same ID.
+}
+
+// formLabel converts an option name (e.g. "write-info-json") into a human-readable
+// label (e.g. "Write Info JSON").
+func formLabel(name string) string {
+	words := strings.Split(name, "-")
+
+	for i, w := range words {
+		if slices.Contains(labelAcronyms, w) {
+			words[i] = strings.ToUpper(w)
+			continue
+		}
+
+		if w != "" {
+			words[i] = strings.ToUpper(w[:1]) + w[1:]
+		}
+	}
+
+	return strings.Join(words, " ")
+}
+
+// formInput returns the input type to use for the option.
+func formInput(o *Option) string {
+	switch {
+	case o.Executable:
+		return "action"
+	case len(o.Choices) > 0:
+		return "select"
+	case o.Type == "bool":
+		return "checkbox"
+	case o.Type == "int" || o.Type == "float64":
+		return "number"
+	default:
+		return "text"
+	}
+}
+
+// generateFormMetadata generates the UI form metadata for all options.
+func generateFormMetadata(groups []OptionGroup) []*FormField {
+	var fields []*FormField
+
+	byID := map[string][]string{}
+
+	for _, group := range groups {
+		for i := range group.Options {
+			o := &group.Options[i]
+
+			method := o.Method
+
+			if o.ID != "" {
+				byID[o.ID] = append(byID[o.ID], method)
+			}
+
+			field := &FormField{
+				ID:         o.ID,
+				Flag:       o.Flag,
+				Method:     method,
+				Label:      formLabel(o.Name),
+				Help:       o.Help,
+				Group:      group.Name,
+				Input:      formInput(o),
+				Type:       o.Type,
+				Choices:    o.Choices,
+				NArgs:      o.NArgs,
+				Advanced:   o.Hidden || o.Deprecated != "" || slices.Contains(advancedGroups, group.Name),
+				Hidden:     o.Hidden,
+				Deprecated: o.Deprecated,
+			}
+
+			if o.NArgs > 0 {
+				field.ArgNames = o.ArgNames
+			}
+
+			fields = append(fields, field)
+		}
+	}
+
+	for _, field := range fields {
+		for _, method := range byID[field.ID] {
+			if method != field.Method {
+				field.Conflicting = append(field.Conflicting, method)
+			}
+		}
+	}
+
+	return fields
+}
+
+func createJSONFile(dir, name string, data any) {
+	name = filepath.Join(dir, name)
+
+	// Check if the file exists first, and if it does, panic.
+	if _, err := os.Stat(name); err == nil {
+		panic("file " + name + " already exists, not doing anything")
+	}
+
+	b, err := json.MarshalIndent(data, "", "    ")
+	if err != nil {
+		panic(err)
+	}
+
+	err = os.WriteFile(name, append(b, '\n'), 0o644) //nolint:gosec
+	if err != nil {
+		panic(err)
+	}
+}
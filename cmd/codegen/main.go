@@ -53,6 +53,12 @@ var (
 			ParseGlob("./templates/builder*.gotmpl"),
 	)
 
+	runnerTmpl = template.Must(
+		template.New("runner.gotmpl").
+			Funcs(funcMap).
+			ParseFiles("./templates/runner.gotmpl", "./templates/builder_meta_args.gotmpl"),
+	)
+
 	optionDataTmpl = template.Must(
 		template.New("optiondata.gotmpl").
 			Funcs(funcMap).
@@ -172,4 +178,6 @@ func main() {
 	createTemplateFile(os.Args[2], "constants.gen.go", constantsTmpl, data)
 	createTemplateFile(os.Args[2], "builder.gen.go", builderTmpl, data)
 	createTemplateFile(os.Args[2], "builder.gen_test.go", builderTestTmpl, data)
+	createTemplateFile(os.Args[2], "runner.gen.go", runnerTmpl, data)
+	createJSONFile(os.Args[2], "optiondata/form.gen.json", generateFormMetadata(data.OptionGroups))
 }
@@ -5,6 +5,7 @@
 package main
 
 import (
+	"fmt"
 	"regexp"
 	"slices"
 	"strings"
@@ -13,6 +14,10 @@ import (
 )
 
 type Extractor struct {
+	// Generated fields.
+	URLTemplate string `json:"-"` // if known, the template used to build a URL from an ID.
+
+	// Extractor data fields.
 	Name        string `json:"name"`
 	Description string `json:"description"`
 	AgeLimit    int    `json:"age_limit"`
@@ -34,6 +39,37 @@ func (c *OptionData) Generate() {
 	for i := range c.OptionGroups {
 		c.OptionGroups[i].Generate(c)
 	}
+
+	// Unset methods are shared by options with the same name (ignoring any "No" or
+	// "Yes" prefix), and only generated once (see builder.gotmpl), removing the ID
+	// of the first such option, so only options with that ID are unset by it.
+	unsets := map[string]string{}
+
+	for i := range c.OptionGroups {
+		for j := range c.OptionGroups[i].Options {
+			o := &c.OptionGroups[i].Options[j]
+
+			if o.Executable {
+				continue
+			}
+
+			name := "Unset" + strings.TrimPrefix(strings.TrimPrefix(o.Method, "No"), "Yes")
+
+			id, ok := unsets[name]
+			if !ok {
+				unsets[name] = o.ID
+				id = o.ID
+			}
+
+			if id == o.ID {
+				o.UnsetMethod = name
+			}
+		}
+	}
+
+	for i := range c.Extractors {
+		c.Extractors[i].URLTemplate = extractorURLTemplates[c.Extractors[i].Name]
+	}
 }
 
 type OptionGroup struct {
@@ -63,14 +99,17 @@ func (o *OptionGroup) Generate(parent *OptionData) {
 
 type Option struct {
 	// Generated fields.
-	Parent     *OptionGroup `json:"-"` // Reference to parent.
-	Name       string       `json:"-"` // simplified name, based off the first found flags.
-	Flag       string       `json:"-"` // first flag (priority on long flags).
-	AllFlags   []string     `json:"-"` // all flags, short + long.
-	ArgNames   []string     `json:"-"` // MetaArgs converted to function arguments.
-	Executable bool         `json:"-"` // if the option means yt-dlp doesn't accept arguments, and some callback is done.
-	Deprecated string       `json:"-"` // if the option is deprecated, this will be the deprecation description.
-	URLs       []OptionURL  `json:"-"` // if the option has any links to the documentation.
+	Parent      *OptionGroup `json:"-"` // Reference to parent.
+	Name        string       `json:"-"` // simplified name, based off the first found flags.
+	Flag        string       `json:"-"` // first flag (priority on long flags).
+	AllFlags    []string     `json:"-"` // all flags, short + long.
+	ArgNames    []string     `json:"-"` // MetaArgs converted to function arguments.
+	Executable  bool         `json:"-"` // if the option means yt-dlp doesn't accept arguments, and some callback is done.
+	Deprecated  string       `json:"-"` // if the option is deprecated, this will be the deprecation description.
+	URLs        []OptionURL  `json:"-"` // if the option has any links to the documentation.
+	Method      string       `json:"-"` // builder method name.
+	UnsetMethod string       `json:"-"` // builder method which unsets the option, if any.
+	ConfigPath  string       `json:"-"` // JSONPath of the option within the JSON encoding of Command.GetFlagConfig.
 
 	// Command data fields.
 	ID           string   `json:"id"`
@@ -104,6 +143,9 @@ func (o *Option) Generate(parent *OptionGroup) {
 		o.Flag = o.ShortFlags[0]
 	}
 
+	o.Method = acronymReplacer.Replace(strcase.ToCamel(o.Name))
+	o.ConfigPath = fmt.Sprintf("$[?(@.flag==%q)]", o.Flag)
+
 	if slices.Contains(knownExecutable, o.ID) || slices.Contains(knownExecutable, o.Flag) {
 		o.Executable = true
 	}
@@ -0,0 +1,81 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"regexp"
+	"strings"
+)
+
+// ColorPolicy is the policy yt-dlp uses to decide whether to emit color codes
+// in output. See [Command.SetColorPolicy].
+type ColorPolicy string
+
+const (
+	ColorAlways     ColorPolicy = "always"
+	ColorAuto       ColorPolicy = "auto" // Default.
+	ColorNever      ColorPolicy = "never"
+	ColorNoColor    ColorPolicy = "no_color"     // Use non-color terminal sequences.
+	ColorAutoTTY    ColorPolicy = "auto-tty"     // Decide based on terminal support only.
+	ColorNoColorTTY ColorPolicy = "no_color-tty" // Decide based on terminal support only.
+)
+
+// ColorStream is the output stream that a [ColorPolicy] applies to.
+type ColorStream string
+
+const (
+	ColorStreamStdout ColorStream = "stdout"
+	ColorStreamStderr ColorStream = "stderr"
+)
+
+// SetColorPolicy is a typed wrapper around [Command.Color], which sets the color
+// policy for the provided streams (or all streams, if none are provided). Note
+// that ANSI escape sequences are always stripped from the lines stored in
+// [Result], when a color policy that may emit them is set.
+func (c *Command) SetColorPolicy(policy ColorPolicy, streams ...ColorStream) *Command {
+	c.UnsetColor()
+
+	if len(streams) == 0 {
+		return c.Color(string(policy))
+	}
+
+	for _, stream := range streams {
+		c.Color(string(stream) + ":" + string(policy))
+	}
+
+	return c
+}
+
+// mayEmitColor returns true if any of the color flags set on the command may
+// result in yt-dlp emitting ANSI escape sequences.
+func (c *Command) mayEmitColor() bool {
+	for _, f := range c.getFlagsByID("color") {
+		if len(f.Args) == 0 {
+			continue // --no-colors.
+		}
+
+		policy := f.Args[0]
+		if i := strings.LastIndex(policy, ":"); i >= 0 {
+			policy = policy[i+1:]
+		}
+
+		if policy != string(ColorNever) && policy != string(ColorNoColor) {
+			return true
+		}
+	}
+
+	return false
+}
+
+var reANSI = regexp.MustCompile(`\x1b\[[0-9;?]*[ -/]*[@-~]|\x1b\][^\x07\x1b]*(?:\x07|\x1b\\)|\x1b[@-Z\\-_]`)
+
+// StripANSI removes any ANSI escape sequences (e.g. color codes) from s.
+func StripANSI(s string) string {
+	if !strings.Contains(s, "\x1b") {
+		return s
+	}
+
+	return reANSI.ReplaceAllString(s, "")
+}
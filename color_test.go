@@ -0,0 +1,43 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+func TestColor_StripANSI(t *testing.T) {
+	tests := map[string]string{
+		"plain":                                   "plain",
+		"\x1b[0;32m[download]\x1b[0m 100%":        "[download] 100%",
+		"\x1b]0;title\x07[info] foo":              "[info] foo",
+		"\x1b[1;31mERROR:\x1b[0m something broke": "ERROR: something broke",
+	}
+
+	for in, want := range tests {
+		if got := StripANSI(in); got != want {
+			t.Fatalf("expected %q, got %q", want, got)
+		}
+	}
+}
+
+func TestColor_SetColorPolicy(t *testing.T) {
+	builder := New().SetColorPolicy(ColorAlways, ColorStreamStdout, ColorStreamStderr)
+
+	cmd := builder.buildCommand(context.Background())
+	if !slices.Contains(cmd.Args, "stdout:always") || !slices.Contains(cmd.Args, "stderr:always") {
+		t.Fatalf("expected per-stream color policies, got %#v", cmd.Args)
+	}
+
+	if !builder.mayEmitColor() {
+		t.Fatal("expected command to emit color")
+	}
+
+	if New().SetColorPolicy(ColorNever).mayEmitColor() {
+		t.Fatal("expected command to not emit color")
+	}
+}
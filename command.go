@@ -6,8 +6,16 @@ package ytdlp
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"maps"
+	"os"
 	"os/exec"
+	"slices"
+	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 // New is the recommended way to return a new yt-dlp command builder. Once all
@@ -21,6 +29,11 @@ func New() *Command {
 	return cmd
 }
 
+// cancelMaxWait is the maximum amount of time to wait for yt-dlp (and any of its
+// child processes) to exit, and for its output to be drained, after the context
+// of an invocation is cancelled.
+const cancelMaxWait = 5 * time.Second
+
 type Command struct {
 	mu         sync.RWMutex
 	executable string
@@ -28,18 +41,108 @@ type Command struct {
 	env        map[string]string
 	flags      []*Flag
 
-	progress *progressHandler
+	maxStdoutBytes int64
+	maxStderrBytes int64
+
+	stdout io.Writer
+	stderr io.Writer
+
+	progress     *progressHandler
+	checkpointFn SegmentCheckpointCallbackFunc
+	logFn        LogCallbackFunc
+	promptFn     PromptCallbackFunc
+	retry        *RetryPolicy
+
+	throughputSamples  int
+	jsRuntimeInstallFn JSRuntimeInstallFunc
+
+	resolver *Resolver
+	priority *ProcessPriority
+
+	sponsorBlockTolerance time.Duration
+	integrity             *IntegrityOptions
+	duplicates            DuplicateStrategy
+	stats                 StatsRecorder
+	python                *pythonModule
+	bandwidth             bool
+	safeMode              bool
+	split                 *BatchSplit
+	faults                FaultInjector
+	noHeaderRedaction     bool
+	vodWait               *VODWaitOptions
+	ytdlpVersion          string
+	ffmpegFallback        *FFmpegFallbackOptions
+	completeFn            CompleteCallbackFunc
+	progressPrefix        string
+	customProgressPrefix  bool // Set with [Command.SetProgressPrefix], so kept by [Command.Clone].
+
+	onStart func(pid int) // Invoked with the PID of yt-dlp once started (see [Command.Start]).
 }
 
 // Clone returns a copy of the command, with all flags, env vars, executable, and
-// working directory copied over.
+// working directory copied over. Callbacks (e.g. [Command.ProgressFunc]) and
+// output writers (e.g. [Command.SetStdout]) aren't copied, so must be set again
+// on the copy if needed.
 func (c *Command) Clone() *Command {
+	cc := c.clone()
+
+	cc.mu.Lock()
+	cc.stdout = nil
+	cc.stderr = nil
+	cc.progress = nil
+	cc.checkpointFn = nil
+	cc.logFn = nil
+	cc.promptFn = nil
+	cc.completeFn = nil
+	cc.onStart = nil
+	cc.mu.Unlock()
+
+	return cc
+}
+
+// clone is the same as [Command.Clone], however callbacks and output writers are
+// shared with the copy. It's used when invoking yt-dlp on behalf of the command
+// (e.g. [Command.RunConcurrently]), so callbacks still receive updates.
+func (c *Command) clone() *Command {
 	c.mu.RLock()
 	cc := &Command{
-		executable: c.executable,
-		directory:  c.directory,
-		env:        make(map[string]string, len(c.env)),
-		flags:      make([]*Flag, len(c.flags)),
+		executable:     c.executable,
+		directory:      c.directory,
+		env:            make(map[string]string, len(c.env)),
+		flags:          make([]*Flag, len(c.flags)),
+		maxStdoutBytes: c.maxStdoutBytes,
+		maxStderrBytes: c.maxStderrBytes,
+		stdout:         c.stdout,
+		stderr:         c.stderr,
+		progress:       c.progress,
+		checkpointFn:   c.checkpointFn,
+		logFn:          c.logFn,
+		promptFn:       c.promptFn,
+		retry:          c.retry,
+
+		throughputSamples:  c.throughputSamples,
+		jsRuntimeInstallFn: c.jsRuntimeInstallFn,
+
+		resolver: c.resolver,
+		priority: c.priority,
+		onStart:  c.onStart,
+
+		sponsorBlockTolerance: c.sponsorBlockTolerance,
+		integrity:             c.integrity,
+		duplicates:            c.duplicates,
+		stats:                 c.stats,
+		python:                c.python,
+		bandwidth:             c.bandwidth,
+		safeMode:              c.safeMode,
+		split:                 c.split,
+		faults:                c.faults,
+		noHeaderRedaction:     c.noHeaderRedaction,
+		vodWait:               c.vodWait,
+		ytdlpVersion:          c.ytdlpVersion,
+		ffmpegFallback:        c.ffmpegFallback,
+		completeFn:            c.completeFn,
+		progressPrefix:        c.progressPrefix,
+		customProgressPrefix:  c.customProgressPrefix,
 	}
 
 	for k, v := range c.env {
@@ -51,9 +154,21 @@ func (c *Command) Clone() *Command {
 	}
 	c.mu.RUnlock()
 
+	// Progress lines from the copy shouldn't be mistaken for progress of the
+	// original, e.g. when one is the input of the other.
+	if cc.progressPrefix != "" && !cc.customProgressPrefix {
+		cc.SetProgressPrefix("")
+	}
+
 	return cc
 }
 
+// CloneRunner is the same as [Command.Clone], however the copy is returned as a
+// [Runner], so that [Command] implements [Runner].
+func (c *Command) CloneRunner() Runner {
+	return c.Clone()
+}
+
 // SetExecutable sets the executable path to yt-dlp for the command.
 func (c *Command) SetExecutable(path string) *Command {
 	c.mu.Lock()
@@ -87,6 +202,123 @@ func (c *Command) SetEnvVar(key, value string) *Command {
 	return c
 }
 
+// SetMaxCaptureBytes sets the maximum number of bytes of stdout and stderr that
+// will be captured (and stored in the [Result]). Once a limit is reached, further
+// output lines from that pipe are discarded, and [Result.StdoutTruncated] or
+// [Result.StderrTruncated] will be set. This is useful to protect against
+// unexpectedly large output (e.g. [Command.DumpPages]). A limit of 0 or less
+// means unlimited, which is the default.
+func (c *Command) SetMaxCaptureBytes(stdout, stderr int64) *Command {
+	c.mu.Lock()
+	c.maxStdoutBytes = stdout
+	c.maxStderrBytes = stderr
+	c.mu.Unlock()
+
+	return c
+}
+
+// SetStdout sets a writer which receives the raw stdout output of yt-dlp as it's
+// written (e.g. to a terminal, websocket, or log file), in addition to it being
+// captured in the [Result]. Errors returned by the writer are ignored. Writes to
+// the stdout and stderr writers are serialized, so the same writer can be used
+// for both. Pass nil to unset it.
+func (c *Command) SetStdout(w io.Writer) *Command {
+	c.mu.Lock()
+	c.stdout = w
+	c.mu.Unlock()
+
+	return c
+}
+
+// SetStderr is the same as [Command.SetStdout], for the stderr output of yt-dlp.
+func (c *Command) SetStderr(w io.Writer) *Command {
+	c.mu.Lock()
+	c.stderr = w
+	c.mu.Unlock()
+
+	return c
+}
+
+// rawFlagID is the ID used for flags added via [Command.AddRawFlags]. It can't
+// conflict with yt-dlp's own IDs/"dests", which are always valid Python identifiers.
+const rawFlagID = ":raw"
+
+// AddRawFlags adds flags which aren't (yet) exposed by the generated builder methods,
+// e.g. flags added in a newer yt-dlp release than go-ytdlp was generated with. Each
+// argument must be a flag (i.e. start with "-"). Flags which accept a value should
+// use the "--flag=value" syntax. Invalid flags will cause [Command.Run] (or similar)
+// to return an error. Raw flags can be retrieved with [Command.GetRawFlags], and
+// removed with [Command.UnsetRawFlags]. They're also included (with their own ID)
+// in [Command.GetFlagConfig], so [Command.SetFlagConfig] restores them as raw flags.
+func (c *Command) AddRawFlags(args ...string) *Command {
+	for _, arg := range args {
+		// Args is non-nil, so raw flags are never de-duplicated.
+		c.addFlag(&Flag{ID: rawFlagID, Flag: arg, Args: []string{}})
+	}
+
+	return c
+}
+
+// GetFlagConfig returns a copy of all flags currently set on the command (both
+// those set with builder methods, and raw flags added with [Command.AddRawFlags]),
+// in the order they were added. The flags can be stored (e.g. as JSON), and
+// restored with [Command.SetFlagConfig].
+func (c *Command) GetFlagConfig() []*Flag {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	flags := make([]*Flag, len(c.flags))
+	for i, f := range c.flags {
+		flags[i] = f.Clone()
+		flags[i].Args = slices.Clone(f.Args)
+	}
+
+	return flags
+}
+
+// SetFlagConfig replaces all flags set on the command with copies of the provided
+// flags (e.g. from [Command.GetFlagConfig]), in the same order. Raw flags keep
+// their own ID, so they're restored as raw flags, and validated the same way when
+// the command is invoked.
+func (c *Command) SetFlagConfig(flags []*Flag) *Command {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.flags = make([]*Flag, len(flags))
+	for i, f := range flags {
+		c.flags[i] = f.Clone()
+		c.flags[i].Args = slices.Clone(f.Args)
+	}
+
+	return c
+}
+
+// GetRawFlags returns all flags which were previously added with [Command.AddRawFlags].
+func (c *Command) GetRawFlags() []string {
+	var args []string
+
+	for _, f := range c.getFlagsByID(rawFlagID) {
+		args = append(args, f.Flag)
+	}
+
+	return args
+}
+
+// UnsetRawFlags removes all flags which were previously added with [Command.AddRawFlags].
+func (c *Command) UnsetRawFlags() *Command {
+	c.removeFlagByID(rawFlagID)
+	return c
+}
+
+// validateRawFlag returns an error if the raw flag is invalid.
+func validateRawFlag(flag string) error {
+	if len(flag) < 2 || flag[0] != '-' || flag == "--" { //nolint:gomnd
+		return fmt.Errorf("invalid raw flag %q: must be a flag, starting with \"-\"", flag)
+	}
+
+	return nil
+}
+
 // getFlagsByID returns all flags with the provided ID/"dest".
 func (c *Command) getFlagsByID(id string) []*Flag {
 	c.mu.RLock()
@@ -127,12 +359,10 @@ func (c *Command) removeFlagByID(id string) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	for i, f := range c.flags {
-		if f.ID == id {
-			c.flags = append(c.flags[:i], c.flags[i+1:]...)
-			// don't return as there might be multiple.
-		}
-	}
+	// don't stop at the first match, as there might be multiple.
+	c.flags = slices.DeleteFunc(c.flags, func(f *Flag) bool {
+		return f.ID == id
+	})
 }
 
 func (c *Command) hasJSONFlag() bool {
@@ -140,45 +370,129 @@ func (c *Command) hasJSONFlag() bool {
 
 	return (len(pf) > 0 && len(pf[0].Args) > 0 && pf[0].Args[0] == "%()j") ||
 		c.getFlagsByID("print_json") != nil ||
-		c.getFlagsByID("dumpjson") != nil
+		c.getFlagsByID("dumpjson") != nil ||
+		c.getFlagsByID("dump_single_json") != nil
+}
+
+// builtCommand is a command built with [Command.buildCommand], along with what it
+// was built from.
+type builtCommand struct {
+	*exec.Cmd
+
+	prefix []string // Args before the flags, e.g. when invoking yt-dlp with python.
+	flags  []*Flag  // Flags passed to yt-dlp, including those added by go-ytdlp.
+	inputs []string // Inputs passed to yt-dlp after the flags, e.g. URLs.
+	safe   bool     // Whether safe mode was enabled.
+}
+
+// maskedArgs returns the args of the command (excluding the executable), with any
+// secrets masked.
+func (b *builtCommand) maskedArgs() []string {
+	return maskArgs(b.prefix, b.flags, b.inputs)
 }
 
 // buildCommand builds the command to be executed. args passed here are any additional
 // arguments to be passed to yt-dlp (commonly URLs or similar).
-func (c *Command) buildCommand(ctx context.Context, args ...string) *exec.Cmd {
-	var cmdArgs []string
+func (c *Command) buildCommand(ctx context.Context, args ...string) *builtCommand {
+	var cmdArgs, prefix []string
+
+	c.mu.RLock()
+	flags, _, err := c.resolveFlags()
+	safe, dir := c.safeMode || safeMode.Load(), c.directory
+	c.mu.RUnlock()
+
+	if safe && err == nil {
+		flags, err = applySafeMode(flags, dir)
+	}
+
+	if safe && err == nil {
+		err = checkSafeInputs(args)
+	}
+
+	// Added after safe mode, as the cache isn't controlled by the caller.
+	if f := ytdlpCacheFlag(flags); f != nil && err == nil {
+		flags = append(flags, f)
+	}
+
+	// Plugins execute arbitrary code, so aren't loaded in safe mode.
+	if f := pluginDirsFlag(flags); f != nil && err == nil && !safe {
+		flags = append(flags, f)
+	}
+
+	for _, f := range flags {
+		if f.ID == rawFlagID && err == nil {
+			err = validateRawFlag(f.Flag)
+		}
+
+		if f.err != nil && err == nil {
+			err = f.err
+		}
 
-	for _, f := range c.flags {
 		cmdArgs = append(cmdArgs, f.Raw()...)
 	}
 
 	cmdArgs = append(cmdArgs, args...) // URLs or similar.
 
 	var name string
-	var err error
 
 	c.mu.RLock()
 	name = c.executable
 
-	if name == "" {
+	if c.python != nil {
+		name = c.python.python
+		prefix = c.python.args()
+		cmdArgs = append(prefix, cmdArgs...)
+
+		if err == nil {
+			err = c.python.validate()
+		}
+	}
+
+	if c.priority != nil && err == nil {
+		if perr := c.priority.Validate(); perr != nil {
+			err = fmt.Errorf("unable to set process priority: %w", perr)
+		}
+	}
+
+	if name == "" && err == nil {
 		var r *ResolvedInstall
-		r, err = resolveExecutable(true, false)
+		if c.ytdlpVersion != "" {
+			r, err = c.resolver.resolveVersion(ctx, c.ytdlpVersion)
+		} else {
+			r, err = c.resolver.resolve(ctx)
+		}
 		if err == nil {
 			name = r.Executable
 		}
 	}
 
+	// If PATH was overridden for the command, resolve the executable using it,
+	// rather than the PATH of the current process.
+	if pathList, ok := lookupEnv(c.env, "PATH"); ok && name != "" && !strings.ContainsAny(name, pathSeparators) {
+		if bin, lerr := lookupExecutable(name, pathList); lerr == nil {
+			name = bin
+		}
+	}
+
 	cmd := exec.CommandContext(ctx, name, cmdArgs...)
+	cmd.WaitDelay = cancelMaxWait
+	c.applySyscall(cmd)
+
+	if err == nil {
+		err = checkCapabilities(cmd.Path, c.python, flags)
+	}
 
 	if err != nil {
-		cmd.Err = err // Hijack the existing command to return the error from resolveExecutable.
+		cmd.Err = err // Hijack the existing command to return the error from validation/resolveExecutable.
 	}
 
 	if c.directory != "" {
 		cmd.Dir = c.directory
 	}
 
-	if len(c.env) > 0 {
+	if c.python != nil {
+		cmd.Env = c.python.environ(c.env)
+	} else if len(c.env) > 0 {
 		cmd.Env = make([]string, 0, len(c.env))
 		for k, v := range c.env {
 			cmd.Env = append(cmd.Env, k+"="+v)
@@ -186,37 +500,182 @@ func (c *Command) buildCommand(ctx context.Context, args ...string) *exec.Cmd {
 	}
 	c.mu.RUnlock()
 
-	return cmd
+	return &builtCommand{Cmd: cmd, prefix: prefix, flags: flags, inputs: args, safe: safe}
+}
+
+// BuildVersionCommand returns the [exec.Cmd] that [Command.Version] would invoke,
+// without running it, which can be useful for inspection. Any flags already set
+// on the command are included, and the command itself is not modified.
+func (c *Command) BuildVersionCommand(ctx context.Context) *exec.Cmd {
+	cc := c.clone()
+	cc.addFlag(&Flag{
+		ID:   "",
+		Flag: "--version",
+		Args: nil,
+	})
+
+	return cc.buildCommand(ctx).Cmd
 }
 
 // runWithResult runs the provided command, collects stdout/stderr, massages the
 // result into a Result struct, and returns it (with error wrapping).
-func (c *Command) runWithResult(cmd *exec.Cmd) (*Result, error) {
+func (c *Command) runWithResult(ctx context.Context, cmd *builtCommand, inputs ...string) (*Result, error) {
 	if cmd.Err != nil {
 		return wrapError(nil, cmd.Err)
 	}
 
-	stdout := &timestampWriter{pipe: "stdout", progress: c.progress}
-	stderr := &timestampWriter{pipe: "stderr"}
+	seq := &atomic.Uint64{}
+
+	c.mu.RLock()
+	resolved, dropped, _ := c.resolveFlags()
+
+	// Internal flags depend on the state of the invocation (e.g. temporary files),
+	// so can't be reproduced with [Result.Command].
+	flags := make([]*Flag, 0, len(resolved))
+	for _, f := range resolved {
+		if !f.internal {
+			flags = append(flags, f.Clone())
+		}
+	}
+
+	for i, f := range dropped {
+		dropped[i] = f.Clone()
+	}
+
+	env := maps.Clone(c.env)
+	panics := &callbackPanics{}
+	progress := c.progress
+
+	var bandwidth *bandwidthTracker
+	if c.bandwidth {
+		bandwidth = newBandwidthTracker()
+	}
+
+	faults := c.faults
+	var kill sync.Once
+
+	if c.checkpointFn != nil || bandwidth != nil || faults != nil {
+		// Compose a per-invocation handler, as checkpoint, bandwidth and fault
+		// state is per-invocation.
+		var tracker *checkpointTracker
+		if c.checkpointFn != nil {
+			tracker = newCheckpointTracker(c.checkpointFn, cmd.Dir)
+		}
+
+		userProgress := c.progress
+		progress = newProgressHandler(func(update ProgressUpdate) {
+			if faults != nil && faults.InjectProgress(&update) {
+				kill.Do(func() { _ = cmd.Process.Kill() })
+				return
+			}
+
+			if tracker != nil {
+				tracker.record(update)
+			}
+
+			if bandwidth != nil {
+				bandwidth.record(update)
+			}
+
+			if userProgress != nil {
+				userProgress.fn(update)
+			}
+		})
+	}
+
+	stdout := &timestampWriter{pipe: "stdout", seq: seq, progress: progress, progressPrefix: []byte(c.progressPrefix), inputs: inputs, maxBytes: c.maxStdoutBytes, panics: panics, faults: faults}
+	stderr := &timestampWriter{pipe: "stderr", seq: seq, maxBytes: c.maxStderrBytes, panics: panics, faults: faults}
+	if c.logFn != nil {
+		log := &logHandler{fn: c.logFn}
+		stdout.log = log
+		stderr.log = log
+	}
+
+	promptFn := c.promptFn
+	priority := c.priority
+	onStart := c.onStart
+	redact := !c.noHeaderRedaction
+	teeMu := &sync.Mutex{}
+	teeStdout := &teeWriter{w: stdout, tee: c.stdout, mu: teeMu}
+	teeStderr := &teeWriter{w: stderr, tee: c.stderr, mu: teeMu}
+	c.mu.RUnlock()
 
 	if c.hasJSONFlag() {
 		stdout.checkJSON = true
 		stderr.checkJSON = true
 	}
 
-	cmd.Stdout = stdout
-	cmd.Stderr = stderr
+	if c.mayEmitColor() {
+		stdout.stripANSI = true
+		stderr.stripANSI = true
+	}
 
-	c.applySyscall(cmd)
-	err := cmd.Run()
+	if redact && c.mayLogHeaders() {
+		stdout.redact = true
+		stderr.redact = true
+	}
+
+	cmd.Stdout = teeStdout
+	cmd.Stderr = teeStderr
+
+	if promptFn != nil {
+		stdin, err := cmd.StdinPipe()
+		if err != nil {
+			return wrapError(nil, fmt.Errorf("unable to create stdin pipe: %w", err))
+		}
+
+		prompt := &promptHandler{ctx: ctx, fn: promptFn, stdin: stdin, panics: panics}
+		stdout.prompt = prompt
+		stderr.prompt = prompt
+	}
+
+	started := time.Now()
+
+	var perr error
+
+	err := startProcess(cmd.Cmd, priority)
+	if err == nil {
+		if onStart != nil {
+			onStart(cmd.Process.Pid)
+		}
+
+		// Not fatal, as yt-dlp is already running, so returned once it exits.
+		perr = c.applyPriority(cmd.Cmd, priority)
+		err = cmd.Wait()
+	}
+
+	if perr != nil && err == nil {
+		err = fmt.Errorf("unable to set process priority: %w", perr)
+	}
 
 	result := &Result{
 		Executable: cmd.Path,
-		Args:       cmd.Args[1:],
+		Args:       cmd.maskedArgs(),
+		Flags:      maskFlags(flags),
+		SafeMode:   cmd.safe,
+		Inputs:     inputs,
+		WorkDir:    cmd.Dir,
+		Env:        maskEnvVars(env),
 		ExitCode:   cmd.ProcessState.ExitCode(),
 		Stdout:     stdout.String(),
 		Stderr:     stderr.String(),
 		OutputLogs: stdout.mergeResults(stderr),
+
+		StdoutTruncated: stdout.truncated,
+		StderrTruncated: stderr.truncated,
+		DroppedFlags:    maskFlags(dropped),
+		CallbackPanics:  panics.list(),
+	}
+
+	result.FormatDownloads = parseFormatDownloads(result.OutputLogs)
+	result.duration = time.Since(started)
+
+	if bandwidth != nil {
+		result.bytesByCategory = bandwidth.bytesByCategory(result.OutputLogs, cmd.Dir)
+	}
+
+	if err != nil && ctx.Err() != nil {
+		return result, &ErrCanceled{wrapped: ctx.Err(), result: result}
 	}
 
 	return wrapError(result, err)
@@ -224,26 +683,187 @@ func (c *Command) runWithResult(cmd *exec.Cmd) (*Result, error) {
 
 // Run invokes yt-dlp with the provided arguments (and any flags previously set),
 // and returns the results (stdout/stderr, exit code, etc). args should be the
-// URLs that would normally be passed in to yt-dlp.
+// URLs that would normally be passed in to yt-dlp. Failed invocations are retried
+// according to the policy set with [Command.SetRetryPolicy], if any, and once more
+// if a JavaScript runtime was installed with the function set with
+// [Command.SetJSRuntimeInstallFunc]. See [Command.VerifySponsorBlock] and
+// [Command.VerifyIntegrity] for verification of the downloaded files, and
+// [Command.SetBatchSplit] for splitting many URLs across multiple processes, and
+// [Command.WaitForVOD] for waiting on livestream VODs which are still processing.
+// See [Command.OnComplete] for a callback invoked with a summary of each run.
+//
+// If ctx is cancelled (or its deadline is exceeded) before yt-dlp exits, yt-dlp
+// (and any child processes) are killed, and [ErrCanceled] is returned, along with
+// the partial results (including all output logs captured up until that point).
 func (c *Command) Run(ctx context.Context, args ...string) (*Result, error) {
+	c.mu.RLock()
+	tolerance := c.sponsorBlockTolerance
+	integrity := c.integrity
+	stats := c.stats
+	split := c.split
+	vodWait := c.vodWait
+	ffmpegFallback := c.ffmpegFallback
+	completeFn := c.completeFn
+	c.mu.RUnlock()
+
+	if completeFn != nil {
+		started := time.Now()
+		result, err := c.clone().OnComplete(nil).Run(ctx, args...)
+
+		p := callSafely("complete", func() { completeFn(newRunSummary(started, args, result, err)) })
+		if p != nil && result != nil {
+			result.CallbackPanics = append(result.CallbackPanics, p)
+		}
+
+		return result, err
+	}
+
+	if split != nil && len(args) > split.Size {
+		return c.runSplit(ctx, split, args...)
+	}
+
+	if stats != nil {
+		started := time.Now()
+		result, err := c.clone().SetStatsRecorder(nil).Run(ctx, args...)
+
+		p := callSafely("stats", func() { stats.RecordRun(newRunStats(started, result, err)) })
+		if p != nil && result != nil {
+			result.CallbackPanics = append(result.CallbackPanics, p)
+		}
+
+		return result, err
+	}
+
+	if integrity != nil {
+		return c.runVerifyIntegrity(ctx, integrity, args...)
+	}
+
+	if ffmpegFallback != nil {
+		return c.runFFmpegFallback(ctx, ffmpegFallback, args...)
+	}
+
+	if vodWait != nil {
+		return c.runWaitForVOD(ctx, vodWait, args...)
+	}
+
+	if tolerance > 0 && len(c.getFlagsByID("sponsorblock_remove")) > 0 {
+		return c.runVerifySponsorBlock(ctx, tolerance, args...)
+	}
+
+	result, err := c.run(ctx, args...)
+
+	if c.handleJSRuntimeRequired(ctx, &err) {
+		return c.run(ctx, args...)
+	}
+
+	return result, err
+}
+
+// run invokes yt-dlp once, or multiple times according to the retry policy.
+func (c *Command) run(ctx context.Context, args ...string) (*Result, error) {
+	c.mu.RLock()
+	policy := c.retry
+	c.mu.RUnlock()
+
+	if policy != nil && policy.MaxAttempts > 1 {
+		return c.runWithRetry(ctx, policy, args...)
+	}
+
 	cmd := c.buildCommand(ctx, args...)
-	return c.runWithResult(cmd)
+	return c.runWithResult(ctx, cmd, args...)
+}
+
+// RunWithInfo is the same as [Command.Run], however the extracted info of each
+// video is also parsed and returned, so [Command.DumpJSON] (or similar) and
+// [Result.GetExtractedInfo] don't need to be used manually. If no JSON output
+// flags are set, [Command.DumpJSON] is used (with [Command.NoSimulate], so videos
+// are still downloaded, unless simulation was explicitly configured). The command
+// itself is not modified.
+func (c *Command) RunWithInfo(ctx context.Context, urls ...string) (*Result, []*ExtractedInfo, error) {
+	cmd := c
+
+	if !c.hasJSONFlag() {
+		cmd = c.clone().DumpJSON()
+
+		if len(cmd.getFlagsByID("simulate")) == 0 {
+			cmd.NoSimulate()
+		}
+	}
+
+	result, err := cmd.Run(ctx, urls...)
+	if err != nil {
+		return result, nil, err
+	}
+
+	infos, err := result.GetExtractedInfo()
+	if err != nil {
+		return result, nil, fmt.Errorf("unable to parse extracted info: %w", err)
+	}
+
+	return result, infos, nil
+}
+
+// RunInTempDir is the same as [Command.Run], however yt-dlp is invoked within a
+// newly created (unique) temporary directory, preventing concurrent invocations
+// from clobbering shared relative paths. If a working directory was set with
+// [Command.SetWorkDir], the temporary directory will be created within it,
+// otherwise the default temporary directory ([os.TempDir]) is used. The command
+// itself is not modified.
+//
+// The caller is responsible for calling cleanup (which removes the directory and
+// all of its contents) once done with any downloaded files. cleanup is always
+// non-nil, and is safe to call multiple times, even when an error is returned.
+func (c *Command) RunInTempDir(ctx context.Context, args ...string) (result *Result, dir string, cleanup func(), err error) {
+	c.mu.RLock()
+	base := c.directory
+	c.mu.RUnlock()
+
+	dir, err = os.MkdirTemp(base, "go-ytdlp-*")
+	if err != nil {
+		return nil, "", func() {}, fmt.Errorf("unable to create temporary working directory: %w", err)
+	}
+
+	var once sync.Once
+	cleanup = func() {
+		once.Do(func() { _ = os.RemoveAll(dir) })
+	}
+
+	result, err = c.clone().SetWorkDir(dir).Run(ctx, args...)
+	return result, dir, cleanup, err
 }
 
 type Flag struct {
 	ID   string   `json:"id"`   // Unique ID to ensure boolean flags are not duplicated.
 	Flag string   `json:"flag"` // Actual flag, e.g. "--version".
 	Args []string `json:"args"` // Optional args. If nil, it's a boolean flag.
+
+	err      error // Validation error, returned when the command is invoked.
+	internal bool  // Added by go-ytdlp itself, so exempt from safe mode path checks.
 }
 
 func (f *Flag) Clone() *Flag {
 	return &Flag{
-		ID:   f.ID,
-		Flag: f.Flag,
-		Args: f.Args,
+		ID:       f.ID,
+		Flag:     f.Flag,
+		Args:     f.Args,
+		err:      f.err,
+		internal: f.internal,
 	}
 }
 
+// printToFileInternal is the same as [Command.PrintToFile], however path is
+// escaped, and the flag is marked as added by go-ytdlp itself (e.g. to record
+// downloaded files to a temporary file), so it's allowed in safe mode.
+func (c *Command) printToFileInternal(template, path string) *Command {
+	c.addFlag(&Flag{
+		ID:       "print_to_file",
+		Flag:     "--print-to-file",
+		Args:     []string{template, escapeTemplate(path)},
+		internal: true,
+	})
+	return c
+}
+
 func (f *Flag) Raw() (args []string) {
 	args = append(args, f.Flag)
 	if f.Args == nil {
@@ -2,7 +2,7 @@
 // this source code is governed by the MIT license that can be found in
 // the LICENSE file.
 
-//go:build !windows
+//go:build !windows && !unix
 
 package ytdlp
 
@@ -14,3 +14,8 @@ import (
 func (c *Command) applySyscall(_ *exec.Cmd) {
 	// No-op by default.
 }
+
+// applyPriority applies the process priority to the started command.
+func (c *Command) applyPriority(_ *exec.Cmd, _ *ProcessPriority) error {
+	return nil // Not supported.
+}
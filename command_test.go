@@ -6,12 +6,18 @@
 package ytdlp
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"io/fs"
 	"os"
 	"path/filepath"
+	"reflect"
 	"slices"
+	"strings"
 	"testing"
 	"time"
 )
@@ -187,6 +193,23 @@ func TestCommand_Clone(t *testing.T) {
 	}
 }
 
+func TestCommand_Clone_Callbacks(t *testing.T) {
+	t.Parallel()
+
+	cmd := New().
+		ProgressFunc(time.Second, func(ProgressUpdate) {}).
+		LogFunc(func(ResultLog) {}).
+		SetStdout(io.Discard)
+
+	if cc := cmd.Clone(); cc.progress != nil || cc.logFn != nil || cc.stdout != nil {
+		t.Fatal("expected callbacks not to be copied")
+	}
+
+	if cc := cmd.clone(); cc.progress == nil || cc.logFn == nil || cc.stdout == nil {
+		t.Fatal("expected callbacks to be shared with internal copies")
+	}
+}
+
 func TestCommand_SetExecutable(t *testing.T) {
 	MustInstall(context.Background(), nil)
 
@@ -216,3 +239,338 @@ func TestCommand_SetEnvVar(t *testing.T) {
 		t.Fatalf("expected env var to be TEST=1, got %s", cmd.Env[0])
 	}
 }
+
+func TestCommand_AddRawFlags(t *testing.T) {
+	MustInstall(context.Background(), nil)
+
+	builder := New().NoProgress().AddRawFlags("--some-new-flag", "--some-new-option=foo", "--some-new-flag")
+
+	if got := builder.GetRawFlags(); len(got) != 3 {
+		t.Fatalf("expected 3 raw flags, got %#v", got)
+	}
+
+	cmd := builder.buildCommand(context.Background(), sampleFiles[0].url)
+
+	if cmd.Err != nil {
+		t.Fatalf("expected no error, got %v", cmd.Err)
+	}
+
+	if !slices.Contains(cmd.Args, "--some-new-option=foo") {
+		t.Fatalf("expected raw flag to be set, got %#v", cmd.Args)
+	}
+
+	cmd = builder.Clone().AddRawFlags("not-a-flag").buildCommand(context.Background(), sampleFiles[0].url)
+
+	if cmd.Err == nil {
+		t.Fatal("expected error for invalid raw flag")
+	}
+
+	if got := builder.UnsetRawFlags().GetRawFlags(); len(got) != 0 {
+		t.Fatalf("expected raw flags to be removed, got %#v", got)
+	}
+}
+
+func TestCommand_FlagConfig(t *testing.T) {
+	t.Parallel()
+
+	cmd := New().NoProgress().Output("%(id)s.%(ext)s").AddRawFlags("--some-new-option=foo", "--some-new-flag")
+
+	b, err := json.Marshal(cmd.GetFlagConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var flags []*Flag
+
+	if err = json.Unmarshal(b, &flags); err != nil {
+		t.Fatal(err)
+	}
+
+	restored := New().Verbose().SetFlagConfig(flags)
+
+	if !reflect.DeepEqual(restored.GetFlagConfig(), cmd.GetFlagConfig()) {
+		t.Fatalf("expected flags to round-trip, got %v", restored.GetFlagConfig())
+	}
+
+	if got := restored.GetRawFlags(); !slices.Equal(got, []string{"--some-new-option=foo", "--some-new-flag"}) {
+		t.Fatalf("expected raw flags to round-trip, got %#v", got)
+	}
+
+	if len(restored.getFlagsByID("verbose")) != 0 {
+		t.Fatal("expected existing flags to be replaced")
+	}
+
+	for _, f := range flags {
+		if f.ID == "outtmpl" {
+			f.Args[0] = "modified"
+		}
+	}
+
+	if restored.getFlagsByID("outtmpl")[0].Args[0] != "%(id)s.%(ext)s" {
+		t.Fatal("expected flags to be copied")
+	}
+}
+
+func TestCommand_SetFlagConfig_Unknown(t *testing.T) {
+	t.Parallel()
+
+	// Flags from a newer go-ytdlp version, which this version doesn't know about.
+	var flags []*Flag
+
+	err := json.Unmarshal([]byte(`[{"id":"future_option","flag":"--future-option","args":["foo"]},{"id":"future_flag","flag":"--future-flag","args":null}]`), &flags)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := New().SetFlagConfig(flags).GetFlagConfig()
+
+	if len(got) != 2 || !slices.Equal(got[0].Raw(), []string{"--future-option", "foo"}) || !slices.Equal(got[1].Raw(), []string{"--future-flag"}) {
+		t.Fatalf("expected unknown flags to be kept as-is, got %v", got)
+	}
+}
+
+func TestCommand_RunInTempDir(t *testing.T) {
+	MustInstall(context.Background(), nil)
+
+	base := t.TempDir()
+
+	res, dir, cleanup, err := New().SetWorkDir(base).RunInTempDir(context.Background(), "--version")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if filepath.Dir(dir) != base {
+		t.Fatalf("expected temp dir to be created within %q, got %q", base, dir)
+	}
+
+	if res.WorkDir != dir {
+		t.Fatalf("expected result workdir to be %q, got %q", dir, res.WorkDir)
+	}
+
+	if _, err = os.Stat(dir); err != nil {
+		t.Fatalf("expected temp dir to exist: %v", err)
+	}
+
+	cleanup()
+	cleanup()
+
+	if _, err = os.Stat(dir); !os.IsNotExist(err) {
+		t.Fatalf("expected temp dir to be removed, got: %v", err)
+	}
+}
+
+func TestCommand_BuildVersionCommand(t *testing.T) {
+	t.Parallel()
+
+	cmd := New().SetExecutable("yt-dlp").NoColors()
+	vc := cmd.BuildVersionCommand(context.Background())
+
+	if !slices.Equal(vc.Args[1:], []string{"--no-colors", "--version"}) {
+		t.Fatalf("unexpected args: %q", vc.Args[1:])
+	}
+
+	if vc.WaitDelay != cancelMaxWait {
+		t.Fatalf("expected wait delay to be %v, got %v", cancelMaxWait, vc.WaitDelay)
+	}
+
+	if len(cmd.getFlagsByID("")) != 0 {
+		t.Fatal("expected command to not be modified")
+	}
+}
+
+func TestCommand_CancelChildren(t *testing.T) {
+	t.Parallel()
+
+	bin := writeFakeYtdlp(t, "#!/bin/sh\nsleep 30 &\nwait\n")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 250*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+
+	_, err := New().SetExecutable(bin).Run(ctx)
+	if err == nil {
+		t.Fatal("expected error from cancelled context")
+	}
+
+	if time.Since(start) > cancelMaxWait {
+		t.Fatalf("expected process (and children) to be killed promptly, took %v", time.Since(start))
+	}
+}
+
+func TestCommand_Canceled(t *testing.T) {
+	t.Parallel()
+
+	bin := writeFakeYtdlp(t, "#!/bin/sh\necho '[download] partial'\nsleep 30 &\nwait\n")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 250*time.Millisecond)
+	defer cancel()
+
+	res, err := New().SetExecutable(bin).Run(ctx)
+	if !IsCanceledError(err) || !errors.Is(err, context.DeadlineExceeded) || IsExitCodeError(err) {
+		t.Fatalf("expected canceled error, got %#v", err)
+	}
+
+	if res == nil || len(res.OutputLogs) != 1 || res.OutputLogs[0].Line != "[download] partial" {
+		t.Fatalf("expected partial results, got %+v", res)
+	}
+
+	var cerr *ErrCanceled
+	if !errors.As(err, &cerr) || cerr.Result() != res {
+		t.Fatal("expected partial results to be attached to the error")
+	}
+}
+
+func TestCommand_CallbackPanic(t *testing.T) {
+	t.Parallel()
+
+	script := "#!/bin/sh\n" + fakeProgressPreamble +
+		`echo "$PROGRESS"'{"info":{"id":"abc","_type":"video"},"progress":{"status":"downloading","filename":"abc.mp4"}}'` + "\n" +
+		`echo "$PROGRESS"'{"info":{"id":"abc","_type":"video"},"progress":{"status":"finished","filename":"abc.mp4"}}'` + "\n" +
+		"echo done\n"
+
+	bin := writeFakeYtdlp(t, script)
+
+	result, err := New().
+		SetExecutable(bin).
+		ProgressFunc(time.Second, func(update ProgressUpdate) {
+			if update.Status == ProgressStatusDownloading {
+				panic("buggy callback")
+			}
+		}).
+		Run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Stdout != "done" {
+		t.Fatalf("expected invocation to continue after panic, got stdout %q", result.Stdout)
+	}
+
+	if len(result.CallbackPanics) != 1 {
+		t.Fatalf("expected 1 callback panic, got %d", len(result.CallbackPanics))
+	}
+
+	if p := result.CallbackPanics[0]; p.Callback != "progress" || p.Value != "buggy callback" || !IsCallbackPanicError(p) {
+		t.Fatalf("unexpected callback panic: %v", p)
+	}
+}
+
+func TestCommand_SetStdout(t *testing.T) {
+	t.Parallel()
+
+	bin := writeFakeYtdlp(t, "#!/bin/sh\necho out1\necho err1 >&2\nprintf out2\n")
+
+	var stdout, combined bytes.Buffer
+
+	res, err := New().SetExecutable(bin).SetStdout(&stdout).Run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if stdout.String() != "out1\nout2" || res.Stdout != "out1\nout2" {
+		t.Fatalf("unexpected stdout: tee %q, result %q", stdout.String(), res.Stdout)
+	}
+
+	_, err = New().SetExecutable(bin).SetStdout(&combined).SetStderr(&combined).Run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(combined.String(), "err1\n") || !strings.Contains(combined.String(), "out1\n") {
+		t.Fatalf("unexpected combined output: %q", combined.String())
+	}
+}
+
+func TestCommand_RunWithInfo(t *testing.T) {
+	t.Parallel()
+
+	// Echo the args as part of the JSON, to validate which flags were used.
+	script := "#!/bin/sh\n" + `echo "{\"_type\":\"video\",\"id\":\"abc\",\"title\":\"$*\"}"` + "\n"
+
+	bin := writeFakeYtdlp(t, script)
+
+	cmd := New().SetExecutable(bin)
+
+	_, infos, err := cmd.RunWithInfo(context.Background(), "https://example.com/abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(infos) != 1 || infos[0].ID != "abc" || *infos[0].Title != "--dump-json --no-simulate https://example.com/abc" {
+		t.Fatalf("unexpected infos: %+v", infos)
+	}
+
+	if len(cmd.getFlagsByID("dumpjson")) != 0 {
+		t.Fatal("expected command to not be modified")
+	}
+
+	_, infos, err = cmd.Simulate().RunWithInfo(context.Background(), "https://example.com/abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(infos) != 1 || *infos[0].Title != "--simulate --dump-json https://example.com/abc" {
+		t.Fatalf("expected simulate to be preserved: %+v", infos)
+	}
+}
+
+func TestCommand_LogFunc(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	// The second line is only written once the first was received, to ensure
+	// lines are passed to the log function live.
+	script := "#!/bin/sh\necho first\nwhile [ ! -f ack ]; do sleep 0.01; done\necho second >&2\n"
+
+	bin := writeFakeExecutable(t, dir, "log.sh", script)
+
+	var logs []ResultLog
+
+	res, err := New().SetExecutable(bin).SetWorkDir(dir).LogFunc(func(log ResultLog) {
+		logs = append(logs, log)
+
+		if log.Line == "first" {
+			_ = os.WriteFile(filepath.Join(dir, "ack"), nil, 0o600)
+		}
+	}).Run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(logs) != 2 || logs[0].Line != "first" || logs[0].Pipe != "stdout" || logs[1].Line != "second" || logs[1].Pipe != "stderr" {
+		t.Fatalf("unexpected logs: %+v", logs)
+	}
+
+	if logs[1].Sequence != res.OutputLogs[1].Sequence {
+		t.Fatal("expected logs to match output logs")
+	}
+}
+
+func TestCommand_Runner(t *testing.T) {
+	t.Parallel()
+
+	var runner Runner = New().NoProgress().AddRawFlags("--some-flag")
+
+	clone := runner.CloneRunner()
+	clone.(*Command).Output("test.mp4")
+
+	flags := runner.GetFlagConfig()
+	if len(flags) != 2 || flags[0].Flag != "--no-progress" || flags[1].Flag != "--some-flag" {
+		t.Fatalf("unexpected flags: %v", flags)
+	}
+
+	flags[0].Flag = "--modified"
+
+	if runner.GetFlagConfig()[0].Flag != "--no-progress" {
+		t.Fatal("expected flag config to be a copy")
+	}
+
+	if len(clone.GetFlagConfig()) != 3 {
+		t.Fatal("expected clone to be independent of the original")
+	}
+
+	if raw := runner.GetRawFlags(); len(raw) != 1 || raw[0] != "--some-flag" {
+		t.Fatalf("unexpected raw flags: %v", raw)
+	}
+}
@@ -0,0 +1,28 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+//go:build unix
+
+package ytdlp
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"syscall"
+)
+
+// applySyscall applies any OS-specific syscall attributes to the command. yt-dlp
+// is started in its own process group, so that when the context is cancelled,
+// any child processes it spawned (e.g. ffmpeg) are killed as well.
+func (c *Command) applySyscall(cmd *exec.Cmd) {
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		err := syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+		if errors.Is(err, syscall.ESRCH) {
+			return os.ErrProcessDone
+		}
+		return err
+	}
+}
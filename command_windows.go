@@ -11,10 +11,16 @@ import (
 	"syscall"
 )
 
-// applySyscall applies any OS-specific syscall attributes to the command.
+// applySyscall applies any OS-specific syscall attributes to the command. The
+// priority class (if any) is set on creation, and is inherited by child processes.
 func (c *Command) applySyscall(cmd *exec.Cmd) {
 	cmd.SysProcAttr = &syscall.SysProcAttr{
-		CreationFlags: 0x08000000, // CREATE_NO_WINDOW.
+		CreationFlags: 0x08000000 | c.priority.windowsPriorityClass(), // CREATE_NO_WINDOW.
 		HideWindow:    true,
 	}
 }
+
+// applyPriority is a no-op, as the priority class is applied by applySyscall.
+func (c *Command) applyPriority(_ *exec.Cmd, _ *ProcessPriority) error {
+	return nil
+}
@@ -0,0 +1,88 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"strings"
+	"time"
+)
+
+// RunSummary is a summary of a single invocation of [Command.Run], passed to the
+// callback set with [Command.OnComplete].
+type RunSummary struct {
+	// Inputs are the inputs (commonly URLs) passed to [Command.Run].
+	Inputs []string
+
+	// Result is the result of the invocation. May be nil if yt-dlp wasn't
+	// invoked (e.g. due to a validation error).
+	Result *Result
+
+	// Err is the error returned by the invocation, if any.
+	Err error
+
+	// Stats is metadata about the invocation, the same as is passed to the
+	// [StatsRecorder] set with [Command.SetStatsRecorder].
+	Stats *RunStats
+
+	// Files are the files written by yt-dlp, see [Result.Files].
+	Files []*ResultFile
+
+	// Info is the extracted info of each video, if JSON output was enabled (e.g.
+	// with [Command.PrintJSON]), see [Result.GetExtractedInfo].
+	Info []*ExtractedInfo
+
+	// Failures are the errors reported by yt-dlp (e.g. for individual videos,
+	// with [Command.IgnoreErrors]), without the "ERROR: " prefix.
+	Failures []string
+
+	// Warnings are the warnings reported by yt-dlp, without the "WARNING: "
+	// prefix.
+	Warnings []string
+}
+
+// CompleteCallbackFunc is a callback function that is called once an invocation
+// of [Command.Run] completes, see [Command.OnComplete].
+type CompleteCallbackFunc func(summary RunSummary)
+
+// OnComplete sets a callback which is invoked once each invocation of
+// [Command.Run] (and functions which use it) completes, regardless of whether
+// it succeeded, with a summary of the files, stats, failures, warnings and
+// extracted info of the invocation. The callback is invoked synchronously,
+// before Run returns. Any panics in the callback are recovered, and recorded in
+// [Result.CallbackPanics]. Pass nil to unset it (the default).
+func (c *Command) OnComplete(fn CompleteCallbackFunc) *Command {
+	c.mu.Lock()
+	c.completeFn = fn
+	c.mu.Unlock()
+
+	return c
+}
+
+// newRunSummary builds the summary of an invocation of [Command.Run].
+func newRunSummary(started time.Time, inputs []string, result *Result, err error) RunSummary {
+	summary := RunSummary{
+		Inputs: inputs,
+		Result: result,
+		Err:    err,
+		Stats:  newRunStats(started, result, err),
+	}
+
+	if result == nil {
+		return summary
+	}
+
+	summary.Files = result.Files()
+	summary.Info, _ = result.GetExtractedInfo()
+
+	for _, log := range result.OutputLogs {
+		if v, ok := strings.CutPrefix(log.Line, "ERROR: "); ok {
+			summary.Failures = append(summary.Failures, v)
+		} else if v, ok := strings.CutPrefix(log.Line, "WARNING: "); ok {
+			summary.Warnings = append(summary.Warnings, v)
+		}
+	}
+
+	return summary
+}
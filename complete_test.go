@@ -0,0 +1,84 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+const fakeCompleteScript = `#!/bin/sh
+echo '{"_type": "video", "id": "abc", "extractor": "youtube"}'
+echo "WARNING: [youtube] abc: some formats are missing" >&2
+echo "ERROR: [youtube] def: Video unavailable" >&2
+for arg; do code=$arg; done
+exit $code
+`
+
+func TestCommand_OnComplete(t *testing.T) {
+	t.Parallel()
+
+	bin := writeFakeYtdlp(t, fakeCompleteScript)
+
+	var summaries []RunSummary
+
+	cmd := New().
+		SetExecutable(bin).
+		PrintJSON().
+		OnComplete(func(summary RunSummary) {
+			summaries = append(summaries, summary)
+		})
+
+	if _, err := cmd.Run(context.Background(), "0"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := cmd.Run(context.Background(), "1"); !IsExitCodeError(err) {
+		t.Fatalf("expected exit code error, got %v", err)
+	}
+
+	if len(summaries) != 2 {
+		t.Fatalf("expected 2 summaries, got %d", len(summaries))
+	}
+
+	summary := summaries[0]
+
+	if summary.Err != nil || summary.Result == nil || summary.Stats == nil || summary.Stats.Outcome != RunOutcomeSuccess {
+		t.Fatalf("unexpected summary: %+v", summary)
+	}
+
+	if !slices.Equal(summary.Inputs, []string{"0"}) {
+		t.Fatalf("unexpected inputs: %q", summary.Inputs)
+	}
+
+	if len(summary.Info) != 1 || summary.Info[0].ID != "abc" {
+		t.Fatalf("unexpected extracted info: %v", summary.Info)
+	}
+
+	if !slices.Equal(summary.Warnings, []string{"[youtube] abc: some formats are missing"}) {
+		t.Fatalf("unexpected warnings: %q", summary.Warnings)
+	}
+
+	if !slices.Equal(summary.Failures, []string{"[youtube] def: Video unavailable"}) {
+		t.Fatalf("unexpected failures: %q", summary.Failures)
+	}
+
+	if !IsExitCodeError(summaries[1].Err) || summaries[1].Stats.Outcome != RunOutcomeFailed {
+		t.Fatalf("unexpected summary for failed run: %+v", summaries[1])
+	}
+
+	result, err := New().
+		SetExecutable(bin).
+		OnComplete(func(_ RunSummary) { panic("boom") }).
+		Run(context.Background(), "0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(result.CallbackPanics) != 1 || result.CallbackPanics[0].Callback != "complete" {
+		t.Fatalf("expected recovered complete panic, got %v", result.CallbackPanics)
+	}
+}
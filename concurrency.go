@@ -0,0 +1,133 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Concurrency configures both levels of concurrency available when downloading,
+// which are commonly confused:
+//
+//   - Fragments is concurrency within a single yt-dlp process, where multiple
+//     fragments of a single fragmented (e.g. DASH or HLS) download are fetched
+//     at once. This is yt-dlp's -N/--concurrent-fragments flag, and does NOT
+//     result in multiple videos being downloaded at once. Non-fragmented
+//     downloads are unaffected.
+//   - Processes is concurrency across yt-dlp processes, where each input is
+//     downloaded by a separate yt-dlp process (see [Command.RunConcurrently]).
+//     yt-dlp itself always downloads multiple inputs sequentially.
+//
+// The maximum number of concurrent connections is roughly Fragments * Processes
+// (see [Concurrency.MaxConnections]), which should be kept reasonable to avoid
+// being rate limited.
+type Concurrency struct {
+	// Fragments is the number of fragments to download concurrently within each
+	// yt-dlp process. 0 uses the value set on the command (or yt-dlp's default
+	// of 1).
+	Fragments int
+
+	// Processes is the number of yt-dlp processes to run concurrently. 0 means 1.
+	Processes int
+}
+
+// Validate returns an error if the concurrency configuration is invalid.
+func (c Concurrency) Validate() error {
+	if c.Fragments < 0 {
+		return fmt.Errorf("invalid fragment concurrency: %d", c.Fragments)
+	}
+
+	if c.Processes < 0 {
+		return fmt.Errorf("invalid process concurrency: %d", c.Processes)
+	}
+
+	return nil
+}
+
+// MaxConnections returns the approximate maximum number of concurrent download
+// connections.
+func (c Concurrency) MaxConnections() int {
+	return max(c.Fragments, 1) * max(c.Processes, 1)
+}
+
+// SetConcurrency applies the process-local concurrency (see [Concurrency.Fragments])
+// to the command. Process-level concurrency only applies to [Command.RunConcurrently].
+func (c *Command) SetConcurrency(conc Concurrency) *Command {
+	if conc.Fragments > 0 {
+		c.UnsetConcurrentFragments().ConcurrentFragments(conc.Fragments)
+	}
+
+	return c
+}
+
+// RunConcurrently invokes a separate yt-dlp process for each of the provided
+// inputs (e.g. URLs), running up to [Concurrency.Processes] processes at once,
+// each configured with [Concurrency.Fragments] (see [Concurrency] for the
+// difference). Results are returned in the same order as inputs (with nil
+// entries for invocations which couldn't be started), along with a joined error
+// of all failed invocations. The command itself is not modified.
+func (c *Command) RunConcurrently(ctx context.Context, conc Concurrency, inputs ...string) ([]*Result, error) {
+	if err := conc.Validate(); err != nil {
+		return nil, err
+	}
+
+	batches := make([][]string, len(inputs))
+	for i, input := range inputs {
+		batches[i] = []string{input}
+	}
+
+	return runPool(ctx, c.clone().SetConcurrency(conc), conc.Processes, batches, func(i int) string {
+		return fmt.Sprintf("input %q", inputs[i])
+	})
+}
+
+// runPool invokes a copy of cmd for each batch of args, running up to n (0 means
+// 1) at once. Results are returned in the same order as batches (with nil entries
+// for invocations which couldn't be started), along with a joined error of all
+// failed invocations, each prefixed with the label for its batch.
+func runPool(ctx context.Context, cmd *Command, n int, batches [][]string, label func(i int) string) ([]*Result, error) {
+	return runPoolFunc(ctx, n, len(batches), label, func(i int) (*Result, error) {
+		return cmd.clone().Run(ctx, batches[i]...)
+	})
+}
+
+// runPoolFunc is the same as runPool, however invokes run for each of count
+// items, instead of a command.
+func runPoolFunc(ctx context.Context, n, count int, label func(i int) string, run func(i int) (*Result, error)) ([]*Result, error) {
+	results := make([]*Result, count)
+	errs := make([]error, count)
+	sem := make(chan struct{}, max(n, 1))
+
+	var wg sync.WaitGroup
+
+	for i := range count {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			errs[i] = fmt.Errorf("%s: %w", label(i), ctx.Err())
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			var err error
+
+			results[i], err = run(i)
+			if err != nil {
+				errs[i] = fmt.Errorf("%s: %w", label(i), err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	return results, errors.Join(errs...)
+}
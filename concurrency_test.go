@@ -0,0 +1,47 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+func TestCommand_RunConcurrently(t *testing.T) {
+	t.Parallel()
+
+	// Echo the args, and fail for the "bad" input.
+	bin := writeFakeYtdlp(t, "#!/bin/sh\necho \"$@\"\nfor a in \"$@\"; do [ \"$a\" = bad ] && exit 1; done\nexit 0\n")
+
+	cmd := New().SetExecutable(bin).ConcurrentFragments(8)
+
+	results, err := cmd.RunConcurrently(context.Background(), Concurrency{Fragments: 4, Processes: 2}, "a", "bad", "c")
+	if err == nil {
+		t.Fatal("expected error for bad input")
+	}
+
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+
+	for i, input := range []string{"a", "bad", "c"} {
+		if results[i] == nil || results[i].Stdout != "--concurrent-fragments 4 "+input {
+			t.Fatalf("unexpected result for %q: %+v", input, results[i])
+		}
+	}
+
+	if flags := cmd.getFlagsByID("concurrent_fragment_downloads"); len(flags) != 1 || !slices.Equal(flags[0].Args, []string{"8"}) {
+		t.Fatal("expected command to not be modified")
+	}
+
+	if (Concurrency{Fragments: 4, Processes: 3}).MaxConnections() != 12 || (Concurrency{}).MaxConnections() != 1 {
+		t.Fatal("unexpected max connections")
+	}
+
+	if _, err = cmd.RunConcurrently(context.Background(), Concurrency{Processes: -1}, "a"); err == nil {
+		t.Fatal("expected validation error")
+	}
+}
@@ -26,6 +26,10 @@ type Extractor struct {
 
 	// AgeLimit of the extractor.
 	AgeLimit int `json:"age_limit,omitempty"`
+
+	// URLTemplate is the template used to build a canonical URL from a bare ID,
+	// if known, where "{id}" is replaced with the ID. See [Extractor.URL].
+	URLTemplate string `json:"url_template,omitempty"`
 }
 
 var SupportedExtractors = []*Extractor{
@@ -116,7 +120,7 @@ var SupportedExtractors = []*Extractor{
 	{Name: "ApplePodcasts"},
 	{Name: "appletrailers"},
 	{Name: "appletrailers:section"},
-	{Name: "archive.org", Description: "archive.org video and audio"},
+	{Name: "archive.org", Description: "archive.org video and audio", URLTemplate: "https://archive.org/details/{id}"},
 	{Name: "ArcPublishing"},
 	{Name: "ARD"},
 	{Name: "ARDMediathek"},
@@ -191,7 +195,7 @@ var SupportedExtractors = []*Extractor{
 	{Name: "Bigflix"},
 	{Name: "Bigo"},
 	{Name: "Bild", Description: "Bild.de"},
-	{Name: "BiliBili"},
+	{Name: "BiliBili", URLTemplate: "https://www.bilibili.com/video/{id}"},
 	{Name: "Bilibili category extractor"},
 	{Name: "BilibiliAudio"},
 	{Name: "BilibiliAudioAlbum"},
@@ -214,7 +218,7 @@ var SupportedExtractors = []*Extractor{
 	{Name: "BiliLive"},
 	{Name: "BioBioChileTV"},
 	{Name: "Biography"},
-	{Name: "BitChute"},
+	{Name: "BitChute", URLTemplate: "https://www.bitchute.com/video/{id}/"},
 	{Name: "BitChuteChannel"},
 	{Name: "BlackboardCollaborate"},
 	{Name: "BleacherReport", Description: "(Currently broken)"},
@@ -356,7 +360,7 @@ var SupportedExtractors = []*Extractor{
 	{Name: "DacastVOD"},
 	{Name: "DagelijkseKost", Description: "dagelijksekost.een.be"},
 	{Name: "DailyMail"},
-	{Name: "dailymotion", Description: "[dailymotion]", AgeLimit: 18},
+	{Name: "dailymotion", Description: "[dailymotion]", AgeLimit: 18, URLTemplate: "https://www.dailymotion.com/video/{id}"},
 	{Name: "dailymotion:playlist", Description: "[dailymotion]"},
 	{Name: "dailymotion:search", Description: "[dailymotion]"},
 	{Name: "dailymotion:user", Description: "[dailymotion]"},
@@ -641,7 +645,7 @@ var SupportedExtractors = []*Extractor{
 	{Name: "Inc"},
 	{Name: "IndavideoEmbed"},
 	{Name: "InfoQ"},
-	{Name: "Instagram", Description: "[instagram]"},
+	{Name: "Instagram", Description: "[instagram]", URLTemplate: "https://www.instagram.com/p/{id}/"},
 	{Name: "instagram:story", Description: "[instagram]"},
 	{Name: "instagram:tag", Description: "[instagram] Instagram hashtag search URLs"},
 	{Name: "instagram:user", Description: "[instagram] Instagram user profile (Currently broken)"},
@@ -698,7 +702,7 @@ var SupportedExtractors = []*Extractor{
 	{Name: "khanacademy:unit"},
 	{Name: "kick:clips", AgeLimit: 18},
 	{Name: "kick:live", AgeLimit: 18},
-	{Name: "kick:vod"},
+	{Name: "kick:vod", URLTemplate: "https://kick.com/video/{id}"},
 	{Name: "Kicker"},
 	{Name: "KickStarter"},
 	{Name: "Kika", Description: "KiKA.de"},
@@ -974,7 +978,7 @@ var SupportedExtractors = []*Extractor{
 	{Name: "nick.de"},
 	{Name: "nickelodeon:br"},
 	{Name: "nickelodeonru"},
-	{Name: "niconico", Description: "[niconico] ニコニコ動画"},
+	{Name: "niconico", Description: "[niconico] ニコニコ動画", URLTemplate: "https://www.nicovideo.jp/watch/{id}"},
 	{Name: "niconico:history", Description: "NicoNico user history or likes. Requires cookies."},
 	{Name: "niconico:live", Description: "ニコニコ生放送"},
 	{Name: "niconico:playlist"},
@@ -1430,7 +1434,7 @@ var SupportedExtractors = []*Extractor{
 	{Name: "StoryFire"},
 	{Name: "StoryFireSeries"},
 	{Name: "StoryFireUser"},
-	{Name: "Streamable"},
+	{Name: "Streamable", URLTemplate: "https://streamable.com/{id}"},
 	{Name: "StreamCZ"},
 	{Name: "StreetVoice"},
 	{Name: "StretchInternet"},
@@ -1512,7 +1516,7 @@ var SupportedExtractors = []*Extractor{
 	{Name: "ThisVidPlaylist", AgeLimit: 18},
 	{Name: "ThreeSpeak"},
 	{Name: "ThreeSpeakUser"},
-	{Name: "TikTok"},
+	{Name: "TikTok", URLTemplate: "https://www.tiktok.com/embed/{id}"},
 	{Name: "tiktok:collection"},
 	{Name: "tiktok:effect", Description: "(Currently broken)"},
 	{Name: "tiktok:live"},
@@ -1592,14 +1596,14 @@ var SupportedExtractors = []*Extractor{
 	{Name: "TwitCasting"},
 	{Name: "TwitCastingLive"},
 	{Name: "TwitCastingUser"},
-	{Name: "twitch:clips", Description: "[twitch]"},
+	{Name: "twitch:clips", Description: "[twitch]", URLTemplate: "https://clips.twitch.tv/{id}"},
 	{Name: "twitch:stream", Description: "[twitch]"},
-	{Name: "twitch:vod", Description: "[twitch]"},
+	{Name: "twitch:vod", Description: "[twitch]", URLTemplate: "https://www.twitch.tv/videos/{id}"},
 	{Name: "TwitchCollection", Description: "[twitch]"},
 	{Name: "TwitchVideos", Description: "[twitch]"},
 	{Name: "TwitchVideosClips", Description: "[twitch]"},
 	{Name: "TwitchVideosCollections", Description: "[twitch]"},
-	{Name: "twitter", Description: "[twitter]", AgeLimit: 18},
+	{Name: "twitter", Description: "[twitter]", AgeLimit: 18, URLTemplate: "https://twitter.com/i/web/status/{id}"},
 	{Name: "twitter:amplify", Description: "[twitter]"},
 	{Name: "twitter:broadcast", Description: "[twitter]"},
 	{Name: "twitter:card"},
@@ -1673,7 +1677,7 @@ var SupportedExtractors = []*Extractor{
 	{Name: "Viidea"},
 	{Name: "viki", Description: "[viki]", AgeLimit: 13},
 	{Name: "viki:channel", Description: "[viki]"},
-	{Name: "vimeo", Description: "[vimeo]"},
+	{Name: "vimeo", Description: "[vimeo]", URLTemplate: "https://vimeo.com/{id}"},
 	{Name: "vimeo:album", Description: "[vimeo]"},
 	{Name: "vimeo:channel", Description: "[vimeo]"},
 	{Name: "vimeo:group", Description: "[vimeo]"},
@@ -1823,7 +1827,7 @@ var SupportedExtractors = []*Extractor{
 	{Name: "YouPornStar", Description: "YouPorn Pornstar, with description, sorting and pagination"},
 	{Name: "YouPornTag", Description: "YouPorn tag (porntags), with sorting, filtering and pagination"},
 	{Name: "YouPornVideos", Description: "YouPorn video (browse) playlists, with sorting, filtering and pagination"},
-	{Name: "youtube", Description: "[youtube] YouTube", AgeLimit: 18},
+	{Name: "youtube", Description: "[youtube] YouTube", AgeLimit: 18, URLTemplate: "https://www.youtube.com/watch?v={id}"},
 	{Name: "youtube:clip", Description: "[youtube]"},
 	{Name: "youtube:consent", Description: "[youtube] [HIDDEN]"},
 	{Name: "youtube:favorites", Description: "[youtube] YouTube liked videos; \":ytfav\" keyword (requires cookies)"},
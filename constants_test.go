@@ -54,3 +54,23 @@ func TestConstant_ValidateExtractors(t *testing.T) {
 		t.Fatal("no extractors have age limits")
 	}
 }
+
+func TestConstant_ExtractorURL(t *testing.T) {
+	e := GetExtractor("YouTube")
+	if e == nil {
+		t.Fatal("expected youtube extractor to exist")
+	}
+
+	u, err := e.URL("dQw4w9WgXcQ")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if u != "https://www.youtube.com/watch?v=dQw4w9WgXcQ" {
+		t.Fatalf("unexpected url: %s", u)
+	}
+
+	if _, err = GetExtractor("generic").URL("foo"); err == nil {
+		t.Fatal("expected error for extractor without url template")
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ContainerRuntime is a container runtime, as detected by [DetectContainerRuntime].
+type ContainerRuntime string
+
+const (
+	ContainerRuntimeNone       ContainerRuntime = ""
+	ContainerRuntimeDocker     ContainerRuntime = "docker"
+	ContainerRuntimePodman     ContainerRuntime = "podman"
+	ContainerRuntimeKubernetes ContainerRuntime = "kubernetes"
+	ContainerRuntimeContainerd ContainerRuntime = "containerd"
+	ContainerRuntimeLXC        ContainerRuntime = "lxc"
+	ContainerRuntimeOther      ContainerRuntime = "other" // Detected, but unknown runtime.
+)
+
+// containerRoot is the root of the filesystem used for container detection, which
+// is overridden in tests.
+var containerRoot = "/"
+
+// DetectContainerRuntime returns the container runtime the current process is
+// running in (based on well-known marker files, environment variables and
+// cgroups), or [ContainerRuntimeNone] if not running in a (known) container.
+// Detection is best-effort.
+func DetectContainerRuntime() ContainerRuntime {
+	exists := func(path string) bool {
+		_, err := os.Stat(filepath.Join(containerRoot, path))
+		return err == nil
+	}
+
+	switch {
+	case os.Getenv("KUBERNETES_SERVICE_HOST") != "":
+		return ContainerRuntimeKubernetes
+	case exists(".dockerenv"):
+		return ContainerRuntimeDocker
+	case exists("run/.containerenv") || os.Getenv("container") == "podman":
+		return ContainerRuntimePodman
+	}
+
+	cgroup, _ := os.ReadFile(filepath.Join(containerRoot, "proc/1/cgroup"))
+
+	switch s := string(cgroup); {
+	case strings.Contains(s, "kubepods"):
+		return ContainerRuntimeKubernetes
+	case strings.Contains(s, "docker"):
+		return ContainerRuntimeDocker
+	case strings.Contains(s, "libpod"):
+		return ContainerRuntimePodman
+	case strings.Contains(s, "containerd"):
+		return ContainerRuntimeContainerd
+	case strings.Contains(s, "lxc") || os.Getenv("container") == "lxc":
+		return ContainerRuntimeLXC
+	case os.Getenv("container") != "":
+		return ContainerRuntimeOther
+	}
+
+	return ContainerRuntimeNone
+}
+
+// ContainerOptions are options for [Command.Containerized].
+type ContainerOptions struct {
+	// VolumeDir is a directory on a mounted (persistent) volume. If set, yt-dlp's
+	// cache (see [Command.CacheDir]) is stored in "yt-dlp" within it, and
+	// [ContainerOptions.InstallOptions] caches executables in "go-ytdlp" within
+	// it. If empty, yt-dlp's cache is disabled, as the container filesystem is
+	// usually ephemeral.
+	VolumeDir string
+
+	// Force applies the container defaults, even when not running in a detected
+	// container (see [DetectContainerRuntime]).
+	Force bool
+}
+
+// InstallOptions returns the install options for [Install] (or [Resolver]), which
+// cache executables on the volume (if configured), so they aren't re-downloaded
+// each time the container is recreated.
+func (o *ContainerOptions) InstallOptions() *InstallOptions {
+	opts := &InstallOptions{}
+
+	if o != nil && o.VolumeDir != "" {
+		opts.CacheDir = filepath.Join(o.VolumeDir, xdgCacheDir)
+	}
+
+	return opts
+}
+
+// Containerized configures the command with defaults which work best when running
+// in a container, if one is detected (see [DetectContainerRuntime]), or
+// [ContainerOptions.Force] is set. Specifically:
+//
+//   - yt-dlp's cache is stored on the volume (see [ContainerOptions.VolumeDir]),
+//     or disabled.
+//   - Loading cookies from browsers is disabled, as containers don't usually
+//     have browser profiles (and attempting to do so fails).
+//   - File modification times aren't set from the Last-modified header (see
+//     [Command.NoMtime]), as this is known to cause issues with overlayfs and
+//     some volume drivers.
+//
+// File permissions aren't configured by Containerized, as they are controlled
+// by the umask of the container process (e.g. "umask 002" for volumes shared
+// with other users). yt-dlp applies it to downloaded files, as does go-ytdlp for
+// files it writes on your behalf (e.g. [FetchThumbnailFile]). go-ytdlp's own
+// state (e.g. [FileJobStore], presets, batch files and workspace indexes) is
+// always private to the owner, as it may contain credentials.
+//
+// Any of these can be overridden afterwards, as with any other flag. opts may be
+// nil to use the defaults.
+func (c *Command) Containerized(opts *ContainerOptions) *Command {
+	if opts == nil {
+		opts = &ContainerOptions{}
+	}
+
+	if !opts.Force && DetectContainerRuntime() == ContainerRuntimeNone {
+		return c
+	}
+
+	if opts.VolumeDir != "" {
+		c.CacheDir(filepath.Join(opts.VolumeDir, "yt-dlp"))
+	} else {
+		c.NoCacheDir()
+	}
+
+	return c.NoCookiesFromBrowser().NoMtime()
+}
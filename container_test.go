@@ -0,0 +1,79 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestDetectContainerRuntime(t *testing.T) {
+	root := t.TempDir()
+
+	prev := containerRoot
+	containerRoot = root
+	t.Cleanup(func() { containerRoot = prev })
+
+	t.Setenv("KUBERNETES_SERVICE_HOST", "")
+	t.Setenv("container", "")
+
+	if rt := DetectContainerRuntime(); rt != ContainerRuntimeNone {
+		t.Fatalf("expected no container runtime, got %q", rt)
+	}
+
+	if err := os.MkdirAll(filepath.Join(root, "proc", "1"), 0o750); err != nil {
+		t.Fatal(err)
+	}
+
+	err := os.WriteFile(filepath.Join(root, "proc", "1", "cgroup"), []byte("0::/system.slice/containerd.service\n"), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if rt := DetectContainerRuntime(); rt != ContainerRuntimeContainerd {
+		t.Fatalf("expected containerd, got %q", rt)
+	}
+
+	if err = os.WriteFile(filepath.Join(root, ".dockerenv"), nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if rt := DetectContainerRuntime(); rt != ContainerRuntimeDocker {
+		t.Fatalf("expected docker, got %q", rt)
+	}
+
+	t.Setenv("KUBERNETES_SERVICE_HOST", "10.0.0.1")
+
+	if rt := DetectContainerRuntime(); rt != ContainerRuntimeKubernetes {
+		t.Fatalf("expected kubernetes, got %q", rt)
+	}
+}
+
+func TestCommand_Containerized(t *testing.T) {
+	t.Parallel()
+
+	opts := &ContainerOptions{VolumeDir: "/data", Force: true}
+
+	cmd := New().SetExecutable("yt-dlp").CookiesFromBrowser("firefox").Containerized(opts)
+
+	want := []string{"--no-cookies-from-browser", "--cache-dir", filepath.Join("/data", "yt-dlp"), "--no-mtime"}
+	if args := cmd.buildCommand(context.Background()).Args[1:]; !slices.Equal(args, want) {
+		t.Fatalf("expected args %v, got %v", want, args)
+	}
+
+	if dir := opts.InstallOptions().CacheDir; dir != filepath.Join("/data", "go-ytdlp") {
+		t.Fatalf("unexpected install cache dir: %q", dir)
+	}
+
+	cmd = New().SetExecutable("yt-dlp").Containerized(&ContainerOptions{Force: true})
+
+	want = []string{"--no-cache-dir", "--no-cookies-from-browser", "--no-mtime"}
+	if args := cmd.buildCommand(context.Background()).Args[1:]; !slices.Equal(args, want) {
+		t.Fatalf("expected args %v, got %v", want, args)
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"slices"
+	"strconv"
+)
+
+// InfoField is a field of [ExtractedInfo] which is compared by [DiffInfo].
+type InfoField string
+
+const (
+	InfoFieldTitle             InfoField = "title"
+	InfoFieldDescription       InfoField = "description"
+	InfoFieldAvailability      InfoField = "availability"
+	InfoFieldLiveStatus        InfoField = "live_status"
+	InfoFieldDuration          InfoField = "duration"
+	InfoFieldFormats           InfoField = "formats"            // Compared by format ID.
+	InfoFieldSubtitles         InfoField = "subtitles"          // Compared by language.
+	InfoFieldAutomaticCaptions InfoField = "automatic_captions" // Compared by language.
+)
+
+// InfoChange is a single change between two extractions, as returned by [DiffInfo].
+// For scalar fields (e.g. title), Old and New are populated. For collection fields
+// (e.g. formats), Added and Removed are populated.
+type InfoChange struct {
+	Field   InfoField `json:"field"`
+	Old     string    `json:"old,omitempty"`
+	New     string    `json:"new,omitempty"`
+	Added   []string  `json:"added,omitempty"`
+	Removed []string  `json:"removed,omitempty"`
+}
+
+// DiffInfo compares two extractions of the same video (e.g. when re-extracting
+// periodically, for monitoring), and returns the changes between them, such as
+// title changes, availability changes (e.g. a video becoming private), and new
+// formats or subtitle languages. Returns nil if there are no changes, or if
+// either extraction is nil.
+func DiffInfo(old, new *ExtractedInfo) []*InfoChange { //nolint:predeclared
+	if old == nil || new == nil {
+		return nil
+	}
+
+	var changes []*InfoChange
+
+	scalar := func(field InfoField, o, n string) {
+		if o != n {
+			changes = append(changes, &InfoChange{Field: field, Old: o, New: n})
+		}
+	}
+
+	collection := func(field InfoField, o, n []string) {
+		added, removed := diffStrings(o, n)
+		if len(added) > 0 || len(removed) > 0 {
+			changes = append(changes, &InfoChange{Field: field, Added: added, Removed: removed})
+		}
+	}
+
+	scalar(InfoFieldTitle, derefString(old.Title), derefString(new.Title))
+	scalar(InfoFieldDescription, derefString(old.Description), derefString(new.Description))
+	scalar(InfoFieldAvailability, derefString(old.Availability), derefString(new.Availability))
+	scalar(InfoFieldLiveStatus, derefString(old.LiveStatus), derefString(new.LiveStatus))
+	scalar(InfoFieldDuration, formatDuration(old.Duration), formatDuration(new.Duration))
+
+	collection(InfoFieldFormats, formatIDs(old.Formats), formatIDs(new.Formats))
+	collection(InfoFieldSubtitles, sortedKeys(old.Subtitles), sortedKeys(new.Subtitles))
+	collection(InfoFieldAutomaticCaptions, sortedKeys(old.AutomaticCaptions), sortedKeys(new.AutomaticCaptions))
+
+	return changes
+}
+
+func derefString[T ~string](v *T) string {
+	if v == nil {
+		return ""
+	}
+	return string(*v)
+}
+
+func formatDuration(v *float64) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*v, 'f', -1, 64)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	slices.Sort(keys)
+	return keys
+}
+
+func formatIDs(formats []*ExtractedFormat) []string {
+	ids := make([]string, 0, len(formats))
+
+	for _, f := range formats {
+		if f != nil && f.FormatID != nil {
+			ids = append(ids, *f.FormatID)
+		}
+	}
+
+	return ids
+}
+
+// diffStrings returns the values which are only in n (added) and only in o
+// (removed), in their original order.
+func diffStrings(o, n []string) (added, removed []string) {
+	for _, v := range n {
+		if !slices.Contains(o, v) && !slices.Contains(added, v) {
+			added = append(added, v)
+		}
+	}
+
+	for _, v := range o {
+		if !slices.Contains(n, v) && !slices.Contains(removed, v) {
+			removed = append(removed, v)
+		}
+	}
+
+	return added, removed
+}
@@ -0,0 +1,67 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDiffInfo(t *testing.T) {
+	t.Parallel()
+
+	old := &ExtractedInfo{
+		Title:        ptr("Old title"),
+		Availability: ptr(ExtractedAvailabilityPublic),
+		Duration:     ptr(120.0),
+		Formats: []*ExtractedFormat{
+			{FormatID: ptr("18")},
+			{FormatID: ptr("137")},
+		},
+		Subtitles: map[string][]*ExtractedSubtitle{"en": nil},
+	}
+
+	new := &ExtractedInfo{ //nolint:predeclared
+		Title:        ptr("New title"),
+		Availability: ptr(ExtractedAvailabilityPrivate),
+		Duration:     ptr(120.0),
+		Formats: []*ExtractedFormat{
+			{FormatID: ptr("137")},
+			{FormatID: ptr("313")},
+		},
+		Subtitles: map[string][]*ExtractedSubtitle{"en": nil, "de": nil},
+	}
+
+	changes := DiffInfo(old, new)
+
+	byField := map[InfoField]*InfoChange{}
+	for _, c := range changes {
+		byField[c.Field] = c
+	}
+
+	if len(changes) != 4 {
+		t.Fatalf("expected 4 changes, got %d", len(changes))
+	}
+
+	if c := byField[InfoFieldTitle]; c == nil || c.Old != "Old title" || c.New != "New title" {
+		t.Fatalf("unexpected title change: %+v", c)
+	}
+
+	if c := byField[InfoFieldAvailability]; c == nil || c.Old != "public" || c.New != "private" {
+		t.Fatalf("unexpected availability change: %+v", c)
+	}
+
+	if c := byField[InfoFieldFormats]; c == nil || !slices.Equal(c.Added, []string{"313"}) || !slices.Equal(c.Removed, []string{"18"}) {
+		t.Fatalf("unexpected formats change: %+v", c)
+	}
+
+	if c := byField[InfoFieldSubtitles]; c == nil || !slices.Equal(c.Added, []string{"de"}) || c.Removed != nil {
+		t.Fatalf("unexpected subtitles change: %+v", c)
+	}
+
+	if changes = DiffInfo(old, old); changes != nil {
+		t.Fatalf("expected no changes, got %d", len(changes))
+	}
+}
@@ -0,0 +1,285 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// MaskedValue replaces secrets in the output of [Command.DryRun]. It matches the
+// value used by [url.URL.Redacted].
+const MaskedValue = "xxxxx"
+
+// secretFlagIDs are the IDs of flags whose arguments are always secrets.
+var secretFlagIDs = []string{
+	"password",
+	"videopassword",
+	"ap_password",
+	"client_certificate_password",
+	"twofactor",
+}
+
+// secretFlags are flags whose argument is always a secret, used to mask raw
+// flags (see [Command.AddRawFlags]), which can't be matched by ID.
+var secretFlags = []string{
+	"-p",
+	"-2",
+	"--password",
+	"--video-password",
+	"--ap-password",
+	"--client-certificate-password",
+	"--twofactor",
+}
+
+// secretKeywords are (case-insensitive) substrings of header names and env vars
+// whose values are considered secrets.
+var secretKeywords = []string{"PASSWORD", "PASSWD", "SECRET", "TOKEN", "AUTH", "COOKIE", "CREDENTIAL", "KEY"}
+
+// DryRunResult is the invocation that [Command.Run] would perform.
+type DryRunResult struct {
+	// Executable is the resolved path to the yt-dlp executable.
+	Executable string `json:"executable"`
+
+	// Args are the arguments that would be passed to yt-dlp, excluding the
+	// executable, with any secrets masked.
+	Args []string `json:"args"`
+
+	// WorkDir is the working directory that was set on the command, if any.
+	WorkDir string `json:"work_dir,omitempty"`
+
+	// Env are the environment variables that were explicitly set on the command
+	// (all others are inherited from the current process), with any secrets
+	// masked.
+	Env map[string]string `json:"env,omitempty"`
+}
+
+// String returns the invocation as a shell-quoted string (including the working
+// directory and environment variables, if any were set). See [Result.CLIString].
+func (d *DryRunResult) String() string {
+	return (&Result{Executable: d.Executable, Args: d.Args, WorkDir: d.WorkDir, Env: d.Env}).CLIString()
+}
+
+// DryRun returns the invocation that [Command.Run] would perform with the
+// provided URLs, without downloading anything, which is useful for audit logging
+// and debugging. Note that if the executable isn't set with
+// [Command.SetExecutable], resolving it may invoke "yt-dlp --version", to check
+// its version (see [Resolver]). Secrets (e.g. [Command.Password], sensitive headers set with
+// [Command.AddHeaders], credentials within URLs, and env vars which look like
+// secrets) are replaced with [MaskedValue]. Any validation errors, or errors
+// resolving the executable, are returned.
+func (c *Command) DryRun(ctx context.Context, urls ...string) (*DryRunResult, error) {
+	cmd := c.buildCommand(ctx, urls...)
+	if cmd.Err != nil {
+		return nil, cmd.Err
+	}
+
+	c.mu.RLock()
+	result := &DryRunResult{
+		Executable: cmd.Path,
+		Args:       cmd.maskedArgs(),
+		WorkDir:    c.directory,
+	}
+
+	result.Env = maskEnvVars(c.env)
+	c.mu.RUnlock()
+
+	return result, nil
+}
+
+// isSecretName returns true if the header or env var name looks like it contains
+// a secret.
+func isSecretName(name string) bool {
+	name = strings.ToUpper(name)
+
+	for _, kw := range secretKeywords {
+		if strings.Contains(name, kw) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// maskURL masks the password of a URL (e.g. a proxy), if it contains one.
+func maskURL(s string) string {
+	u, err := url.Parse(s)
+	if err != nil || u.User == nil {
+		return s
+	}
+
+	if _, ok := u.User.Password(); !ok {
+		return s
+	}
+
+	return u.Redacted()
+}
+
+// maskEnv masks the value of an env var, if it looks like a secret.
+func maskEnv(key, value string) string {
+	if isSecretName(key) {
+		return MaskedValue
+	}
+
+	return maskURL(value)
+}
+
+// maskEnvVars returns a copy of env, with any secrets masked, or nil if empty.
+func maskEnvVars(env map[string]string) map[string]string {
+	if len(env) == 0 {
+		return nil
+	}
+
+	out := make(map[string]string, len(env))
+	for k, v := range env {
+		out[k] = maskEnv(k, v)
+	}
+
+	return out
+}
+
+// maskHeader masks the value of a "Name:Value" header, if it looks like a secret.
+func maskHeader(header string) string {
+	name, _, ok := strings.Cut(header, ":")
+	if !ok || !isSecretName(name) {
+		return header
+	}
+
+	return name + ":" + MaskedValue
+}
+
+// maskRawArgs returns a copy of args (raw flags, see [Command.AddRawFlags]),
+// with any secrets masked.
+func maskRawArgs(args []string) []string {
+	out := make([]string, len(args))
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+
+		// Flags may also be provided as "--flag=value".
+		if flag, value, ok := strings.Cut(arg, "="); ok && strings.HasPrefix(flag, "--") {
+			out[i] = flag + "=" + maskFlagValue(flag, value)
+			continue
+		}
+
+		// Short flags may also be provided as "-pvalue".
+		if len(arg) > 2 && (strings.HasPrefix(arg, "-p") || strings.HasPrefix(arg, "-2")) {
+			out[i] = arg[:2] + MaskedValue
+			continue
+		}
+
+		out[i] = maskURL(arg)
+
+		if strings.HasPrefix(arg, "-") && i+1 < len(args) && (slices.Contains(secretFlags, arg) || arg == "--add-headers") {
+			i++
+			out[i] = maskFlagValue(arg, args[i])
+		}
+	}
+
+	return out
+}
+
+// maskFlag returns a copy of the flag, with any secrets in its arguments masked.
+// Flags are matched by ID, as well as by flag (including inline values, e.g.
+// "--password=value"), as flags provided with [Command.SetFlagConfig] may not
+// match their ID.
+func maskFlag(f *Flag) *Flag {
+	out := f.Clone()
+
+	name, _ := parseFlag(f)
+	secret := slices.Contains(secretFlagIDs, f.ID) || slices.Contains(secretFlags, name)
+	header := f.ID == "headers" || name == "--add-headers"
+
+	if name != f.Flag {
+		if secret {
+			out.Flag = name + "=" + MaskedValue
+		} else {
+			out.Flag = maskRawArgs([]string{f.Flag})[0]
+		}
+	}
+
+	if len(f.Args) == 0 {
+		return out
+	}
+
+	out.Args = make([]string, len(f.Args))
+
+	for i, arg := range f.Args {
+		switch {
+		case secret:
+			out.Args[i] = MaskedValue
+		case header:
+			out.Args[i] = maskHeader(arg)
+		default:
+			out.Args[i] = maskURL(arg)
+		}
+	}
+
+	return out
+}
+
+// maskFlags returns a copy of flags, with any secrets masked. Flags are matched
+// by ID and flag (see maskFlag), except for raw flags, which are parsed (together with any raw flags
+// directly following them, e.g. "--password" followed by the password).
+func maskFlags(flags []*Flag) []*Flag {
+	if flags == nil {
+		return nil
+	}
+
+	out := make([]*Flag, len(flags))
+
+	for i := 0; i < len(flags); i++ {
+		if flags[i].ID != rawFlagID {
+			out[i] = maskFlag(flags[i])
+			continue
+		}
+
+		j := i
+		var raw []string
+
+		for ; j < len(flags) && flags[j].ID == rawFlagID; j++ {
+			raw = append(raw, flags[j].Flag)
+		}
+
+		for k, arg := range maskRawArgs(raw) {
+			out[i+k] = flags[i+k].Clone()
+			out[i+k].Flag = arg
+		}
+
+		i = j - 1
+	}
+
+	return out
+}
+
+// maskArgs returns the args passed to yt-dlp for the provided flags and inputs
+// (e.g. URLs), after prefix, with any secrets masked.
+func maskArgs(prefix []string, flags []*Flag, inputs []string) []string {
+	out := slices.Clone(prefix)
+
+	for _, f := range maskFlags(flags) {
+		out = append(out, f.Raw()...)
+	}
+
+	for _, input := range inputs {
+		out = append(out, maskURL(input))
+	}
+
+	return out
+}
+
+// maskFlagValue masks the value of the provided flag, if it's a secret.
+func maskFlagValue(flag, value string) string {
+	switch {
+	case slices.Contains(secretFlags, flag):
+		return MaskedValue
+	case flag == "--add-headers":
+		return maskHeader(value)
+	default:
+		return maskURL(value)
+	}
+}
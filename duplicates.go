@@ -0,0 +1,184 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// DuplicateStrategy controls how flags which were set multiple times (e.g. when
+// merging presets, or calling [Command.Format] twice) are resolved when the
+// command is invoked. See [Command.SetDuplicateStrategy].
+type DuplicateStrategy int
+
+const (
+	// DuplicateKeepAll passes all duplicate flags through to yt-dlp (the
+	// default), which generally uses the last one.
+	DuplicateKeepAll DuplicateStrategy = iota
+
+	// DuplicateLastWins drops all but the last of each duplicate flag.
+	DuplicateLastWins
+
+	// DuplicateFirstWins drops all but the first of each duplicate flag.
+	DuplicateFirstWins
+
+	// DuplicateError returns [ErrDuplicateFlags] if any duplicate flags are set.
+	DuplicateError
+)
+
+// repeatableFlags are the IDs of flags which yt-dlp accepts multiple times
+// (appending or merging the values), and are therefore never duplicates.
+var repeatableFlags = map[string]bool{
+	rawFlagID:                  true,
+	presetFlagID:               true,
+	"add_postprocessors":       true,
+	"allowed_extractors":       true,
+	"color":                    true,
+	"compat_opts":              true,
+	"config_locations":         true,
+	"download_ranges":          true,
+	"exec_cmd":                 true,
+	"external_downloader":      true,
+	"external_downloader_args": true,
+	"extractor_args":           true,
+	"forceprint":               true,
+	"format_sort":              true,
+	"headers":                  true,
+	"match_filter":             true,
+	"outtmpl":                  true,
+	"parse_metadata":           true,
+	"paths":                    true,
+	"plugin_dirs":              true,
+	"postprocessor_args":       true,
+	"print_to_file":            true,
+	"progress_template":        true,
+	"remove_chapters":          true,
+	"retry_sleep":              true,
+	"sponsorblock_mark":        true,
+	"sponsorblock_remove":      true,
+	"subtitleslangs":           true,
+}
+
+// ErrDuplicateFlags is returned when duplicate flags are set, and the
+// [DuplicateError] strategy is used.
+type ErrDuplicateFlags struct {
+	Flags []*Flag // All flags which have duplicates (including the first).
+}
+
+func (e *ErrDuplicateFlags) Error() string {
+	var out []string
+	seen := make(map[string]bool)
+
+	for _, f := range e.Flags {
+		if !seen[f.ID] {
+			seen[f.ID] = true
+			out = append(out, f.Flag)
+		}
+	}
+
+	return fmt.Sprintf("duplicate flags set: %s", strings.Join(out, ", "))
+}
+
+// IsDuplicateFlagsError returns true when duplicate flags are set, and the
+// [DuplicateError] strategy is used.
+func IsDuplicateFlagsError(err error) bool {
+	var e *ErrDuplicateFlags
+	return errors.As(err, &e)
+}
+
+// SetDuplicateStrategy sets how duplicate flags are resolved when the command is
+// invoked. Flags which yt-dlp accepts multiple times (e.g. [Command.AddHeaders]
+// or [Command.Print]) are never considered duplicates. Any flags which are
+// dropped are recorded in [Result.DroppedFlags]. Defaults to [DuplicateKeepAll].
+func (c *Command) SetDuplicateStrategy(strategy DuplicateStrategy) *Command {
+	c.mu.Lock()
+	c.duplicates = strategy
+	c.mu.Unlock()
+
+	return c
+}
+
+// DuplicateFlags returns all flags which have been set multiple times (excluding
+// those which yt-dlp accepts multiple times), grouped by ID, in the order they
+// were set.
+func (c *Command) DuplicateFlags() [][]*Flag {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return duplicateFlags(c.flags)
+}
+
+// duplicateFlags groups the provided flags by ID, returning only the groups with
+// duplicates.
+func duplicateFlags(flags []*Flag) (groups [][]*Flag) {
+	index := make(map[string]int)
+
+	for _, f := range flags {
+		if repeatableFlags[f.ID] || f.err != nil {
+			continue
+		}
+
+		i, ok := index[f.ID]
+		if !ok {
+			i = len(groups)
+			index[f.ID] = i
+			groups = append(groups, nil)
+		}
+
+		groups[i] = append(groups[i], f)
+	}
+
+	return slices.DeleteFunc(groups, func(g []*Flag) bool { return len(g) < 2 })
+}
+
+// resolveFlags returns the flags to be used for an invocation, according to the
+// duplicate strategy, along with any flags which were dropped. Must be called
+// with the lock held.
+func (c *Command) resolveFlags() (flags, dropped []*Flag, err error) {
+	if c.duplicates == DuplicateKeepAll {
+		return c.flags, nil, nil
+	}
+
+	groups := duplicateFlags(c.flags)
+	if len(groups) == 0 {
+		return c.flags, nil, nil
+	}
+
+	if c.duplicates == DuplicateError {
+		var dupes []*Flag
+		for _, g := range groups {
+			dupes = append(dupes, g...)
+		}
+
+		return c.flags, nil, &ErrDuplicateFlags{Flags: dupes}
+	}
+
+	drop := make(map[*Flag]bool)
+
+	for _, g := range groups {
+		if c.duplicates == DuplicateFirstWins {
+			g = g[1:]
+		} else {
+			g = g[:len(g)-1]
+		}
+
+		for _, f := range g {
+			drop[f] = true
+		}
+	}
+
+	for _, f := range c.flags {
+		if drop[f] {
+			dropped = append(dropped, f)
+		} else {
+			flags = append(flags, f)
+		}
+	}
+
+	return flags, dropped, nil
+}
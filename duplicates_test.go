@@ -0,0 +1,84 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+func newDuplicatesCommand() *Command {
+	return New().
+		Format("best").
+		AddHeaders("A:1").
+		Format("worst").
+		AddHeaders("B:2").
+		Output("%(id)s.%(ext)s").
+		Format("bestaudio")
+}
+
+func TestCommand_DuplicateFlags(t *testing.T) {
+	t.Parallel()
+
+	groups := newDuplicatesCommand().DuplicateFlags()
+
+	if len(groups) != 1 || len(groups[0]) != 3 || groups[0][0].ID != "format" {
+		t.Fatalf("expected a single group of 3 format flags, got %v", groups)
+	}
+}
+
+func TestCommand_SetDuplicateStrategy(t *testing.T) {
+	t.Parallel()
+
+	bin := writeFakeYtdlp(t, "#!/bin/sh\nexit 0\n")
+
+	tests := []struct {
+		strategy DuplicateStrategy
+		args     []string
+		dropped  int
+	}{
+		{
+			strategy: DuplicateKeepAll,
+			args:     []string{"--format", "best", "--add-headers", "A:1", "--format", "worst", "--add-headers", "B:2", "--output", "%(id)s.%(ext)s", "--format", "bestaudio"},
+		},
+		{
+			strategy: DuplicateLastWins,
+			args:     []string{"--add-headers", "A:1", "--add-headers", "B:2", "--output", "%(id)s.%(ext)s", "--format", "bestaudio"},
+			dropped:  2,
+		},
+		{
+			strategy: DuplicateFirstWins,
+			args:     []string{"--format", "best", "--add-headers", "A:1", "--add-headers", "B:2", "--output", "%(id)s.%(ext)s"},
+			dropped:  2,
+		},
+	}
+
+	for _, tt := range tests {
+		cmd := newDuplicatesCommand().SetExecutable(bin).SetDuplicateStrategy(tt.strategy)
+
+		result, err := cmd.Run(context.Background())
+		if err != nil {
+			t.Fatalf("strategy %d: %v", tt.strategy, err)
+		}
+
+		if !slices.Equal(result.Args, tt.args) {
+			t.Fatalf("strategy %d: expected args %q, got %q", tt.strategy, tt.args, result.Args)
+		}
+
+		if len(result.DroppedFlags) != tt.dropped {
+			t.Fatalf("strategy %d: expected %d dropped flags, got %d", tt.strategy, tt.dropped, len(result.DroppedFlags))
+		}
+
+		if len(cmd.getFlagsByID("format")) != 3 {
+			t.Fatalf("strategy %d: expected command to not be modified", tt.strategy)
+		}
+	}
+
+	_, err := newDuplicatesCommand().SetExecutable(bin).SetDuplicateStrategy(DuplicateError).Run(context.Background())
+	if !IsDuplicateFlagsError(err) {
+		t.Fatalf("expected duplicate flags error, got %v", err)
+	}
+}
@@ -0,0 +1,53 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"bytes"
+	"context"
+	"sync/atomic"
+)
+
+// embeddedExecutable is the yt-dlp executable set with [SetEmbeddedExecutable].
+var embeddedExecutable atomic.Pointer[[]byte]
+
+// SetEmbeddedExecutable sets the yt-dlp executable (for the current platform)
+// embedded within the application, e.g. using go:embed, for deployments without
+// any network access at runtime. When set, [Install] (and [Resolver.Install])
+// extract it into the go-ytdlp cache (if the expected version isn't already
+// cached), rather than downloading yt-dlp, even if
+// [InstallOptions.DisableDownload] is set. The embedded executable must be the
+// expected version (see [InstallOptions.Version]), unless
+// [InstallOptions.AllowVersionMismatch] is set. Checksums aren't verified, as
+// the executable is part of the application. Pass nil to disable.
+//
+// Alternatively, build with the "ytdlp_embed" build tag to embed the file at
+// "embedded/yt-dlp" within the go-ytdlp module (e.g. when vendored), which is
+// set automatically.
+func SetEmbeddedExecutable(data []byte) {
+	if data == nil {
+		embeddedExecutable.Store(nil)
+		return
+	}
+
+	embeddedExecutable.Store(&data)
+}
+
+// installEmbedded extracts the embedded executable into the cache. rs.mu must be
+// held.
+func (rs *Resolver) installEmbedded(ctx context.Context, data []byte, version string, opts *InstallOptions) (*ResolvedInstall, error) {
+	dir, err := opts.makeCacheDir()
+	if err != nil {
+		return nil, err
+	}
+
+	resolved, err := installExecutable(ctx, bytes.NewReader(data), dir, version, opts.AllowVersionMismatch, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	rs.cache.Store(resolved)
+	return resolved, nil
+}
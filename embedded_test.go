@@ -0,0 +1,45 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestSetEmbeddedExecutable(t *testing.T) { //nolint:paralleltest
+	if runtime.GOOS == "windows" {
+		t.Skip("requires a posix shell")
+	}
+
+	SetEmbeddedExecutable([]byte("#!/bin/sh\necho 2000.01.05\n"))
+	t.Cleanup(func() { SetEmbeddedExecutable(nil) })
+
+	dir := t.TempDir()
+
+	if _, err := NewResolver(&InstallOptions{CacheDir: dir, Version: "2000.01.06", DisableDownload: true}).Install(context.Background()); err == nil {
+		t.Fatal("expected version mismatch error")
+	}
+
+	opts := &InstallOptions{CacheDir: dir, Version: "2000.01.05", DisableDownload: true}
+
+	r, err := NewResolver(opts).Install(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r.Version != "2000.01.05" || r.Executable != filepath.Join(dir, "yt-dlp-2000.01.05") {
+		t.Fatalf("unexpected install: %+v", r)
+	}
+
+	SetEmbeddedExecutable(nil)
+
+	// Already extracted into the cache.
+	if r, err = NewResolver(opts).Install(context.Background()); err != nil || !r.FromCache || r.Downloaded {
+		t.Fatalf("expected install to be resolved from cache: %v: %+v", err, r)
+	}
+}
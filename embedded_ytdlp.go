@@ -0,0 +1,18 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+//go:build ytdlp_embed
+
+package ytdlp
+
+import (
+	_ "embed"
+)
+
+//go:embed embedded/yt-dlp
+var embeddedFile []byte
+
+func init() {
+	SetEmbeddedExecutable(embeddedFile)
+}
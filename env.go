@@ -0,0 +1,61 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"slices"
+	"strings"
+)
+
+// noCertifiCompatOption makes yt-dlp use the system certificate store (which
+// respects SSL_CERT_FILE), rather than the bundled certifi store.
+const noCertifiCompatOption = "no-certifi"
+
+// setEnvVariants sets both the upper and lower case variants of the provided
+// environment variable, as different libraries respect different variants.
+func (c *Command) setEnvVariants(key, value string) {
+	c.SetEnvVar(strings.ToUpper(key), value)
+	c.SetEnvVar(strings.ToLower(key), value)
+}
+
+// SetProxyEnv sets the proxy environment variables (HTTP_PROXY, HTTPS_PROXY and
+// NO_PROXY, in both upper and lower case) for the command. Empty values remove
+// the respective variables. Note that [Command.Proxy] takes precedence over these
+// variables, if set.
+//
+// Note that like [Command.SetEnvVar], setting any environment variable means the
+// environment of the current process is no longer inherited.
+func (c *Command) SetProxyEnv(httpProxy, httpsProxy, noProxy string) *Command {
+	c.setEnvVariants("HTTP_PROXY", httpProxy)
+	c.setEnvVariants("HTTPS_PROXY", httpsProxy)
+	c.setEnvVariants("NO_PROXY", noProxy)
+
+	return c
+}
+
+// SetCABundle sets the path to a PEM-encoded CA bundle, used to verify TLS
+// certificates (e.g. for TLS-intercepting proxies). This sets the SSL_CERT_FILE
+// and REQUESTS_CA_BUNDLE environment variables, and because yt-dlp otherwise
+// prefers its bundled certificate store, also sets the "no-certifi" compat option
+// (see [Command.CompatOptions]). An empty path removes all of the above.
+//
+// Note that like [Command.SetEnvVar], setting any environment variable means the
+// environment of the current process is no longer inherited.
+func (c *Command) SetCABundle(path string) *Command {
+	c.SetEnvVar("SSL_CERT_FILE", path)
+	c.SetEnvVar("REQUESTS_CA_BUNDLE", path)
+
+	c.mu.Lock()
+	c.flags = slices.DeleteFunc(c.flags, func(f *Flag) bool {
+		return f.ID == "compat_opts" && slices.Equal(f.Args, []string{noCertifiCompatOption})
+	})
+	c.mu.Unlock()
+
+	if path != "" {
+		c.CompatOptions(noCertifiCompatOption)
+	}
+
+	return c
+}
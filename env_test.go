@@ -0,0 +1,60 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"testing"
+)
+
+func TestCommand_SetProxyEnv(t *testing.T) {
+	t.Parallel()
+
+	cmd := New().SetProxyEnv("http://proxy:8080", "http://proxy:8443", "localhost,127.0.0.1")
+
+	expected := map[string]string{
+		"HTTP_PROXY":  "http://proxy:8080",
+		"http_proxy":  "http://proxy:8080",
+		"HTTPS_PROXY": "http://proxy:8443",
+		"https_proxy": "http://proxy:8443",
+		"NO_PROXY":    "localhost,127.0.0.1",
+		"no_proxy":    "localhost,127.0.0.1",
+	}
+
+	for k, v := range expected {
+		if cmd.env[k] != v {
+			t.Fatalf("expected %s=%q, got %q", k, v, cmd.env[k])
+		}
+	}
+
+	cmd.SetProxyEnv("", "", "")
+
+	if len(cmd.env) != 0 {
+		t.Fatalf("expected all proxy env vars to be removed, got %v", cmd.env)
+	}
+}
+
+func TestCommand_SetCABundle(t *testing.T) {
+	t.Parallel()
+
+	cmd := New().CompatOptions("filename").SetCABundle("/etc/ssl/custom.pem").SetCABundle("/etc/ssl/other.pem")
+
+	if cmd.env["SSL_CERT_FILE"] != "/etc/ssl/other.pem" || cmd.env["REQUESTS_CA_BUNDLE"] != "/etc/ssl/other.pem" {
+		t.Fatalf("unexpected env: %v", cmd.env)
+	}
+
+	if flags := cmd.getFlagsByID("compat_opts"); len(flags) != 2 || flags[1].Args[0] != noCertifiCompatOption {
+		t.Fatalf("expected a single %q compat option to be added, got %d flags", noCertifiCompatOption, len(flags))
+	}
+
+	cmd.SetCABundle("")
+
+	if len(cmd.env) != 0 {
+		t.Fatalf("expected env vars to be removed, got %v", cmd.env)
+	}
+
+	if flags := cmd.getFlagsByID("compat_opts"); len(flags) != 1 || flags[0].Args[0] != "filename" {
+		t.Fatal("expected only the user-provided compat option to remain")
+	}
+}
@@ -8,7 +8,10 @@ import (
 	"errors"
 	"fmt"
 	"os/exec"
+	"regexp"
+	"runtime/debug"
 	"strings"
+	"sync"
 )
 
 func wrapError(r *Result, err error) (*Result, error) {
@@ -27,6 +30,17 @@ func wrapError(r *Result, err error) (*Result, error) {
 	}
 
 	if r.ExitCode != 0 {
+		if cerr := classifyExitError(r, &ErrExitCode{wrapped: err, result: r}); cerr != nil {
+			return r, cerr
+		}
+
+		if warnings := r.JSRuntimeWarnings(); len(warnings) > 0 {
+			return r, &ErrJSRuntimeRequired{
+				wrapped:  &ErrExitCode{wrapped: err, result: r},
+				Warnings: warnings,
+			}
+		}
+
 		return r, &ErrExitCode{wrapped: err, result: r}
 	}
 
@@ -57,6 +71,231 @@ func IsExitCodeError(err error) bool {
 	return errors.As(err, &e)
 }
 
+// errorPatterns classify yt-dlp error messages, checked in order. It's shared
+// by [classifyExitError] (for entries with wrap set, each of which wraps
+// [ErrExitCode]) and [classifyAvailability] (for entries with an availability
+// other than [AvailabilityUnknown]).
+var errorPatterns = []struct {
+	re           *regexp.Regexp
+	availability Availability
+	wrap         func(err error) error
+}{
+	{
+		regexp.MustCompile(`(?i)private video|video is private`),
+		AvailabilityPrivate,
+		func(err error) error { return &ErrPrivateVideo{wrapped: err} },
+	},
+	{regexp.MustCompile(`(?i)members[- ]only|join this channel`), AvailabilitySubscriberOnly, nil},
+	{regexp.MustCompile(`(?i)requires payment|premium (?:members|subscribers|only)|rental`), AvailabilityPremiumOnly, nil},
+	{
+		regexp.MustCompile(`(?i)available in your (?:country|location)|geo[- ]?restrict|geo[- ]?block`),
+		AvailabilityGeoBlocked,
+		func(err error) error { return &ErrGeoRestricted{wrapped: err} },
+	},
+	{
+		regexp.MustCompile(`(?i)confirm your age|age[- ]restricted|inappropriate for some users`),
+		AvailabilityNeedsAuth,
+		func(err error) error { return &ErrAgeRestricted{wrapped: err} },
+	},
+	{regexp.MustCompile(`(?i)sign in to|login required|account.*required`), AvailabilityNeedsAuth, nil},
+	{
+		regexp.MustCompile(`(?i)been removed|been terminated|no longer available|does not exist|video unavailable|http error 404|410: gone`),
+		AvailabilityRemoved,
+		nil,
+	},
+	{
+		regexp.MustCompile(`(?i)\bDRM\b`),
+		AvailabilityUnknown,
+		func(err error) error { return &ErrDRMProtected{wrapped: err} },
+	},
+	{
+		regexp.MustCompile(`(?i)HTTP Error 429|too many requests|rate[- ]limit`),
+		AvailabilityUnknown,
+		func(err error) error { return &ErrRateLimited{wrapped: err} },
+	},
+	{
+		regexp.MustCompile(`(?i)unsupported url`),
+		AvailabilityUnknown,
+		func(err error) error { return &ErrUnsupportedURL{wrapped: err} },
+	},
+}
+
+// classifyExitError classifies the error of a failed invocation using the
+// "ERROR:" lines of yt-dlp's stderr, returning nil if the error is unknown.
+func classifyExitError(r *Result, err error) error {
+	var lines []string
+
+	for _, line := range strings.Split(r.Stderr, "\n") {
+		if strings.HasPrefix(line, "ERROR:") {
+			lines = append(lines, line)
+		}
+	}
+
+	if len(lines) == 0 {
+		return nil
+	}
+
+	msg := strings.Join(lines, "\n")
+
+	for _, p := range errorPatterns {
+		if p.wrap != nil && p.re.MatchString(msg) {
+			return p.wrap(err)
+		}
+	}
+
+	return nil
+}
+
+// ErrGeoRestricted is returned when the video isn't available in the current
+// location. It wraps [ErrExitCode].
+type ErrGeoRestricted struct {
+	wrapped error
+}
+
+func (e *ErrGeoRestricted) Unwrap() error {
+	return e.wrapped
+}
+
+func (e *ErrGeoRestricted) Error() string {
+	return fmt.Sprintf("geo-restricted: %s", e.wrapped)
+}
+
+// IsGeoRestrictedError returns true when the video isn't available in the current
+// location.
+func IsGeoRestrictedError(err error) bool {
+	var e *ErrGeoRestricted
+	return errors.As(err, &e)
+}
+
+// ErrPrivateVideo is returned when the video is private. It wraps [ErrExitCode].
+type ErrPrivateVideo struct {
+	wrapped error
+}
+
+func (e *ErrPrivateVideo) Unwrap() error {
+	return e.wrapped
+}
+
+func (e *ErrPrivateVideo) Error() string {
+	return fmt.Sprintf("private video: %s", e.wrapped)
+}
+
+// IsPrivateVideoError returns true when the video is private.
+func IsPrivateVideoError(err error) bool {
+	var e *ErrPrivateVideo
+	return errors.As(err, &e)
+}
+
+// ErrAgeRestricted is returned when the video is age-restricted, and requires
+// authentication (e.g. cookies). It wraps [ErrExitCode].
+type ErrAgeRestricted struct {
+	wrapped error
+}
+
+func (e *ErrAgeRestricted) Unwrap() error {
+	return e.wrapped
+}
+
+func (e *ErrAgeRestricted) Error() string {
+	return fmt.Sprintf("age-restricted: %s", e.wrapped)
+}
+
+// IsAgeRestrictedError returns true when the video is age-restricted.
+func IsAgeRestrictedError(err error) bool {
+	var e *ErrAgeRestricted
+	return errors.As(err, &e)
+}
+
+// ErrRateLimited is returned when the site rate limited the requests (e.g. HTTP
+// 429). It wraps [ErrExitCode].
+type ErrRateLimited struct {
+	wrapped error
+}
+
+func (e *ErrRateLimited) Unwrap() error {
+	return e.wrapped
+}
+
+func (e *ErrRateLimited) Error() string {
+	return fmt.Sprintf("rate limited: %s", e.wrapped)
+}
+
+// IsRateLimitedError returns true when the site rate limited the requests.
+func IsRateLimitedError(err error) bool {
+	var e *ErrRateLimited
+	return errors.As(err, &e)
+}
+
+// ErrDRMProtected is returned when the video is DRM protected, which yt-dlp
+// doesn't support. It wraps [ErrExitCode].
+type ErrDRMProtected struct {
+	wrapped error
+}
+
+func (e *ErrDRMProtected) Unwrap() error {
+	return e.wrapped
+}
+
+func (e *ErrDRMProtected) Error() string {
+	return fmt.Sprintf("drm protected: %s", e.wrapped)
+}
+
+// IsDRMProtectedError returns true when the video is DRM protected.
+func IsDRMProtectedError(err error) bool {
+	var e *ErrDRMProtected
+	return errors.As(err, &e)
+}
+
+// ErrUnsupportedURL is returned when no extractor supports the URL. It wraps
+// [ErrExitCode].
+type ErrUnsupportedURL struct {
+	wrapped error
+}
+
+func (e *ErrUnsupportedURL) Unwrap() error {
+	return e.wrapped
+}
+
+func (e *ErrUnsupportedURL) Error() string {
+	return fmt.Sprintf("unsupported url: %s", e.wrapped)
+}
+
+// IsUnsupportedURLError returns true when no extractor supports the URL.
+func IsUnsupportedURLError(err error) bool {
+	var e *ErrUnsupportedURL
+	return errors.As(err, &e)
+}
+
+// ErrCanceled is returned when the context of the invocation was cancelled (or its
+// deadline exceeded) before yt-dlp exited, rather than yt-dlp failing on its own.
+// It wraps the context error, so [errors.Is] can be used with [context.Canceled]
+// and [context.DeadlineExceeded]. The partial results up until cancellation are
+// returned alongside the error, and are also available via [ErrCanceled.Result].
+type ErrCanceled struct {
+	wrapped error
+	result  *Result
+}
+
+func (e *ErrCanceled) Unwrap() error {
+	return e.wrapped
+}
+
+func (e *ErrCanceled) Error() string {
+	return fmt.Sprintf("canceled: %s", e.wrapped)
+}
+
+// Result returns the partial results of the invocation, up until it was cancelled.
+func (e *ErrCanceled) Result() *Result {
+	return e.result
+}
+
+// IsCanceledError returns true when the context of the invocation was cancelled
+// (or its deadline exceeded) before yt-dlp exited.
+func IsCanceledError(err error) bool {
+	var e *ErrCanceled
+	return errors.As(err, &e)
+}
+
 // ErrMisconfig is returned when the yt-dlp executable is not found, or is not
 // configured properly.
 type ErrMisconfig struct {
@@ -124,3 +363,57 @@ func IsUnknownError(err error) bool {
 	var e *ErrUnknown
 	return errors.As(err, &e)
 }
+
+// ErrCallbackPanic is recorded when a user-provided callback (e.g. the function
+// passed to [Command.ProgressFunc]) panics. The panic is recovered, and the
+// invocation continues. See [Result.CallbackPanics].
+type ErrCallbackPanic struct {
+	Callback string // Callback which panicked, e.g. "progress" or "prompt".
+	Value    any    // Value passed to panic.
+	Stack    []byte // Stack trace of the panic.
+}
+
+func (e *ErrCallbackPanic) Error() string {
+	return fmt.Sprintf("%s callback panicked: %v", e.Callback, e.Value)
+}
+
+// IsCallbackPanicError returns true when a user-provided callback panicked.
+func IsCallbackPanicError(err error) bool {
+	var e *ErrCallbackPanic
+	return errors.As(err, &e)
+}
+
+// callSafely invokes fn, recovering (and returning) any panic.
+func callSafely(callback string, fn func()) (err *ErrCallbackPanic) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = &ErrCallbackPanic{Callback: callback, Value: r, Stack: debug.Stack()}
+		}
+	}()
+
+	fn()
+	return nil
+}
+
+// callbackPanics collects recovered callback panics for a single invocation.
+type callbackPanics struct {
+	mu     sync.Mutex
+	panics []*ErrCallbackPanic
+}
+
+func (c *callbackPanics) add(err *ErrCallbackPanic) {
+	if c == nil || err == nil {
+		return
+	}
+
+	c.mu.Lock()
+	c.panics = append(c.panics, err)
+	c.mu.Unlock()
+}
+
+func (c *callbackPanics) list() []*ErrCallbackPanic {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.panics
+}
@@ -0,0 +1,45 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWrapError_Classify(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		stderr string
+		is     func(err error) bool
+	}{
+		{"ERROR: [youtube] abc: Private video. Sign in if you've been granted access to this video", IsPrivateVideoError},
+		{"ERROR: [youtube] abc: Sign in to confirm your age. This video may be inappropriate for some users.", IsAgeRestrictedError},
+		{"ERROR: [youtube] abc: The uploader has not made this video available in your country", IsGeoRestrictedError},
+		{"ERROR: [youtube] abc: Unable to download webpage: HTTP Error 429: Too Many Requests", IsRateLimitedError},
+		{"ERROR: [example] abc: This video is DRM protected", IsDRMProtectedError},
+		{"ERROR: Unsupported URL: https://example.com/", IsUnsupportedURLError},
+		{"WARNING: [youtube] abc: Private video\nERROR: [youtube] abc: Unable to extract data", isOnlyExitCodeError},
+	}
+
+	for _, tt := range tests {
+		_, err := wrapError(&Result{ExitCode: 1, Stderr: tt.stderr}, errors.New("exit status 1"))
+
+		if !tt.is(err) {
+			t.Fatalf("unexpected classification for %q: %#v", tt.stderr, err)
+		}
+
+		if !IsExitCodeError(err) {
+			t.Fatalf("expected %q to also be an exit code error", tt.stderr)
+		}
+	}
+}
+
+// isOnlyExitCodeError returns true if the error is only an exit code error.
+func isOnlyExitCodeError(err error) bool {
+	var e *ErrExitCode
+	return errors.As(err, &e) && err == error(e)
+}
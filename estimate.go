@@ -0,0 +1,173 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// defaultEstimateConcurrency is the default number of extractions to run at once
+// with [Command.EstimateBatch].
+const defaultEstimateConcurrency = 4
+
+// BatchConstraints are the format selection constraints used when estimating a
+// batch with [Command.EstimateBatch]. These should match the constraints used
+// for the actual download, for the estimate to be accurate.
+type BatchConstraints struct {
+	// Format is the format selector (see [Command.Format]). If empty, the format
+	// set on the command (or yt-dlp's default) is used.
+	Format string
+
+	// FormatSort is the format sort order (see [Command.FormatSort]). If empty,
+	// the sort order set on the command (or yt-dlp's default) is used.
+	FormatSort string
+
+	// Concurrency is the number of extractions to run at once. Defaults to 4.
+	Concurrency int
+}
+
+// BatchEstimateItem is the estimate for a single video in a batch.
+type BatchEstimateItem struct {
+	// URL is the input URL the video was extracted from. Multiple items can share
+	// the same URL (e.g. playlists).
+	URL string
+
+	// Info is the extracted info, with the selected formats.
+	Info *ExtractedInfo
+
+	// Bytes is the approximate size of the selected formats, or 0 if unknown.
+	Bytes int64
+
+	// Duration is the media duration, or 0 if unknown.
+	Duration time.Duration
+}
+
+// BatchEstimate is the result of [Command.EstimateBatch].
+type BatchEstimate struct {
+	// Items contains an estimate for each video, in the order of the input URLs.
+	Items []*BatchEstimateItem
+
+	// Bytes is the total approximate size of all videos with a known size.
+	Bytes int64
+
+	// Duration is the total media duration of all videos with a known duration.
+	Duration time.Duration
+
+	// UnknownSize is the number of videos where the size couldn't be estimated,
+	// meaning the actual total is larger than [BatchEstimate.Bytes].
+	UnknownSize int
+
+	// Failed contains the input URLs which couldn't be extracted.
+	Failed []string
+}
+
+// estimateInfo returns the approximate size of the selected formats of the info,
+// or 0 if unknown.
+func estimateInfo(info *ExtractedInfo) (size int64) {
+	if len(info.RequestedFormats) > 0 {
+		for _, f := range info.RequestedFormats {
+			s := f.SizeEstimate()
+			if s <= 0 {
+				return 0 // Partial sizes would be misleading.
+			}
+
+			size += int64(s)
+		}
+
+		return size
+	}
+
+	if info.ExtractedFormat != nil {
+		return int64(info.ExtractedFormat.SizeEstimate())
+	}
+
+	return 0
+}
+
+// EstimateBatch runs simulated extractions (without downloading) for all of the
+// provided URLs, and returns the approximate total size and media duration of the
+// formats that would be selected with the provided constraints. This is useful to
+// warn users before starting a large download. Any flags already set on the
+// command are also used (e.g. cookies), and the command itself is not modified.
+//
+// Sizes are based on the size reported by the extractor, which may be approximate
+// or unknown (see [BatchEstimate.UnknownSize]). If some URLs fail to extract, the
+// estimate for the remaining URLs is still returned, along with an error.
+func (c *Command) EstimateBatch(ctx context.Context, urls []string, constraints BatchConstraints) (*BatchEstimate, error) {
+	cmd := c.clone().SkipDownload().DumpJSON()
+
+	if constraints.Format != "" {
+		cmd.UnsetFormat().Format(constraints.Format)
+	}
+
+	if constraints.FormatSort != "" {
+		cmd.UnsetFormatSort().FormatSort(constraints.FormatSort)
+	}
+
+	concurrency := constraints.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultEstimateConcurrency
+	}
+
+	items := make([][]*BatchEstimateItem, len(urls))
+	estimated := make([]bool, len(urls))
+
+	_, err := runPoolFunc(ctx, concurrency, len(urls), func(i int) string {
+		return fmt.Sprintf("unable to estimate %q", urls[i])
+	}, func(i int) (*Result, error) {
+		result, err := cmd.clone().Run(ctx, urls[i])
+		if err != nil {
+			return result, err
+		}
+
+		infos, err := result.GetExtractedInfo()
+		if err != nil {
+			return result, err
+		}
+
+		for _, info := range infos {
+			item := &BatchEstimateItem{URL: urls[i], Info: info, Bytes: estimateInfo(info)}
+
+			if info.Duration != nil {
+				item.Duration = time.Duration(*info.Duration * float64(time.Second))
+			}
+
+			items[i] = append(items[i], item)
+		}
+
+		estimated[i] = true
+
+		return result, nil
+	})
+
+	estimate := &BatchEstimate{}
+
+	for i := range urls {
+		if !estimated[i] {
+			estimate.Failed = append(estimate.Failed, urls[i])
+			continue
+		}
+
+		for _, item := range items[i] {
+			estimate.Items = append(estimate.Items, item)
+			estimate.Bytes += item.Bytes
+			estimate.Duration += item.Duration
+
+			if item.Bytes == 0 {
+				estimate.UnknownSize++
+			}
+		}
+	}
+
+	return estimate, err
+}
+
+// EstimateBatch is the same as [Command.EstimateBatch], using a new command with
+// no additional flags.
+func EstimateBatch(ctx context.Context, urls []string, constraints BatchConstraints) (*BatchEstimate, error) {
+	return New().EstimateBatch(ctx, urls, constraints)
+}
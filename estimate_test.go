@@ -0,0 +1,64 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+)
+
+const estimateScript = `#!/bin/sh
+for last in "$@"; do :; done
+case "$last" in
+	a)
+		echo '{"_type":"video","id":"a1","duration":60,"requested_formats":[{"format_id":"137","filesize":1000},{"format_id":"140","filesize_approx":500}]}'
+		echo '{"_type":"video","id":"a2","duration":30,"requested_formats":[{"format_id":"137","filesize":1000},{"format_id":"140"}]}'
+		;;
+	b)
+		echo '{"_type":"video","id":"b1","duration":90.5,"format_id":"18","filesize_approx":2000}'
+		;;
+	*)
+		echo "ERROR: unsupported url" >&2
+		exit 1
+		;;
+esac
+`
+
+func TestCommand_EstimateBatch(t *testing.T) {
+	t.Parallel()
+
+	bin := writeFakeYtdlp(t, estimateScript)
+
+	estimate, err := New().SetExecutable(bin).EstimateBatch(
+		context.Background(),
+		[]string{"a", "bad", "b"},
+		BatchConstraints{Format: "bv*+ba", Concurrency: 2},
+	)
+	if err == nil {
+		t.Fatal("expected error for bad url")
+	}
+
+	if len(estimate.Items) != 3 || estimate.Items[0].Info.ID != "a1" || estimate.Items[2].URL != "b" {
+		t.Fatalf("unexpected items: %+v", estimate.Items)
+	}
+
+	if estimate.Bytes != 3500 {
+		t.Fatalf("expected 3500 bytes, got %d", estimate.Bytes)
+	}
+
+	if estimate.UnknownSize != 1 {
+		t.Fatalf("expected 1 unknown size, got %d", estimate.UnknownSize)
+	}
+
+	if want := 180*time.Second + 500*time.Millisecond; estimate.Duration != want {
+		t.Fatalf("expected duration %v, got %v", want, estimate.Duration)
+	}
+
+	if !slices.Equal(estimate.Failed, []string{"bad"}) {
+		t.Fatalf("unexpected failed urls: %v", estimate.Failed)
+	}
+}
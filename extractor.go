@@ -0,0 +1,64 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// GetExtractor returns the supported extractor with the provided name (case
+// insensitive), or nil if no extractor with that name exists.
+func GetExtractor(name string) *Extractor {
+	for _, e := range SupportedExtractors {
+		if strings.EqualFold(e.Name, name) {
+			return e
+		}
+	}
+
+	return nil
+}
+
+// URL returns the canonical URL for the provided ID, using [Extractor.URLTemplate].
+// An error is returned if the extractor doesn't have a known URL template.
+func (e *Extractor) URL(id string) (string, error) {
+	if e.URLTemplate == "" {
+		return "", fmt.Errorf("extractor %q does not support building URLs from IDs", e.Name)
+	}
+
+	id = strings.TrimSpace(id)
+	if id == "" {
+		return "", errors.New("id is empty")
+	}
+
+	return strings.ReplaceAll(e.URLTemplate, "{id}", url.PathEscape(id)), nil
+}
+
+// RunIDs is the same as [Command.Run], however it accepts bare IDs (e.g. the
+// YouTube video ID "dQw4w9WgXcQ"), which are converted to canonical URLs for the
+// provided extractor (see [Extractor.URLTemplate] for which extractors are
+// supported).
+func (c *Command) RunIDs(ctx context.Context, extractor string, ids ...string) (*Result, error) {
+	e := GetExtractor(extractor)
+	if e == nil {
+		return nil, fmt.Errorf("unknown extractor %q", extractor)
+	}
+
+	urls := make([]string, 0, len(ids))
+
+	for _, id := range ids {
+		u, err := e.URL(id)
+		if err != nil {
+			return nil, err
+		}
+
+		urls = append(urls, u)
+	}
+
+	return c.Run(ctx, urls...)
+}
@@ -0,0 +1,108 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// FaultInjector injects failures into invocations, so retry and resume logic
+// (of go-ytdlp, or applications using it) can be tested deterministically. See
+// [Faults] for a ready-made implementation, and [Command.SetFaultInjector].
+type FaultInjector interface {
+	// InjectLine is invoked for each line of output (including progress lines,
+	// before they are parsed), returning the line to use instead, and false if the
+	// line should be dropped. Progress lines are passed with their prefix replaced
+	// by "progress:" (see [Command.SetProgressPrefix]), which should be kept for
+	// the line to still be parsed as progress.
+	InjectLine(pipe, line string) (string, bool)
+
+	// InjectProgress is invoked for each progress update (before any progress
+	// callbacks), returning true if yt-dlp should be killed, simulating a crash.
+	InjectProgress(update *ProgressUpdate) (kill bool)
+}
+
+// Faults is a deterministic [FaultInjector]. The zero value injects no faults.
+// Faults is safe for concurrent use, and counts are shared between all
+// invocations it's used with.
+type Faults struct {
+	// DropProgressEvery drops every Nth progress line (lines starting with
+	// "progress:", see [FaultInjector.InjectLine]). 0 disables.
+	DropProgressEvery int
+
+	// CorruptJSON truncates all JSON output lines (e.g. from [Command.PrintJSON]),
+	// so they fail to parse.
+	CorruptJSON bool
+
+	// KillAtPercent kills yt-dlp once a download reaches the provided percentage
+	// (0-100). 0 disables.
+	KillAtPercent float64
+
+	// KillAfter kills yt-dlp once the provided number of progress updates have
+	// been received. 0 disables.
+	KillAfter int
+
+	mu            sync.Mutex
+	progressLines int
+	updates       int
+}
+
+var _ FaultInjector = (*Faults)(nil)
+
+// InjectLine implements [FaultInjector].
+func (f *Faults) InjectLine(_, line string) (string, bool) {
+	if strings.HasPrefix(line, string(progressPrefix)) {
+		if f.DropProgressEvery <= 0 {
+			return line, true
+		}
+
+		f.mu.Lock()
+		f.progressLines++
+		drop := f.progressLines%f.DropProgressEvery == 0
+		f.mu.Unlock()
+
+		return line, !drop
+	}
+
+	if f.CorruptJSON && strings.HasPrefix(line, "{") {
+		return line[:len(line)/2], true
+	}
+
+	return line, true
+}
+
+// InjectProgress implements [FaultInjector].
+func (f *Faults) InjectProgress(update *ProgressUpdate) bool {
+	f.mu.Lock()
+	f.updates++
+	updates := f.updates
+	f.mu.Unlock()
+
+	if f.KillAfter > 0 && updates >= f.KillAfter {
+		return true
+	}
+
+	return f.KillAtPercent > 0 &&
+		update.Status == ProgressStatusDownloading &&
+		update.Percent() >= f.KillAtPercent
+}
+
+// SetFaultInjector sets the fault injector used by all invocations of the
+// command, for testing. Killing yt-dlp from progress updates requires progress
+// output, so progress reporting is enabled if not already configured (e.g. with
+// [Command.ProgressFunc]). Pass nil to disable (the default).
+func (c *Command) SetFaultInjector(fi FaultInjector) *Command {
+	if fi != nil && len(c.getFlagsByID("progress_template")) == 0 {
+		c.setProgressFlags(time.Second)
+	}
+
+	c.mu.Lock()
+	c.faults = fi
+	c.mu.Unlock()
+
+	return c
+}
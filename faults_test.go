@@ -0,0 +1,72 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// writeFaultsScript writes a fake yt-dlp which prints a JSON line, then 10
+// progress updates (10% each), sleeping between them.
+func writeFaultsScript(t *testing.T) string {
+	t.Helper()
+
+	script := "#!/bin/sh\n" + fakeProgressPreamble + "echo '{\"_type\":\"video\",\"id\":\"abc\",\"extractor\":\"generic\"}'\n"
+	for n := 1; n <= 10; n++ {
+		script += `echo "$PROGRESS"'{"info":{"id":"abc","_type":"video"},"progress":{"status":"downloading","downloaded_bytes":` +
+			strconv.Itoa(n*10) + `,"total_bytes":100,"filename":"abc.mp4"}}'` + "\nsleep 0.05\n"
+	}
+
+	return writeFakeYtdlp(t, script)
+}
+
+func TestCommand_SetFaultInjector(t *testing.T) {
+	t.Parallel()
+
+	bin := writeFaultsScript(t)
+
+	var updates int
+
+	result, err := New().
+		SetExecutable(bin).
+		PrintJSON().
+		ProgressFunc(time.Second, func(_ ProgressUpdate) { updates++ }).
+		SetFaultInjector(&Faults{DropProgressEvery: 3, CorruptJSON: true}).
+		Run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if updates != 7 {
+		t.Fatalf("expected every 3rd progress update to be dropped, got %d updates", updates)
+	}
+
+	if info, _ := result.GetExtractedInfo(); len(info) != 0 || result.Stdout == "" {
+		t.Fatalf("expected JSON output to be corrupted, got %d entries from %q", len(info), result.Stdout)
+	}
+
+	updates = 0
+
+	_, err = New().
+		SetExecutable(bin).
+		ProgressFunc(time.Second, func(_ ProgressUpdate) { updates++ }).
+		SetFaultInjector(&Faults{KillAtPercent: 50}).
+		Run(context.Background())
+	if err == nil {
+		t.Fatal("expected error from killed process")
+	}
+
+	if updates != 4 {
+		t.Fatalf("expected process to be killed at 50%%, got %d updates", updates)
+	}
+
+	// Progress is enabled automatically, when not already configured.
+	if _, err = New().SetExecutable(bin).SetFaultInjector(&Faults{KillAfter: 2}).Run(context.Background()); err == nil {
+		t.Fatal("expected error from killed process")
+	}
+}
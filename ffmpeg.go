@@ -0,0 +1,230 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+)
+
+// ffmpegPostProcessors are the IDs of flags which require ffmpeg after
+// downloading, which can't be worked around by changing the format selection.
+var ffmpegPostProcessors = []string{
+	"addchapters",
+	"addmetadata",
+	"convertsubtitles",
+	"convertthumbnails",
+	"embedsubtitles",
+	"embedthumbnail",
+	"extractaudio",
+	"recodevideo",
+	"remuxvideo",
+	"sponsorblock_remove",
+	"split_chapters",
+}
+
+// FFmpegFallbackOptions configures what [Command.Run] does when ffmpeg is
+// required, but can't be resolved, see [Command.SetFFmpegFallback].
+type FFmpegFallbackOptions struct {
+	// Install installs ffmpeg with [InstallTool], if a tool named "ffmpeg" was
+	// registered with [RegisterTool], and uses it with [Command.FFmpegLocation].
+	// If not registered, or installing fails, the format selection is downgraded
+	// instead.
+	Install bool
+
+	// InstallOptions are the options used to install ffmpeg. May be nil.
+	InstallOptions *InstallOptions
+}
+
+// FFmpegFallback describes how an invocation was changed, as ffmpeg couldn't be
+// resolved, see [Command.SetFFmpegFallback].
+type FFmpegFallback struct {
+	// Installed is the ffmpeg executable which was installed, if any. If set, the
+	// format selection wasn't changed.
+	Installed string `json:"installed,omitempty"`
+
+	// InstallError is the error installing ffmpeg, if it failed.
+	InstallError string `json:"install_error,omitempty"`
+
+	// Format is the format selection which was requested (see [Command.Format]).
+	Format string `json:"format,omitempty"`
+
+	// DowngradedFormat is the format selection which was used instead, which
+	// only selects single files (which don't need merging).
+	DowngradedFormat string `json:"downgraded_format,omitempty"`
+}
+
+// ErrFFmpegMissing is returned when ffmpeg is required by post-processing
+// flags, but can't be resolved, see [Command.SetFFmpegFallback].
+type ErrFFmpegMissing struct {
+	Flags []*Flag // Flags which require ffmpeg.
+}
+
+func (e *ErrFFmpegMissing) Error() string {
+	flags := make([]string, len(e.Flags))
+	for i, f := range e.Flags {
+		flags[i] = f.Flag
+	}
+
+	return fmt.Sprintf("ffmpeg not found, but required by: %s", strings.Join(flags, ", "))
+}
+
+// IsFFmpegMissingError returns true when ffmpeg is required by post-processing
+// flags, but can't be resolved.
+func IsFFmpegMissingError(err error) bool {
+	var e *ErrFFmpegMissing
+	return errors.As(err, &e)
+}
+
+// SetFFmpegFallback configures [Command.Run] to check whether ffmpeg can be
+// resolved (from [Command.FFmpegLocation], or PATH) before invoking yt-dlp, when
+// the command requires it, rather than failing during post-processing after
+// everything was downloaded. If ffmpeg is missing:
+//   - It's installed, if enabled with [FFmpegFallbackOptions.Install].
+//   - Otherwise, if the format selection (see [Command.Format]) merges formats
+//     (e.g. "bv*+ba/b"), alternatives which merge are removed (e.g. "b"), or "b"
+//     is used if all alternatives merge, so a single pre-merged file is
+//     downloaded instead. The change is available via [Result.FFmpegFallback].
+//   - Otherwise, if post-processing flags which require ffmpeg are set (e.g.
+//     [Command.ExtractAudio] or [Command.RecodeVideo]), [ErrFFmpegMissing] is
+//     returned without invoking yt-dlp.
+//
+// Pass nil to disable (the default).
+func (c *Command) SetFFmpegFallback(opts *FFmpegFallbackOptions) *Command {
+	c.mu.Lock()
+	if opts == nil {
+		c.ffmpegFallback = nil
+	} else {
+		o := *opts
+		c.ffmpegFallback = &o
+	}
+	c.mu.Unlock()
+
+	return c
+}
+
+// resolveFFmpegExecutable resolves the ffmpeg (or ffprobe) executable, using the
+// directory (or ffmpeg executable) set with [Command.FFmpegLocation] if any,
+// otherwise the PATH of the command (see [Command.SetEnvVar]), or the current
+// process.
+func (c *Command) resolveFFmpegExecutable(name string) (string, error) {
+	bin := name
+	if runtime.GOOS == "windows" {
+		bin += ".exe"
+	}
+
+	if flags := c.getFlagsByID("ffmpeg_location"); len(flags) > 0 && len(flags[0].Args) > 0 {
+		loc := flags[0].Args[0]
+
+		if stat, err := os.Stat(loc); err == nil && !stat.IsDir() {
+			if filepath.Base(loc) == bin || name == "ffmpeg" {
+				return loc, nil
+			}
+
+			loc = filepath.Dir(loc)
+		}
+
+		if path, ok := findExecutable(filepath.Join(loc, bin)); ok {
+			return path, nil
+		}
+	}
+
+	c.mu.RLock()
+	pathList, ok := lookupEnv(c.env, "PATH")
+	c.mu.RUnlock()
+
+	if ok {
+		return lookupExecutable(name, pathList)
+	}
+
+	return LookupExecutable(name)
+}
+
+// singleFileFormat returns the format selection with all alternatives which
+// merge formats removed, and true if any were removed.
+func singleFileFormat(format string) (string, bool) {
+	alternatives := strings.Split(format, "/")
+
+	kept := slices.DeleteFunc(slices.Clone(alternatives), func(alt string) bool {
+		return strings.Contains(alt, "+")
+	})
+
+	if len(kept) == len(alternatives) {
+		return format, false
+	}
+
+	if len(kept) == 0 {
+		return "b", true
+	}
+
+	return strings.Join(kept, "/"), true
+}
+
+// runFFmpegFallback invokes the command, falling back according to opts if
+// ffmpeg is required, but missing.
+func (c *Command) runFFmpegFallback(ctx context.Context, opts *FFmpegFallbackOptions, args ...string) (*Result, error) {
+	cmd := c.clone().SetFFmpegFallback(nil)
+
+	var format string
+	if flags := cmd.getFlagsByID("format"); len(flags) > 0 && len(flags[0].Args) > 0 {
+		format = flags[0].Args[0]
+	}
+
+	downgraded, merges := singleFileFormat(format)
+
+	var required []*Flag
+	for _, id := range ffmpegPostProcessors {
+		for _, f := range cmd.getFlagsByID(id) {
+			if !strings.HasPrefix(f.Flag, "--no-") {
+				required = append(required, f)
+			}
+		}
+	}
+
+	if !merges && len(required) == 0 {
+		return cmd.Run(ctx, args...)
+	}
+
+	if _, err := cmd.resolveFFmpegExecutable("ffmpeg"); err == nil {
+		return cmd.Run(ctx, args...)
+	}
+
+	fallback := &FFmpegFallback{}
+
+	if opts.Install {
+		if _, err := getTool("ffmpeg"); err == nil {
+			resolved, err := InstallTool(ctx, "ffmpeg", opts.InstallOptions)
+			if err == nil {
+				fallback.Installed = resolved.Executable
+				cmd.UnsetFFmpegLocation().FFmpegLocation(resolved.Executable)
+			} else {
+				fallback.InstallError = err.Error()
+			}
+		}
+	}
+
+	if fallback.Installed == "" {
+		if len(required) > 0 {
+			return nil, &ErrFFmpegMissing{Flags: required}
+		}
+
+		fallback.Format = format
+		fallback.DowngradedFormat = downgraded
+		cmd.UnsetFormat().Format(downgraded)
+	}
+
+	result, err := cmd.Run(ctx, args...)
+	if result != nil {
+		result.FFmpegFallback = fallback
+	}
+
+	return result, err
+}
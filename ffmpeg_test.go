@@ -0,0 +1,75 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSingleFileFormat(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"":                        "",
+		"b":                       "b",
+		"bv*+ba/b":                "b",
+		"bv+ba":                   "b",
+		"bv*+ba/b[height<=720]/w": "b[height<=720]/w",
+	}
+
+	for in, want := range tests {
+		if got, _ := singleFileFormat(in); got != want {
+			t.Fatalf("expected %q to be downgraded to %q, got %q", in, want, got)
+		}
+	}
+}
+
+func TestCommand_SetFFmpegFallback(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	bin := writeFakeExecutable(t, dir, "yt-dlp.sh", "#!/bin/sh\necho \"$@\"\n")
+
+	// PATH without ffmpeg.
+	newCmd := func() *Command {
+		return New().SetExecutable(bin).SetEnvVar("PATH", t.TempDir()).SetFFmpegFallback(&FFmpegFallbackOptions{})
+	}
+
+	result, err := newCmd().Format("bv*+ba/b").Run(context.Background(), "https://example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Stdout != "--format b https://example.com" {
+		t.Fatalf("expected format to be downgraded, got %q", result.Stdout)
+	}
+
+	if fb := result.FFmpegFallback; fb == nil || fb.Format != "bv*+ba/b" || fb.DowngradedFormat != "b" {
+		t.Fatalf("unexpected fallback: %+v", fb)
+	}
+
+	_, err = newCmd().ExtractAudio().Run(context.Background(), "https://example.com")
+	if !IsFFmpegMissingError(err) {
+		t.Fatalf("expected ffmpeg missing error, got %v", err)
+	}
+
+	// Not required.
+	result, err = newCmd().NoEmbedSubs().Run(context.Background(), "https://example.com")
+	if err != nil || result.FFmpegFallback != nil {
+		t.Fatalf("expected no fallback when ffmpeg isn't required: %v", err)
+	}
+
+	writeFakeExecutable(t, dir, "ffmpeg", "#!/bin/sh\n")
+
+	result, err = newCmd().FFmpegLocation(dir).Format("bv*+ba").ExtractAudio().Run(context.Background(), "https://example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.FFmpegFallback != nil || result.Stdout != "--ffmpeg-location "+dir+" --format bv*+ba --extract-audio https://example.com" {
+		t.Fatalf("expected no fallback when ffmpeg is available: %q", result.Stdout)
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// resolveFFprobe resolves the ffprobe executable, using the directory (or ffmpeg
+// executable) set with [Command.FFmpegLocation] if any, otherwise the PATH of the
+// command (see [Command.SetEnvVar]), or the current process.
+func (c *Command) resolveFFprobe() (string, error) {
+	return c.resolveFFmpegExecutable("ffprobe")
+}
+
+// probeResult is the output of ffprobe for a single file.
+type probeResult struct {
+	Format struct {
+		FormatName string `json:"format_name"`
+		Duration   string `json:"duration"`
+	} `json:"format"`
+}
+
+// duration returns the duration of the file, or 0 if unknown.
+func (p *probeResult) duration() time.Duration {
+	secs, err := strconv.ParseFloat(p.Format.Duration, 64)
+	if err != nil {
+		return 0
+	}
+
+	return time.Duration(secs * float64(time.Second))
+}
+
+// probeFile runs ffprobe on the file. Any errors reported by ffprobe (e.g. due to
+// a corrupt or truncated file) are returned as the second return value.
+func probeFile(ctx context.Context, ffprobe, path string) (probe *probeResult, problems []string, err error) {
+	args := []string{"-v", "error", "-show_entries", "format=format_name,duration", "-of", "json"}
+
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, ffprobe, append(args, path)...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	runErr := cmd.Run()
+
+	for _, line := range strings.Split(stderr.String(), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			problems = append(problems, line)
+		}
+	}
+
+	var exitErr *exec.ExitError
+	if runErr != nil && !errors.As(runErr, &exitErr) {
+		return nil, nil, fmt.Errorf("unable to run ffprobe: %w", runErr)
+	}
+
+	probe = &probeResult{}
+
+	if err = json.Unmarshal(stdout.Bytes(), probe); err != nil && runErr == nil {
+		return nil, nil, fmt.Errorf("unable to parse ffprobe output: %w", err)
+	}
+
+	if runErr != nil && len(problems) == 0 {
+		problems = append(problems, runErr.Error())
+	}
+
+	return probe, problems, nil
+}
@@ -0,0 +1,22 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+//go:build !windows && (!unix || aix)
+
+package ytdlp
+
+import (
+	"os"
+)
+
+// lockFile is a no-op on this platform, so files are only locked within the
+// process.
+func lockFile(_ *os.File) error {
+	return nil
+}
+
+// unlockFile is a no-op on this platform.
+func unlockFile(_ *os.File) error {
+	return nil
+}
@@ -0,0 +1,148 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"regexp"
+	"time"
+)
+
+// EntryPredicate is a function which returns true if the provided entry should be
+// kept. See [FilterEntries].
+type EntryPredicate func(entry *ExtractedInfo) bool
+
+// FilterEntries returns all entries within info (e.g. the results of a playlist,
+// when invoked with [Command.FlatPlaylist] and [Command.DumpSingleJSON]) for which
+// pred returns true. Nested playlists are flattened. If info isn't a playlist, it
+// will be checked against pred itself. Use [EntryURLs] to convert the results
+// back into URLs to download.
+func FilterEntries(info *ExtractedInfo, pred EntryPredicate) []*ExtractedInfo {
+	if info == nil {
+		return nil
+	}
+
+	if info.Type != ExtractedTypePlaylist && info.Type != ExtractedTypeMultiVideo {
+		if pred(info) {
+			return []*ExtractedInfo{info}
+		}
+		return nil
+	}
+
+	var entries []*ExtractedInfo
+
+	for _, entry := range info.Entries {
+		entries = append(entries, FilterEntries(entry, pred)...)
+	}
+
+	return entries
+}
+
+// EntryURLs returns the URLs for the provided entries, which can be passed to
+// [Command.Run]. Entries without a URL are skipped.
+func EntryURLs(entries []*ExtractedInfo) []string {
+	urls := make([]string, 0, len(entries))
+
+	for _, entry := range entries {
+		switch {
+		case entry.WebpageURL != nil:
+			urls = append(urls, *entry.WebpageURL)
+		case entry.URL != nil:
+			urls = append(urls, *entry.URL)
+		}
+	}
+
+	return urls
+}
+
+// AllOf returns a predicate which returns true if all of the provided predicates
+// return true.
+func AllOf(preds ...EntryPredicate) EntryPredicate {
+	return func(entry *ExtractedInfo) bool {
+		for _, pred := range preds {
+			if !pred(entry) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// AnyOf returns a predicate which returns true if any of the provided predicates
+// return true.
+func AnyOf(preds ...EntryPredicate) EntryPredicate {
+	return func(entry *ExtractedInfo) bool {
+		for _, pred := range preds {
+			if pred(entry) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not returns a predicate which negates the provided predicate.
+func Not(pred EntryPredicate) EntryPredicate {
+	return func(entry *ExtractedInfo) bool {
+		return !pred(entry)
+	}
+}
+
+// DurationBetween returns a predicate which returns true if the duration of the
+// entry is between minimum and maximum (inclusive). A maximum of 0 means no upper
+// limit. Entries with an unknown duration are excluded.
+func DurationBetween(minimum, maximum time.Duration) EntryPredicate {
+	return func(entry *ExtractedInfo) bool {
+		if entry.Duration == nil {
+			return false
+		}
+
+		d := time.Duration(*entry.Duration * float64(time.Second))
+
+		return d >= minimum && (maximum == 0 || d <= maximum)
+	}
+}
+
+// UploadedAfter returns a predicate which returns true if the entry was uploaded
+// after t. Entries with an unknown upload date are excluded. Note that if only
+// the upload date is known (and not the exact timestamp), the date is compared
+// as midnight UTC.
+func UploadedAfter(t time.Time) EntryPredicate {
+	return func(entry *ExtractedInfo) bool {
+		uploaded, ok := entryUploadTime(entry)
+		return ok && uploaded.After(t)
+	}
+}
+
+// UploadedBefore returns a predicate which returns true if the entry was uploaded
+// before t. See [UploadedAfter] for more information.
+func UploadedBefore(t time.Time) EntryPredicate {
+	return func(entry *ExtractedInfo) bool {
+		uploaded, ok := entryUploadTime(entry)
+		return ok && uploaded.Before(t)
+	}
+}
+
+func entryUploadTime(entry *ExtractedInfo) (time.Time, bool) {
+	if entry.Timestamp != nil {
+		return time.Unix(int64(*entry.Timestamp), 0), true
+	}
+
+	if entry.UploadDate != nil {
+		t, err := time.Parse("20060102", *entry.UploadDate)
+		if err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// TitleMatches returns a predicate which returns true if the title of the entry
+// matches the provided regular expression.
+func TitleMatches(re *regexp.Regexp) EntryPredicate {
+	return func(entry *ExtractedInfo) bool {
+		return entry.Title != nil && re.MatchString(*entry.Title)
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"regexp"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestFilterEntries(t *testing.T) {
+	info := &ExtractedInfo{
+		Type: ExtractedTypePlaylist,
+		Entries: []*ExtractedInfo{
+			{Type: ExtractedTypeURL, URL: ptr("https://example.com/1"), Title: ptr("Episode 1"), Duration: ptr(60.0), UploadDate: ptr("20240101")},
+			{Type: ExtractedTypeURL, URL: ptr("https://example.com/2"), Title: ptr("Trailer"), Duration: ptr(30.0), UploadDate: ptr("20240201")},
+			{
+				Type: ExtractedTypePlaylist,
+				Entries: []*ExtractedInfo{
+					{Type: ExtractedTypeURL, URL: ptr("https://example.com/3"), Title: ptr("Episode 2"), Duration: ptr(120.0), Timestamp: ptr(1709251200.0)},
+				},
+			},
+			{Type: ExtractedTypeURL, URL: ptr("https://example.com/4"), Title: ptr("Episode 3")},
+		},
+	}
+
+	tests := []struct {
+		name string
+		pred EntryPredicate
+		want []string
+	}{
+		{
+			name: "duration",
+			pred: DurationBetween(time.Minute, 0),
+			want: []string{"https://example.com/1", "https://example.com/3"},
+		},
+		{
+			name: "uploaded-after",
+			pred: UploadedAfter(time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC)),
+			want: []string{"https://example.com/2", "https://example.com/3"},
+		},
+		{
+			name: "title",
+			pred: AllOf(TitleMatches(regexp.MustCompile(`^Episode`)), Not(DurationBetween(90*time.Second, 0))),
+			want: []string{"https://example.com/1", "https://example.com/4"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := EntryURLs(FilterEntries(info, tt.pred))
+
+			if !slices.Equal(got, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
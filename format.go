@@ -0,0 +1,295 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// DefaultFormatSortKeys are the keys used by [ExtractedFormat.Better] when no
+// keys are provided.
+var DefaultFormatSortKeys = SortKeys{SortField("quality"), Res(0), FPS(0), TBR(0), Size("")}
+
+// comparableSortFields are the sort fields supported by [ExtractedFormat.Better].
+var comparableSortFields = []string{
+	"quality", "res", "width", "height", "fps", "tbr", "br", "vbr", "abr", "asr",
+	"channels", "size", "filesize", "fs_approx", "source", "lang",
+}
+
+// formatSortAliases maps aliases of the sort fields supported by
+// [ExtractedFormat.Better] to the field.
+var formatSortAliases = map[string]string{
+	"dimension":         "res",
+	"resolution":        "res",
+	"framerate":         "fps",
+	"total_bitrate":     "tbr",
+	"video_bitrate":     "vbr",
+	"audio_bitrate":     "abr",
+	"bitrate":           "br",
+	"samplerate":        "asr",
+	"filesize_estimate": "size",
+}
+
+// Bitrate returns the average bitrate (audio and video) of the format in KBit/s,
+// or 0 if unknown.
+func (f *ExtractedFormat) Bitrate() float64 {
+	if f.TBR != nil {
+		return *f.TBR
+	}
+
+	var rate float64
+
+	if f.VBR != nil {
+		rate += *f.VBR
+	}
+
+	if f.ABR != nil {
+		rate += *f.ABR
+	}
+
+	return rate
+}
+
+// SizeEstimate returns the exact file size of the format if known, otherwise the
+// approximate file size, or 0 if neither is known.
+func (f *ExtractedFormat) SizeEstimate() int {
+	if f.FileSize != nil && *f.FileSize > 0 {
+		return *f.FileSize
+	}
+
+	if f.FileSizeApprox != nil {
+		return *f.FileSizeApprox
+	}
+
+	return 0
+}
+
+// IsAudioOnly returns true if the format only contains audio.
+func (f *ExtractedFormat) IsAudioOnly() bool {
+	return f.ACodec != nil && f.VCodec == nil
+}
+
+// IsVideoOnly returns true if the format only contains video.
+func (f *ExtractedFormat) IsVideoOnly() bool {
+	return f.VCodec != nil && f.ACodec == nil
+}
+
+// formatSortValue returns the numeric value of the provided sort field for the
+// format, and false if the value is unknown, or the field isn't supported.
+func (f *ExtractedFormat) formatSortValue(field string) (float64, bool) {
+	deref := func(v *float64) (float64, bool) {
+		if v == nil {
+			return 0, false
+		}
+		return *v, true
+	}
+
+	switch field {
+	case "quality":
+		return deref(f.Quality)
+	case "res":
+		var known []float64
+
+		for _, v := range []*float64{f.Width, f.Height} {
+			if v != nil && *v > 0 {
+				known = append(known, *v)
+			}
+		}
+
+		if len(known) == 0 {
+			return 0, false
+		}
+
+		return slices.Min(known), true
+	case "width":
+		return deref(f.Width)
+	case "height":
+		return deref(f.Height)
+	case "fps":
+		return deref(f.FPS)
+	case "tbr", "br":
+		rate := f.Bitrate()
+		return rate, rate > 0
+	case "vbr":
+		return deref(f.VBR)
+	case "abr":
+		return deref(f.ABR)
+	case "asr":
+		return deref(f.ASR)
+	case "channels":
+		return deref(f.AudioChannels)
+	case "size":
+		size := f.SizeEstimate()
+		return float64(size), size > 0
+	case "filesize":
+		if f.FileSize != nil {
+			return float64(*f.FileSize), true
+		}
+	case "fs_approx":
+		if f.FileSizeApprox != nil {
+			return float64(*f.FileSizeApprox), true
+		}
+	case "source":
+		if f.SourcePreference != nil {
+			return float64(*f.SourcePreference), true
+		}
+	case "lang":
+		if f.LanguagePreference != nil {
+			return float64(*f.LanguagePreference), true
+		}
+	}
+
+	return 0, false
+}
+
+// formatSortLimit returns the field of the sort key (resolving aliases), and its
+// value (if any) as a number, or an error if it can't be used with
+// [ExtractedFormat.Better].
+func formatSortLimit(k SortKey) (field string, limit float64, err error) {
+	if err = k.Validate(); err != nil {
+		return "", 0, err
+	}
+
+	field = k.Field
+	if alias, ok := formatSortAliases[field]; ok {
+		field = alias
+	}
+
+	if !slices.Contains(comparableSortFields, field) {
+		return "", 0, fmt.Errorf("unsupported format sort key %q: field %q can't be compared locally", k.String(), k.Field)
+	}
+
+	if k.Value == "" {
+		return field, math.NaN(), nil
+	}
+
+	switch field {
+	case "size", "filesize", "fs_approx":
+		var size ByteSize
+
+		size, err = ParseByteSize(k.Value)
+		limit = float64(size)
+	default:
+		limit, err = strconv.ParseFloat(strings.TrimRightFunc(k.Value, unicode.IsLetter), 64)
+	}
+
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid format sort key %q: %w", k.String(), err)
+	}
+
+	return field, limit, nil
+}
+
+// formatSortPreference returns the preference of the value for the sort key
+// (compared in order, higher is better), following yt-dlp's format sorting.
+// limit is NaN if the sort key has no value.
+func formatSortPreference(k SortKey, value float64, known bool, limit float64) []float64 {
+	hasLimit := !math.IsNaN(limit)
+
+	switch {
+	case !known:
+		return []float64{-10, 0, 0}
+	case hasLimit && k.Closest && k.Reverse:
+		return []float64{0, -math.Abs(value - limit), value - limit}
+	case hasLimit && k.Closest:
+		return []float64{0, -math.Abs(value - limit), limit - value}
+	case !k.Reverse && (!hasLimit || value <= limit):
+		return []float64{0, value, 0}
+	case !hasLimit || value >= limit:
+		return []float64{0, -value, 0}
+	default:
+		return []float64{-1, value, 0}
+	}
+}
+
+// Better returns true if f is a better format than other, according to the
+// provided sort keys (which are compared in order, with the first non-equal key
+// determining the result), mirroring yt-dlp's format sorting (see
+// [Command.FormatSortKeys]), including limits (e.g. Res(1080)), [SortKey.Asc]
+// and [SortKey.Nearest]. Only the numeric fields are supported: quality, res,
+// width, height, fps, tbr, br, vbr, abr, asr, channels, size, filesize,
+// fs_approx, source and lang (and their aliases). An error is returned for any
+// other field. If no keys are provided, [DefaultFormatSortKeys] are used.
+//
+//   - See also [Command.FormatSort], for yt-dlp-side format sorting.
+func (f *ExtractedFormat) Better(other *ExtractedFormat, keys ...SortKey) (bool, error) {
+	if len(keys) == 0 {
+		keys = DefaultFormatSortKeys
+	}
+
+	fields := make([]string, len(keys))
+	limits := make([]float64, len(keys))
+
+	for i, k := range keys {
+		var err error
+
+		fields[i], limits[i], err = formatSortLimit(k)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	if other == nil {
+		return true, nil
+	}
+
+	for i, k := range keys {
+		a, aok := f.formatSortValue(fields[i])
+		b, bok := other.formatSortValue(fields[i])
+
+		if c := slices.Compare(
+			formatSortPreference(k, a, aok, limits[i]),
+			formatSortPreference(k, b, bok, limits[i]),
+		); c != 0 {
+			return c > 0, nil
+		}
+	}
+
+	return false, nil
+}
+
+// String returns a human-readable representation of the format, similar to
+// yt-dlp's own representation, e.g. "137 - 1920x1080 (1080p)".
+func (f *ExtractedFormat) String() string {
+	if f == nil {
+		return "<nil>"
+	}
+
+	if f.Format != nil && *f.Format != "" {
+		return *f.Format
+	}
+
+	var id, res string
+
+	if f.FormatID != nil {
+		id = *f.FormatID
+	}
+
+	switch {
+	case f.Resolution != nil:
+		res = *f.Resolution
+	case f.Width != nil && f.Height != nil:
+		res = fmt.Sprintf("%dx%d", int(*f.Width), int(*f.Height))
+	case f.Height != nil:
+		res = strconv.Itoa(int(*f.Height)) + "p"
+	case f.IsAudioOnly():
+		res = "audio only"
+	default:
+		res = "unknown"
+	}
+
+	out := id + " - " + res
+
+	if f.FormatNote != nil && *f.FormatNote != "" {
+		out += " (" + *f.FormatNote + ")"
+	}
+
+	return out
+}
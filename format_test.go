@@ -0,0 +1,95 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import "testing"
+
+func TestExtractedFormat_String(t *testing.T) {
+	tests := []struct {
+		format *ExtractedFormat
+		want   string
+	}{
+		{
+			format: &ExtractedFormat{FormatID: ptr("137"), Width: ptr(1920.0), Height: ptr(1080.0), FormatNote: ptr("1080p")},
+			want:   "137 - 1920x1080 (1080p)",
+		},
+		{
+			format: &ExtractedFormat{FormatID: ptr("140"), ACodec: ptr("mp4a.40.2"), FormatNote: ptr("medium")},
+			want:   "140 - audio only (medium)",
+		},
+		{
+			format: &ExtractedFormat{Format: ptr("18 - 640x360 (360p)")},
+			want:   "18 - 640x360 (360p)",
+		},
+	}
+
+	for _, tt := range tests {
+		if got := tt.format.String(); got != tt.want {
+			t.Fatalf("expected %q, got %q", tt.want, got)
+		}
+	}
+}
+
+func TestExtractedFormat_Better(t *testing.T) {
+	hd := &ExtractedFormat{Width: ptr(1920.0), Height: ptr(1080.0), FPS: ptr(30.0), TBR: ptr(4000.0), FileSize: ptr(5000)}
+	sd := &ExtractedFormat{Width: ptr(640.0), Height: ptr(360.0), FPS: ptr(60.0), TBR: ptr(1000.0), FileSize: ptr(1000)}
+	unknown := &ExtractedFormat{}
+
+	tests := []struct {
+		name string
+		a, b *ExtractedFormat
+		keys []SortKey
+		want bool
+	}{
+		{name: "default", a: hd, b: sd, want: true},
+		{name: "fps", a: hd, b: sd, keys: []SortKey{FPS(0)}, want: false},
+		{name: "asc-size", a: hd, b: sd, keys: []SortKey{Size("").Asc()}, want: false},
+		{name: "equal", a: hd, b: hd, want: false},
+		{name: "res-limit", a: sd, b: hd, keys: []SortKey{Res(720)}, want: true},
+		{name: "res-nearest", a: hd, b: sd, keys: []SortKey{Res(900).Nearest()}, want: true},
+		{name: "size-limit", a: sd, b: hd, keys: []SortKey{Size("2KiB")}, want: true},
+		{name: "alias", a: hd, b: sd, keys: []SortKey{SortField("resolution")}, want: true},
+		{name: "unknown-asc", a: sd, b: unknown, keys: []SortKey{Size("").Asc()}, want: true},
+		{name: "nil", a: sd, b: nil, want: true},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.a.Better(tt.b, tt.keys...)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+
+		if got != tt.want {
+			t.Fatalf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+
+	for _, k := range []SortKey{VCodec("av01"), SortField("hasvid"), SortField("bogus")} {
+		if _, err := hd.Better(sd, k); err == nil {
+			t.Fatalf("expected error for unsupported sort key %q", k)
+		}
+	}
+}
+
+func TestExtractedFormat_Helpers(t *testing.T) {
+	audio := &ExtractedFormat{ACodec: ptr("opus"), ABR: ptr(128.0), FileSizeApprox: ptr(100)}
+	video := &ExtractedFormat{VCodec: ptr("avc1"), VBR: ptr(1000.0)}
+
+	if !audio.IsAudioOnly() || audio.IsVideoOnly() {
+		t.Fatal("expected audio format to be audio only")
+	}
+
+	if !video.IsVideoOnly() || video.IsAudioOnly() {
+		t.Fatal("expected video format to be video only")
+	}
+
+	if audio.Bitrate() != 128 {
+		t.Fatalf("expected bitrate 128, got %f", audio.Bitrate())
+	}
+
+	if audio.SizeEstimate() != 100 {
+		t.Fatalf("expected size estimate 100, got %d", audio.SizeEstimate())
+	}
+}
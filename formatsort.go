@@ -0,0 +1,307 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// sortFieldKind is the kind of value a format sort field accepts.
+type sortFieldKind int
+
+const (
+	sortFieldNoValue sortFieldKind = iota // Field doesn't accept a value.
+	sortFieldNumeric                      // Field accepts a numeric value (with optional unit suffix).
+	sortFieldString                       // Field accepts an arbitrary string value.
+)
+
+// sortFields are the fields supported by yt-dlp's format sorting (including
+// aliases). See: https://github.com/yt-dlp/yt-dlp#sorting-formats
+var sortFields = map[string]sortFieldKind{
+	"hasvid":    sortFieldNoValue,
+	"hasaud":    sortFieldNoValue,
+	"ie_pref":   sortFieldNoValue,
+	"lang":      sortFieldNoValue,
+	"quality":   sortFieldNoValue,
+	"source":    sortFieldNoValue,
+	"id":        sortFieldNoValue,
+	"proto":     sortFieldString,
+	"vcodec":    sortFieldString,
+	"acodec":    sortFieldString,
+	"codec":     sortFieldString,
+	"vext":      sortFieldString,
+	"aext":      sortFieldString,
+	"ext":       sortFieldString,
+	"hdr":       sortFieldString,
+	"filesize":  sortFieldNumeric,
+	"fs_approx": sortFieldNumeric,
+	"size":      sortFieldNumeric,
+	"height":    sortFieldNumeric,
+	"width":     sortFieldNumeric,
+	"res":       sortFieldNumeric,
+	"fps":       sortFieldNumeric,
+	"channels":  sortFieldNumeric,
+	"tbr":       sortFieldNumeric,
+	"vbr":       sortFieldNumeric,
+	"abr":       sortFieldNumeric,
+	"br":        sortFieldNumeric,
+	"asr":       sortFieldNumeric,
+
+	// Aliases.
+	"video":             sortFieldNoValue,
+	"audio":             sortFieldNoValue,
+	"extractor":         sortFieldNoValue,
+	"video_codec":       sortFieldString,
+	"audio_codec":       sortFieldString,
+	"video_ext":         sortFieldString,
+	"audio_ext":         sortFieldString,
+	"extension":         sortFieldString,
+	"dimension":         sortFieldNumeric,
+	"resolution":        sortFieldNumeric,
+	"filesize_estimate": sortFieldNumeric,
+	"framerate":         sortFieldNumeric,
+	"total_bitrate":     sortFieldNumeric,
+	"video_bitrate":     sortFieldNumeric,
+	"audio_bitrate":     sortFieldNumeric,
+	"bitrate":           sortFieldNumeric,
+	"samplerate":        sortFieldNumeric,
+}
+
+var (
+	reSortKey     = regexp.MustCompile(`^(\+?)([a-z_]+)(?:([:~])(.+))?$`)
+	reSortNumeric = regexp.MustCompile(`^\d+(?:\.\d+)?[a-zA-Z]*$`)
+)
+
+// SortKey is a single key of yt-dlp's format sorting (see [Command.FormatSortKeys]),
+// e.g. "res:1080", "+size" or "vcodec:av01".
+type SortKey struct {
+	// Field is the field to sort by, e.g. "res".
+	Field string
+
+	// Value is the preferred value (or limit) of the field, e.g. "1080" for "res".
+	// Formats up to this value are preferred. Optional.
+	Value string
+
+	// Closest prefers the value closest to Value, rather than using it as a limit
+	// (i.e. "~" instead of ":").
+	Closest bool
+
+	// Reverse reverses the sort order of the field (i.e. "+" prefix), e.g. to
+	// prefer smaller files with "+size".
+	Reverse bool
+}
+
+// SortField returns a sort key for an arbitrary field, optionally with a preferred
+// value.
+func SortField(field string, value ...string) SortKey {
+	return SortKey{Field: field, Value: strings.Join(value, ":")}
+}
+
+// Res returns a sort key preferring the largest resolution up to the provided
+// height (e.g. 1080). Use 0 to prefer the largest resolution.
+func Res(height int) SortKey {
+	return numericSortKey("res", height)
+}
+
+// FPS returns a sort key preferring the highest framerate up to the provided
+// framerate. Use 0 to prefer the highest framerate.
+func FPS(fps int) SortKey {
+	return numericSortKey("fps", fps)
+}
+
+// TBR returns a sort key preferring the highest total bitrate (in KBit/s) up to
+// the provided bitrate. Use 0 to prefer the highest bitrate.
+func TBR(kbps int) SortKey {
+	return numericSortKey("tbr", kbps)
+}
+
+// Size returns a sort key preferring the largest file size up to the provided
+// size (e.g. "500M"). Use an empty string to prefer the largest size, or
+// [SortKey.Asc] to prefer the smallest size.
+func Size(size string) SortKey {
+	return SortKey{Field: "size", Value: size}
+}
+
+// Codec returns a sort key preferring the provided video codec (e.g. "av01"),
+// and optionally audio codec (e.g. "opus"), or better.
+func Codec(codec ...string) SortKey {
+	return SortField("codec", codec...)
+}
+
+// VCodec returns a sort key preferring the provided video codec (e.g. "av01"),
+// or better.
+func VCodec(codec string) SortKey {
+	return SortKey{Field: "vcodec", Value: codec}
+}
+
+// ACodec returns a sort key preferring the provided audio codec (e.g. "opus"),
+// or better.
+func ACodec(codec string) SortKey {
+	return SortKey{Field: "acodec", Value: codec}
+}
+
+// Ext returns a sort key preferring the provided video extension (e.g. "mp4"),
+// and optionally audio extension (e.g. "m4a").
+func Ext(ext ...string) SortKey {
+	return SortField("ext", ext...)
+}
+
+// Proto returns a sort key preferring the provided protocol (e.g. "https"), or
+// better.
+func Proto(proto string) SortKey {
+	return SortKey{Field: "proto", Value: proto}
+}
+
+// Lang returns a sort key preferring formats in the language preferred by the
+// extractor. yt-dlp doesn't support preferring a specific language when sorting,
+// use a format filter instead (e.g. "[language=en]" with [Command.Format]).
+func Lang() SortKey {
+	return SortKey{Field: "lang"}
+}
+
+func numericSortKey(field string, value int) SortKey {
+	k := SortKey{Field: field}
+
+	if value > 0 {
+		k.Value = strconv.Itoa(value)
+	}
+
+	return k
+}
+
+// Asc returns a copy of the sort key, with the sort order reversed (i.e.
+// preferring smaller values).
+func (k SortKey) Asc() SortKey {
+	k.Reverse = true
+	return k
+}
+
+// Nearest returns a copy of the sort key, preferring the value closest to
+// [SortKey.Value], rather than using it as a limit.
+func (k SortKey) Nearest() SortKey {
+	k.Closest = true
+	return k
+}
+
+// String returns the sort key in yt-dlp's format sort syntax.
+func (k SortKey) String() string {
+	var sb strings.Builder
+
+	if k.Reverse {
+		sb.WriteByte('+')
+	}
+
+	sb.WriteString(k.Field)
+
+	if k.Value != "" {
+		if k.Closest {
+			sb.WriteByte('~')
+		} else {
+			sb.WriteByte(':')
+		}
+
+		sb.WriteString(k.Value)
+	}
+
+	return sb.String()
+}
+
+// Validate returns an error if the sort key is invalid.
+func (k SortKey) Validate() error {
+	kind, ok := sortFields[k.Field]
+	if !ok {
+		return fmt.Errorf("invalid format sort key %q: unknown field %q", k.String(), k.Field)
+	}
+
+	switch {
+	case k.Value == "" && k.Closest:
+		return fmt.Errorf("invalid format sort key %q: closest requires a value", k.String())
+	case k.Value == "":
+		return nil
+	case kind == sortFieldNoValue:
+		return fmt.Errorf("invalid format sort key %q: field %q doesn't accept a value", k.String(), k.Field)
+	case kind == sortFieldNumeric && !reSortNumeric.MatchString(k.Value):
+		return fmt.Errorf("invalid format sort key %q: field %q requires a numeric value", k.String(), k.Field)
+	case strings.ContainsAny(k.Value, ", "):
+		return fmt.Errorf("invalid format sort key %q: value contains invalid characters", k.String())
+	}
+
+	return nil
+}
+
+// SortKeys is a list of format sort keys, in order of priority.
+type SortKeys []SortKey
+
+// String returns the sort keys in yt-dlp's format sort syntax (e.g.
+// "res:1080,vcodec:av01").
+func (keys SortKeys) String() string {
+	s := make([]string, len(keys))
+
+	for i, k := range keys {
+		s[i] = k.String()
+	}
+
+	return strings.Join(s, ",")
+}
+
+// Validate returns an error if any of the sort keys are invalid.
+func (keys SortKeys) Validate() error {
+	if len(keys) == 0 {
+		return fmt.Errorf("no format sort keys provided")
+	}
+
+	for _, k := range keys {
+		if err := k.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ParseFormatSort parses yt-dlp's format sort syntax (e.g. "res:1080,+size"), as
+// used with [Command.FormatSort], into typed sort keys.
+func ParseFormatSort(s string) (SortKeys, error) {
+	var keys SortKeys
+
+	for _, raw := range strings.Split(s, ",") {
+		raw = strings.TrimSpace(raw)
+
+		m := reSortKey.FindStringSubmatch(raw)
+		if m == nil {
+			return nil, fmt.Errorf("invalid format sort key %q", raw)
+		}
+
+		k := SortKey{Field: m[2], Value: m[4], Closest: m[3] == "~", Reverse: m[1] == "+"}
+
+		if err := k.Validate(); err != nil {
+			return nil, err
+		}
+
+		keys = append(keys, k)
+	}
+
+	return keys, nil
+}
+
+// FormatSortKeys is the same as [Command.FormatSort], using typed sort keys (e.g.
+// Res(1080), Codec("av01"), Ext("mp4")), which are validated. If any of the keys
+// are invalid, the error is returned when the command is invoked.
+func (c *Command) FormatSortKeys(keys ...SortKey) *Command {
+	if err := SortKeys(keys).Validate(); err != nil {
+		c.addFlag(&Flag{
+			ID:   "format_sort",
+			Flag: "--format-sort",
+			Args: []string{},
+			err:  fmt.Errorf("unable to set format sort: %w", err),
+		})
+		return c
+	}
+
+	return c.FormatSort(SortKeys(keys).String())
+}
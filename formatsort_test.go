@@ -0,0 +1,53 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+func TestSortKeys(t *testing.T) {
+	t.Parallel()
+
+	keys := SortKeys{Res(1080), FPS(0), Codec("av01", "opus"), Ext("mp4", "m4a"), Size("500M").Asc(), TBR(2000).Nearest(), Lang()}
+
+	want := "res:1080,fps,codec:av01:opus,ext:mp4:m4a,+size:500M,tbr~2000,lang"
+	if s := keys.String(); s != want {
+		t.Fatalf("expected %q, got %q", want, s)
+	}
+
+	parsed, err := ParseFormatSort(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(parsed, keys) {
+		t.Fatalf("expected parsed keys to match: %+v", parsed)
+	}
+
+	for _, invalid := range []string{"", "foo", "res:abc", "lang:en", "+", "res~", "hasvid~1"} {
+		if _, err = ParseFormatSort(invalid); err == nil {
+			t.Fatalf("expected error parsing %q", invalid)
+		}
+	}
+}
+
+func TestCommand_FormatSortKeys(t *testing.T) {
+	t.Parallel()
+
+	cmd := New().SetExecutable("yt-dlp").FormatSortKeys(Res(720), VCodec("h264"))
+
+	if args := cmd.buildCommand(context.Background()).Args[1:]; !slices.Equal(args, []string{"--format-sort", "res:720,vcodec:h264"}) {
+		t.Fatalf("unexpected args: %v", args)
+	}
+
+	cmd = New().SetExecutable("yt-dlp").FormatSortKeys(SortField("lang", "en"))
+
+	if err := cmd.buildCommand(context.Background()).Err; err == nil {
+		t.Fatal("expected error for invalid sort key")
+	}
+}
@@ -4,10 +4,13 @@ go 1.22.0
 
 toolchain go1.23.1
 
-require github.com/ProtonMail/go-crypto v1.1.3
+require (
+	github.com/ProtonMail/go-crypto v1.1.3
+	golang.org/x/sync v0.10.0
+	golang.org/x/sys v0.28.0
+)
 
 require (
 	github.com/cloudflare/circl v1.5.0 // indirect
 	golang.org/x/crypto v0.31.0 // indirect
-	golang.org/x/sys v0.28.0 // indirect
 )
@@ -0,0 +1,129 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"sync"
+)
+
+// Handle is the interface implemented by [RunHandle], returned by
+// [Runner.StartHandle], which allows substituting fakes in tests. Use a type
+// assertion to [*RunHandle] to access child processes (see
+// [RunHandle.Children]).
+type Handle interface {
+	Done() <-chan struct{}
+	Wait() (*Result, error)
+	Cancel()
+	Throughput(filename string) ThroughputSamples
+	Downloads() []string
+}
+
+var _ Handle = (*RunHandle)(nil)
+
+// RunHandle is a handle to an asynchronous invocation of yt-dlp, started with
+// [Command.Start].
+type RunHandle struct {
+	cancel     context.CancelFunc
+	done       chan struct{}
+	result     *Result
+	err        error
+	throughput *throughputRecorder
+
+	mu  sync.Mutex
+	pid int // PID of the running yt-dlp process, or 0 if none.
+}
+
+// Start is the same as [Command.Run], however yt-dlp is invoked in the background,
+// returning a handle which can be used to wait for (or cancel) the invocation. The
+// command itself is not modified.
+func (c *Command) Start(ctx context.Context, args ...string) *RunHandle {
+	ctx, cancel := context.WithCancel(ctx)
+
+	h := &RunHandle{
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+
+	cmd := c.clone()
+
+	cmd.mu.RLock()
+	samples := cmd.throughputSamples
+	progress := cmd.progress
+	cmd.mu.RUnlock()
+
+	if samples > 0 {
+		h.throughput = newThroughputRecorder(samples)
+
+		if progress == nil {
+			cmd.ProgressFunc(throughputSampleInterval, h.throughput.record)
+		} else {
+			// Progress flags are already set, so only the handler needs replacing.
+			cmd.mu.Lock()
+			cmd.progress = newProgressHandler(func(update ProgressUpdate) {
+				h.throughput.record(update)
+				progress.fn(update)
+			})
+			cmd.mu.Unlock()
+		}
+	}
+
+	cmd.mu.Lock()
+	cmd.onStart = h.setPID
+	cmd.mu.Unlock()
+
+	go func() {
+		defer close(h.done)
+		defer cancel()
+		defer h.setPID(0)
+
+		h.result, h.err = cmd.Run(ctx, args...)
+	}()
+
+	return h
+}
+
+// StartHandle is the same as [Command.Start], however the handle is returned as a
+// [Handle], so that [Command] implements [Runner].
+func (c *Command) StartHandle(ctx context.Context, args ...string) Handle {
+	return c.Start(ctx, args...)
+}
+
+// Done returns a channel which is closed once the invocation has finished.
+func (h *RunHandle) Done() <-chan struct{} {
+	return h.done
+}
+
+// Wait waits for the invocation to finish, and returns the results.
+func (h *RunHandle) Wait() (*Result, error) {
+	<-h.done
+	return h.result, h.err
+}
+
+// Cancel cancels the invocation. Use [RunHandle.Wait] to wait for it to exit.
+func (h *RunHandle) Cancel() {
+	h.cancel()
+}
+
+// Throughput returns the most recent throughput samples for the download with
+// the provided filename (see [ProgressUpdate.Filename]), ordered from oldest to
+// newest. Requires [Command.SetThroughputSamples] to be set before starting.
+func (h *RunHandle) Throughput(filename string) ThroughputSamples {
+	if h.throughput == nil {
+		return nil
+	}
+
+	return h.throughput.samples(filename)
+}
+
+// Downloads returns the filenames of all downloads which throughput samples
+// have been recorded for, in the order they started.
+func (h *RunHandle) Downloads() []string {
+	if h.throughput == nil {
+		return nil
+	}
+
+	return h.throughput.filenames()
+}
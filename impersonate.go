@@ -0,0 +1,181 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// ImpersonateTarget is a client which yt-dlp can impersonate (e.g. the TLS
+// fingerprint and headers of a specific browser), which many sites require. See
+// [Command.ImpersonateTarget] and [Command.GetImpersonateTargets].
+type ImpersonateTarget struct {
+	// Client is the client to impersonate (e.g. "chrome", "safari"). Empty
+	// impersonates any available client.
+	Client string `json:"client,omitempty"`
+
+	// Version is the version of the client (e.g. "124"). Empty uses any version.
+	Version string `json:"version,omitempty"`
+
+	// OS is the operating system of the client (e.g. "windows", "macos"). Empty
+	// uses any operating system.
+	OS string `json:"os,omitempty"`
+
+	// OSVersion is the version of the operating system (e.g. "10"). Empty uses
+	// any version.
+	OSVersion string `json:"os_version,omitempty"`
+
+	// Source is the request handler which provides the target (e.g.
+	// "curl_cffi"), as reported by yt-dlp. Only set for targets returned by
+	// [Command.GetImpersonateTargets].
+	Source string `json:"source,omitempty"`
+
+	// Available is false if the target is known to yt-dlp, but the dependencies
+	// required to impersonate it (e.g. curl_cffi) aren't installed. Only set for
+	// targets returned by [Command.GetImpersonateTargets].
+	Available bool `json:"available"`
+}
+
+// String returns the target in the format accepted by [Command.Impersonate],
+// e.g. "chrome-124:macos-14".
+func (t ImpersonateTarget) String() string {
+	s := joinNonEmpty("-", t.Client, t.Version)
+
+	if osName := joinNonEmpty("-", t.OS, t.OSVersion); osName != "" {
+		s += ":" + osName
+	}
+
+	return s
+}
+
+// joinNonEmpty joins the non-empty values with sep.
+func joinNonEmpty(sep string, values ...string) string {
+	var out []string
+	for _, v := range values {
+		if v != "" {
+			out = append(out, v)
+		}
+	}
+
+	return strings.Join(out, sep)
+}
+
+// ParseImpersonateTarget parses a target in the format accepted by
+// [Command.Impersonate] (CLIENT[:OS], e.g. "chrome", "chrome-110" or
+// "chrome:windows-10"). Names are case-insensitive.
+func ParseImpersonateTarget(s string) ImpersonateTarget {
+	client, osName, _ := strings.Cut(strings.ToLower(strings.TrimSpace(s)), ":")
+
+	var t ImpersonateTarget
+	t.Client, t.Version, _ = strings.Cut(client, "-")
+	t.OS, t.OSVersion, _ = strings.Cut(osName, "-")
+
+	return t
+}
+
+// ImpersonateTarget impersonates the provided client for requests, a typed
+// wrapper around [Command.Impersonate]. Any previously set target is replaced. An
+// empty target impersonates any available client. Impersonation requires
+// yt-dlp to be installed with curl_cffi, which is bundled with the standalone
+// executables installed by [Install] on most platforms (but not the
+// platform-independent zipapp, or pip installations without the
+// "curl-cffi" extra). See [Command.GetImpersonateTargets] for detecting which
+// targets are available.
+//   - See [Command.UnsetImpersonate], for unsetting the flag.
+func (c *Command) ImpersonateTarget(target ImpersonateTarget) *Command {
+	return c.UnsetImpersonate().Impersonate(target.String())
+}
+
+// GetImpersonateTargets returns the clients which the yt-dlp executable can
+// impersonate, by invoking it with --list-impersonate-targets (using the same
+// executable, environment and working directory as the command, but none of its
+// flags). Targets which yt-dlp knows of, but which are missing dependencies,
+// are included with [ImpersonateTarget.Available] set to false.
+func (c *Command) GetImpersonateTargets(ctx context.Context) ([]ImpersonateTarget, error) {
+	cc := c.clone()
+
+	cc.mu.Lock()
+	cc.flags = nil
+	cc.duplicates = DuplicateKeepAll
+	cc.mu.Unlock()
+
+	cc.addFlag(&Flag{ID: "list_impersonate_targets", Flag: "--list-impersonate-targets", Args: nil})
+
+	result, err := cc.runWithResult(ctx, cc.buildCommand(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("unable to list impersonate targets: %w", err)
+	}
+
+	return parseImpersonateTargets(result.Stdout), nil
+}
+
+// SupportsImpersonation returns true if the yt-dlp executable supports
+// --impersonate, and can impersonate at least one client (i.e. curl_cffi, or
+// another impersonation handler, is installed). See
+// [Command.GetImpersonateTargets].
+func (c *Command) SupportsImpersonation(ctx context.Context) (bool, error) {
+	caps, err := c.Capabilities(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	if !caps.Impersonate {
+		return false, nil
+	}
+
+	targets, err := c.GetImpersonateTargets(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	for _, t := range targets {
+		if t.Available {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// parseImpersonateTargets parses the table output by --list-impersonate-targets,
+// e.g.:
+//
+//	Client       OS          Source
+//	---------------------------------------
+//	Firefox      -           curl_cffi>=0.10 (unavailable)
+//	Chrome-124   Macos-14    curl_cffi
+//	Safari-15.3  Macos-11    curl_cffi
+func parseImpersonateTargets(out string) []ImpersonateTarget {
+	var targets []ImpersonateTarget
+
+	header := false
+
+	for _, line := range strings.Split(StripANSI(out), "\n") {
+		fields := strings.Fields(line)
+
+		if !header {
+			header = len(fields) > 0 && strings.Trim(fields[0], "-") == ""
+			continue
+		}
+
+		if len(fields) < 2 {
+			continue
+		}
+
+		t := ParseImpersonateTarget(strings.TrimPrefix(fields[0], "-") + ":" + strings.TrimPrefix(fields[1], "-"))
+
+		source := strings.Join(fields[2:], " ")
+		source, unavailable := strings.CutSuffix(source, " (unavailable)")
+
+		t.Source = source
+		t.Available = !unavailable
+
+		targets = append(targets, t)
+	}
+
+	return targets
+}
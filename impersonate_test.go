@@ -0,0 +1,99 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+const fakeImpersonateScript = `#!/bin/sh
+case "$1" in
+--help)
+	echo "  --impersonate CLIENT[:OS]"
+	;;
+--list-impersonate-targets)
+	echo "[info] Available impersonate targets" >&2
+	echo "Client       OS          Source"
+	echo "---------------------------------------"
+	echo "Firefox      -           curl_cffi>=0.10 (unavailable)"
+	echo "Chrome-124   Macos-14    curl_cffi"
+	echo "Safari-15.3  Macos-11    curl_cffi"
+	;;
+*)
+	exit 1
+	;;
+esac
+`
+
+func TestImpersonateTarget(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in       string
+		expected ImpersonateTarget
+		str      string
+	}{
+		{in: "", expected: ImpersonateTarget{}, str: ""},
+		{in: "chrome", expected: ImpersonateTarget{Client: "chrome"}, str: "chrome"},
+		{in: "Chrome-110", expected: ImpersonateTarget{Client: "chrome", Version: "110"}, str: "chrome-110"},
+		{
+			in:       "chrome:windows-10",
+			expected: ImpersonateTarget{Client: "chrome", OS: "windows", OSVersion: "10"},
+			str:      "chrome:windows-10",
+		},
+		{in: ":macos", expected: ImpersonateTarget{OS: "macos"}, str: ":macos"},
+	}
+
+	for _, tt := range tests {
+		target := ParseImpersonateTarget(tt.in)
+		if target != tt.expected {
+			t.Fatalf("ParseImpersonateTarget(%q): expected %+v, got %+v", tt.in, tt.expected, target)
+		}
+
+		if s := target.String(); s != tt.str {
+			t.Fatalf("expected %q, got %q", tt.str, s)
+		}
+	}
+
+	cmd := New().Impersonate("safari").ImpersonateTarget(ImpersonateTarget{Client: "chrome", Version: "124"})
+
+	if flags := cmd.getFlagsByID("impersonate"); len(flags) != 1 || !slices.Equal(flags[0].Args, []string{"chrome-124"}) {
+		t.Fatalf("expected a single chrome-124 impersonate flag, got %v", flags)
+	}
+}
+
+func TestCommand_GetImpersonateTargets(t *testing.T) {
+	t.Parallel()
+
+	bin := writeFakeYtdlp(t, fakeImpersonateScript)
+
+	cmd := New().SetExecutable(bin).Impersonate("chrome")
+
+	targets, err := cmd.GetImpersonateTargets(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []ImpersonateTarget{
+		{Client: "firefox", Source: "curl_cffi>=0.10", Available: false},
+		{Client: "chrome", Version: "124", OS: "macos", OSVersion: "14", Source: "curl_cffi", Available: true},
+		{Client: "safari", Version: "15.3", OS: "macos", OSVersion: "11", Source: "curl_cffi", Available: true},
+	}
+
+	if !slices.Equal(targets, expected) {
+		t.Fatalf("expected %+v, got %+v", expected, targets)
+	}
+
+	ok, err := cmd.SupportsImpersonation(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !ok {
+		t.Fatal("expected impersonation to be supported")
+	}
+}
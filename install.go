@@ -10,16 +10,17 @@ import (
 	"context"
 	"crypto/sha256"
 	_ "embed"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"runtime"
+	"slices"
+	"sort"
 	"strings"
-	"sync"
-	"sync/atomic"
 	"time"
 
 	"github.com/ProtonMail/go-crypto/openpgp"
@@ -34,47 +35,85 @@ var (
 	//go:embed ytdlp-public.key
 	ytdlpPublicKey []byte // From: https://github.com/yt-dlp/yt-dlp/blob/master/public.key
 
-	resolveCache = atomic.Pointer[ResolvedInstall]{} // Should only be used by [Install].
-	installLock  sync.Mutex
-
-	binConfigs = map[string]struct {
-		src  string
-		dest []string
-	}{
-		"darwin_amd64":  {"yt-dlp_macos", []string{"yt-dlp-" + Version, "yt-dlp"}},
-		"darwin_arm64":  {"yt-dlp_macos", []string{"yt-dlp-" + Version, "yt-dlp"}},
-		"linux_amd64":   {"yt-dlp_linux", []string{"yt-dlp-" + Version, "yt-dlp"}},
-		"linux_arm64":   {"yt-dlp_linux_aarch64", []string{"yt-dlp-" + Version, "yt-dlp"}},
-		"linux_armv7l":  {"yt-dlp_linux_armv7l", []string{"yt-dlp-" + Version, "yt-dlp"}},
-		"linux_unknown": {"yt-dlp", []string{"yt-dlp-" + Version, "yt-dlp"}},
-		"windows_amd64": {"yt-dlp.exe", []string{"yt-dlp-" + Version + ".exe", "yt-dlp.exe"}},
+	defaultResolver = &Resolver{} // Used by [Install], and commands without a resolver.
+
+	binConfigs = map[string]string{
+		"darwin_amd64":  "yt-dlp_macos",
+		"darwin_arm64":  "yt-dlp_macos",
+		"linux_amd64":   "yt-dlp_linux",
+		"linux_arm64":   "yt-dlp_linux_aarch64",
+		"linux_armv7l":  "yt-dlp_linux_armv7l",
+		"linux_unknown": "yt-dlp",
+		"windows_amd64": "yt-dlp.exe",
 	}
 )
 
 // getDownloadBinary returns the source and destination binary names for the
-// current runtime. If the current runtime is not supported, an error is
-// returned. dest will always be returned (it will be an assumption).
-func getDownloadBinary() (src string, dest []string, err error) {
+// current runtime, for the provided version (empty for the version go-ytdlp was
+// built with). If the current runtime is not supported, an error is returned.
+// dest will always be returned (it will be an assumption).
+func getDownloadBinary(version string) (src string, dest []string, err error) {
+	if version == "" {
+		version = Version
+	}
+
+	ext := ""
+	if runtime.GOOS == "windows" {
+		ext = ".exe"
+	}
+
+	dest = []string{"yt-dlp-" + version + ext, "yt-dlp" + ext}
+
 	if binary, ok := binConfigs[runtime.GOOS+"_"+runtime.GOARCH]; ok {
-		return binary.src, binary.dest, nil
+		return binary, dest, nil
 	}
 
 	if runtime.GOOS == "linux" {
-		return binConfigs["linux_unknown"].src, binConfigs["linux_unknown"].dest, nil
+		return binConfigs["linux_unknown"], dest, nil
 	}
 
 	var supported []string
 	for k := range binConfigs {
-		supported = append(supported, k)
+		supported = append(supported, strings.Replace(k, "_", "/", 1))
 	}
 
-	if runtime.GOOS == "windows" {
-		dest = []string{"yt-dlp.exe"}
-	} else {
-		dest = []string{"yt-dlp"}
+	sort.Strings(supported)
+
+	return "", []string{"yt-dlp" + ext}, &ErrUnsupportedPlatform{
+		Name:      "yt-dlp",
+		Platform:  runtime.GOOS + "/" + runtime.GOARCH,
+		Supported: supported,
 	}
+}
+
+// InstallChannel is the release channel of yt-dlp to install from.
+type InstallChannel string
+
+const (
+	// InstallChannelStable is the stable release channel (the default).
+	InstallChannelStable InstallChannel = "stable"
+
+	// InstallChannelNightly is the nightly release channel, which is built daily
+	// when there are upstream changes.
+	InstallChannelNightly InstallChannel = "nightly"
+
+	// InstallChannelMaster is the master release channel, which is built on every
+	// upstream commit.
+	InstallChannelMaster InstallChannel = "master"
+)
 
-	return "", dest, fmt.Errorf("unsupported os/arch combo: %s/%s (supported: %s)", runtime.GOOS, runtime.GOARCH, strings.Join(supported, ", "))
+// repo returns the GitHub repository which hosts the releases of the channel.
+func (c InstallChannel) repo() (string, error) {
+	switch c {
+	case "", InstallChannelStable:
+		return "yt-dlp/yt-dlp", nil
+	case InstallChannelNightly:
+		return "yt-dlp/yt-dlp-nightly-builds", nil
+	case InstallChannelMaster:
+		return "yt-dlp/yt-dlp-master-builds", nil
+	default:
+		return "", fmt.Errorf("unknown install channel %q", string(c))
+	}
 }
 
 // InstallOptions are configuration options for installing yt-dlp dynamically (when
@@ -97,37 +136,270 @@ type InstallOptions struct {
 	// DownloadURL is the exact url to the binary location to download (and store).
 	// Leave empty to use GitHub + auto-detected os/arch.
 	DownloadURL string
+
+	// DownloadURLs are additional exact urls to the binary location (e.g. internal
+	// artifact mirrors), which are tried in order (after DownloadURL, if set) until
+	// one succeeds. If neither DownloadURL nor DownloadURLs are set, GitHub is used.
+	// Mirrors are expected to host the release's checksum and signature files
+	// alongside the binary (as GitHub does), otherwise they are fetched from
+	// GitHub.
+	DownloadURLs []string
+
+	// DownloadURLTimeout is the timeout for downloading from each url (including
+	// any retries), so slow or unresponsive mirrors are skipped. Leave empty to
+	// only use the timeout of the HTTP client.
+	DownloadURLTimeout time.Duration
+
+	// Retries is the number of times each download is retried after a transient
+	// failure (network errors, and 408, 429 or 5xx responses). 0 disables retries.
+	Retries int
+
+	// Backoff returns how long to wait before each retry. Defaults to
+	// [ExponentialBackoff], starting at 1 second.
+	Backoff BackoffFunc
+
+	// Version is the version of yt-dlp to install (e.g. "2025.01.15", or
+	// "2025.01.16.232854" for nightly/master builds). Leave empty to use the
+	// version go-ytdlp was built with ([Version]), which is only valid for the
+	// stable channel. Note that go-ytdlp's flags are generated for [Version],
+	// so other versions may not support all flags.
+	Version string
+
+	// Channel is the release channel that [InstallOptions.Version] is downloaded
+	// from. Defaults to [InstallChannelStable]. [InstallOptions.Version] is
+	// required when using other channels.
+	Channel InstallChannel
+
+	// CacheDir is the directory where the yt-dlp executable is cached (and resolved
+	// from). Leave empty to use [CacheDir]. Use [ScopedCacheDir] to avoid sharing
+	// the cache with other applications.
+	CacheDir string
+
+	// Client is the HTTP client used for downloads, which can be used to configure
+	// proxies (e.g. corporate proxies), custom CAs, or timeouts. Defaults to a
+	// client with a reasonable timeout, which respects the proxy environment
+	// variables (HTTP_PROXY, etc).
+	Client *http.Client
+
+	// GitHubToken is a GitHub token which is sent with requests to GitHub, to
+	// avoid being rate limited (e.g. in CI environments, where many requests share
+	// the same IP). Defaults to the [GitHubTokenEnv] environment variable. It's
+	// only sent to GitHub, never to other hosts (e.g. mirrors).
+	GitHubToken string
+}
+
+// GitHubTokenEnv is the environment variable which, if set, is used as the
+// default [InstallOptions.GitHubToken].
+const GitHubTokenEnv = "GITHUB_TOKEN"
+
+// githubHosts are the hosts which [InstallOptions.GitHubToken] is sent to.
+var githubHosts = []string{"github.com", "api.github.com"}
+
+// githubToken returns the token to send with the request to rawURL, if any.
+func (o *InstallOptions) githubToken(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Scheme != "https" || !slices.Contains(githubHosts, strings.ToLower(u.Hostname())) {
+		return ""
+	}
+
+	if o.GitHubToken != "" {
+		return o.GitHubToken
+	}
+
+	return os.Getenv(GitHubTokenEnv)
+}
+
+// release returns the GitHub repository and version of the release to install.
+func (o *InstallOptions) release() (repo, version string, err error) {
+	repo, err = o.Channel.repo()
+	if err != nil {
+		return "", "", err
+	}
+
+	if o.Version != "" {
+		return repo, o.Version, nil
+	}
+
+	if o.Channel != "" && o.Channel != InstallChannelStable {
+		return "", "", fmt.Errorf("install channel %q requires a version", string(o.Channel))
+	}
+
+	return repo, Version, nil
+}
+
+// makeCacheDir returns the directory the yt-dlp executable is cached in, creating
+// it if necessary.
+func (o *InstallOptions) makeCacheDir() (dir string, err error) {
+	dir = o.CacheDir
+	if dir == "" {
+		dir, err = CacheDir()
+		if err != nil {
+			return "", err
+		}
+	}
+
+	err = os.MkdirAll(dir, 0o750) //nolint:gomnd
+	if err != nil {
+		return "", fmt.Errorf("unable to create yt-dlp executable cache directory: %w", err)
+	}
+
+	return dir, nil
+}
+
+// httpClient returns the HTTP client to use for downloads.
+func (o *InstallOptions) httpClient() *http.Client {
+	if o != nil && o.Client != nil {
+		return o.Client
+	}
+
+	return &http.Client{Timeout: downloadTimeout}
+}
+
+// downloadURLs returns the urls to download the binary from, in order.
+func (o *InstallOptions) downloadURLs(releaseURL string) []string {
+	var urls []string
+
+	if o.DownloadURL != "" {
+		urls = append(urls, o.DownloadURL)
+	}
+
+	urls = append(urls, o.DownloadURLs...)
+
+	if len(urls) == 0 {
+		urls = append(urls, releaseURL)
+	}
+
+	return urls
+}
+
+// download downloads the url to dest, using the configured client, per-url
+// timeout, and retries.
+func (o *InstallOptions) download(ctx context.Context, url, dest string, perms os.FileMode) error {
+	if o.DownloadURLTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.DownloadURLTimeout)
+		defer cancel()
+	}
+
+	backoff := o.Backoff
+	if backoff == nil {
+		backoff = ExponentialBackoff(time.Second, 30*time.Second) //nolint:gomnd
+	}
+
+	client := o.httpClient()
+
+	for attempt := 1; ; attempt++ {
+		err := downloadFile(ctx, client, url, o.githubToken(url), dest, perms)
+
+		var derr *ErrDownloadFailed
+		if err == nil || attempt > o.Retries || !errors.As(err, &derr) || !derr.Transient || ctx.Err() != nil {
+			return err
+		}
+
+		select {
+		case <-time.After(backoff(attempt + 1)):
+		case <-ctx.Done():
+			return err
+		}
+	}
+}
+
+// downloadFirst downloads the first of the urls which succeeds to dest, returning
+// the url that was used.
+func (o *InstallOptions) downloadFirst(ctx context.Context, urls []string, dest string, perms os.FileMode) (string, error) {
+	var errs []error
+
+	for _, u := range urls {
+		err := o.download(ctx, u, dest, perms)
+		if err == nil {
+			return u, nil
+		}
+
+		errs = append(errs, err)
+
+		if ctx.Err() != nil {
+			break
+		}
+
+		emitInstallEvent(&InstallEvent{Type: InstallEventDownloadFailed, Path: dest, URL: u, Error: err})
+	}
+
+	return "", errors.Join(errs...)
+}
+
+// siblingURL returns the url of the file with the provided name, in the same
+// location as the file at u.
+func siblingURL(u, name string) string {
+	if i := strings.IndexAny(u, "?#"); i >= 0 {
+		u = u[:i]
+	}
+
+	return u[:strings.LastIndex(u, "/")+1] + name
 }
 
-func downloadFile(ctx context.Context, url, dest string, perms os.FileMode) error {
+// downloadFile downloads the url to dest, authenticating with token (if not
+// empty). dest is removed if the download fails (including when ctx is
+// cancelled), so partially written files are never left behind.
+func downloadFile(ctx context.Context, client *http.Client, url, token, dest string, perms os.FileMode) (err error) {
 	f, err := os.OpenFile(dest, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, perms)
 	if err != nil {
 		return fmt.Errorf("unable to create go-ytdlp dependent cache file %q: %w", dest, err)
 	}
 	defer f.Close()
 
+	defer func() {
+		if err != nil {
+			_ = f.Close()
+			_ = os.Remove(dest)
+		}
+	}()
+
 	// Download the binary.
-	client := &http.Client{Timeout: downloadTimeout}
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
 	if err != nil {
-		return fmt.Errorf("unable to download go-ytdlp dependent file %q: request creation: %w", dest, err)
+		return &ErrDownloadFailed{
+			wrapped: fmt.Errorf("unable to download go-ytdlp dependent file %q: request creation: %w", dest, err),
+			URL:     url,
+		}
 	}
 
 	req.Header.Set("User-Agent", fmt.Sprintf("github.com/lrstanley/go-ytdlp; version/%s", Version))
 
+	if token != "" {
+		// Not forwarded by the client when redirected to other hosts (e.g. where
+		// release assets are stored).
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
 	resp, err := client.Do(req)
 	if err != nil {
-		return fmt.Errorf("unable to download go-ytdlp dependent file %q: %w", dest, err)
+		return &ErrDownloadFailed{
+			wrapped:   fmt.Errorf("unable to download go-ytdlp dependent file %q: %w", dest, err),
+			URL:       url,
+			Transient: true,
+		}
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return fmt.Errorf("unable to download go-ytdlp dependent file %q: bad status: %s", dest, resp.Status)
+		return &ErrDownloadFailed{
+			wrapped:    fmt.Errorf("unable to download go-ytdlp dependent file %q: bad status: %s", dest, resp.Status),
+			URL:        url,
+			StatusCode: resp.StatusCode,
+			Transient: resp.StatusCode >= http.StatusInternalServerError ||
+				resp.StatusCode == http.StatusTooManyRequests ||
+				resp.StatusCode == http.StatusRequestTimeout,
+		}
 	}
 
 	_, err = f.ReadFrom(resp.Body)
 	if err != nil {
-		return fmt.Errorf("unable to download go-ytdlp dependent file %q: streaming data: %w", dest, err)
+		return &ErrDownloadFailed{
+			wrapped:    fmt.Errorf("unable to download go-ytdlp dependent file %q: streaming data: %w", dest, err),
+			URL:        url,
+			StatusCode: resp.StatusCode,
+			Transient:  true,
+		}
 	}
 
 	err = f.Close()
@@ -135,11 +407,12 @@ func downloadFile(ctx context.Context, url, dest string, perms os.FileMode) erro
 		return fmt.Errorf("unable to download go-ytdlp dependent file %q: closing file: %w", dest, err)
 	}
 
+	emitInstallEvent(&InstallEvent{Type: InstallEventDownloaded, Path: dest, URL: url})
 	return nil
 }
 
-func githubReleaseAsset(name string) string {
-	return fmt.Sprintf("https://github.com/yt-dlp/yt-dlp/releases/download/%s/%s", Version, name)
+func githubReleaseAsset(repo, version, name string) string {
+	return fmt.Sprintf("https://github.com/%s/releases/download/%s/%s", repo, version, name)
 }
 
 // verifyFileChecksum will verify the checksum of the target file, using the
@@ -183,7 +456,7 @@ func verifyFileChecksum(checksumPath, signaturePath, targetPath, checkAgainst st
 
 	_, err = openpgp.CheckDetachedSignature(keyring, checksumFile, signatureFile, nil)
 	if err != nil {
-		return fmt.Errorf("unable to check detached signature: %w", err)
+		return &ErrSignatureInvalid{wrapped: err}
 	}
 
 	// Now make sure the checksum from checksumFile matches the target file.
@@ -210,7 +483,7 @@ func verifyFileChecksum(checksumPath, signaturePath, targetPath, checkAgainst st
 
 		if fields[1] == checkAgainst {
 			if fields[0] != sum {
-				return fmt.Errorf("checksum mismatch: expected %s, got %s", fields[0], sum)
+				return &ErrChecksumMismatch{Path: targetPath, Expected: fields[0], Actual: sum}
 			}
 
 			return nil
@@ -228,90 +501,134 @@ func verifyFileChecksum(checksumPath, signaturePath, targetPath, checkAgainst st
 //
 // Note: If [Install] is not called, go-ytdlp WILL NOT DOWNLOAD yt-dlp. Only use
 // this function if you want to ensure yt-dlp is installed, and are ok with it being
-// downloaded.
+// downloaded. If an executable was embedded with [SetEmbeddedExecutable], it's
+// extracted instead of downloading.
+//
+// The resolved install is cached process-wide, and used by all commands without
+// a [Resolver] (see [Command.SetResolver]).
 func Install(ctx context.Context, opts *InstallOptions) (*ResolvedInstall, error) {
+	return defaultResolver.install(ctx, opts)
+}
+
+// install is the implementation of [Install] and [Resolver.Install], caching the
+// resolved install in the resolver.
+func (rs *Resolver) install(ctx context.Context, opts *InstallOptions) (*ResolvedInstall, error) {
 	if opts == nil {
 		opts = &InstallOptions{}
 	}
 
-	if r := resolveCache.Load(); r != nil {
+	repo, version, err := opts.release()
+	if err != nil {
+		return nil, err
+	}
+
+	// Only use the cached install if it matches the requested version, or no
+	// specific version or channel was requested.
+	if r := rs.cache.Load(); r != nil && (r.Version == version || (opts.Version == "" && opts.Channel == "")) {
 		return r, nil
 	}
 
 	// Ensure only one install invocation is running at a time.
-	installLock.Lock()
-	defer installLock.Unlock()
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
 
-	resolved, err := resolveExecutable(false, false)
+	resolved, err := rs.resolveExecutable(ctx, opts.CacheDir, version, false, false)
 	if err == nil {
 		if opts.AllowVersionMismatch {
-			resolveCache.Store(resolved)
+			rs.cache.Store(resolved)
 			return resolved, nil
 		}
 
-		if resolved.Version == Version {
-			resolveCache.Store(resolved)
+		if resolved.Version == version {
+			rs.cache.Store(resolved)
 			return resolved, nil
 		}
 
 		// If we're not allowed to download, and the version doesn't match, return
 		// an error.
-		if opts.DisableDownload {
-			return nil, fmt.Errorf("yt-dlp version mismatch: expected %s, got %s", Version, resolved.Version)
+		if opts.DisableDownload && embeddedExecutable.Load() == nil {
+			return nil, fmt.Errorf("yt-dlp version mismatch: expected %s, got %s", version, resolved.Version)
 		}
 	}
 
+	if data := embeddedExecutable.Load(); data != nil {
+		return rs.installEmbedded(ctx, *data, version, opts)
+	}
+
 	if opts.DisableDownload {
 		return nil, fmt.Errorf("yt-dlp executable not found, and downloading is disabled")
 	}
 
-	src, dest, err := getDownloadBinary()
+	src, dest, err := getDownloadBinary(version)
 	if err != nil {
 		return nil, err
 	}
 
-	downloadURL := opts.DownloadURL
-
-	if downloadURL == "" {
-		downloadURL = githubReleaseAsset(src)
-	}
-
-	baseCacheDir, err := os.UserCacheDir()
+	dir, err := opts.makeCacheDir()
 	if err != nil {
-		return nil, fmt.Errorf("unable to get user cache dir: %w", err)
+		return nil, err
 	}
-	dir := filepath.Join(baseCacheDir, xdgCacheDir)
 
-	err = os.MkdirAll(dir, 0o750)
-	if err != nil {
-		return nil, fmt.Errorf("unable to create yt-dlp executable cache directory: %w", err)
-	}
+	releaseURL := githubReleaseAsset(repo, version, src)
 
-	err = downloadFile(ctx, downloadURL, filepath.Join(dir, dest[0]+".tmp"), 0o750) //nolint:gomnd
+	downloadURL, err := opts.downloadFirst(ctx, opts.downloadURLs(releaseURL), filepath.Join(dir, dest[0]+".tmp"), 0o750) //nolint:gomnd
 	if err != nil {
 		return nil, err
 	}
 
 	if !opts.DisableChecksum {
-		err = downloadFile(ctx, githubReleaseAsset("SHA2-256SUMS"), filepath.Join(dir, "SHA2-256SUMS-"+Version), 0o640) //nolint:gomnd
-		if err != nil {
-			return nil, err
+		// Mirrors are expected to mirror the release layout, so prefer the checksums
+		// alongside the executable, falling back to GitHub.
+		var sumsURLs [][]string
+		if downloadURL != releaseURL {
+			sumsURLs = append(sumsURLs, []string{siblingURL(downloadURL, "SHA2-256SUMS"), siblingURL(downloadURL, "SHA2-256SUMS.sig")})
+		}
+
+		sumsURLs = append(sumsURLs, []string{
+			githubReleaseAsset(repo, version, "SHA2-256SUMS"),
+			githubReleaseAsset(repo, version, "SHA2-256SUMS.sig"),
+		})
+
+		for i, urls := range sumsURLs {
+			err = opts.download(ctx, urls[0], filepath.Join(dir, "SHA2-256SUMS-"+version), 0o640) //nolint:gomnd
+			if err == nil {
+				err = opts.download(ctx, urls[1], filepath.Join(dir, "SHA2-256SUMS-"+version+".sig"), 0o640) //nolint:gomnd
+			}
+
+			if err == nil || ctx.Err() != nil {
+				break
+			}
+
+			if i < len(sumsURLs)-1 {
+				emitInstallEvent(&InstallEvent{Type: InstallEventDownloadFailed, Path: dir, URL: urls[0], Error: err})
+			}
 		}
 
-		err = downloadFile(ctx, githubReleaseAsset("SHA2-256SUMS.sig"), filepath.Join(dir, "SHA2-256SUMS-"+Version+".sig"), 0o640) //nolint:gomnd
 		if err != nil {
 			return nil, err
 		}
 
 		err = verifyFileChecksum(
-			filepath.Join(dir, "SHA2-256SUMS-"+Version),
-			filepath.Join(dir, "SHA2-256SUMS-"+Version+".sig"),
+			filepath.Join(dir, "SHA2-256SUMS-"+version),
+			filepath.Join(dir, "SHA2-256SUMS-"+version+".sig"),
 			filepath.Join(dir, dest[0]+".tmp"),
 			src,
 		)
 		if err != nil {
+			emitInstallEvent(&InstallEvent{
+				Type:  InstallEventChecksumFailed,
+				Path:  filepath.Join(dir, dest[0]+".tmp"),
+				URL:   downloadURL,
+				Error: err,
+			})
 			return nil, err
 		}
+
+		emitInstallEvent(&InstallEvent{
+			Type: InstallEventChecksumVerified,
+			Path: filepath.Join(dir, dest[0]+".tmp"),
+			URL:  downloadURL,
+		})
 	}
 
 	// Rename the file to the correct name.
@@ -321,12 +638,12 @@ func Install(ctx context.Context, opts *InstallOptions) (*ResolvedInstall, error
 	}
 
 	// re-resolve now that we've downloaded the binary, and validated things.
-	resolved, err = resolveExecutable(false, true)
+	resolved, err = rs.resolveExecutable(ctx, opts.CacheDir, version, false, true)
 	if err != nil {
 		return nil, err
 	}
 
-	resolveCache.Store(resolved)
+	rs.cache.Store(resolved)
 	return resolved, nil
 }
 
@@ -341,45 +658,50 @@ func MustInstall(ctx context.Context, opts *InstallOptions) {
 
 // resolveExecutable will attempt to resolve the yt-dlp executable, either from
 // the go-ytdlp cache (first), or from the PATH (second). If it's not found, an
-// error is returned.
-func resolveExecutable(fromCache, calleeIsDownloader bool) (r *ResolvedInstall, err error) {
+// error is returned. If cacheDir is empty, [CacheDir] is used. If version is
+// empty, [Version] is used. If fromCache is true, the install previously cached
+// in the resolver is returned, if any.
+func (rs *Resolver) resolveExecutable(ctx context.Context, cacheDir, version string, fromCache, calleeIsDownloader bool) (r *ResolvedInstall, err error) {
 	if fromCache {
-		r = resolveCache.Load()
+		r = rs.cache.Load()
 		if r != nil {
 			return r, nil
 		}
 	}
 
-	_, dest, _ := getDownloadBinary() // don't check error yet.
+	if version == "" {
+		version = Version
+	}
+
+	_, dest, _ := getDownloadBinary(version) // don't check error yet.
 
-	var stat os.FileInfo
-	var bin, baseCacheDir string
+	var bin string
+
+	if cacheDir == "" {
+		cacheDir, err = CacheDir()
+	}
 
-	baseCacheDir, err = os.UserCacheDir()
 	if err == nil {
 		// Check out cache dirs first.
 		for _, d := range dest {
-			bin = filepath.Join(baseCacheDir, xdgCacheDir, d)
-
-			stat, err = os.Stat(bin)
-			if err != nil {
-				continue
-			}
+			bin = filepath.Join(cacheDir, d)
 
-			if !stat.IsDir() && (stat.Mode().Perm()&0o100 != 0 || stat.Mode().Perm()&0o010 != 0 || stat.Mode().Perm()&0o001 != 0) {
+			if _, ok := findExecutable(bin); ok {
 				r = &ResolvedInstall{
 					Executable: bin,
 					FromCache:  true,
 					Downloaded: calleeIsDownloader,
 				}
 				if calleeIsDownloader {
-					r.Version = Version
+					r.Version = version
 				} else {
-					err = r.getVersion()
+					err = r.getVersion(ctx)
 					if err != nil {
 						return nil, err
 					}
 				}
+
+				emitInstallEvent(&InstallEvent{Type: InstallEventResolvedFromCache, Path: bin, Version: r.Version})
 				return r, nil
 			}
 		}
@@ -387,7 +709,7 @@ func resolveExecutable(fromCache, calleeIsDownloader bool) (r *ResolvedInstall,
 
 	// Check PATH for the binary.
 	for _, d := range dest {
-		bin, err = exec.LookPath(d)
+		bin, err = LookupExecutable(d)
 		if err == nil {
 			r = &ResolvedInstall{
 				Executable: bin,
@@ -395,11 +717,12 @@ func resolveExecutable(fromCache, calleeIsDownloader bool) (r *ResolvedInstall,
 				Downloaded: false,
 			}
 
-			err = r.getVersion()
+			err = r.getVersion(ctx)
 			if err != nil {
 				return nil, err
 			}
 
+			emitInstallEvent(&InstallEvent{Type: InstallEventResolvedFromPath, Path: bin, Version: r.Version})
 			return r, nil
 		}
 	}
@@ -414,14 +737,23 @@ type ResolvedInstall struct {
 	Version    string // Version of yt-dlp that was resolved. If [InstallOptions.AllowVersionMismatch] is specified, this will be empty.
 	FromCache  bool   // Whether the executable was resolved from the cache.
 	Downloaded bool   // Whether the executable was downloaded during this invocation.
+
+	// Name is the name of the executable ("yt-dlp", or the name of a tool
+	// registered with [RegisterTool]). Only set by [ResolveAll].
+	Name string
+
+	// NeedsInstall is true if the executable couldn't be resolved, or its
+	// version doesn't match the expected version, so it would be installed by
+	// [InstallAll]. Only set by [ResolveAll].
+	NeedsInstall bool
 }
 
 // getVersion returns true if the resolved version of yt-dlp matches the version
 // that go-ytdlp was built with.
-func (r *ResolvedInstall) getVersion() error {
+func (r *ResolvedInstall) getVersion(ctx context.Context) error {
 	var stdout bytes.Buffer
 
-	cmd := exec.Command(r.Executable, "--version") //nolint:gosec
+	cmd := New().SetExecutable(r.Executable).BuildVersionCommand(ctx)
 	cmd.Stdout = &stdout
 
 	if err := cmd.Run(); err != nil {
@@ -0,0 +1,107 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrUnsupportedPlatform is returned when an executable (yt-dlp, or a tool
+// registered with [RegisterTool]) can't be installed, as no build is available
+// for the current os/arch.
+type ErrUnsupportedPlatform struct {
+	Name      string   // Name of the executable, e.g. "yt-dlp" or "ffmpeg".
+	Platform  string   // Current os/arch, e.g. "linux/riscv64".
+	Supported []string // Supported os/arch combos, if known.
+}
+
+func (e *ErrUnsupportedPlatform) Error() string {
+	msg := fmt.Sprintf("unable to install %s: unsupported os/arch combo: %s", e.Name, e.Platform)
+
+	if len(e.Supported) > 0 {
+		msg += " (supported: " + strings.Join(e.Supported, ", ") + ")"
+	}
+
+	return msg
+}
+
+// IsUnsupportedPlatformError returns true when an executable can't be installed,
+// as no build is available for the current os/arch.
+func IsUnsupportedPlatformError(err error) bool {
+	var e *ErrUnsupportedPlatform
+	return errors.As(err, &e)
+}
+
+// ErrChecksumMismatch is returned when the checksum of a downloaded (or
+// embedded, or offline) executable doesn't match the expected checksum.
+type ErrChecksumMismatch struct {
+	Path     string // Path of the file which was verified.
+	Expected string // Expected SHA-256 checksum.
+	Actual   string // Actual SHA-256 checksum.
+}
+
+func (e *ErrChecksumMismatch) Error() string {
+	return fmt.Sprintf("checksum mismatch: expected %s, got %s", e.Expected, e.Actual)
+}
+
+// IsChecksumMismatchError returns true when the checksum of an executable
+// doesn't match the expected checksum.
+func IsChecksumMismatchError(err error) bool {
+	var e *ErrChecksumMismatch
+	return errors.As(err, &e)
+}
+
+// ErrSignatureInvalid is returned when the checksums of yt-dlp weren't signed
+// with the yt-dlp release key.
+type ErrSignatureInvalid struct {
+	wrapped error
+}
+
+func (e *ErrSignatureInvalid) Unwrap() error {
+	return e.wrapped
+}
+
+func (e *ErrSignatureInvalid) Error() string {
+	return fmt.Sprintf("unable to check detached signature: %s", e.wrapped)
+}
+
+// IsSignatureInvalidError returns true when the checksums of yt-dlp weren't
+// signed with the yt-dlp release key.
+func IsSignatureInvalidError(err error) bool {
+	var e *ErrSignatureInvalid
+	return errors.As(err, &e)
+}
+
+// ErrDownloadFailed is returned when downloading a file (e.g. an executable, or
+// its checksums) fails.
+type ErrDownloadFailed struct {
+	wrapped error
+
+	// URL is the URL which failed to download.
+	URL string
+
+	// StatusCode is the HTTP status code of the response, if one was received.
+	StatusCode int
+
+	// Transient is true if the failure may succeed if retried (e.g. network
+	// errors, or 5xx/429 responses), see [InstallOptions.Retries].
+	Transient bool
+}
+
+func (e *ErrDownloadFailed) Unwrap() error {
+	return e.wrapped
+}
+
+func (e *ErrDownloadFailed) Error() string {
+	return e.wrapped.Error()
+}
+
+// IsDownloadFailedError returns true when downloading a file fails.
+func IsDownloadFailedError(err error) bool {
+	var e *ErrDownloadFailed
+	return errors.As(err, &e)
+}
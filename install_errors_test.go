@@ -0,0 +1,90 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestInstallErrors(t *testing.T) {
+	t.Parallel()
+
+	other := "plan9_mips"
+	if runtime.GOOS == "plan9" {
+		other = "linux_amd64"
+	}
+
+	err := RegisterTool("test-unsupported", InstallSpec{
+		Version: "1.0.0",
+		URLs:    map[string]string{other: "https://example.invalid/tool"},
+		SHA256:  map[string]string{other: "deadbeef"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = InstallTool(context.Background(), "test-unsupported", &InstallOptions{CacheDir: t.TempDir()})
+
+	var perr *ErrUnsupportedPlatform
+	if !errors.As(err, &perr) || !IsUnsupportedPlatformError(err) {
+		t.Fatalf("expected unsupported platform error, got %v", err)
+	}
+
+	if perr.Name != "test-unsupported" || perr.Platform != runtime.GOOS+"/"+runtime.GOARCH || !slices.Equal(perr.Supported, []string{strings.Replace(other, "_", "/", 1)}) {
+		t.Fatalf("unexpected unsupported platform error: %+v", perr)
+	}
+
+	status := http.StatusServiceUnavailable
+	client := &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: status, Status: http.StatusText(status), Body: http.NoBody, Request: r}, nil
+	})}
+
+	opts := &InstallOptions{Client: client}
+	dest := filepath.Join(t.TempDir(), "file")
+
+	for _, transient := range []bool{true, false} {
+		if !transient {
+			status = http.StatusNotFound
+		}
+
+		err = opts.download(context.Background(), "https://example.invalid/file", dest, 0o600)
+
+		var derr *ErrDownloadFailed
+		if !errors.As(err, &derr) || !IsDownloadFailedError(err) {
+			t.Fatalf("expected download failed error, got %v", err)
+		}
+
+		if derr.URL != "https://example.invalid/file" || derr.StatusCode != status || derr.Transient != transient {
+			t.Fatalf("unexpected download failed error: %+v", derr)
+		}
+	}
+
+	if err = os.WriteFile(dest, []byte("data"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	err = verifySHA256(dest, "deadbeef")
+
+	var cerr *ErrChecksumMismatch
+	if !errors.As(err, &cerr) || cerr.Path != dest || cerr.Expected != "deadbeef" || cerr.Actual == "" {
+		t.Fatalf("expected checksum mismatch error, got %v", err)
+	}
+
+	if IsSignatureInvalidError(err) || IsSignatureInvalidError(&ErrDownloadFailed{wrapped: err}) {
+		t.Fatal("expected checksum mismatch not to be a signature error")
+	}
+
+	if !IsSignatureInvalidError(&ErrSignatureInvalid{wrapped: errors.New("bad signature")}) {
+		t.Fatal("expected signature error")
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// InstallEventType is the type of an [InstallEvent].
+type InstallEventType string
+
+const (
+	InstallEventResolvedFromCache InstallEventType = "resolved-from-cache" // Executable found in the go-ytdlp cache.
+	InstallEventResolvedFromPath  InstallEventType = "resolved-from-path"  // Executable found in PATH.
+	InstallEventDownloaded        InstallEventType = "downloaded"          // A file (executable, checksums, signature) was downloaded.
+	InstallEventDownloadFailed    InstallEventType = "download-failed"     // A download failed, and the next url (e.g. mirror) is tried.
+	InstallEventChecksumVerified  InstallEventType = "checksum-verified"   // The downloaded executable passed signature and checksum verification.
+	InstallEventChecksumFailed    InstallEventType = "checksum-failed"     // The downloaded executable failed signature or checksum verification.
+	InstallEventInstalledFromFile InstallEventType = "installed-from-file" // A locally provided executable was installed into the cache.
+)
+
+// InstallEvent is a structured event emitted while resolving or installing
+// yt-dlp, which can be used to audit when (and from where) executables are
+// fetched. See [SetInstallEventHook].
+type InstallEvent struct {
+	Type    InstallEventType `json:"type"`
+	Time    time.Time        `json:"time"`
+	Path    string           `json:"path"`              // Path to the file on disk.
+	URL     string           `json:"url,omitempty"`     // Source URL, for downloaded files.
+	Version string           `json:"version,omitempty"` // Resolved version of yt-dlp, if known.
+	Error   error            `json:"-"`                 // Error, for failure events.
+}
+
+// InstallEventHook is a function which receives install events.
+type InstallEventHook func(event *InstallEvent)
+
+var installEventHook atomic.Pointer[InstallEventHook]
+
+// SetInstallEventHook sets the package-level hook which receives all install
+// events, replacing any previously set hook. Pass nil to disable. The hook is
+// invoked synchronously, so it should not block. Panics within the hook are
+// recovered and discarded.
+func SetInstallEventHook(fn InstallEventHook) {
+	if fn == nil {
+		installEventHook.Store(nil)
+		return
+	}
+
+	installEventHook.Store(&fn)
+}
+
+// emitInstallEvent sends the event to the install event hook, if one is set.
+func emitInstallEvent(event *InstallEvent) {
+	fn := installEventHook.Load()
+	if fn == nil {
+		return
+	}
+
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+
+	// There is no result to attach panics to, so they are discarded, to ensure
+	// a misbehaving hook can't break installation.
+	_ = callSafely("install-event", func() { (*fn)(event) })
+}
@@ -0,0 +1,53 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"testing"
+)
+
+func TestInstallEvents_ResolvedFromPath(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Setenv(CacheDirEnv, "")
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("PATH", dir)
+
+	writeFakeExecutable(t, dir, "yt-dlp", "#!/bin/sh\necho 2000.01.01\n")
+
+	var events []*InstallEvent
+
+	SetInstallEventHook(func(event *InstallEvent) {
+		events = append(events, event)
+	})
+	t.Cleanup(func() { SetInstallEventHook(nil) })
+
+	r, err := NewResolver(nil).resolveExecutable(context.Background(), "", "", false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(events) != 1 {
+		t.Fatalf("expected 1 event, got %d", len(events))
+	}
+
+	e := events[0]
+
+	if e.Type != InstallEventResolvedFromPath || e.Path != r.Executable || e.Version != "2000.01.01" || e.Time.IsZero() {
+		t.Fatalf("unexpected event: %#v", e)
+	}
+
+	SetInstallEventHook(nil)
+
+	if _, err = NewResolver(nil).resolveExecutable(context.Background(), "", "", false, false); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(events) != 1 {
+		t.Fatalf("expected no events after unsetting hook, got %d", len(events))
+	}
+}
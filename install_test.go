@@ -0,0 +1,269 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"time"
+)
+
+func TestInstallOptions_Release(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		opts    InstallOptions
+		repo    string
+		version string
+		wantErr bool
+	}{
+		{opts: InstallOptions{}, repo: "yt-dlp/yt-dlp", version: Version},
+		{opts: InstallOptions{Version: "2025.01.15"}, repo: "yt-dlp/yt-dlp", version: "2025.01.15"},
+		{opts: InstallOptions{Channel: InstallChannelNightly, Version: "2025.01.16.232854"}, repo: "yt-dlp/yt-dlp-nightly-builds", version: "2025.01.16.232854"},
+		{opts: InstallOptions{Channel: InstallChannelMaster, Version: "2025.01.16.232854"}, repo: "yt-dlp/yt-dlp-master-builds", version: "2025.01.16.232854"},
+		{opts: InstallOptions{Channel: InstallChannelNightly}, wantErr: true},
+		{opts: InstallOptions{Channel: "beta", Version: "2025.01.15"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		repo, version, err := tt.opts.release()
+		if (err != nil) != tt.wantErr {
+			t.Fatalf("release(%+v): unexpected error: %v", tt.opts, err)
+		}
+
+		if repo != tt.repo || version != tt.version {
+			t.Fatalf("release(%+v): expected %s@%s, got %s@%s", tt.opts, tt.repo, tt.version, repo, version)
+		}
+	}
+}
+
+func TestInstall_Channel(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("requires a posix shell")
+	}
+
+	const version = "2025.01.16.232854"
+
+	var requested []string
+
+	// GitHub isn't reachable from tests, so record and answer requests directly.
+	client := &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		requested = append(requested, r.URL.String())
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader("#!/bin/sh\necho " + version + "\n")),
+			Request:    r,
+		}, nil
+	})}
+
+	dir := t.TempDir()
+
+	r, err := NewResolver(&InstallOptions{
+		CacheDir:        dir,
+		Channel:         InstallChannelNightly,
+		Version:         version,
+		DisableChecksum: true,
+		Client:          client,
+	}).Install(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !r.Downloaded || r.Version != version || !strings.HasSuffix(r.Executable, "yt-dlp-"+version) {
+		t.Fatalf("unexpected install: %+v", r)
+	}
+
+	if len(requested) != 1 || !strings.HasPrefix(requested[0], "https://github.com/yt-dlp/yt-dlp-nightly-builds/releases/download/"+version+"/") {
+		t.Fatalf("unexpected requests: %v", requested)
+	}
+
+	if _, err = os.Stat(filepath.Join(dir, "yt-dlp-"+version)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestInstall_DownloadURLs(t *testing.T) { //nolint:paralleltest
+	if runtime.GOOS == "windows" {
+		t.Skip("requires a posix shell")
+	}
+
+	const version = "2000.01.03"
+
+	var requested []string
+
+	client := &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		requested = append(requested, r.URL.String())
+
+		if r.URL.Host == "down.invalid" {
+			return &http.Response{StatusCode: http.StatusBadGateway, Status: "502 Bad Gateway", Body: http.NoBody, Request: r}, nil
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader("#!/bin/sh\necho " + version + "\n")),
+			Request:    r,
+		}, nil
+	})}
+
+	var failed []string
+	SetInstallEventHook(func(e *InstallEvent) {
+		if e.Type == InstallEventDownloadFailed {
+			failed = append(failed, e.URL)
+		}
+	})
+	t.Cleanup(func() { SetInstallEventHook(nil) })
+
+	opts := &InstallOptions{
+		CacheDir:     t.TempDir(),
+		Version:      version,
+		DownloadURLs: []string{"https://down.invalid/yt-dlp/yt-dlp", "https://mirror.invalid/yt-dlp/" + version + "/yt-dlp?token=abc"},
+		Client:       client,
+	}
+
+	// Checksums are fetched alongside the mirrored executable, and the fake
+	// checksum file isn't signed.
+	if _, err := NewResolver(opts).Install(context.Background()); err == nil {
+		t.Fatal("expected checksum verification error")
+	}
+
+	expected := []string{
+		"https://down.invalid/yt-dlp/yt-dlp",
+		"https://mirror.invalid/yt-dlp/" + version + "/yt-dlp?token=abc",
+		"https://mirror.invalid/yt-dlp/" + version + "/SHA2-256SUMS",
+		"https://mirror.invalid/yt-dlp/" + version + "/SHA2-256SUMS.sig",
+	}
+
+	if !slices.Equal(requested, expected) {
+		t.Fatalf("expected requests %v, got %v", expected, requested)
+	}
+
+	if !slices.Equal(failed, expected[:1]) {
+		t.Fatalf("expected download failed event for %v, got %v", expected[:1], failed)
+	}
+
+	opts.DisableChecksum = true
+
+	r, err := NewResolver(opts).Install(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !r.Downloaded || r.Version != version {
+		t.Fatalf("unexpected install: %+v", r)
+	}
+}
+
+func TestInstallOptions_DownloadRetries(t *testing.T) {
+	t.Parallel()
+
+	var requests int
+
+	client := &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		requests++
+
+		switch requests {
+		case 1:
+			return nil, errors.New("connection reset by peer")
+		case 2:
+			return &http.Response{StatusCode: http.StatusServiceUnavailable, Status: "503 Service Unavailable", Body: http.NoBody, Request: r}, nil
+		case 3:
+			// Fails mid-stream.
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(io.MultiReader(strings.NewReader("partial"), iotest.ErrReader(io.ErrUnexpectedEOF))), Request: r}, nil
+		case 4:
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("data")), Request: r}, nil
+		default:
+			return &http.Response{StatusCode: http.StatusNotFound, Status: "404 Not Found", Body: http.NoBody, Request: r}, nil
+		}
+	})}
+
+	dest := filepath.Join(t.TempDir(), "file")
+	opts := &InstallOptions{Client: client, Retries: 3, Backoff: func(int) time.Duration { return time.Millisecond }}
+
+	if err := opts.download(context.Background(), "https://example.invalid/file", dest, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if b, err := os.ReadFile(dest); err != nil || string(b) != "data" || requests != 4 {
+		t.Fatalf("unexpected download after %d requests: %q: %v", requests, b, err)
+	}
+
+	// Not transient, so not retried, and the partial file is removed.
+	if err := opts.download(context.Background(), "https://example.invalid/file", dest, 0o600); err == nil || requests != 5 {
+		t.Fatalf("expected error without retries, got %v after %d requests", err, requests)
+	}
+
+	if _, err := os.Stat(dest); !os.IsNotExist(err) {
+		t.Fatalf("expected file to be removed, got %v", err)
+	}
+
+	// Cancelled while waiting to retry.
+	ctx, cancel := context.WithCancel(context.Background())
+	requests = 0
+	opts.Backoff = func(int) time.Duration {
+		cancel()
+		return time.Hour
+	}
+
+	if err := opts.download(ctx, "https://example.invalid/file", dest, 0o600); err == nil || requests != 1 {
+		t.Fatalf("expected error after cancellation, got %v after %d requests", err, requests)
+	}
+
+	if _, err := os.Stat(dest); !os.IsNotExist(err) {
+		t.Fatalf("expected file to be removed, got %v", err)
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (fn roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return fn(r)
+}
+
+func TestInstallOptions_GitHubToken(t *testing.T) { //nolint:paralleltest
+	t.Setenv(GitHubTokenEnv, "env-token")
+
+	var auth []string
+
+	client := &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		auth = append(auth, r.Header.Get("Authorization"))
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("data")), Request: r}, nil
+	})}
+
+	dest := filepath.Join(t.TempDir(), "file")
+
+	for _, opts := range []*InstallOptions{{Client: client}, {Client: client, GitHubToken: "opts-token"}} {
+		for _, u := range []string{
+			"https://github.com/yt-dlp/yt-dlp/releases/download/2025.01.15/yt-dlp",
+			"https://api.github.com/repos/yt-dlp/yt-dlp/releases/latest",
+			"https://mirror.example.com/github.com/yt-dlp",
+			"http://github.com/yt-dlp/yt-dlp",
+		} {
+			if err := opts.download(context.Background(), u, dest, 0o600); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	expected := []string{
+		"Bearer env-token", "Bearer env-token", "", "",
+		"Bearer opts-token", "Bearer opts-token", "", "",
+	}
+
+	if !slices.Equal(auth, expected) {
+		t.Fatalf("expected %q, got %q", expected, auth)
+	}
+}
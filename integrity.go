@@ -0,0 +1,267 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// integrityTemplate is the --print-to-file template used to record the final
+// files of each video.
+const integrityTemplate = "after_move:%(.{id,filepath,webpage_url})j"
+
+// IntegrityOptions configures [Command.VerifyIntegrity].
+type IntegrityOptions struct {
+	// DeleteCorrupt deletes any files which fail verification.
+	DeleteCorrupt bool
+
+	// Retries is the number of times the videos of any files which fail
+	// verification are re-downloaded (and verified again). Requires DeleteCorrupt,
+	// as yt-dlp would otherwise skip the existing files.
+	Retries int
+}
+
+// FileIntegrity is the result of verifying a single file with ffprobe.
+type FileIntegrity struct {
+	// Format is the container format(s) reported by ffprobe.
+	Format string `json:"format,omitempty"`
+
+	// Duration is the duration reported by ffprobe.
+	Duration time.Duration `json:"duration"`
+
+	// Problems are any errors reported by ffprobe (e.g. due to a truncated or
+	// corrupt container), or a zero duration.
+	Problems []string `json:"problems,omitempty"`
+
+	// Deleted is true if the file was deleted due to failing verification (see
+	// [IntegrityOptions.DeleteCorrupt]).
+	Deleted bool `json:"deleted,omitempty"`
+}
+
+// OK returns true if the file passed verification.
+func (i *FileIntegrity) OK() bool {
+	return len(i.Problems) == 0
+}
+
+// ResultFile is a single final file produced by an invocation.
+type ResultFile struct {
+	// ID is the ID of the video.
+	ID string `json:"id"`
+
+	// URL is the webpage URL of the video.
+	URL string `json:"url,omitempty"`
+
+	// Path is the path to the file, as reported by yt-dlp (which may be relative
+	// to the working directory of the command).
+	Path string `json:"path"`
+
+	// Attempts is the number of times the file was downloaded, when retried with
+	// [IntegrityOptions.Retries].
+	Attempts int `json:"attempts"`
+
+	// Integrity is the result of verifying the file, if enabled with
+	// [Command.VerifyIntegrity].
+	Integrity *FileIntegrity `json:"integrity,omitempty"`
+}
+
+// Files returns the final files produced by the invocation, when recorded (see
+// [Command.VerifyIntegrity]).
+func (r *Result) Files() []*ResultFile {
+	return r.files
+}
+
+// ErrCorruptFiles is returned when one or more files fail integrity verification.
+// See [Command.VerifyIntegrity].
+type ErrCorruptFiles struct {
+	Files []*ResultFile // Only those which failed.
+}
+
+func (e *ErrCorruptFiles) Error() string {
+	out := make([]string, 0, len(e.Files))
+
+	for _, f := range e.Files {
+		out = append(out, fmt.Sprintf("%q (%s)", f.Path, strings.Join(f.Integrity.Problems, "; ")))
+	}
+
+	return fmt.Sprintf("integrity verification failed: %s", strings.Join(out, ", "))
+}
+
+// IsCorruptFilesError returns true when one or more files fail integrity
+// verification.
+func IsCorruptFilesError(err error) bool {
+	var e *ErrCorruptFiles
+	return errors.As(err, &e)
+}
+
+// VerifyIntegrity enables verification of each file produced by [Command.Run],
+// using ffprobe to detect truncated or corrupt files (e.g. container errors, or a
+// zero duration). The results are available via [Result.Files], and
+// [ErrCorruptFiles] is returned if any file fails verification. Verification only
+// runs if yt-dlp exits successfully. If opts is nil, corrupt files are only
+// reported.
+//
+// ffprobe is resolved from [Command.FFmpegLocation] if set, otherwise from PATH.
+//   - See [Command.UnsetVerifyIntegrity], for disabling verification.
+func (c *Command) VerifyIntegrity(opts *IntegrityOptions) *Command {
+	if opts == nil {
+		opts = &IntegrityOptions{}
+	}
+
+	o := *opts
+
+	c.mu.Lock()
+	c.integrity = &o
+	c.mu.Unlock()
+
+	return c
+}
+
+// UnsetVerifyIntegrity disables integrity verification, previously enabled with
+// [Command.VerifyIntegrity].
+func (c *Command) UnsetVerifyIntegrity() *Command {
+	c.mu.Lock()
+	c.integrity = nil
+	c.mu.Unlock()
+
+	return c
+}
+
+// integrityRecord is a single line written by yt-dlp using integrityTemplate.
+type integrityRecord struct {
+	ID         string `json:"id"`
+	Filepath   string `json:"filepath"`
+	WebpageURL string `json:"webpage_url"`
+}
+
+// runVerifyIntegrity invokes yt-dlp, recording the final files of each video,
+// and verifies each file (retrying if configured).
+func (c *Command) runVerifyIntegrity(ctx context.Context, opts *IntegrityOptions, args ...string) (*Result, error) {
+	ffprobe, err := c.resolveFFprobe()
+	if err != nil {
+		return nil, fmt.Errorf("unable to resolve ffprobe: %w", err)
+	}
+
+	c.mu.RLock()
+	workDir := c.directory
+	c.mu.RUnlock()
+
+	result, files, err := c.runRecordFiles(ctx, args...)
+	if err != nil {
+		return result, err
+	}
+
+	var corrupt []*ResultFile
+
+	for _, f := range files {
+		if !verifyFile(ctx, ffprobe, workDir, f, opts) {
+			corrupt = append(corrupt, f)
+		}
+	}
+
+	for retry := 0; retry < opts.Retries && opts.DeleteCorrupt && len(corrupt) > 0; retry++ {
+		urls := make([]string, 0, len(corrupt))
+		byID := make(map[string]*ResultFile, len(corrupt))
+
+		for _, f := range corrupt {
+			if f.URL != "" && byID[f.ID] == nil {
+				urls = append(urls, f.URL)
+			}
+
+			byID[f.ID] = f
+		}
+
+		if len(urls) == 0 {
+			break
+		}
+
+		_, retried, rerr := c.runRecordFiles(ctx, urls...)
+		if rerr != nil {
+			return result, fmt.Errorf("unable to re-download corrupt files: %w", rerr)
+		}
+
+		for _, f := range retried {
+			if prev := byID[f.ID]; prev != nil {
+				prev.Path = f.Path
+				prev.Attempts++
+				verifyFile(ctx, ffprobe, workDir, prev, opts)
+			}
+		}
+
+		prev := corrupt
+		corrupt = nil
+
+		for _, f := range prev {
+			if !f.Integrity.OK() {
+				corrupt = append(corrupt, f)
+			}
+		}
+	}
+
+	result.files = files
+
+	if len(corrupt) > 0 {
+		return result, &ErrCorruptFiles{Files: corrupt}
+	}
+
+	return result, nil
+}
+
+// runRecordFiles invokes a copy of the command (without integrity verification),
+// recording the final files of each video.
+func (c *Command) runRecordFiles(ctx context.Context, args ...string) (*Result, []*ResultFile, error) {
+	result, records, err := runRecorded(ctx, c.clone().UnsetVerifyIntegrity(), []string{integrityTemplate}, func(r *integrityRecord) bool {
+		return r.Filepath != ""
+	}, args...)
+	if err != nil {
+		return result, nil, err
+	}
+
+	files := make([]*ResultFile, 0, len(records))
+	for _, r := range records {
+		files = append(files, &ResultFile{ID: r.ID, URL: r.WebpageURL, Path: r.Filepath, Attempts: 1})
+	}
+
+	return result, files, nil
+}
+
+// verifyFile probes the file, populating its integrity, and deleting it if
+// corrupt (and configured to do so). Returns true if the file passed verification.
+func verifyFile(ctx context.Context, ffprobe, workDir string, f *ResultFile, opts *IntegrityOptions) bool {
+	path := f.Path
+	if !filepath.IsAbs(path) && workDir != "" {
+		path = filepath.Join(workDir, path)
+	}
+
+	f.Integrity = &FileIntegrity{}
+
+	probe, problems, err := probeFile(ctx, ffprobe, path)
+	if err != nil {
+		f.Integrity.Problems = append(f.Integrity.Problems, err.Error())
+	} else {
+		f.Integrity.Problems = problems
+		f.Integrity.Format = probe.Format.FormatName
+		f.Integrity.Duration = probe.duration()
+
+		if f.Integrity.Duration <= 0 {
+			f.Integrity.Problems = append(f.Integrity.Problems, "zero or unknown duration")
+		}
+	}
+
+	if f.Integrity.OK() {
+		return true
+	}
+
+	if opts.DeleteCorrupt && os.Remove(path) == nil {
+		f.Integrity.Deleted = true
+	}
+
+	return false
+}
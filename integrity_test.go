@@ -0,0 +1,109 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// fakeIntegrityScript emulates yt-dlp downloading each URL, writing the file
+// records to the --print-to-file file. The first download of "b" is corrupt.
+const fakeIntegrityScript = `#!/bin/sh
+while [ $# -gt 0 ]; do
+	case "$1" in
+		--print-to-file) pfile="$3"; shift; shift ;;
+		https://*) ids="$ids ${1##*/}" ;;
+	esac
+	shift
+done
+for id in $ids; do
+	if [ "$id" = b ] && [ ! -e b.attempted ]; then
+		echo corrupt > "$id.mp4"
+	else
+		echo 10 > "$id.mp4"
+	fi
+	: > "$id.attempted"
+	echo '{"id": "'"$id"'", "filepath": "'"$id"'.mp4", "webpage_url": "https://example.com/'"$id"'"}' >> "$pfile"
+done
+`
+
+func newIntegrityCommand(t *testing.T) (cmd *Command, dir string) {
+	t.Helper()
+
+	dir = t.TempDir()
+	bin := writeFakeExecutable(t, dir, "yt-dlp.sh", fakeIntegrityScript)
+
+	writeFakeExecutable(t, dir, "ffprobe", fakeFFprobeScript)
+
+	return New().SetExecutable(bin).SetWorkDir(dir).FFmpegLocation(dir), dir
+}
+
+func TestCommand_VerifyIntegrity(t *testing.T) {
+	t.Parallel()
+
+	cmd, dir := newIntegrityCommand(t)
+
+	result, err := cmd.VerifyIntegrity(nil).Run(context.Background(), "https://example.com/a", "https://example.com/b")
+
+	var corrupt *ErrCorruptFiles
+	if !errors.As(err, &corrupt) {
+		t.Fatalf("expected corrupt files error, got %v", err)
+	}
+
+	if len(corrupt.Files) != 1 || corrupt.Files[0].ID != "b" {
+		t.Fatalf("expected only %q to be corrupt, got %v", "b", corrupt.Files)
+	}
+
+	files := result.Files()
+	if len(files) != 2 {
+		t.Fatalf("expected 2 files, got %d", len(files))
+	}
+
+	if !files[0].Integrity.OK() || files[0].Integrity.Format != "mp4" || files[0].Integrity.Duration <= 0 {
+		t.Fatalf("expected %q to pass verification, got %+v", files[0].Path, files[0].Integrity)
+	}
+
+	if files[1].Integrity.OK() || files[1].Integrity.Deleted {
+		t.Fatalf("expected %q to fail verification without being deleted, got %+v", files[1].Path, files[1].Integrity)
+	}
+
+	if _, err = os.Stat(filepath.Join(dir, "b.mp4")); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(cmd.getFlagsByID("print_to_file")) != 0 {
+		t.Fatal("expected command to not be modified")
+	}
+}
+
+func TestCommand_VerifyIntegrity_Retry(t *testing.T) {
+	t.Parallel()
+
+	cmd, _ := newIntegrityCommand(t)
+
+	result, err := cmd.
+		VerifyIntegrity(&IntegrityOptions{DeleteCorrupt: true, Retries: 1}).
+		Run(context.Background(), "https://example.com/a", "https://example.com/b")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files := result.Files()
+	if len(files) != 2 {
+		t.Fatalf("expected 2 files, got %d", len(files))
+	}
+
+	if files[0].Attempts != 1 || files[1].Attempts != 2 {
+		t.Fatalf("expected 1 and 2 attempts, got %d and %d", files[0].Attempts, files[1].Attempts)
+	}
+
+	if !files[1].Integrity.OK() {
+		t.Fatalf("expected %q to pass verification after retry, got %+v", files[1].Path, files[1].Integrity)
+	}
+}
@@ -0,0 +1,460 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// jobLease is how long a claim on a job (see [Job.Claim]) lasts, unless renewed.
+// [Command.RunJobs] renews claims of running jobs well before they expire.
+const jobLease = time.Minute
+
+// ErrJobUnavailable is returned by [JobStore.ClaimJob] (and [Job.Claim]) when the
+// job doesn't exist, isn't queued or interrupted, or is claimed by another owner
+// whose lease hasn't expired.
+var ErrJobUnavailable = errors.New("job is unavailable")
+
+// JobStatus is the status of a [Job].
+type JobStatus string
+
+const (
+	// JobQueued is the status of jobs which haven't been started yet.
+	JobQueued JobStatus = "queued"
+
+	// JobRunning is the status of jobs which are being run by [Job.Owner]. If the
+	// owner's lease (see [Job.LeaseExpires]) has expired, the job was interrupted
+	// (e.g. by the process exiting, or the context being cancelled), and is
+	// resumed by the next [Command.RunJobs].
+	JobRunning JobStatus = "running"
+
+	// JobFinished is the status of jobs which finished successfully.
+	JobFinished JobStatus = "finished"
+
+	// JobFailed is the status of jobs which failed, see [Job.Error].
+	JobFailed JobStatus = "failed"
+)
+
+// Job is a single invocation of yt-dlp with a set of inputs (e.g. URLs), which is
+// persisted by a [JobStore], so it can be resumed after a restart.
+type Job struct {
+	ID           string    `json:"id"`
+	Inputs       []string  `json:"inputs"`
+	Status       JobStatus `json:"status"`
+	Owner        string    `json:"owner,omitempty"`  // Owner of the claim on the job, see [Job.Claim].
+	LeaseExpires time.Time `json:"lease_expires"`    // When the owner's claim expires, unless renewed.
+	Error        string    `json:"error,omitempty"`  // Error of the last invocation, if it failed.
+	Result       *Result   `json:"result,omitempty"` // Result of the last invocation.
+	Resumed      int       `json:"resumed,omitempty"`
+	Created      time.Time `json:"created"`
+	Updated      time.Time `json:"updated"`
+}
+
+// Claim claims the job for owner, for the provided lease duration. Queued jobs
+// and interrupted jobs (running, with an expired lease) can be claimed, in which
+// case the status is changed to [JobRunning] (and [Job.Resumed] is incremented
+// for interrupted jobs). If the job is already claimed by owner, the claim is
+// renewed. Otherwise, [ErrJobUnavailable] is returned. [JobStore]
+// implementations use Claim to implement [JobStore.ClaimJob].
+func (j *Job) Claim(owner string, lease time.Duration) error {
+	now := time.Now()
+
+	switch {
+	case j.Status == JobQueued:
+	case j.Status != JobRunning:
+		return ErrJobUnavailable
+	case j.Owner == owner:
+		j.LeaseExpires = now.Add(lease)
+		return nil
+	case now.Before(j.LeaseExpires):
+		return ErrJobUnavailable
+	default:
+		j.Resumed++
+	}
+
+	j.Status = JobRunning
+	j.Owner = owner
+	j.LeaseExpires = now.Add(lease)
+	j.Updated = now
+
+	return nil
+}
+
+// claimable returns true if the job could be claimed by a new owner.
+func (j *Job) claimable() bool {
+	return j.Status == JobQueued || (j.Status == JobRunning && !time.Now().Before(j.LeaseExpires))
+}
+
+// JobStore persists jobs (see [EnqueueJob] and [Command.RunJobs]). See
+// [FileJobStore] for a bundled implementation. Implementations must be safe for
+// concurrent use.
+type JobStore interface {
+	// SaveJob creates or replaces the job with the same ID.
+	SaveJob(ctx context.Context, job *Job) error
+
+	// ClaimJob loads the job with the provided ID, claims it for owner with
+	// [Job.Claim], and saves it, returning the claimed job. This must be atomic
+	// for all users of the store (including other processes, if the store is
+	// shared between them), so a job is only ever claimed by one owner at once.
+	ClaimJob(ctx context.Context, id, owner string, lease time.Duration) (*Job, error)
+
+	// Jobs returns all jobs, in the order they were created.
+	Jobs(ctx context.Context) ([]*Job, error)
+
+	// DeleteJob deletes the job with the provided ID, if it exists.
+	DeleteJob(ctx context.Context, id string) error
+}
+
+// validateJobID returns an error if the job ID is empty, or can't be used as a
+// file name.
+func validateJobID(id string) error {
+	if id == "" || strings.ContainsAny(id, `/\`) || strings.Trim(id, ".") == "" {
+		return fmt.Errorf("invalid job id %q", id)
+	}
+
+	return nil
+}
+
+// newJobID returns a new random ID, used for jobs and job owners.
+func newJobID() string {
+	b := make([]byte, 8) //nolint:gomnd
+	_, _ = rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
+
+// EnqueueJob saves a new queued job for the provided inputs to store, which is
+// run by the next [Command.RunJobs].
+func EnqueueJob(ctx context.Context, store JobStore, inputs ...string) (*Job, error) {
+	if len(inputs) == 0 {
+		return nil, errors.New("unable to enqueue job: no inputs provided")
+	}
+
+	now := time.Now()
+	job := &Job{ID: newJobID(), Inputs: inputs, Status: JobQueued, Created: now, Updated: now}
+
+	if err := store.SaveJob(ctx, job); err != nil {
+		return nil, fmt.Errorf("unable to enqueue job: %w", err)
+	}
+
+	return job, nil
+}
+
+// RunJobs runs all queued jobs within store, along with jobs which were
+// interrupted while running (e.g. by the process exiting), which are resumed
+// with --continue (see [Command.Continue]), so partially downloaded files are
+// continued instead of restarted. Each job is claimed (see [JobStore.ClaimJob])
+// before it's started, and the claim is renewed while it runs, so multiple
+// RunJobs calls (including from other processes) can share a store without
+// running the same job twice. A job is only considered interrupted once its
+// claim has expired.
+//
+// Jobs are run the same way as [Command.RunConcurrently] (one yt-dlp process per
+// job, up to [Concurrency.Processes] at once), and each status change (along
+// with the [Result] of the invocation) is saved to store. If ctx is cancelled,
+// running jobs are left as [JobRunning] with an expired claim, to be resumed
+// later. The jobs which were run are returned, along with a joined error of all
+// failed jobs. The command itself is not modified.
+func (c *Command) RunJobs(ctx context.Context, store JobStore, conc Concurrency) ([]*Job, error) {
+	if err := conc.Validate(); err != nil {
+		return nil, err
+	}
+
+	all, err := store.Jobs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load jobs: %w", err)
+	}
+
+	var ids []string
+
+	for _, job := range all {
+		if job.claimable() {
+			ids = append(ids, job.ID)
+		}
+	}
+
+	cmd := c.clone().SetConcurrency(conc)
+	owner := newJobID()
+	jobs := make([]*Job, len(ids))
+
+	// Status updates are saved even once ctx is cancelled, so jobs can be resumed.
+	saveCtx := context.WithoutCancel(ctx)
+
+	_, err = runPoolFunc(ctx, conc.Processes, len(ids), func(i int) string {
+		return fmt.Sprintf("job %s", ids[i])
+	}, func(i int) (*Result, error) {
+		job, err := store.ClaimJob(saveCtx, ids[i], owner, jobLease)
+		if errors.Is(err, ErrJobUnavailable) {
+			return nil, nil // Claimed by another owner (or deleted) since it was listed.
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("unable to claim job: %w", err)
+		}
+
+		jobs[i] = job
+
+		return runJob(ctx, store, cmd.clone(), job, owner)
+	})
+
+	return slices.DeleteFunc(jobs, func(job *Job) bool { return job == nil }), err
+}
+
+// runJob invokes cmd for a job claimed by owner, renewing the claim while it
+// runs, then saves the status of the job. If the claim is lost (e.g. it couldn't
+// be renewed in time, and another owner claimed the job), the invocation is
+// cancelled, and the job isn't saved.
+func runJob(ctx context.Context, store JobStore, cmd *Command, job *Job, owner string) (*Result, error) {
+	saveCtx := context.WithoutCancel(ctx)
+
+	if job.Resumed > 0 {
+		cmd.Continue()
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var lost atomic.Bool
+
+	renewed := make(chan struct{})
+
+	go func() {
+		defer close(renewed)
+
+		ticker := time.NewTicker(jobLease / 3) //nolint:gomnd
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-runCtx.Done():
+				return
+			case <-ticker.C:
+				// Other errors are retried on the next tick, while the claim is
+				// still valid.
+				if _, err := store.ClaimJob(saveCtx, job.ID, owner, jobLease); errors.Is(err, ErrJobUnavailable) {
+					lost.Store(true)
+					cancel()
+					return
+				}
+			}
+		}
+	}()
+
+	result, err := cmd.Run(runCtx, job.Inputs...)
+	cancel()
+	<-renewed
+
+	if lost.Load() {
+		return result, fmt.Errorf("lost claim on job: %w", ErrJobUnavailable)
+	}
+
+	job.Result = result
+	job.Owner = ""
+	job.LeaseExpires = time.Time{}
+	job.Updated = time.Now()
+
+	switch {
+	case err == nil:
+		job.Status = JobFinished
+		job.Error = ""
+	case ctx.Err() != nil:
+		// Left as running, with an expired claim, so it's resumed later.
+		job.Error = err.Error()
+	default:
+		job.Status = JobFailed
+		job.Error = err.Error()
+	}
+
+	if serr := store.SaveJob(saveCtx, job); serr != nil {
+		return result, errors.Join(err, fmt.Errorf("unable to save job: %w", serr))
+	}
+
+	return result, err
+}
+
+// FileJobStore is a [JobStore] which stores each job as a JSON file within a
+// directory. It's safe for concurrent use, including by multiple processes
+// sharing the same directory (on Windows and most unix systems, where the store
+// is locked with a lock file while it's accessed).
+type FileJobStore struct {
+	dir string
+	mu  sync.Mutex
+}
+
+var _ JobStore = (*FileJobStore)(nil)
+
+// NewFileJobStore returns a [FileJobStore] which stores jobs within dir, which
+// is created if necessary.
+func NewFileJobStore(dir string) (*FileJobStore, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil { //nolint:gomnd
+		return nil, fmt.Errorf("unable to create job store dir: %w", err)
+	}
+
+	return &FileJobStore{dir: dir}, nil
+}
+
+// lock locks the store, both within the process, and across processes using a
+// lock file within the store directory. The returned function unlocks it.
+func (s *FileJobStore) lock() (unlock func(), err error) {
+	s.mu.Lock()
+
+	f, err := os.OpenFile(filepath.Join(s.dir, ".lock"), os.O_RDWR|os.O_CREATE, 0o600)
+	if err == nil {
+		if err = lockFile(f); err != nil {
+			_ = f.Close()
+		}
+	}
+
+	if err != nil {
+		s.mu.Unlock()
+		return nil, fmt.Errorf("unable to lock job store: %w", err)
+	}
+
+	return func() {
+		_ = unlockFile(f)
+		_ = f.Close()
+		s.mu.Unlock()
+	}, nil
+}
+
+// readJob reads the job stored at path.
+func readJob(path string) (*Job, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read job: %w", err)
+	}
+
+	job := &Job{}
+
+	if err = json.Unmarshal(b, job); err != nil {
+		return nil, fmt.Errorf("unable to parse job %q: %w", filepath.Base(path), err)
+	}
+
+	return job, nil
+}
+
+// writeJob writes the job to the store. The store must be locked.
+func (s *FileJobStore) writeJob(job *Job) error {
+	b, err := json.Marshal(job)
+	if err != nil {
+		return fmt.Errorf("unable to encode job: %w", err)
+	}
+
+	if err = writeFileAtomic(filepath.Join(s.dir, job.ID+".json"), b, 0o600); err != nil {
+		return fmt.Errorf("unable to write job: %w", err)
+	}
+
+	return nil
+}
+
+// SaveJob implements [JobStore].
+func (s *FileJobStore) SaveJob(_ context.Context, job *Job) error {
+	if err := validateJobID(job.ID); err != nil {
+		return err
+	}
+
+	unlock, err := s.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	return s.writeJob(job)
+}
+
+// ClaimJob implements [JobStore].
+func (s *FileJobStore) ClaimJob(_ context.Context, id, owner string, lease time.Duration) (*Job, error) {
+	if err := validateJobID(id); err != nil {
+		return nil, err
+	}
+
+	unlock, err := s.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	job, err := readJob(filepath.Join(s.dir, id+".json"))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrJobUnavailable
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if err = job.Claim(owner, lease); err != nil {
+		return nil, err
+	}
+
+	if err = s.writeJob(job); err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// Jobs implements [JobStore].
+func (s *FileJobStore) Jobs(_ context.Context) ([]*Job, error) {
+	unlock, err := s.lock()
+	if err != nil {
+		return nil, err
+	}
+	defer unlock()
+
+	paths, err := filepath.Glob(filepath.Join(s.dir, "*.json"))
+	if err != nil {
+		return nil, fmt.Errorf("unable to list jobs: %w", err)
+	}
+
+	jobs := make([]*Job, 0, len(paths))
+
+	for _, path := range paths {
+		job, err := readJob(path)
+		if err != nil {
+			return nil, err
+		}
+
+		jobs = append(jobs, job)
+	}
+
+	slices.SortStableFunc(jobs, func(a, b *Job) int {
+		return a.Created.Compare(b.Created)
+	})
+
+	return jobs, nil
+}
+
+// DeleteJob implements [JobStore].
+func (s *FileJobStore) DeleteJob(_ context.Context, id string) error {
+	if err := validateJobID(id); err != nil {
+		return err
+	}
+
+	unlock, err := s.lock()
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	err = os.Remove(filepath.Join(s.dir, id+".json"))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("unable to delete job: %w", err)
+	}
+
+	return nil
+}
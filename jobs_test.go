@@ -0,0 +1,221 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCommand_RunJobs(t *testing.T) {
+	t.Parallel()
+
+	bin := writeFakeYtdlp(t, "#!/bin/sh\ncase \"$*\" in *bad*) exit 1;; esac\necho \"$*\"\n")
+
+	store, err := NewFileJobStore(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+
+	good, err := EnqueueJob(ctx, store, "https://example.com/good")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bad, err := EnqueueJob(ctx, store, "https://example.com/bad")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Emulates a job which was interrupted by the process exiting, so its claim
+	// expired.
+	interrupted := &Job{
+		ID:           "interrupted",
+		Inputs:       []string{"https://example.com/interrupted"},
+		Status:       JobRunning,
+		Owner:        "exited",
+		LeaseExpires: time.Now().Add(-time.Second),
+		Created:      time.Now(),
+	}
+
+	// Emulates a job which is being run by another process.
+	claimed := &Job{
+		ID:           "claimed",
+		Inputs:       []string{"https://example.com/claimed"},
+		Status:       JobRunning,
+		Owner:        "other",
+		LeaseExpires: time.Now().Add(time.Hour),
+		Created:      time.Now(),
+	}
+
+	for _, job := range []*Job{interrupted, claimed} {
+		if err = store.SaveJob(ctx, job); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err = EnqueueJob(ctx, store); err == nil {
+		t.Fatal("expected error enqueueing job without inputs")
+	}
+
+	if err = store.SaveJob(ctx, &Job{ID: "../bad"}); err == nil {
+		t.Fatal("expected error for invalid job id")
+	}
+
+	cmd := New().SetExecutable(bin).NoContinue()
+
+	jobs, err := cmd.RunJobs(ctx, store, Concurrency{Processes: 2})
+	if err == nil {
+		t.Fatal("expected error from failed job")
+	}
+
+	if len(jobs) != 3 {
+		t.Fatalf("expected 3 jobs to be run, got %d", len(jobs))
+	}
+
+	// Reload from disk, to validate that status and results are persisted.
+	jobs, err = store.Jobs(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	byID := map[string]*Job{}
+	for _, job := range jobs {
+		byID[job.ID] = job
+	}
+
+	if job := byID[good.ID]; job.Status != JobFinished || job.Result == nil || job.Result.Stdout != "--no-continue https://example.com/good" {
+		t.Fatalf("unexpected good job: %+v", job)
+	}
+
+	if job := byID[bad.ID]; job.Status != JobFailed || job.Error == "" || job.Result == nil || job.Result.ExitCode != 1 {
+		t.Fatalf("unexpected bad job: %+v", job)
+	}
+
+	if job := byID["interrupted"]; job.Status != JobFinished || job.Resumed != 1 || job.Result.Stdout != "--continue https://example.com/interrupted" {
+		t.Fatalf("expected interrupted job to be resumed with --continue: %+v", job)
+	}
+
+	if job := byID["claimed"]; job.Status != JobRunning || job.Owner != "other" || job.Result != nil {
+		t.Fatalf("expected job claimed by another owner to not be run: %+v", job)
+	}
+
+	if job := byID[good.ID]; job.Owner != "" || !job.LeaseExpires.IsZero() {
+		t.Fatalf("expected claim to be released: %+v", job)
+	}
+
+	if len(cmd.getFlagsByID("continue_dl")) != 1 || cmd.getFlagsByID("continue_dl")[0].Flag != "--no-continue" {
+		t.Fatal("expected command to not be modified")
+	}
+
+	if jobs, err = cmd.RunJobs(ctx, store, Concurrency{}); err != nil || len(jobs) != 0 {
+		t.Fatalf("expected no jobs to be run, got %d (err: %v)", len(jobs), err)
+	}
+
+	if err = store.DeleteJob(ctx, bad.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	if jobs, err = store.Jobs(ctx); err != nil || len(jobs) != 3 {
+		t.Fatalf("expected 3 jobs after deletion, got %d (err: %v)", len(jobs), err)
+	}
+}
+
+func TestJob_Claim(t *testing.T) {
+	t.Parallel()
+
+	job := &Job{ID: "job", Status: JobQueued}
+
+	if err := job.Claim("a", time.Hour); err != nil || job.Status != JobRunning || job.Owner != "a" || job.Resumed != 0 {
+		t.Fatalf("expected queued job to be claimed: %+v (err: %v)", job, err)
+	}
+
+	if err := job.Claim("b", time.Hour); !errors.Is(err, ErrJobUnavailable) || job.Owner != "a" {
+		t.Fatalf("expected job with a valid claim to be unavailable: %+v (err: %v)", job, err)
+	}
+
+	expires := job.LeaseExpires
+
+	if err := job.Claim("a", 2*time.Hour); err != nil || !job.LeaseExpires.After(expires) {
+		t.Fatalf("expected owner to renew claim: %+v (err: %v)", job, err)
+	}
+
+	job.LeaseExpires = time.Now().Add(-time.Second)
+
+	if err := job.Claim("b", time.Hour); err != nil || job.Owner != "b" || job.Resumed != 1 {
+		t.Fatalf("expected job with an expired claim to be resumed: %+v (err: %v)", job, err)
+	}
+
+	job.Status = JobFinished
+
+	if err := job.Claim("b", time.Hour); !errors.Is(err, ErrJobUnavailable) {
+		t.Fatalf("expected finished job to be unavailable, got %v", err)
+	}
+}
+
+func TestCommand_RunJobs_shared(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	calls := filepath.Join(t.TempDir(), "calls")
+	bin := writeFakeYtdlp(t, "#!/bin/sh\necho \"$*\" >> '"+calls+"'\n")
+
+	ctx := context.Background()
+
+	store, err := NewFileJobStore(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := range 10 {
+		if _, err = EnqueueJob(ctx, store, fmt.Sprintf("https://example.com/%d", i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Each RunJobs call uses its own store (as separate processes would), sharing
+	// the same directory.
+	var wg sync.WaitGroup
+
+	for range 3 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			s, err := NewFileJobStore(dir)
+			if err == nil {
+				_, err = New().SetExecutable(bin).RunJobs(ctx, s, Concurrency{Processes: 2})
+			}
+
+			if err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	b, err := os.ReadFile(calls)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	slices.Sort(lines)
+
+	if len(lines) != 10 || len(slices.Compact(lines)) != 10 {
+		t.Fatalf("expected each job to be run once, got %q", lines)
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// JSRuntimeRemediation describes how to resolve [ErrJSRuntimeRequired].
+const JSRuntimeRemediation = "yt-dlp requires a JavaScript runtime (e.g. deno) to solve signature/n " +
+	"challenges for some sites (e.g. YouTube). Install a supported runtime and ensure it is on PATH (or " +
+	"configure it with \"--js-runtimes\", see Command.AddRawFlags), and update yt-dlp. See " +
+	"https://github.com/yt-dlp/yt-dlp/wiki/EJS for details."
+
+// reJSRuntimeWarning matches yt-dlp warnings which indicate a JavaScript runtime is
+// missing, or challenge solving (which requires one) failed.
+var reJSRuntimeWarning = regexp.MustCompile(
+	`(?i)^WARNING: .*(?:javascript runtime|(?:signature|n challenge) solving failed)`,
+)
+
+// jsRuntimeWarnings returns all warning lines in the provided output which
+// indicate a JavaScript runtime is required.
+func jsRuntimeWarnings(output string) (warnings []string) {
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+
+		if reJSRuntimeWarning.MatchString(line) {
+			warnings = append(warnings, line)
+		}
+	}
+
+	return warnings
+}
+
+// JSRuntimeWarnings returns all warnings from yt-dlp which indicate a JavaScript
+// runtime is missing, or challenge solving failed. Even when the invocation is
+// successful, these warnings indicate that some formats may have been missing
+// (e.g. a lower quality format was selected).
+func (r *Result) JSRuntimeWarnings() []string {
+	return jsRuntimeWarnings(r.Stderr)
+}
+
+// ErrJSRuntimeRequired is returned when the yt-dlp process fails, and yt-dlp
+// warned that a JavaScript runtime is required (e.g. for signature solving). It
+// wraps [ErrExitCode]. See [JSRuntimeRemediation] and [Command.SetJSRuntimeInstallFunc].
+type ErrJSRuntimeRequired struct {
+	wrapped error
+
+	// Warnings are the warnings from yt-dlp related to the JavaScript runtime.
+	Warnings []string
+}
+
+func (e *ErrJSRuntimeRequired) Unwrap() error {
+	return e.wrapped
+}
+
+func (e *ErrJSRuntimeRequired) Error() string {
+	return fmt.Sprintf("javascript runtime required: %s", e.wrapped)
+}
+
+// Remediation returns information on how to resolve the error.
+func (e *ErrJSRuntimeRequired) Remediation() string {
+	return JSRuntimeRemediation
+}
+
+// IsJSRuntimeRequiredError returns true when the yt-dlp process fails, and yt-dlp
+// warned that a JavaScript runtime is required.
+func IsJSRuntimeRequiredError(err error) bool {
+	var e *ErrJSRuntimeRequired
+	return errors.As(err, &e)
+}
+
+// JSRuntimeInstallFunc is invoked when an invocation fails with
+// [ErrJSRuntimeRequired], and should install (or otherwise make available) a
+// JavaScript runtime. If it returns nil, the invocation is retried once.
+type JSRuntimeInstallFunc func(ctx context.Context, err *ErrJSRuntimeRequired) error
+
+// SetJSRuntimeInstallFunc sets a function which is invoked when [Command.Run]
+// fails with [ErrJSRuntimeRequired], e.g. to install a JavaScript runtime (and
+// add it with [Command.SetEnvVar] or [Command.AddRawFlags], if not on PATH). If
+// the function returns nil, the invocation is retried once, otherwise the
+// original error is returned with the function's error joined to it. The
+// function is invoked with the command's lock released, so it may modify the
+// command. Pass nil to unset it.
+func (c *Command) SetJSRuntimeInstallFunc(fn JSRuntimeInstallFunc) *Command {
+	c.mu.Lock()
+	c.jsRuntimeInstallFn = fn
+	c.mu.Unlock()
+
+	return c
+}
+
+// handleJSRuntimeRequired invokes the JavaScript runtime install function (if set)
+// when err is [ErrJSRuntimeRequired], returning true if the invocation should be
+// retried. If the install function fails, its error is joined to err.
+func (c *Command) handleJSRuntimeRequired(ctx context.Context, err *error) bool {
+	var jerr *ErrJSRuntimeRequired
+
+	if !errors.As(*err, &jerr) || ctx.Err() != nil {
+		return false
+	}
+
+	c.mu.RLock()
+	fn := c.jsRuntimeInstallFn
+	c.mu.RUnlock()
+
+	if fn == nil {
+		return false
+	}
+
+	var ierr error
+
+	if perr := callSafely("js-runtime-install", func() { ierr = fn(ctx, jerr) }); perr != nil {
+		ierr = perr
+	}
+
+	if ierr != nil {
+		*err = errors.Join(*err, fmt.Errorf("unable to install javascript runtime: %w", ierr))
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,63 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const jsRuntimeScript = `#!/bin/sh
+if [ ! -f "$(dirname "$0")/deno" ]; then
+	echo "WARNING: [youtube] No supported JavaScript runtime could be found." >&2
+	echo "WARNING: [youtube] abc: n challenge solving failed: Some formats may be missing." >&2
+	echo "ERROR: [youtube] abc: Requested format is not available." >&2
+	exit 1
+fi
+echo ok
+`
+
+func TestCommand_JSRuntimeRequired(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	bin := writeFakeExecutable(t, dir, "yt-dlp.sh", jsRuntimeScript)
+
+	_, err := New().SetExecutable(bin).Run(context.Background(), "abc")
+	if !IsJSRuntimeRequiredError(err) || !IsExitCodeError(err) {
+		t.Fatalf("expected js runtime and exit code error, got %v", err)
+	}
+
+	var jerr *ErrJSRuntimeRequired
+	if !errors.As(err, &jerr) || len(jerr.Warnings) != 2 || jerr.Remediation() == "" {
+		t.Fatalf("unexpected error details: %+v", jerr)
+	}
+
+	installErr := errors.New("install failed")
+
+	_, err = New().SetExecutable(bin).SetJSRuntimeInstallFunc(func(_ context.Context, _ *ErrJSRuntimeRequired) error {
+		return installErr
+	}).Run(context.Background(), "abc")
+	if !errors.Is(err, installErr) || !IsJSRuntimeRequiredError(err) {
+		t.Fatalf("expected joined install error, got %v", err)
+	}
+
+	var calls int
+
+	result, err := New().SetExecutable(bin).SetJSRuntimeInstallFunc(func(_ context.Context, _ *ErrJSRuntimeRequired) error {
+		calls++
+		return os.WriteFile(filepath.Join(dir, "deno"), nil, 0o600)
+	}).Run(context.Background(), "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if calls != 1 || result.Stdout != "ok" || len(result.JSRuntimeWarnings()) != 0 {
+		t.Fatalf("unexpected result after install (calls: %d): %+v", calls, result)
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"sync"
+)
+
+// LogCallbackFunc is a callback function that is called for each stdout/stderr
+// log line, as it's written by yt-dlp.
+type LogCallbackFunc func(log ResultLog)
+
+// logHandler invokes the log callback function, and is shared between the stdout
+// and stderr writers of a single invocation, so calls are serialized.
+type logHandler struct {
+	fn LogCallbackFunc
+	mu sync.Mutex
+}
+
+// handle invokes the log function. If the log function panics, the recovered
+// panic is returned.
+func (h *logHandler) handle(log *ResultLog) *ErrCallbackPanic {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	return callSafely("log", func() { h.fn(*log) })
+}
+
+// LogFunc can be used to register a callback function that will be called for each
+// stdout/stderr log line as soon as it's written by yt-dlp (with the same
+// information as [Result.OutputLogs]), which allows monitoring long-running
+// invocations (e.g. playlists) live, rather than only once they've finished.
+// Calls are serialized between stdout and stderr, and are made in the order lines
+// are written for each pipe. Progress lines (see [Command.ProgressFunc]), and
+// lines discarded due to [Command.SetMaxCaptureBytes], are not included.
+//   - See [Command.UnsetLogFunc], for unsetting the log function.
+func (c *Command) LogFunc(fn LogCallbackFunc) *Command {
+	c.mu.Lock()
+	c.logFn = fn
+	c.mu.Unlock()
+
+	return c
+}
+
+// UnsetLogFunc can be used to unset the log function that was previously set with
+// [Command.LogFunc].
+func (c *Command) UnsetLogFunc() *Command {
+	c.mu.Lock()
+	c.logFn = nil
+	c.mu.Unlock()
+
+	return c
+}
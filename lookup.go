@@ -0,0 +1,67 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// LookupExecutable searches for the named executable in the directories named by
+// the PATH environment variable, similar to [exec.LookPath]. If name contains a
+// path separator, it is checked directly, without consulting PATH. On Windows,
+// extensions from PATHEXT are tried when name doesn't already have one. Relative
+// PATH entries are ignored, as they would resolve relative to the current working
+// directory.
+func LookupExecutable(name string) (string, error) {
+	return lookupExecutable(name, os.Getenv("PATH"))
+}
+
+// lookupExecutable is the same as [LookupExecutable], but uses the provided
+// PATH-style list, rather than the PATH of the current process.
+func lookupExecutable(name, pathList string) (string, error) {
+	if name == "" {
+		return "", &exec.Error{Name: name, Err: exec.ErrNotFound}
+	}
+
+	if strings.ContainsAny(name, pathSeparators) {
+		if path, ok := findExecutable(name); ok {
+			return path, nil
+		}
+		return "", &exec.Error{Name: name, Err: exec.ErrNotFound}
+	}
+
+	for _, dir := range filepath.SplitList(pathList) {
+		if dir == "" || !filepath.IsAbs(dir) {
+			continue
+		}
+
+		if path, ok := findExecutable(filepath.Join(dir, name)); ok {
+			return path, nil
+		}
+	}
+
+	return "", &exec.Error{Name: name, Err: exec.ErrNotFound}
+}
+
+// lookupEnv returns the value of key from env, comparing keys the same way the
+// current OS does (case-insensitive on Windows). Keys with a leading "=" (e.g.
+// the per-drive working directory variables on Windows, like "=C:") are never
+// matched.
+func lookupEnv(env map[string]string, key string) (string, bool) {
+	for k, v := range env {
+		if strings.HasPrefix(k, "=") {
+			continue
+		}
+
+		if envKeyEqual(k, key) {
+			return v, true
+		}
+	}
+
+	return "", false
+}
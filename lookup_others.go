@@ -0,0 +1,31 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+//go:build !windows
+
+package ytdlp
+
+import (
+	"os"
+)
+
+// pathSeparators are the characters which, if found in an executable name,
+// indicate that it's a path rather than something to look up in PATH.
+const pathSeparators = "/"
+
+// findExecutable returns path if it exists, isn't a directory, and has any of the
+// executable permission bits set.
+func findExecutable(path string) (string, bool) {
+	stat, err := os.Stat(path)
+	if err != nil || stat.IsDir() || stat.Mode().Perm()&0o111 == 0 {
+		return "", false
+	}
+
+	return path, true
+}
+
+// envKeyEqual returns true if the provided environment variable keys are equal.
+func envKeyEqual(a, b string) bool {
+	return a == b
+}
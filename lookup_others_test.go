@@ -0,0 +1,62 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+//go:build !windows
+
+package ytdlp
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLookupExecutable(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	other := t.TempDir()
+
+	bin := writeFakeExecutable(t, dir, "example", "#!/bin/sh\n")
+
+	if err := os.WriteFile(filepath.Join(other, "example"), []byte("not executable"), 0o644); err != nil { //nolint:gosec
+		t.Fatal(err)
+	}
+
+	path, err := lookupExecutable("example", "relative"+string(os.PathListSeparator)+other+string(os.PathListSeparator)+dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if path != bin {
+		t.Fatalf("expected %q, got %q", bin, path)
+	}
+
+	if _, err = lookupExecutable(bin, ""); err != nil {
+		t.Fatalf("expected direct path to resolve: %v", err)
+	}
+
+	if _, err = lookupExecutable("example", other); err == nil {
+		t.Fatal("expected non-executable file to not resolve")
+	}
+
+	if _, err = lookupExecutable(dir, ""); err == nil {
+		t.Fatal("expected directory to not resolve")
+	}
+}
+
+func TestCommand_PathOverride(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	bin := writeFakeExecutable(t, dir, "example", "#!/bin/sh\n")
+
+	cmd := New().SetExecutable("example").SetEnvVar("PATH", dir).buildCommand(context.Background())
+
+	if cmd.Path != bin {
+		t.Fatalf("expected %q, got %q", bin, cmd.Path)
+	}
+}
@@ -0,0 +1,76 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+//go:build windows
+
+package ytdlp
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// pathSeparators are the characters which, if found in an executable name,
+// indicate that it's a path rather than something to look up in PATH. This
+// includes ":", for drive-relative paths (e.g. "C:yt-dlp.exe").
+const pathSeparators = `/\:`
+
+// defaultPathExt is used when PATHEXT isn't set.
+const defaultPathExt = ".com;.exe;.bat;.cmd"
+
+// pathExts returns the lowercased executable extensions from PATHEXT.
+func pathExts() []string {
+	env := os.Getenv("PATHEXT")
+	if env == "" {
+		env = defaultPathExt
+	}
+
+	var exts []string
+
+	for _, ext := range strings.Split(strings.ToLower(env), ";") {
+		if ext == "" {
+			continue
+		}
+
+		if !strings.HasPrefix(ext, ".") {
+			ext = "." + ext
+		}
+
+		exts = append(exts, ext)
+	}
+
+	return exts
+}
+
+// findExecutable returns path if it exists and has an executable extension (from
+// PATHEXT). If path doesn't have an executable extension, each extension is tried
+// in order.
+func findExecutable(path string) (string, bool) {
+	exts := pathExts()
+
+	if slices.Contains(exts, strings.ToLower(filepath.Ext(path))) && isFile(path) {
+		return path, true
+	}
+
+	for _, ext := range exts {
+		if isFile(path + ext) {
+			return path + ext, true
+		}
+	}
+
+	return "", false
+}
+
+func isFile(path string) bool {
+	stat, err := os.Stat(path)
+	return err == nil && !stat.IsDir()
+}
+
+// envKeyEqual returns true if the provided environment variable keys are equal.
+// Environment variables are case-insensitive on Windows.
+func envKeyEqual(a, b string) bool {
+	return strings.EqualFold(a, b)
+}
@@ -0,0 +1,103 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+//go:build windows
+
+package ytdlp
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLookupExecutable(t *testing.T) {
+	t.Setenv("PATHEXT", ".COM;.EXE;.BAT")
+
+	dir := t.TempDir()
+	other := t.TempDir()
+
+	bin := filepath.Join(dir, "example.exe")
+	if err := os.WriteFile(bin, []byte{}, 0o644); err != nil { //nolint:gosec
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(other, "example.txt"), []byte{}, 0o644); err != nil { //nolint:gosec
+		t.Fatal(err)
+	}
+
+	pathList := `relative;"` + other + `";` + dir
+
+	for _, name := range []string{"example", "example.exe", "EXAMPLE.EXE"} {
+		path, err := lookupExecutable(name, pathList)
+		if err != nil {
+			t.Fatalf("expected %q to resolve: %v", name, err)
+		}
+
+		if !strings.EqualFold(path, bin) {
+			t.Fatalf("expected %q, got %q", bin, path)
+		}
+	}
+
+	if _, err := lookupExecutable(strings.TrimSuffix(bin, ".exe"), ""); err != nil {
+		t.Fatalf("expected direct path without extension to resolve: %v", err)
+	}
+
+	if _, err := lookupExecutable("example.txt", pathList); err == nil {
+		t.Fatal("expected non-executable extension to not resolve")
+	}
+}
+
+func TestLookupExecutable_UNC(t *testing.T) {
+	t.Parallel()
+
+	if _, err := lookupExecutable(`\\nonexistent-host\share\example.exe`, ""); err == nil {
+		t.Fatal("expected missing UNC path to not resolve")
+	}
+
+	// Drive-relative and UNC paths must be checked directly, and not looked up
+	// in PATH.
+	for _, name := range []string{`C:example.exe`, `\\host\share\example.exe`, `dir/example.exe`} {
+		if !strings.ContainsAny(name, pathSeparators) {
+			t.Fatalf("expected %q to be treated as a path", name)
+		}
+	}
+}
+
+func TestLookupEnv(t *testing.T) {
+	t.Parallel()
+
+	env := map[string]string{
+		"=C:":  `C:\somewhere`,
+		"Path": `C:\bin`,
+	}
+
+	v, ok := lookupEnv(env, "PATH")
+	if !ok || v != `C:\bin` {
+		t.Fatalf("expected case-insensitive match, got %q (%v)", v, ok)
+	}
+
+	if _, ok = lookupEnv(env, "=C:"); ok {
+		t.Fatal("expected keys with a leading \"=\" to be ignored")
+	}
+}
+
+func TestCommand_PathOverride(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	bin := filepath.Join(dir, "example.exe")
+	if err := os.WriteFile(bin, []byte{}, 0o644); err != nil { //nolint:gosec
+		t.Fatal(err)
+	}
+
+	cmd := New().SetExecutable("example").SetEnvVar("Path", dir).buildCommand(context.Background())
+
+	if !strings.EqualFold(cmd.Path, bin) {
+		t.Fatalf("expected %q, got %q", bin, cmd.Path)
+	}
+}
@@ -0,0 +1,63 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestMain(m *testing.M) {
+	// Tests shouldn't read from or write to the cache of the user running them
+	// (e.g. cached executables or installed plugins), which would also make the
+	// args passed to yt-dlp depend on the machine.
+	dir, err := os.MkdirTemp("", "go-ytdlp-test-*")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	_ = os.Setenv(CacheDirEnv, dir)
+
+	code := m.Run()
+
+	_ = os.RemoveAll(dir)
+	os.Exit(code)
+}
+
+// fakeProgressPreamble sets $PROGRESS, within fake yt-dlp scripts, to the
+// progress prefix of the command (see [Command.SetProgressPrefix]), so progress
+// lines can be printed with e.g. echo "$PROGRESS"'{"progress":{...}}'.
+const fakeProgressPreamble = `for a in "$@"; do case "$a" in download:progress:*) PROGRESS=${a#download:}; PROGRESS=${PROGRESS%'` + progressFormat + `'};; esac; done` + "\n"
+
+// writeFakeYtdlp writes script (a posix shell script, including the shebang) as
+// a fake yt-dlp executable within a new temporary directory, returning its path.
+// The test is skipped on Windows.
+func writeFakeYtdlp(t *testing.T, script string) string {
+	t.Helper()
+	return writeFakeExecutable(t, t.TempDir(), "yt-dlp", script)
+}
+
+// writeFakeExecutable writes script (a posix shell script, including the
+// shebang) as an executable with the provided name within dir, returning its
+// path. The test is skipped on Windows.
+func writeFakeExecutable(t *testing.T, dir, name, script string) string {
+	t.Helper()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("requires a posix shell")
+	}
+
+	path := filepath.Join(dir, name)
+
+	if err := os.WriteFile(path, []byte(script), 0o755); err != nil { //nolint:gosec
+		t.Fatal(err)
+	}
+
+	return path
+}
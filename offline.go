@@ -0,0 +1,244 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// InstallFromFile installs a locally provided yt-dlp executable (e.g. shipped
+// alongside an application, for fully offline deployments) into the go-ytdlp
+// cache, the same way [Install] would after downloading it, without any network
+// access. The executable must be a release asset for the current platform (e.g.
+// "yt-dlp_linux"), and unless [InstallOptions.DisableChecksum] is set, the
+// release's SHA2-256SUMS and SHA2-256SUMS.sig files must be in the same
+// directory, which are verified the same as when downloading. The version of the
+// executable must match the expected version (see [InstallOptions.Version]),
+// unless [InstallOptions.AllowVersionMismatch] is set. Archives aren't supported.
+//
+// The resolved install is cached process-wide, and used by all commands without
+// a [Resolver] (see [Command.SetResolver]).
+func InstallFromFile(ctx context.Context, path string, opts *InstallOptions) (*ResolvedInstall, error) {
+	return defaultResolver.installFromFile(ctx, path, opts)
+}
+
+// InstallFromFile is the same as [InstallFromFile], using the options of the
+// resolver, and caching the resolved install in the resolver, rather than
+// process-wide.
+func (rs *Resolver) InstallFromFile(ctx context.Context, path string) (*ResolvedInstall, error) {
+	return rs.installFromFile(ctx, path, &rs.opts)
+}
+
+// installFromFile is the implementation of [InstallFromFile] and
+// [Resolver.InstallFromFile], caching the resolved install in the resolver.
+func (rs *Resolver) installFromFile(ctx context.Context, path string, opts *InstallOptions) (*ResolvedInstall, error) {
+	if opts == nil {
+		opts = &InstallOptions{}
+	}
+
+	_, version, err := opts.release()
+	if err != nil {
+		return nil, err
+	}
+
+	dir, err := opts.makeCacheDir()
+	if err != nil {
+		return nil, err
+	}
+
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open yt-dlp executable: %w", err)
+	}
+	defer f.Close()
+
+	var verify func(tmp string) error
+
+	if !opts.DisableChecksum {
+		verify = func(tmp string) error {
+			err := verifyFileChecksum(
+				filepath.Join(filepath.Dir(path), "SHA2-256SUMS"),
+				filepath.Join(filepath.Dir(path), "SHA2-256SUMS.sig"),
+				tmp,
+				filepath.Base(path),
+			)
+			if err != nil {
+				emitInstallEvent(&InstallEvent{Type: InstallEventChecksumFailed, Path: path, Error: err})
+				return fmt.Errorf("unable to verify yt-dlp executable %q: %w", path, err)
+			}
+
+			emitInstallEvent(&InstallEvent{Type: InstallEventChecksumVerified, Path: path})
+			return nil
+		}
+	}
+
+	resolved, err := installExecutable(ctx, f, dir, version, opts.AllowVersionMismatch, verify)
+	if err != nil {
+		return nil, err
+	}
+
+	rs.cache.Store(resolved)
+	return resolved, nil
+}
+
+// installExecutable installs the yt-dlp executable read from src into the cache
+// dir, verifying it with verify (if not nil), and ensuring it's the expected
+// version (unless allowMismatch is true).
+func installExecutable(ctx context.Context, src io.Reader, dir, version string, allowMismatch bool, verify func(tmp string) error) (*ResolvedInstall, error) {
+	_, dest, _ := getDownloadBinary(version)
+	tmp := filepath.Join(dir, dest[0]+".tmp")
+
+	err := copyToFile(tmp, src, 0o750) //nolint:gomnd
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(tmp) //nolint:errcheck
+
+	if verify != nil {
+		if err = verify(tmp); err != nil {
+			return nil, err
+		}
+	}
+
+	resolved := &ResolvedInstall{Executable: tmp}
+
+	err = resolved.getVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if resolved.Version != version && !allowMismatch {
+		return nil, fmt.Errorf("yt-dlp version mismatch: expected %s, got %s", version, resolved.Version)
+	}
+
+	// Named by the actual version, so it isn't mistaken for the expected version.
+	_, dest, _ = getDownloadBinary(resolved.Version)
+	resolved.Executable = filepath.Join(dir, dest[0])
+
+	err = os.Rename(tmp, resolved.Executable)
+	if err != nil {
+		return nil, fmt.Errorf("unable to rename yt-dlp executable: %w", err)
+	}
+
+	resolved.FromCache = true
+
+	emitInstallEvent(&InstallEvent{Type: InstallEventInstalledFromFile, Path: resolved.Executable, Version: resolved.Version})
+
+	return resolved, nil
+}
+
+// InstallToolFromFile installs a locally provided executable of a tool registered
+// with [RegisterTool] into the go-ytdlp cache, the same way [InstallTool] would
+// after downloading it, without any network access. The checksum of the
+// executable is verified against [InstallSpec.SHA256] for the current platform
+// (or any of the builds which run on it, as with [InstallSpec.URLs]), unless
+// [InstallOptions.DisableChecksum] is set. Of the options, only DisableChecksum
+// and CacheDir are supported. Archives aren't supported.
+func InstallToolFromFile(name, path string, opts *InstallOptions) (*ResolvedInstall, error) {
+	t, err := getTool(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts == nil {
+		opts = &InstallOptions{}
+	}
+
+	dir, err := toolCacheDir(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if !opts.DisableChecksum {
+		platforms := toolPlatforms(runtime.GOOS, runtime.GOARCH, isMuslLibc())
+
+		// The file may be any of the builds which run on the current platform.
+		var sums []string
+		for _, p := range platforms {
+			if sum := t.spec.SHA256[p]; sum != "" {
+				sums = append(sums, sum)
+			}
+		}
+
+		if len(sums) == 0 {
+			return nil, fmt.Errorf("unable to install %s: no checksum for %s", name, strings.Join(platforms, ", "))
+		}
+
+		for _, sum := range sums {
+			if err = verifySHA256(path, sum); err == nil {
+				break
+			}
+		}
+
+		if err != nil {
+			emitInstallEvent(&InstallEvent{Type: InstallEventChecksumFailed, Path: path, Error: err})
+			return nil, fmt.Errorf("unable to install %s: %w", name, err)
+		}
+
+		emitInstallEvent(&InstallEvent{Type: InstallEventChecksumVerified, Path: path})
+	}
+
+	if err = os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("unable to create tool cache directory: %w", err)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	bin := filepath.Join(dir, t.cachedName())
+
+	if err = copyFile(path, bin+".tmp", 0o750); err != nil { //nolint:gomnd
+		return nil, err
+	}
+
+	if err = os.Rename(bin+".tmp", bin); err != nil {
+		_ = os.Remove(bin + ".tmp")
+		return nil, fmt.Errorf("unable to rename %s executable: %w", name, err)
+	}
+
+	emitInstallEvent(&InstallEvent{Type: InstallEventInstalledFromFile, Path: bin, Version: t.spec.Version})
+
+	t.resolved = &ResolvedInstall{Executable: bin, Version: t.spec.Version, FromCache: true}
+	return t.resolved, nil
+}
+
+// copyFile copies the file at src to dest, with the provided permissions.
+func copyFile(src, dest string, perms os.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("unable to open %q: %w", src, err)
+	}
+	defer in.Close()
+
+	return copyToFile(dest, in, perms)
+}
+
+// copyToFile writes the contents of src to dest, with the provided permissions.
+func copyToFile(dest string, src io.Reader, perms os.FileMode) error {
+	out, err := os.OpenFile(dest, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, perms)
+	if err != nil {
+		return fmt.Errorf("unable to create go-ytdlp dependent cache file %q: %w", dest, err)
+	}
+	defer out.Close()
+
+	if _, err = io.Copy(out, src); err != nil {
+		return fmt.Errorf("unable to write %q: %w", dest, err)
+	}
+
+	if err = out.Close(); err != nil {
+		return fmt.Errorf("unable to write %q: %w", dest, err)
+	}
+
+	return nil
+}
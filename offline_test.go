@@ -0,0 +1,120 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestResolver_InstallFromFile(t *testing.T) {
+	t.Parallel()
+
+	src := writeFakeYtdlp(t, "#!/bin/sh\necho 2000.01.04\n")
+
+	dir := t.TempDir()
+
+	// No checksum files alongside the executable.
+	if _, err := NewResolver(&InstallOptions{CacheDir: dir, Version: "2000.01.04"}).InstallFromFile(context.Background(), src); err == nil {
+		t.Fatal("expected checksum verification error")
+	}
+
+	if _, err := NewResolver(&InstallOptions{CacheDir: dir, DisableChecksum: true}).InstallFromFile(context.Background(), src); err == nil {
+		t.Fatal("expected version mismatch error")
+	}
+
+	rs := NewResolver(&InstallOptions{CacheDir: dir, Version: "2000.01.04", DisableChecksum: true, DisableDownload: true})
+
+	r, err := rs.InstallFromFile(context.Background(), src)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r.Version != "2000.01.04" || !r.FromCache || r.Executable != filepath.Join(dir, "yt-dlp-2000.01.04") {
+		t.Fatalf("unexpected install: %+v", r)
+	}
+
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Fatalf("expected only the executable in the cache, got %v", entries)
+	}
+
+	// Resolvable without the cached install, e.g. in a later process.
+	if r, err = NewResolver(&InstallOptions{CacheDir: dir, Version: "2000.01.04"}).Resolve(context.Background()); err != nil || !r.FromCache {
+		t.Fatalf("expected install to be resolved from cache: %v: %+v", err, r)
+	}
+}
+
+func TestInstallToolFromFile(t *testing.T) {
+	t.Parallel()
+
+	content := []byte("#!/bin/sh\necho ffmpeg\n")
+	platform := runtime.GOOS + "_" + runtime.GOARCH
+
+	src := filepath.Join(t.TempDir(), "ffmpeg")
+
+	err := os.WriteFile(src, content, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = RegisterTool("test-ffmpeg", InstallSpec{
+		Version: "7.1",
+		SHA256:  map[string]string{platform: fmt.Sprintf("%x", sha256.Sum256(content))},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = RegisterTool("test-ffprobe", InstallSpec{
+		Version: "7.1",
+		SHA256:  map[string]string{platform: fmt.Sprintf("%x", sha256.Sum256([]byte("other")))},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+
+	if _, err = InstallToolFromFile("test-ffprobe", src, &InstallOptions{CacheDir: dir}); err == nil {
+		t.Fatal("expected checksum mismatch error")
+	}
+
+	r, err := InstallToolFromFile("test-ffmpeg", src, &InstallOptions{CacheDir: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r.Version != "7.1" || !r.FromCache || filepath.Dir(r.Executable) != dir {
+		t.Fatalf("unexpected install: %+v", r)
+	}
+
+	if r, err = ResolveTool("test-ffmpeg", &InstallOptions{CacheDir: dir}); err != nil || r.Version != "7.1" {
+		t.Fatalf("expected tool to be resolved: %v: %+v", err, r)
+	}
+
+	// Builds which run on the current platform (e.g. static builds) are accepted,
+	// the same as when downloading.
+	platforms := toolPlatforms(runtime.GOOS, runtime.GOARCH, isMuslLibc())
+
+	err = RegisterTool("test-ffmpeg-static", InstallSpec{
+		Version: "7.1",
+		SHA256: map[string]string{
+			platform:                    fmt.Sprintf("%x", sha256.Sum256([]byte("other"))),
+			platforms[len(platforms)-1]: fmt.Sprintf("%x", sha256.Sum256(content)),
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = InstallToolFromFile("test-ffmpeg-static", src, &InstallOptions{CacheDir: t.TempDir()}); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -4542,3 +4542,2718 @@ var (
 		LongFlags:      []string{"--youtube-skip-hls-manifest", "--no-youtube-include-hls-manifest"},
 	}
 )
+
+// mappings contains the mapping for all options, in the same order as [Options].
+var mappings = []*OptionMapping{
+	{
+		Group:      "General",
+		Method:     "Version",
+		Flag:       "--version",
+		Flags:      []string{"--version"},
+		ConfigPath: "$[?(@.flag==\"--version\")]",
+	},
+	{
+		ID:         "update_self",
+		Group:      "General",
+		Method:     "Update",
+		Flag:       "--update",
+		Flags:      []string{"-U", "--update"},
+		ConfigPath: "$[?(@.flag==\"--update\")]",
+	},
+	{
+		ID:          "update_self",
+		Group:       "General",
+		Method:      "NoUpdate",
+		UnsetMethod: "UnsetUpdate",
+		Flag:        "--no-update",
+		Flags:       []string{"--no-update"},
+		ConfigPath:  "$[?(@.flag==\"--no-update\")]",
+	},
+	{
+		ID:         "update_self",
+		Group:      "General",
+		Method:     "UpdateTo",
+		Flag:       "--update-to",
+		Flags:      []string{"--update-to"},
+		ConfigPath: "$[?(@.flag==\"--update-to\")]",
+	},
+	{
+		ID:          "ignoreerrors",
+		Group:       "General",
+		Method:      "IgnoreErrors",
+		UnsetMethod: "UnsetIgnoreErrors",
+		Flag:        "--ignore-errors",
+		Flags:       []string{"-i", "--ignore-errors"},
+		ConfigPath:  "$[?(@.flag==\"--ignore-errors\")]",
+	},
+	{
+		ID:          "ignoreerrors",
+		Group:       "General",
+		Method:      "NoAbortOnError",
+		UnsetMethod: "UnsetAbortOnError",
+		Flag:        "--no-abort-on-error",
+		Flags:       []string{"--no-abort-on-error"},
+		ConfigPath:  "$[?(@.flag==\"--no-abort-on-error\")]",
+	},
+	{
+		ID:          "ignoreerrors",
+		Group:       "General",
+		Method:      "AbortOnError",
+		UnsetMethod: "UnsetAbortOnError",
+		Flag:        "--abort-on-error",
+		Flags:       []string{"--abort-on-error", "--no-ignore-errors"},
+		ConfigPath:  "$[?(@.flag==\"--abort-on-error\")]",
+	},
+	{
+		ID:         "dump_user_agent",
+		Group:      "General",
+		Method:     "DumpUserAgent",
+		Flag:       "--dump-user-agent",
+		Flags:      []string{"--dump-user-agent"},
+		ConfigPath: "$[?(@.flag==\"--dump-user-agent\")]",
+	},
+	{
+		ID:         "list_extractors",
+		Group:      "General",
+		Method:     "ListExtractors",
+		Flag:       "--list-extractors",
+		Flags:      []string{"--list-extractors"},
+		ConfigPath: "$[?(@.flag==\"--list-extractors\")]",
+	},
+	{
+		ID:         "list_extractor_descriptions",
+		Group:      "General",
+		Method:     "ExtractorDescriptions",
+		Flag:       "--extractor-descriptions",
+		Flags:      []string{"--extractor-descriptions"},
+		ConfigPath: "$[?(@.flag==\"--extractor-descriptions\")]",
+	},
+	{
+		ID:          "allowed_extractors",
+		Group:       "General",
+		Method:      "UseExtractors",
+		UnsetMethod: "UnsetUseExtractors",
+		Flag:        "--use-extractors",
+		Flags:       []string{"--use-extractors", "--ies"},
+		ConfigPath:  "$[?(@.flag==\"--use-extractors\")]",
+	},
+	{
+		ID:          "force_generic_extractor",
+		Group:       "General",
+		Method:      "ForceGenericExtractor",
+		UnsetMethod: "UnsetForceGenericExtractor",
+		Flag:        "--force-generic-extractor",
+		Flags:       []string{"--force-generic-extractor"},
+		ConfigPath:  "$[?(@.flag==\"--force-generic-extractor\")]",
+	},
+	{
+		ID:          "default_search",
+		Group:       "General",
+		Method:      "DefaultSearch",
+		UnsetMethod: "UnsetDefaultSearch",
+		Flag:        "--default-search",
+		Flags:       []string{"--default-search"},
+		ConfigPath:  "$[?(@.flag==\"--default-search\")]",
+	},
+	{
+		ID:          "ignoreconfig",
+		Group:       "General",
+		Method:      "IgnoreConfig",
+		UnsetMethod: "UnsetIgnoreConfig",
+		Flag:        "--ignore-config",
+		Flags:       []string{"--ignore-config", "--no-config"},
+		ConfigPath:  "$[?(@.flag==\"--ignore-config\")]",
+	},
+	{
+		ID:          "config_locations",
+		Group:       "General",
+		Method:      "NoConfigLocations",
+		UnsetMethod: "UnsetConfigLocations",
+		Flag:        "--no-config-locations",
+		Flags:       []string{"--no-config-locations"},
+		ConfigPath:  "$[?(@.flag==\"--no-config-locations\")]",
+	},
+	{
+		ID:          "config_locations",
+		Group:       "General",
+		Method:      "ConfigLocations",
+		UnsetMethod: "UnsetConfigLocations",
+		Flag:        "--config-locations",
+		Flags:       []string{"--config-locations"},
+		ConfigPath:  "$[?(@.flag==\"--config-locations\")]",
+	},
+	{
+		ID:          "plugin_dirs",
+		Group:       "General",
+		Method:      "PluginDirs",
+		UnsetMethod: "UnsetPluginDirs",
+		Flag:        "--plugin-dirs",
+		Flags:       []string{"--plugin-dirs"},
+		ConfigPath:  "$[?(@.flag==\"--plugin-dirs\")]",
+	},
+	{
+		ID:          "extract_flat",
+		Group:       "General",
+		Method:      "FlatPlaylist",
+		UnsetMethod: "UnsetFlatPlaylist",
+		Flag:        "--flat-playlist",
+		Flags:       []string{"--flat-playlist"},
+		ConfigPath:  "$[?(@.flag==\"--flat-playlist\")]",
+	},
+	{
+		ID:          "extract_flat",
+		Group:       "General",
+		Method:      "NoFlatPlaylist",
+		UnsetMethod: "UnsetFlatPlaylist",
+		Flag:        "--no-flat-playlist",
+		Flags:       []string{"--no-flat-playlist"},
+		ConfigPath:  "$[?(@.flag==\"--no-flat-playlist\")]",
+	},
+	{
+		ID:          "live_from_start",
+		Group:       "General",
+		Method:      "LiveFromStart",
+		UnsetMethod: "UnsetLiveFromStart",
+		Flag:        "--live-from-start",
+		Flags:       []string{"--live-from-start"},
+		ConfigPath:  "$[?(@.flag==\"--live-from-start\")]",
+	},
+	{
+		ID:          "live_from_start",
+		Group:       "General",
+		Method:      "NoLiveFromStart",
+		UnsetMethod: "UnsetLiveFromStart",
+		Flag:        "--no-live-from-start",
+		Flags:       []string{"--no-live-from-start"},
+		ConfigPath:  "$[?(@.flag==\"--no-live-from-start\")]",
+	},
+	{
+		ID:          "wait_for_video",
+		Group:       "General",
+		Method:      "WaitForVideo",
+		UnsetMethod: "UnsetWaitForVideo",
+		Flag:        "--wait-for-video",
+		Flags:       []string{"--wait-for-video"},
+		ConfigPath:  "$[?(@.flag==\"--wait-for-video\")]",
+	},
+	{
+		ID:          "wait_for_video",
+		Group:       "General",
+		Method:      "NoWaitForVideo",
+		UnsetMethod: "UnsetWaitForVideo",
+		Flag:        "--no-wait-for-video",
+		Flags:       []string{"--no-wait-for-video"},
+		ConfigPath:  "$[?(@.flag==\"--no-wait-for-video\")]",
+	},
+	{
+		ID:          "mark_watched",
+		Group:       "General",
+		Method:      "MarkWatched",
+		UnsetMethod: "UnsetMarkWatched",
+		Flag:        "--mark-watched",
+		Flags:       []string{"--mark-watched"},
+		ConfigPath:  "$[?(@.flag==\"--mark-watched\")]",
+	},
+	{
+		ID:          "mark_watched",
+		Group:       "General",
+		Method:      "NoMarkWatched",
+		UnsetMethod: "UnsetMarkWatched",
+		Flag:        "--no-mark-watched",
+		Flags:       []string{"--no-mark-watched"},
+		ConfigPath:  "$[?(@.flag==\"--no-mark-watched\")]",
+	},
+	{
+		ID:          "color",
+		Group:       "General",
+		Method:      "NoColors",
+		UnsetMethod: "UnsetColors",
+		Flag:        "--no-colors",
+		Flags:       []string{"--no-colors", "--no-colours"},
+		ConfigPath:  "$[?(@.flag==\"--no-colors\")]",
+	},
+	{
+		ID:          "color",
+		Group:       "General",
+		Method:      "Color",
+		UnsetMethod: "UnsetColor",
+		Flag:        "--color",
+		Flags:       []string{"--color"},
+		ConfigPath:  "$[?(@.flag==\"--color\")]",
+	},
+	{
+		ID:          "compat_opts",
+		Group:       "General",
+		Method:      "CompatOptions",
+		UnsetMethod: "UnsetCompatOptions",
+		Flag:        "--compat-options",
+		Flags:       []string{"--compat-options"},
+		ConfigPath:  "$[?(@.flag==\"--compat-options\")]",
+	},
+	{
+		ID:          "proxy",
+		Group:       "Network",
+		Method:      "Proxy",
+		UnsetMethod: "UnsetProxy",
+		Flag:        "--proxy",
+		Flags:       []string{"--proxy"},
+		ConfigPath:  "$[?(@.flag==\"--proxy\")]",
+	},
+	{
+		ID:          "socket_timeout",
+		Group:       "Network",
+		Method:      "SocketTimeout",
+		UnsetMethod: "UnsetSocketTimeout",
+		Flag:        "--socket-timeout",
+		Flags:       []string{"--socket-timeout"},
+		ConfigPath:  "$[?(@.flag==\"--socket-timeout\")]",
+	},
+	{
+		ID:          "source_address",
+		Group:       "Network",
+		Method:      "SourceAddress",
+		UnsetMethod: "UnsetSourceAddress",
+		Flag:        "--source-address",
+		Flags:       []string{"--source-address"},
+		ConfigPath:  "$[?(@.flag==\"--source-address\")]",
+	},
+	{
+		ID:          "impersonate",
+		Group:       "Network",
+		Method:      "Impersonate",
+		UnsetMethod: "UnsetImpersonate",
+		Flag:        "--impersonate",
+		Flags:       []string{"--impersonate"},
+		ConfigPath:  "$[?(@.flag==\"--impersonate\")]",
+	},
+	{
+		ID:          "list_impersonate_targets",
+		Group:       "Network",
+		Method:      "ListImpersonateTargets",
+		UnsetMethod: "UnsetListImpersonateTargets",
+		Flag:        "--list-impersonate-targets",
+		Flags:       []string{"--list-impersonate-targets"},
+		ConfigPath:  "$[?(@.flag==\"--list-impersonate-targets\")]",
+	},
+	{
+		ID:          "source_address",
+		Group:       "Network",
+		Method:      "ForceIPv4",
+		UnsetMethod: "UnsetForceIPv4",
+		Flag:        "--force-ipv4",
+		Flags:       []string{"-4", "--force-ipv4"},
+		ConfigPath:  "$[?(@.flag==\"--force-ipv4\")]",
+	},
+	{
+		ID:          "source_address",
+		Group:       "Network",
+		Method:      "ForceIPv6",
+		UnsetMethod: "UnsetForceIPv6",
+		Flag:        "--force-ipv6",
+		Flags:       []string{"-6", "--force-ipv6"},
+		ConfigPath:  "$[?(@.flag==\"--force-ipv6\")]",
+	},
+	{
+		ID:          "enable_file_urls",
+		Group:       "Network",
+		Method:      "EnableFileURLs",
+		UnsetMethod: "UnsetEnableFileURLs",
+		Flag:        "--enable-file-urls",
+		Flags:       []string{"--enable-file-urls"},
+		ConfigPath:  "$[?(@.flag==\"--enable-file-urls\")]",
+	},
+	{
+		ID:          "geo_verification_proxy",
+		Group:       "Geo-restriction",
+		Method:      "GeoVerificationProxy",
+		UnsetMethod: "UnsetGeoVerificationProxy",
+		Flag:        "--geo-verification-proxy",
+		Flags:       []string{"--geo-verification-proxy"},
+		ConfigPath:  "$[?(@.flag==\"--geo-verification-proxy\")]",
+	},
+	{
+		ID:          "cn_verification_proxy",
+		Group:       "Geo-restriction",
+		Method:      "CNVerificationProxy",
+		UnsetMethod: "UnsetCNVerificationProxy",
+		Flag:        "--cn-verification-proxy",
+		Flags:       []string{"--cn-verification-proxy"},
+		ConfigPath:  "$[?(@.flag==\"--cn-verification-proxy\")]",
+	},
+	{
+		ID:          "geo_bypass",
+		Group:       "Geo-restriction",
+		Method:      "XFF",
+		UnsetMethod: "UnsetXFF",
+		Flag:        "--xff",
+		Flags:       []string{"--xff"},
+		ConfigPath:  "$[?(@.flag==\"--xff\")]",
+	},
+	{
+		ID:          "geo_bypass",
+		Group:       "Geo-restriction",
+		Method:      "GeoBypass",
+		UnsetMethod: "UnsetGeoBypass",
+		Flag:        "--geo-bypass",
+		Flags:       []string{"--geo-bypass"},
+		ConfigPath:  "$[?(@.flag==\"--geo-bypass\")]",
+	},
+	{
+		ID:          "geo_bypass",
+		Group:       "Geo-restriction",
+		Method:      "NoGeoBypass",
+		UnsetMethod: "UnsetGeoBypass",
+		Flag:        "--no-geo-bypass",
+		Flags:       []string{"--no-geo-bypass"},
+		ConfigPath:  "$[?(@.flag==\"--no-geo-bypass\")]",
+	},
+	{
+		ID:          "geo_bypass",
+		Group:       "Geo-restriction",
+		Method:      "GeoBypassCountry",
+		UnsetMethod: "UnsetGeoBypassCountry",
+		Flag:        "--geo-bypass-country",
+		Flags:       []string{"--geo-bypass-country"},
+		ConfigPath:  "$[?(@.flag==\"--geo-bypass-country\")]",
+	},
+	{
+		ID:          "geo_bypass",
+		Group:       "Geo-restriction",
+		Method:      "GeoBypassIPBlock",
+		UnsetMethod: "UnsetGeoBypassIPBlock",
+		Flag:        "--geo-bypass-ip-block",
+		Flags:       []string{"--geo-bypass-ip-block"},
+		ConfigPath:  "$[?(@.flag==\"--geo-bypass-ip-block\")]",
+	},
+	{
+		ID:          "playliststart",
+		Group:       "Video Selection",
+		Method:      "PlaylistStart",
+		UnsetMethod: "UnsetPlaylistStart",
+		Flag:        "--playlist-start",
+		Flags:       []string{"--playlist-start"},
+		ConfigPath:  "$[?(@.flag==\"--playlist-start\")]",
+	},
+	{
+		ID:          "playlistend",
+		Group:       "Video Selection",
+		Method:      "PlaylistEnd",
+		UnsetMethod: "UnsetPlaylistEnd",
+		Flag:        "--playlist-end",
+		Flags:       []string{"--playlist-end"},
+		ConfigPath:  "$[?(@.flag==\"--playlist-end\")]",
+	},
+	{
+		ID:          "playlist_items",
+		Group:       "Video Selection",
+		Method:      "PlaylistItems",
+		UnsetMethod: "UnsetPlaylistItems",
+		Flag:        "--playlist-items",
+		Flags:       []string{"-I", "--playlist-items"},
+		ConfigPath:  "$[?(@.flag==\"--playlist-items\")]",
+	},
+	{
+		ID:          "matchtitle",
+		Group:       "Video Selection",
+		Method:      "MatchTitle",
+		UnsetMethod: "UnsetMatchTitle",
+		Flag:        "--match-title",
+		Flags:       []string{"--match-title"},
+		ConfigPath:  "$[?(@.flag==\"--match-title\")]",
+	},
+	{
+		ID:          "rejecttitle",
+		Group:       "Video Selection",
+		Method:      "RejectTitle",
+		UnsetMethod: "UnsetRejectTitle",
+		Flag:        "--reject-title",
+		Flags:       []string{"--reject-title"},
+		ConfigPath:  "$[?(@.flag==\"--reject-title\")]",
+	},
+	{
+		ID:          "min_filesize",
+		Group:       "Video Selection",
+		Method:      "MinFileSize",
+		UnsetMethod: "UnsetMinFileSize",
+		Flag:        "--min-filesize",
+		Flags:       []string{"--min-filesize"},
+		ConfigPath:  "$[?(@.flag==\"--min-filesize\")]",
+	},
+	{
+		ID:          "max_filesize",
+		Group:       "Video Selection",
+		Method:      "MaxFileSize",
+		UnsetMethod: "UnsetMaxFileSize",
+		Flag:        "--max-filesize",
+		Flags:       []string{"--max-filesize"},
+		ConfigPath:  "$[?(@.flag==\"--max-filesize\")]",
+	},
+	{
+		ID:          "date",
+		Group:       "Video Selection",
+		Method:      "Date",
+		UnsetMethod: "UnsetDate",
+		Flag:        "--date",
+		Flags:       []string{"--date"},
+		ConfigPath:  "$[?(@.flag==\"--date\")]",
+	},
+	{
+		ID:          "datebefore",
+		Group:       "Video Selection",
+		Method:      "DateBefore",
+		UnsetMethod: "UnsetDateBefore",
+		Flag:        "--datebefore",
+		Flags:       []string{"--datebefore"},
+		ConfigPath:  "$[?(@.flag==\"--datebefore\")]",
+	},
+	{
+		ID:          "dateafter",
+		Group:       "Video Selection",
+		Method:      "DateAfter",
+		UnsetMethod: "UnsetDateAfter",
+		Flag:        "--dateafter",
+		Flags:       []string{"--dateafter"},
+		ConfigPath:  "$[?(@.flag==\"--dateafter\")]",
+	},
+	{
+		ID:          "min_views",
+		Group:       "Video Selection",
+		Method:      "MinViews",
+		UnsetMethod: "UnsetMinViews",
+		Flag:        "--min-views",
+		Flags:       []string{"--min-views"},
+		ConfigPath:  "$[?(@.flag==\"--min-views\")]",
+	},
+	{
+		ID:          "max_views",
+		Group:       "Video Selection",
+		Method:      "MaxViews",
+		UnsetMethod: "UnsetMaxViews",
+		Flag:        "--max-views",
+		Flags:       []string{"--max-views"},
+		ConfigPath:  "$[?(@.flag==\"--max-views\")]",
+	},
+	{
+		ID:          "match_filter",
+		Group:       "Video Selection",
+		Method:      "MatchFilters",
+		UnsetMethod: "UnsetMatchFilters",
+		Flag:        "--match-filters",
+		Flags:       []string{"--match-filters"},
+		ConfigPath:  "$[?(@.flag==\"--match-filters\")]",
+	},
+	{
+		ID:          "match_filter",
+		Group:       "Video Selection",
+		Method:      "NoMatchFilters",
+		UnsetMethod: "UnsetMatchFilters",
+		Flag:        "--no-match-filters",
+		Flags:       []string{"--no-match-filters"},
+		ConfigPath:  "$[?(@.flag==\"--no-match-filters\")]",
+	},
+	{
+		ID:          "breaking_match_filter",
+		Group:       "Video Selection",
+		Method:      "BreakMatchFilters",
+		UnsetMethod: "UnsetBreakMatchFilters",
+		Flag:        "--break-match-filters",
+		Flags:       []string{"--break-match-filters"},
+		ConfigPath:  "$[?(@.flag==\"--break-match-filters\")]",
+	},
+	{
+		ID:          "breaking_match_filter",
+		Group:       "Video Selection",
+		Method:      "NoBreakMatchFilters",
+		UnsetMethod: "UnsetBreakMatchFilters",
+		Flag:        "--no-break-match-filters",
+		Flags:       []string{"--no-break-match-filters"},
+		ConfigPath:  "$[?(@.flag==\"--no-break-match-filters\")]",
+	},
+	{
+		ID:          "noplaylist",
+		Group:       "Video Selection",
+		Method:      "NoPlaylist",
+		UnsetMethod: "UnsetPlaylist",
+		Flag:        "--no-playlist",
+		Flags:       []string{"--no-playlist"},
+		ConfigPath:  "$[?(@.flag==\"--no-playlist\")]",
+	},
+	{
+		ID:          "noplaylist",
+		Group:       "Video Selection",
+		Method:      "YesPlaylist",
+		UnsetMethod: "UnsetPlaylist",
+		Flag:        "--yes-playlist",
+		Flags:       []string{"--yes-playlist"},
+		ConfigPath:  "$[?(@.flag==\"--yes-playlist\")]",
+	},
+	{
+		ID:          "age_limit",
+		Group:       "Video Selection",
+		Method:      "AgeLimit",
+		UnsetMethod: "UnsetAgeLimit",
+		Flag:        "--age-limit",
+		Flags:       []string{"--age-limit"},
+		ConfigPath:  "$[?(@.flag==\"--age-limit\")]",
+	},
+	{
+		ID:          "download_archive",
+		Group:       "Video Selection",
+		Method:      "DownloadArchive",
+		UnsetMethod: "UnsetDownloadArchive",
+		Flag:        "--download-archive",
+		Flags:       []string{"--download-archive"},
+		ConfigPath:  "$[?(@.flag==\"--download-archive\")]",
+	},
+	{
+		ID:          "download_archive",
+		Group:       "Video Selection",
+		Method:      "NoDownloadArchive",
+		UnsetMethod: "UnsetDownloadArchive",
+		Flag:        "--no-download-archive",
+		Flags:       []string{"--no-download-archive"},
+		ConfigPath:  "$[?(@.flag==\"--no-download-archive\")]",
+	},
+	{
+		ID:          "max_downloads",
+		Group:       "Video Selection",
+		Method:      "MaxDownloads",
+		UnsetMethod: "UnsetMaxDownloads",
+		Flag:        "--max-downloads",
+		Flags:       []string{"--max-downloads"},
+		ConfigPath:  "$[?(@.flag==\"--max-downloads\")]",
+	},
+	{
+		ID:          "break_on_existing",
+		Group:       "Video Selection",
+		Method:      "BreakOnExisting",
+		UnsetMethod: "UnsetBreakOnExisting",
+		Flag:        "--break-on-existing",
+		Flags:       []string{"--break-on-existing"},
+		ConfigPath:  "$[?(@.flag==\"--break-on-existing\")]",
+	},
+	{
+		ID:          "break_on_existing",
+		Group:       "Video Selection",
+		Method:      "NoBreakOnExisting",
+		UnsetMethod: "UnsetBreakOnExisting",
+		Flag:        "--no-break-on-existing",
+		Flags:       []string{"--no-break-on-existing"},
+		ConfigPath:  "$[?(@.flag==\"--no-break-on-existing\")]",
+	},
+	{
+		ID:          "break_on_reject",
+		Group:       "Video Selection",
+		Method:      "BreakOnReject",
+		UnsetMethod: "UnsetBreakOnReject",
+		Flag:        "--break-on-reject",
+		Flags:       []string{"--break-on-reject"},
+		ConfigPath:  "$[?(@.flag==\"--break-on-reject\")]",
+	},
+	{
+		ID:          "break_per_url",
+		Group:       "Video Selection",
+		Method:      "BreakPerInput",
+		UnsetMethod: "UnsetBreakPerInput",
+		Flag:        "--break-per-input",
+		Flags:       []string{"--break-per-input"},
+		ConfigPath:  "$[?(@.flag==\"--break-per-input\")]",
+	},
+	{
+		ID:          "break_per_url",
+		Group:       "Video Selection",
+		Method:      "NoBreakPerInput",
+		UnsetMethod: "UnsetBreakPerInput",
+		Flag:        "--no-break-per-input",
+		Flags:       []string{"--no-break-per-input"},
+		ConfigPath:  "$[?(@.flag==\"--no-break-per-input\")]",
+	},
+	{
+		ID:          "skip_playlist_after_errors",
+		Group:       "Video Selection",
+		Method:      "SkipPlaylistAfterErrors",
+		UnsetMethod: "UnsetSkipPlaylistAfterErrors",
+		Flag:        "--skip-playlist-after-errors",
+		Flags:       []string{"--skip-playlist-after-errors"},
+		ConfigPath:  "$[?(@.flag==\"--skip-playlist-after-errors\")]",
+	},
+	{
+		ID:          "include_ads",
+		Group:       "Video Selection",
+		Method:      "IncludeAds",
+		UnsetMethod: "UnsetIncludeAds",
+		Flag:        "--include-ads",
+		Flags:       []string{"--include-ads"},
+		ConfigPath:  "$[?(@.flag==\"--include-ads\")]",
+	},
+	{
+		ID:          "include_ads",
+		Group:       "Video Selection",
+		Method:      "NoIncludeAds",
+		UnsetMethod: "UnsetIncludeAds",
+		Flag:        "--no-include-ads",
+		Flags:       []string{"--no-include-ads"},
+		ConfigPath:  "$[?(@.flag==\"--no-include-ads\")]",
+	},
+	{
+		ID:          "concurrent_fragment_downloads",
+		Group:       "Download",
+		Method:      "ConcurrentFragments",
+		UnsetMethod: "UnsetConcurrentFragments",
+		Flag:        "--concurrent-fragments",
+		Flags:       []string{"-N", "--concurrent-fragments"},
+		ConfigPath:  "$[?(@.flag==\"--concurrent-fragments\")]",
+	},
+	{
+		ID:          "ratelimit",
+		Group:       "Download",
+		Method:      "LimitRate",
+		UnsetMethod: "UnsetLimitRate",
+		Flag:        "--limit-rate",
+		Flags:       []string{"-r", "--limit-rate", "--rate-limit"},
+		ConfigPath:  "$[?(@.flag==\"--limit-rate\")]",
+	},
+	{
+		ID:          "throttledratelimit",
+		Group:       "Download",
+		Method:      "ThrottledRate",
+		UnsetMethod: "UnsetThrottledRate",
+		Flag:        "--throttled-rate",
+		Flags:       []string{"--throttled-rate"},
+		ConfigPath:  "$[?(@.flag==\"--throttled-rate\")]",
+	},
+	{
+		ID:          "retries",
+		Group:       "Download",
+		Method:      "Retries",
+		UnsetMethod: "UnsetRetries",
+		Flag:        "--retries",
+		Flags:       []string{"-R", "--retries"},
+		ConfigPath:  "$[?(@.flag==\"--retries\")]",
+	},
+	{
+		ID:          "file_access_retries",
+		Group:       "Download",
+		Method:      "FileAccessRetries",
+		UnsetMethod: "UnsetFileAccessRetries",
+		Flag:        "--file-access-retries",
+		Flags:       []string{"--file-access-retries"},
+		ConfigPath:  "$[?(@.flag==\"--file-access-retries\")]",
+	},
+	{
+		ID:          "fragment_retries",
+		Group:       "Download",
+		Method:      "FragmentRetries",
+		UnsetMethod: "UnsetFragmentRetries",
+		Flag:        "--fragment-retries",
+		Flags:       []string{"--fragment-retries"},
+		ConfigPath:  "$[?(@.flag==\"--fragment-retries\")]",
+	},
+	{
+		ID:          "retry_sleep",
+		Group:       "Download",
+		Method:      "RetrySleep",
+		UnsetMethod: "UnsetRetrySleep",
+		Flag:        "--retry-sleep",
+		Flags:       []string{"--retry-sleep"},
+		ConfigPath:  "$[?(@.flag==\"--retry-sleep\")]",
+	},
+	{
+		ID:          "skip_unavailable_fragments",
+		Group:       "Download",
+		Method:      "SkipUnavailableFragments",
+		UnsetMethod: "UnsetSkipUnavailableFragments",
+		Flag:        "--skip-unavailable-fragments",
+		Flags:       []string{"--skip-unavailable-fragments", "--no-abort-on-unavailable-fragments"},
+		ConfigPath:  "$[?(@.flag==\"--skip-unavailable-fragments\")]",
+	},
+	{
+		ID:          "skip_unavailable_fragments",
+		Group:       "Download",
+		Method:      "AbortOnUnavailableFragments",
+		UnsetMethod: "UnsetAbortOnUnavailableFragments",
+		Flag:        "--abort-on-unavailable-fragments",
+		Flags:       []string{"--abort-on-unavailable-fragments", "--no-skip-unavailable-fragments"},
+		ConfigPath:  "$[?(@.flag==\"--abort-on-unavailable-fragments\")]",
+	},
+	{
+		ID:          "keep_fragments",
+		Group:       "Download",
+		Method:      "KeepFragments",
+		UnsetMethod: "UnsetKeepFragments",
+		Flag:        "--keep-fragments",
+		Flags:       []string{"--keep-fragments"},
+		ConfigPath:  "$[?(@.flag==\"--keep-fragments\")]",
+	},
+	{
+		ID:          "keep_fragments",
+		Group:       "Download",
+		Method:      "NoKeepFragments",
+		UnsetMethod: "UnsetKeepFragments",
+		Flag:        "--no-keep-fragments",
+		Flags:       []string{"--no-keep-fragments"},
+		ConfigPath:  "$[?(@.flag==\"--no-keep-fragments\")]",
+	},
+	{
+		ID:          "buffersize",
+		Group:       "Download",
+		Method:      "BufferSize",
+		UnsetMethod: "UnsetBufferSize",
+		Flag:        "--buffer-size",
+		Flags:       []string{"--buffer-size"},
+		ConfigPath:  "$[?(@.flag==\"--buffer-size\")]",
+	},
+	{
+		ID:          "noresizebuffer",
+		Group:       "Download",
+		Method:      "ResizeBuffer",
+		UnsetMethod: "UnsetResizeBuffer",
+		Flag:        "--resize-buffer",
+		Flags:       []string{"--resize-buffer"},
+		ConfigPath:  "$[?(@.flag==\"--resize-buffer\")]",
+	},
+	{
+		ID:          "noresizebuffer",
+		Group:       "Download",
+		Method:      "NoResizeBuffer",
+		UnsetMethod: "UnsetResizeBuffer",
+		Flag:        "--no-resize-buffer",
+		Flags:       []string{"--no-resize-buffer"},
+		ConfigPath:  "$[?(@.flag==\"--no-resize-buffer\")]",
+	},
+	{
+		ID:          "http_chunk_size",
+		Group:       "Download",
+		Method:      "HTTPChunkSize",
+		UnsetMethod: "UnsetHTTPChunkSize",
+		Flag:        "--http-chunk-size",
+		Flags:       []string{"--http-chunk-size"},
+		ConfigPath:  "$[?(@.flag==\"--http-chunk-size\")]",
+	},
+	{
+		ID:          "playlist_reverse",
+		Group:       "Download",
+		Method:      "PlaylistReverse",
+		UnsetMethod: "UnsetPlaylistReverse",
+		Flag:        "--playlist-reverse",
+		Flags:       []string{"--playlist-reverse"},
+		ConfigPath:  "$[?(@.flag==\"--playlist-reverse\")]",
+	},
+	{
+		ID:          "playlist_reverse",
+		Group:       "Download",
+		Method:      "NoPlaylistReverse",
+		UnsetMethod: "UnsetPlaylistReverse",
+		Flag:        "--no-playlist-reverse",
+		Flags:       []string{"--no-playlist-reverse"},
+		ConfigPath:  "$[?(@.flag==\"--no-playlist-reverse\")]",
+	},
+	{
+		ID:          "playlist_random",
+		Group:       "Download",
+		Method:      "PlaylistRandom",
+		UnsetMethod: "UnsetPlaylistRandom",
+		Flag:        "--playlist-random",
+		Flags:       []string{"--playlist-random"},
+		ConfigPath:  "$[?(@.flag==\"--playlist-random\")]",
+	},
+	{
+		ID:          "lazy_playlist",
+		Group:       "Download",
+		Method:      "LazyPlaylist",
+		UnsetMethod: "UnsetLazyPlaylist",
+		Flag:        "--lazy-playlist",
+		Flags:       []string{"--lazy-playlist"},
+		ConfigPath:  "$[?(@.flag==\"--lazy-playlist\")]",
+	},
+	{
+		ID:          "lazy_playlist",
+		Group:       "Download",
+		Method:      "NoLazyPlaylist",
+		UnsetMethod: "UnsetLazyPlaylist",
+		Flag:        "--no-lazy-playlist",
+		Flags:       []string{"--no-lazy-playlist"},
+		ConfigPath:  "$[?(@.flag==\"--no-lazy-playlist\")]",
+	},
+	{
+		ID:          "xattr_set_filesize",
+		Group:       "Download",
+		Method:      "XattrSetFileSize",
+		UnsetMethod: "UnsetXattrSetFileSize",
+		Flag:        "--xattr-set-filesize",
+		Flags:       []string{"--xattr-set-filesize"},
+		ConfigPath:  "$[?(@.flag==\"--xattr-set-filesize\")]",
+	},
+	{
+		ID:          "hls_prefer_native",
+		Group:       "Download",
+		Method:      "HLSPreferNative",
+		UnsetMethod: "UnsetHLSPreferNative",
+		Flag:        "--hls-prefer-native",
+		Flags:       []string{"--hls-prefer-native"},
+		ConfigPath:  "$[?(@.flag==\"--hls-prefer-native\")]",
+	},
+	{
+		ID:          "hls_prefer_native",
+		Group:       "Download",
+		Method:      "HLSPreferFFmpeg",
+		UnsetMethod: "UnsetHLSPreferFFmpeg",
+		Flag:        "--hls-prefer-ffmpeg",
+		Flags:       []string{"--hls-prefer-ffmpeg"},
+		ConfigPath:  "$[?(@.flag==\"--hls-prefer-ffmpeg\")]",
+	},
+	{
+		ID:          "hls_use_mpegts",
+		Group:       "Download",
+		Method:      "HLSUseMPEGTS",
+		UnsetMethod: "UnsetHLSUseMPEGTS",
+		Flag:        "--hls-use-mpegts",
+		Flags:       []string{"--hls-use-mpegts"},
+		ConfigPath:  "$[?(@.flag==\"--hls-use-mpegts\")]",
+	},
+	{
+		ID:          "hls_use_mpegts",
+		Group:       "Download",
+		Method:      "NoHLSUseMPEGTS",
+		UnsetMethod: "UnsetHLSUseMPEGTS",
+		Flag:        "--no-hls-use-mpegts",
+		Flags:       []string{"--no-hls-use-mpegts"},
+		ConfigPath:  "$[?(@.flag==\"--no-hls-use-mpegts\")]",
+	},
+	{
+		ID:          "download_ranges",
+		Group:       "Download",
+		Method:      "DownloadSections",
+		UnsetMethod: "UnsetDownloadSections",
+		Flag:        "--download-sections",
+		Flags:       []string{"--download-sections"},
+		ConfigPath:  "$[?(@.flag==\"--download-sections\")]",
+	},
+	{
+		ID:          "external_downloader",
+		Group:       "Download",
+		Method:      "Downloader",
+		UnsetMethod: "UnsetDownloader",
+		Flag:        "--downloader",
+		Flags:       []string{"--downloader", "--external-downloader"},
+		ConfigPath:  "$[?(@.flag==\"--downloader\")]",
+	},
+	{
+		ID:          "external_downloader_args",
+		Group:       "Download",
+		Method:      "DownloaderArgs",
+		UnsetMethod: "UnsetDownloaderArgs",
+		Flag:        "--downloader-args",
+		Flags:       []string{"--downloader-args", "--external-downloader-args"},
+		ConfigPath:  "$[?(@.flag==\"--downloader-args\")]",
+	},
+	{
+		ID:          "batchfile",
+		Group:       "Filesystem",
+		Method:      "BatchFile",
+		UnsetMethod: "UnsetBatchFile",
+		Flag:        "--batch-file",
+		Flags:       []string{"-a", "--batch-file"},
+		ConfigPath:  "$[?(@.flag==\"--batch-file\")]",
+	},
+	{
+		ID:          "batchfile",
+		Group:       "Filesystem",
+		Method:      "NoBatchFile",
+		UnsetMethod: "UnsetBatchFile",
+		Flag:        "--no-batch-file",
+		Flags:       []string{"--no-batch-file"},
+		ConfigPath:  "$[?(@.flag==\"--no-batch-file\")]",
+	},
+	{
+		ID:          "useid",
+		Group:       "Filesystem",
+		Method:      "ID",
+		UnsetMethod: "UnsetID",
+		Flag:        "--id",
+		Flags:       []string{"--id"},
+		ConfigPath:  "$[?(@.flag==\"--id\")]",
+	},
+	{
+		ID:          "paths",
+		Group:       "Filesystem",
+		Method:      "Paths",
+		UnsetMethod: "UnsetPaths",
+		Flag:        "--paths",
+		Flags:       []string{"-P", "--paths"},
+		ConfigPath:  "$[?(@.flag==\"--paths\")]",
+	},
+	{
+		ID:          "outtmpl",
+		Group:       "Filesystem",
+		Method:      "Output",
+		UnsetMethod: "UnsetOutput",
+		Flag:        "--output",
+		Flags:       []string{"-o", "--output"},
+		ConfigPath:  "$[?(@.flag==\"--output\")]",
+	},
+	{
+		ID:          "outtmpl_na_placeholder",
+		Group:       "Filesystem",
+		Method:      "OutputNaPlaceholder",
+		UnsetMethod: "UnsetOutputNaPlaceholder",
+		Flag:        "--output-na-placeholder",
+		Flags:       []string{"--output-na-placeholder"},
+		ConfigPath:  "$[?(@.flag==\"--output-na-placeholder\")]",
+	},
+	{
+		ID:          "autonumber_size",
+		Group:       "Filesystem",
+		Method:      "AutoNumberSize",
+		UnsetMethod: "UnsetAutoNumberSize",
+		Flag:        "--autonumber-size",
+		Flags:       []string{"--autonumber-size"},
+		ConfigPath:  "$[?(@.flag==\"--autonumber-size\")]",
+	},
+	{
+		ID:          "autonumber_start",
+		Group:       "Filesystem",
+		Method:      "AutoNumberStart",
+		UnsetMethod: "UnsetAutoNumberStart",
+		Flag:        "--autonumber-start",
+		Flags:       []string{"--autonumber-start"},
+		ConfigPath:  "$[?(@.flag==\"--autonumber-start\")]",
+	},
+	{
+		ID:          "restrictfilenames",
+		Group:       "Filesystem",
+		Method:      "RestrictFilenames",
+		UnsetMethod: "UnsetRestrictFilenames",
+		Flag:        "--restrict-filenames",
+		Flags:       []string{"--restrict-filenames"},
+		ConfigPath:  "$[?(@.flag==\"--restrict-filenames\")]",
+	},
+	{
+		ID:          "restrictfilenames",
+		Group:       "Filesystem",
+		Method:      "NoRestrictFilenames",
+		UnsetMethod: "UnsetRestrictFilenames",
+		Flag:        "--no-restrict-filenames",
+		Flags:       []string{"--no-restrict-filenames"},
+		ConfigPath:  "$[?(@.flag==\"--no-restrict-filenames\")]",
+	},
+	{
+		ID:          "windowsfilenames",
+		Group:       "Filesystem",
+		Method:      "WindowsFilenames",
+		UnsetMethod: "UnsetWindowsFilenames",
+		Flag:        "--windows-filenames",
+		Flags:       []string{"--windows-filenames"},
+		ConfigPath:  "$[?(@.flag==\"--windows-filenames\")]",
+	},
+	{
+		ID:          "windowsfilenames",
+		Group:       "Filesystem",
+		Method:      "NoWindowsFilenames",
+		UnsetMethod: "UnsetWindowsFilenames",
+		Flag:        "--no-windows-filenames",
+		Flags:       []string{"--no-windows-filenames"},
+		ConfigPath:  "$[?(@.flag==\"--no-windows-filenames\")]",
+	},
+	{
+		ID:          "trim_file_name",
+		Group:       "Filesystem",
+		Method:      "TrimFilenames",
+		UnsetMethod: "UnsetTrimFilenames",
+		Flag:        "--trim-filenames",
+		Flags:       []string{"--trim-filenames", "--trim-file-names"},
+		ConfigPath:  "$[?(@.flag==\"--trim-filenames\")]",
+	},
+	{
+		ID:          "overwrites",
+		Group:       "Filesystem",
+		Method:      "NoOverwrites",
+		UnsetMethod: "UnsetOverwrites",
+		Flag:        "--no-overwrites",
+		Flags:       []string{"-w", "--no-overwrites"},
+		ConfigPath:  "$[?(@.flag==\"--no-overwrites\")]",
+	},
+	{
+		ID:          "overwrites",
+		Group:       "Filesystem",
+		Method:      "ForceOverwrites",
+		UnsetMethod: "UnsetForceOverwrites",
+		Flag:        "--force-overwrites",
+		Flags:       []string{"--force-overwrites", "--yes-overwrites"},
+		ConfigPath:  "$[?(@.flag==\"--force-overwrites\")]",
+	},
+	{
+		ID:          "overwrites",
+		Group:       "Filesystem",
+		Method:      "NoForceOverwrites",
+		UnsetMethod: "UnsetForceOverwrites",
+		Flag:        "--no-force-overwrites",
+		Flags:       []string{"--no-force-overwrites"},
+		ConfigPath:  "$[?(@.flag==\"--no-force-overwrites\")]",
+	},
+	{
+		ID:          "continue_dl",
+		Group:       "Filesystem",
+		Method:      "Continue",
+		UnsetMethod: "UnsetContinue",
+		Flag:        "--continue",
+		Flags:       []string{"-c", "--continue"},
+		ConfigPath:  "$[?(@.flag==\"--continue\")]",
+	},
+	{
+		ID:          "continue_dl",
+		Group:       "Filesystem",
+		Method:      "NoContinue",
+		UnsetMethod: "UnsetContinue",
+		Flag:        "--no-continue",
+		Flags:       []string{"--no-continue"},
+		ConfigPath:  "$[?(@.flag==\"--no-continue\")]",
+	},
+	{
+		ID:          "nopart",
+		Group:       "Filesystem",
+		Method:      "Part",
+		UnsetMethod: "UnsetPart",
+		Flag:        "--part",
+		Flags:       []string{"--part"},
+		ConfigPath:  "$[?(@.flag==\"--part\")]",
+	},
+	{
+		ID:          "nopart",
+		Group:       "Filesystem",
+		Method:      "NoPart",
+		UnsetMethod: "UnsetPart",
+		Flag:        "--no-part",
+		Flags:       []string{"--no-part"},
+		ConfigPath:  "$[?(@.flag==\"--no-part\")]",
+	},
+	{
+		ID:          "updatetime",
+		Group:       "Filesystem",
+		Method:      "Mtime",
+		UnsetMethod: "UnsetMtime",
+		Flag:        "--mtime",
+		Flags:       []string{"--mtime"},
+		ConfigPath:  "$[?(@.flag==\"--mtime\")]",
+	},
+	{
+		ID:          "updatetime",
+		Group:       "Filesystem",
+		Method:      "NoMtime",
+		UnsetMethod: "UnsetMtime",
+		Flag:        "--no-mtime",
+		Flags:       []string{"--no-mtime"},
+		ConfigPath:  "$[?(@.flag==\"--no-mtime\")]",
+	},
+	{
+		ID:          "writedescription",
+		Group:       "Filesystem",
+		Method:      "WriteDescription",
+		UnsetMethod: "UnsetWriteDescription",
+		Flag:        "--write-description",
+		Flags:       []string{"--write-description"},
+		ConfigPath:  "$[?(@.flag==\"--write-description\")]",
+	},
+	{
+		ID:          "writedescription",
+		Group:       "Filesystem",
+		Method:      "NoWriteDescription",
+		UnsetMethod: "UnsetWriteDescription",
+		Flag:        "--no-write-description",
+		Flags:       []string{"--no-write-description"},
+		ConfigPath:  "$[?(@.flag==\"--no-write-description\")]",
+	},
+	{
+		ID:          "writeinfojson",
+		Group:       "Filesystem",
+		Method:      "WriteInfoJSON",
+		UnsetMethod: "UnsetWriteInfoJSON",
+		Flag:        "--write-info-json",
+		Flags:       []string{"--write-info-json"},
+		ConfigPath:  "$[?(@.flag==\"--write-info-json\")]",
+	},
+	{
+		ID:          "writeinfojson",
+		Group:       "Filesystem",
+		Method:      "NoWriteInfoJSON",
+		UnsetMethod: "UnsetWriteInfoJSON",
+		Flag:        "--no-write-info-json",
+		Flags:       []string{"--no-write-info-json"},
+		ConfigPath:  "$[?(@.flag==\"--no-write-info-json\")]",
+	},
+	{
+		ID:          "writeannotations",
+		Group:       "Filesystem",
+		Method:      "WriteAnnotations",
+		UnsetMethod: "UnsetWriteAnnotations",
+		Flag:        "--write-annotations",
+		Flags:       []string{"--write-annotations"},
+		ConfigPath:  "$[?(@.flag==\"--write-annotations\")]",
+	},
+	{
+		ID:          "writeannotations",
+		Group:       "Filesystem",
+		Method:      "NoWriteAnnotations",
+		UnsetMethod: "UnsetWriteAnnotations",
+		Flag:        "--no-write-annotations",
+		Flags:       []string{"--no-write-annotations"},
+		ConfigPath:  "$[?(@.flag==\"--no-write-annotations\")]",
+	},
+	{
+		ID:          "allow_playlist_files",
+		Group:       "Filesystem",
+		Method:      "WritePlaylistMetafiles",
+		UnsetMethod: "UnsetWritePlaylistMetafiles",
+		Flag:        "--write-playlist-metafiles",
+		Flags:       []string{"--write-playlist-metafiles"},
+		ConfigPath:  "$[?(@.flag==\"--write-playlist-metafiles\")]",
+	},
+	{
+		ID:          "allow_playlist_files",
+		Group:       "Filesystem",
+		Method:      "NoWritePlaylistMetafiles",
+		UnsetMethod: "UnsetWritePlaylistMetafiles",
+		Flag:        "--no-write-playlist-metafiles",
+		Flags:       []string{"--no-write-playlist-metafiles"},
+		ConfigPath:  "$[?(@.flag==\"--no-write-playlist-metafiles\")]",
+	},
+	{
+		ID:          "clean_infojson",
+		Group:       "Filesystem",
+		Method:      "CleanInfoJSON",
+		UnsetMethod: "UnsetCleanInfoJSON",
+		Flag:        "--clean-info-json",
+		Flags:       []string{"--clean-info-json", "--clean-infojson"},
+		ConfigPath:  "$[?(@.flag==\"--clean-info-json\")]",
+	},
+	{
+		ID:          "clean_infojson",
+		Group:       "Filesystem",
+		Method:      "NoCleanInfoJSON",
+		UnsetMethod: "UnsetCleanInfoJSON",
+		Flag:        "--no-clean-info-json",
+		Flags:       []string{"--no-clean-info-json", "--no-clean-infojson"},
+		ConfigPath:  "$[?(@.flag==\"--no-clean-info-json\")]",
+	},
+	{
+		ID:          "getcomments",
+		Group:       "Filesystem",
+		Method:      "WriteComments",
+		UnsetMethod: "UnsetWriteComments",
+		Flag:        "--write-comments",
+		Flags:       []string{"--write-comments", "--get-comments"},
+		ConfigPath:  "$[?(@.flag==\"--write-comments\")]",
+	},
+	{
+		ID:          "getcomments",
+		Group:       "Filesystem",
+		Method:      "NoWriteComments",
+		UnsetMethod: "UnsetWriteComments",
+		Flag:        "--no-write-comments",
+		Flags:       []string{"--no-write-comments", "--no-get-comments"},
+		ConfigPath:  "$[?(@.flag==\"--no-write-comments\")]",
+	},
+	{
+		ID:          "load_info_filename",
+		Group:       "Filesystem",
+		Method:      "LoadInfoJSON",
+		UnsetMethod: "UnsetLoadInfoJSON",
+		Flag:        "--load-info-json",
+		Flags:       []string{"--load-info-json", "--load-info"},
+		ConfigPath:  "$[?(@.flag==\"--load-info-json\")]",
+	},
+	{
+		ID:          "cookiefile",
+		Group:       "Filesystem",
+		Method:      "Cookies",
+		UnsetMethod: "UnsetCookies",
+		Flag:        "--cookies",
+		Flags:       []string{"--cookies"},
+		ConfigPath:  "$[?(@.flag==\"--cookies\")]",
+	},
+	{
+		ID:          "cookiefile",
+		Group:       "Filesystem",
+		Method:      "NoCookies",
+		UnsetMethod: "UnsetCookies",
+		Flag:        "--no-cookies",
+		Flags:       []string{"--no-cookies"},
+		ConfigPath:  "$[?(@.flag==\"--no-cookies\")]",
+	},
+	{
+		ID:          "cookiesfrombrowser",
+		Group:       "Filesystem",
+		Method:      "CookiesFromBrowser",
+		UnsetMethod: "UnsetCookiesFromBrowser",
+		Flag:        "--cookies-from-browser",
+		Flags:       []string{"--cookies-from-browser"},
+		ConfigPath:  "$[?(@.flag==\"--cookies-from-browser\")]",
+	},
+	{
+		ID:          "cookiesfrombrowser",
+		Group:       "Filesystem",
+		Method:      "NoCookiesFromBrowser",
+		UnsetMethod: "UnsetCookiesFromBrowser",
+		Flag:        "--no-cookies-from-browser",
+		Flags:       []string{"--no-cookies-from-browser"},
+		ConfigPath:  "$[?(@.flag==\"--no-cookies-from-browser\")]",
+	},
+	{
+		ID:          "cachedir",
+		Group:       "Filesystem",
+		Method:      "CacheDir",
+		UnsetMethod: "UnsetCacheDir",
+		Flag:        "--cache-dir",
+		Flags:       []string{"--cache-dir"},
+		ConfigPath:  "$[?(@.flag==\"--cache-dir\")]",
+	},
+	{
+		ID:          "cachedir",
+		Group:       "Filesystem",
+		Method:      "NoCacheDir",
+		UnsetMethod: "UnsetCacheDir",
+		Flag:        "--no-cache-dir",
+		Flags:       []string{"--no-cache-dir"},
+		ConfigPath:  "$[?(@.flag==\"--no-cache-dir\")]",
+	},
+	{
+		ID:          "rm_cachedir",
+		Group:       "Filesystem",
+		Method:      "RmCacheDir",
+		UnsetMethod: "UnsetRmCacheDir",
+		Flag:        "--rm-cache-dir",
+		Flags:       []string{"--rm-cache-dir"},
+		ConfigPath:  "$[?(@.flag==\"--rm-cache-dir\")]",
+	},
+	{
+		ID:          "writethumbnail",
+		Group:       "Thumbnail",
+		Method:      "WriteThumbnail",
+		UnsetMethod: "UnsetWriteThumbnail",
+		Flag:        "--write-thumbnail",
+		Flags:       []string{"--write-thumbnail"},
+		ConfigPath:  "$[?(@.flag==\"--write-thumbnail\")]",
+	},
+	{
+		ID:          "writethumbnail",
+		Group:       "Thumbnail",
+		Method:      "NoWriteThumbnail",
+		UnsetMethod: "UnsetWriteThumbnail",
+		Flag:        "--no-write-thumbnail",
+		Flags:       []string{"--no-write-thumbnail"},
+		ConfigPath:  "$[?(@.flag==\"--no-write-thumbnail\")]",
+	},
+	{
+		ID:          "writethumbnail",
+		Group:       "Thumbnail",
+		Method:      "WriteAllThumbnails",
+		UnsetMethod: "UnsetWriteAllThumbnails",
+		Flag:        "--write-all-thumbnails",
+		Flags:       []string{"--write-all-thumbnails"},
+		ConfigPath:  "$[?(@.flag==\"--write-all-thumbnails\")]",
+	},
+	{
+		ID:          "list_thumbnails",
+		Group:       "Thumbnail",
+		Method:      "ListThumbnails",
+		UnsetMethod: "UnsetListThumbnails",
+		Flag:        "--list-thumbnails",
+		Flags:       []string{"--list-thumbnails"},
+		ConfigPath:  "$[?(@.flag==\"--list-thumbnails\")]",
+	},
+	{
+		ID:          "writelink",
+		Group:       "Internet Shortcut",
+		Method:      "WriteLink",
+		UnsetMethod: "UnsetWriteLink",
+		Flag:        "--write-link",
+		Flags:       []string{"--write-link"},
+		ConfigPath:  "$[?(@.flag==\"--write-link\")]",
+	},
+	{
+		ID:          "writeurllink",
+		Group:       "Internet Shortcut",
+		Method:      "WriteURLLink",
+		UnsetMethod: "UnsetWriteURLLink",
+		Flag:        "--write-url-link",
+		Flags:       []string{"--write-url-link"},
+		ConfigPath:  "$[?(@.flag==\"--write-url-link\")]",
+	},
+	{
+		ID:          "writewebloclink",
+		Group:       "Internet Shortcut",
+		Method:      "WriteWeblocLink",
+		UnsetMethod: "UnsetWriteWeblocLink",
+		Flag:        "--write-webloc-link",
+		Flags:       []string{"--write-webloc-link"},
+		ConfigPath:  "$[?(@.flag==\"--write-webloc-link\")]",
+	},
+	{
+		ID:          "writedesktoplink",
+		Group:       "Internet Shortcut",
+		Method:      "WriteDesktopLink",
+		UnsetMethod: "UnsetWriteDesktopLink",
+		Flag:        "--write-desktop-link",
+		Flags:       []string{"--write-desktop-link"},
+		ConfigPath:  "$[?(@.flag==\"--write-desktop-link\")]",
+	},
+	{
+		ID:          "quiet",
+		Group:       "Verbosity Simulation",
+		Method:      "Quiet",
+		UnsetMethod: "UnsetQuiet",
+		Flag:        "--quiet",
+		Flags:       []string{"-q", "--quiet"},
+		ConfigPath:  "$[?(@.flag==\"--quiet\")]",
+	},
+	{
+		ID:          "quiet",
+		Group:       "Verbosity Simulation",
+		Method:      "NoQuiet",
+		UnsetMethod: "UnsetQuiet",
+		Flag:        "--no-quiet",
+		Flags:       []string{"--no-quiet"},
+		ConfigPath:  "$[?(@.flag==\"--no-quiet\")]",
+	},
+	{
+		ID:          "no_warnings",
+		Group:       "Verbosity Simulation",
+		Method:      "NoWarnings",
+		UnsetMethod: "UnsetWarnings",
+		Flag:        "--no-warnings",
+		Flags:       []string{"--no-warnings"},
+		ConfigPath:  "$[?(@.flag==\"--no-warnings\")]",
+	},
+	{
+		ID:          "simulate",
+		Group:       "Verbosity Simulation",
+		Method:      "Simulate",
+		UnsetMethod: "UnsetSimulate",
+		Flag:        "--simulate",
+		Flags:       []string{"-s", "--simulate"},
+		ConfigPath:  "$[?(@.flag==\"--simulate\")]",
+	},
+	{
+		ID:          "simulate",
+		Group:       "Verbosity Simulation",
+		Method:      "NoSimulate",
+		UnsetMethod: "UnsetSimulate",
+		Flag:        "--no-simulate",
+		Flags:       []string{"--no-simulate"},
+		ConfigPath:  "$[?(@.flag==\"--no-simulate\")]",
+	},
+	{
+		ID:          "ignore_no_formats_error",
+		Group:       "Verbosity Simulation",
+		Method:      "IgnoreNoFormatsError",
+		UnsetMethod: "UnsetIgnoreNoFormatsError",
+		Flag:        "--ignore-no-formats-error",
+		Flags:       []string{"--ignore-no-formats-error"},
+		ConfigPath:  "$[?(@.flag==\"--ignore-no-formats-error\")]",
+	},
+	{
+		ID:          "ignore_no_formats_error",
+		Group:       "Verbosity Simulation",
+		Method:      "NoIgnoreNoFormatsError",
+		UnsetMethod: "UnsetIgnoreNoFormatsError",
+		Flag:        "--no-ignore-no-formats-error",
+		Flags:       []string{"--no-ignore-no-formats-error"},
+		ConfigPath:  "$[?(@.flag==\"--no-ignore-no-formats-error\")]",
+	},
+	{
+		ID:          "skip_download",
+		Group:       "Verbosity Simulation",
+		Method:      "SkipDownload",
+		UnsetMethod: "UnsetSkipDownload",
+		Flag:        "--skip-download",
+		Flags:       []string{"--skip-download", "--no-download"},
+		ConfigPath:  "$[?(@.flag==\"--skip-download\")]",
+	},
+	{
+		ID:          "forceprint",
+		Group:       "Verbosity Simulation",
+		Method:      "Print",
+		UnsetMethod: "UnsetPrint",
+		Flag:        "--print",
+		Flags:       []string{"-O", "--print"},
+		ConfigPath:  "$[?(@.flag==\"--print\")]",
+	},
+	{
+		ID:          "print_to_file",
+		Group:       "Verbosity Simulation",
+		Method:      "PrintToFile",
+		UnsetMethod: "UnsetPrintToFile",
+		Flag:        "--print-to-file",
+		Flags:       []string{"--print-to-file"},
+		ConfigPath:  "$[?(@.flag==\"--print-to-file\")]",
+	},
+	{
+		ID:          "geturl",
+		Group:       "Verbosity Simulation",
+		Method:      "GetURL",
+		UnsetMethod: "UnsetGetURL",
+		Flag:        "--get-url",
+		Flags:       []string{"-g", "--get-url"},
+		ConfigPath:  "$[?(@.flag==\"--get-url\")]",
+	},
+	{
+		ID:          "gettitle",
+		Group:       "Verbosity Simulation",
+		Method:      "GetTitle",
+		UnsetMethod: "UnsetGetTitle",
+		Flag:        "--get-title",
+		Flags:       []string{"-e", "--get-title"},
+		ConfigPath:  "$[?(@.flag==\"--get-title\")]",
+	},
+	{
+		ID:          "getid",
+		Group:       "Verbosity Simulation",
+		Method:      "GetID",
+		UnsetMethod: "UnsetGetID",
+		Flag:        "--get-id",
+		Flags:       []string{"--get-id"},
+		ConfigPath:  "$[?(@.flag==\"--get-id\")]",
+	},
+	{
+		ID:          "getthumbnail",
+		Group:       "Verbosity Simulation",
+		Method:      "GetThumbnail",
+		UnsetMethod: "UnsetGetThumbnail",
+		Flag:        "--get-thumbnail",
+		Flags:       []string{"--get-thumbnail"},
+		ConfigPath:  "$[?(@.flag==\"--get-thumbnail\")]",
+	},
+	{
+		ID:          "getdescription",
+		Group:       "Verbosity Simulation",
+		Method:      "GetDescription",
+		UnsetMethod: "UnsetGetDescription",
+		Flag:        "--get-description",
+		Flags:       []string{"--get-description"},
+		ConfigPath:  "$[?(@.flag==\"--get-description\")]",
+	},
+	{
+		ID:          "getduration",
+		Group:       "Verbosity Simulation",
+		Method:      "GetDuration",
+		UnsetMethod: "UnsetGetDuration",
+		Flag:        "--get-duration",
+		Flags:       []string{"--get-duration"},
+		ConfigPath:  "$[?(@.flag==\"--get-duration\")]",
+	},
+	{
+		ID:          "getfilename",
+		Group:       "Verbosity Simulation",
+		Method:      "GetFilename",
+		UnsetMethod: "UnsetGetFilename",
+		Flag:        "--get-filename",
+		Flags:       []string{"--get-filename"},
+		ConfigPath:  "$[?(@.flag==\"--get-filename\")]",
+	},
+	{
+		ID:          "getformat",
+		Group:       "Verbosity Simulation",
+		Method:      "GetFormat",
+		UnsetMethod: "UnsetGetFormat",
+		Flag:        "--get-format",
+		Flags:       []string{"--get-format"},
+		ConfigPath:  "$[?(@.flag==\"--get-format\")]",
+	},
+	{
+		ID:          "dumpjson",
+		Group:       "Verbosity Simulation",
+		Method:      "DumpJSON",
+		UnsetMethod: "UnsetDumpJSON",
+		Flag:        "--dump-json",
+		Flags:       []string{"-j", "--dump-json"},
+		ConfigPath:  "$[?(@.flag==\"--dump-json\")]",
+	},
+	{
+		ID:          "dump_single_json",
+		Group:       "Verbosity Simulation",
+		Method:      "DumpSingleJSON",
+		UnsetMethod: "UnsetDumpSingleJSON",
+		Flag:        "--dump-single-json",
+		Flags:       []string{"-J", "--dump-single-json"},
+		ConfigPath:  "$[?(@.flag==\"--dump-single-json\")]",
+	},
+	{
+		ID:          "print_json",
+		Group:       "Verbosity Simulation",
+		Method:      "PrintJSON",
+		UnsetMethod: "UnsetPrintJSON",
+		Flag:        "--print-json",
+		Flags:       []string{"--print-json"},
+		ConfigPath:  "$[?(@.flag==\"--print-json\")]",
+	},
+	{
+		ID:          "force_write_download_archive",
+		Group:       "Verbosity Simulation",
+		Method:      "ForceWriteArchive",
+		UnsetMethod: "UnsetForceWriteArchive",
+		Flag:        "--force-write-archive",
+		Flags:       []string{"--force-write-archive", "--force-write-download-archive", "--force-download-archive"},
+		ConfigPath:  "$[?(@.flag==\"--force-write-archive\")]",
+	},
+	{
+		ID:          "progress_with_newline",
+		Group:       "Verbosity Simulation",
+		Method:      "Newline",
+		UnsetMethod: "UnsetNewline",
+		Flag:        "--newline",
+		Flags:       []string{"--newline"},
+		ConfigPath:  "$[?(@.flag==\"--newline\")]",
+	},
+	{
+		ID:          "noprogress",
+		Group:       "Verbosity Simulation",
+		Method:      "NoProgress",
+		UnsetMethod: "UnsetProgress",
+		Flag:        "--no-progress",
+		Flags:       []string{"--no-progress"},
+		ConfigPath:  "$[?(@.flag==\"--no-progress\")]",
+	},
+	{
+		ID:          "noprogress",
+		Group:       "Verbosity Simulation",
+		Method:      "Progress",
+		UnsetMethod: "UnsetProgress",
+		Flag:        "--progress",
+		Flags:       []string{"--progress"},
+		ConfigPath:  "$[?(@.flag==\"--progress\")]",
+	},
+	{
+		ID:          "consoletitle",
+		Group:       "Verbosity Simulation",
+		Method:      "ConsoleTitle",
+		UnsetMethod: "UnsetConsoleTitle",
+		Flag:        "--console-title",
+		Flags:       []string{"--console-title"},
+		ConfigPath:  "$[?(@.flag==\"--console-title\")]",
+	},
+	{
+		ID:          "progress_template",
+		Group:       "Verbosity Simulation",
+		Method:      "ProgressTemplate",
+		UnsetMethod: "UnsetProgressTemplate",
+		Flag:        "--progress-template",
+		Flags:       []string{"--progress-template"},
+		ConfigPath:  "$[?(@.flag==\"--progress-template\")]",
+	},
+	{
+		ID:          "progress_delta",
+		Group:       "Verbosity Simulation",
+		Method:      "ProgressDelta",
+		UnsetMethod: "UnsetProgressDelta",
+		Flag:        "--progress-delta",
+		Flags:       []string{"--progress-delta"},
+		ConfigPath:  "$[?(@.flag==\"--progress-delta\")]",
+	},
+	{
+		ID:          "verbose",
+		Group:       "Verbosity Simulation",
+		Method:      "Verbose",
+		UnsetMethod: "UnsetVerbose",
+		Flag:        "--verbose",
+		Flags:       []string{"-v", "--verbose"},
+		ConfigPath:  "$[?(@.flag==\"--verbose\")]",
+	},
+	{
+		ID:          "dump_intermediate_pages",
+		Group:       "Verbosity Simulation",
+		Method:      "DumpPages",
+		UnsetMethod: "UnsetDumpPages",
+		Flag:        "--dump-pages",
+		Flags:       []string{"--dump-pages", "--dump-intermediate-pages"},
+		ConfigPath:  "$[?(@.flag==\"--dump-pages\")]",
+	},
+	{
+		ID:          "write_pages",
+		Group:       "Verbosity Simulation",
+		Method:      "WritePages",
+		UnsetMethod: "UnsetWritePages",
+		Flag:        "--write-pages",
+		Flags:       []string{"--write-pages"},
+		ConfigPath:  "$[?(@.flag==\"--write-pages\")]",
+	},
+	{
+		ID:          "debug_printtraffic",
+		Group:       "Verbosity Simulation",
+		Method:      "PrintTraffic",
+		UnsetMethod: "UnsetPrintTraffic",
+		Flag:        "--print-traffic",
+		Flags:       []string{"--print-traffic", "--dump-headers"},
+		ConfigPath:  "$[?(@.flag==\"--print-traffic\")]",
+	},
+	{
+		ID:          "call_home",
+		Group:       "Verbosity Simulation",
+		Method:      "CallHome",
+		UnsetMethod: "UnsetCallHome",
+		Flag:        "--call-home",
+		Flags:       []string{"-C", "--call-home"},
+		ConfigPath:  "$[?(@.flag==\"--call-home\")]",
+	},
+	{
+		ID:          "call_home",
+		Group:       "Verbosity Simulation",
+		Method:      "NoCallHome",
+		UnsetMethod: "UnsetCallHome",
+		Flag:        "--no-call-home",
+		Flags:       []string{"--no-call-home"},
+		ConfigPath:  "$[?(@.flag==\"--no-call-home\")]",
+	},
+	{
+		ID:          "encoding",
+		Group:       "Workarounds",
+		Method:      "Encoding",
+		UnsetMethod: "UnsetEncoding",
+		Flag:        "--encoding",
+		Flags:       []string{"--encoding"},
+		ConfigPath:  "$[?(@.flag==\"--encoding\")]",
+	},
+	{
+		ID:          "legacy_server_connect",
+		Group:       "Workarounds",
+		Method:      "LegacyServerConnect",
+		UnsetMethod: "UnsetLegacyServerConnect",
+		Flag:        "--legacy-server-connect",
+		Flags:       []string{"--legacy-server-connect"},
+		ConfigPath:  "$[?(@.flag==\"--legacy-server-connect\")]",
+	},
+	{
+		ID:          "no_check_certificate",
+		Group:       "Workarounds",
+		Method:      "NoCheckCertificates",
+		UnsetMethod: "UnsetCheckCertificates",
+		Flag:        "--no-check-certificates",
+		Flags:       []string{"--no-check-certificates"},
+		ConfigPath:  "$[?(@.flag==\"--no-check-certificates\")]",
+	},
+	{
+		ID:          "prefer_insecure",
+		Group:       "Workarounds",
+		Method:      "PreferInsecure",
+		UnsetMethod: "UnsetPreferInsecure",
+		Flag:        "--prefer-insecure",
+		Flags:       []string{"--prefer-insecure", "--prefer-unsecure"},
+		ConfigPath:  "$[?(@.flag==\"--prefer-insecure\")]",
+	},
+	{
+		ID:          "user_agent",
+		Group:       "Workarounds",
+		Method:      "UserAgent",
+		UnsetMethod: "UnsetUserAgent",
+		Flag:        "--user-agent",
+		Flags:       []string{"--user-agent"},
+		ConfigPath:  "$[?(@.flag==\"--user-agent\")]",
+	},
+	{
+		ID:          "referer",
+		Group:       "Workarounds",
+		Method:      "Referer",
+		UnsetMethod: "UnsetReferer",
+		Flag:        "--referer",
+		Flags:       []string{"--referer"},
+		ConfigPath:  "$[?(@.flag==\"--referer\")]",
+	},
+	{
+		ID:          "headers",
+		Group:       "Workarounds",
+		Method:      "AddHeaders",
+		UnsetMethod: "UnsetAddHeaders",
+		Flag:        "--add-headers",
+		Flags:       []string{"--add-headers"},
+		ConfigPath:  "$[?(@.flag==\"--add-headers\")]",
+	},
+	{
+		ID:          "bidi_workaround",
+		Group:       "Workarounds",
+		Method:      "BidiWorkaround",
+		UnsetMethod: "UnsetBidiWorkaround",
+		Flag:        "--bidi-workaround",
+		Flags:       []string{"--bidi-workaround"},
+		ConfigPath:  "$[?(@.flag==\"--bidi-workaround\")]",
+	},
+	{
+		ID:          "sleep_interval_requests",
+		Group:       "Workarounds",
+		Method:      "SleepRequests",
+		UnsetMethod: "UnsetSleepRequests",
+		Flag:        "--sleep-requests",
+		Flags:       []string{"--sleep-requests"},
+		ConfigPath:  "$[?(@.flag==\"--sleep-requests\")]",
+	},
+	{
+		ID:          "sleep_interval",
+		Group:       "Workarounds",
+		Method:      "SleepInterval",
+		UnsetMethod: "UnsetSleepInterval",
+		Flag:        "--sleep-interval",
+		Flags:       []string{"--sleep-interval", "--min-sleep-interval"},
+		ConfigPath:  "$[?(@.flag==\"--sleep-interval\")]",
+	},
+	{
+		ID:          "max_sleep_interval",
+		Group:       "Workarounds",
+		Method:      "MaxSleepInterval",
+		UnsetMethod: "UnsetMaxSleepInterval",
+		Flag:        "--max-sleep-interval",
+		Flags:       []string{"--max-sleep-interval"},
+		ConfigPath:  "$[?(@.flag==\"--max-sleep-interval\")]",
+	},
+	{
+		ID:          "sleep_interval_subtitles",
+		Group:       "Workarounds",
+		Method:      "SleepSubtitles",
+		UnsetMethod: "UnsetSleepSubtitles",
+		Flag:        "--sleep-subtitles",
+		Flags:       []string{"--sleep-subtitles"},
+		ConfigPath:  "$[?(@.flag==\"--sleep-subtitles\")]",
+	},
+	{
+		ID:          "format",
+		Group:       "Video Format",
+		Method:      "Format",
+		UnsetMethod: "UnsetFormat",
+		Flag:        "--format",
+		Flags:       []string{"-f", "--format"},
+		ConfigPath:  "$[?(@.flag==\"--format\")]",
+	},
+	{
+		ID:          "format_sort",
+		Group:       "Video Format",
+		Method:      "FormatSort",
+		UnsetMethod: "UnsetFormatSort",
+		Flag:        "--format-sort",
+		Flags:       []string{"-S", "--format-sort"},
+		ConfigPath:  "$[?(@.flag==\"--format-sort\")]",
+	},
+	{
+		ID:          "format_sort_force",
+		Group:       "Video Format",
+		Method:      "FormatSortForce",
+		UnsetMethod: "UnsetFormatSortForce",
+		Flag:        "--format-sort-force",
+		Flags:       []string{"--format-sort-force", "--S-force"},
+		ConfigPath:  "$[?(@.flag==\"--format-sort-force\")]",
+	},
+	{
+		ID:          "format_sort_force",
+		Group:       "Video Format",
+		Method:      "NoFormatSortForce",
+		UnsetMethod: "UnsetFormatSortForce",
+		Flag:        "--no-format-sort-force",
+		Flags:       []string{"--no-format-sort-force"},
+		ConfigPath:  "$[?(@.flag==\"--no-format-sort-force\")]",
+	},
+	{
+		ID:          "allow_multiple_video_streams",
+		Group:       "Video Format",
+		Method:      "VideoMultistreams",
+		UnsetMethod: "UnsetVideoMultistreams",
+		Flag:        "--video-multistreams",
+		Flags:       []string{"--video-multistreams"},
+		ConfigPath:  "$[?(@.flag==\"--video-multistreams\")]",
+	},
+	{
+		ID:          "allow_multiple_video_streams",
+		Group:       "Video Format",
+		Method:      "NoVideoMultistreams",
+		UnsetMethod: "UnsetVideoMultistreams",
+		Flag:        "--no-video-multistreams",
+		Flags:       []string{"--no-video-multistreams"},
+		ConfigPath:  "$[?(@.flag==\"--no-video-multistreams\")]",
+	},
+	{
+		ID:          "allow_multiple_audio_streams",
+		Group:       "Video Format",
+		Method:      "AudioMultistreams",
+		UnsetMethod: "UnsetAudioMultistreams",
+		Flag:        "--audio-multistreams",
+		Flags:       []string{"--audio-multistreams"},
+		ConfigPath:  "$[?(@.flag==\"--audio-multistreams\")]",
+	},
+	{
+		ID:          "allow_multiple_audio_streams",
+		Group:       "Video Format",
+		Method:      "NoAudioMultistreams",
+		UnsetMethod: "UnsetAudioMultistreams",
+		Flag:        "--no-audio-multistreams",
+		Flags:       []string{"--no-audio-multistreams"},
+		ConfigPath:  "$[?(@.flag==\"--no-audio-multistreams\")]",
+	},
+	{
+		ID:          "format",
+		Group:       "Video Format",
+		Method:      "AllFormats",
+		UnsetMethod: "UnsetAllFormats",
+		Flag:        "--all-formats",
+		Flags:       []string{"--all-formats"},
+		ConfigPath:  "$[?(@.flag==\"--all-formats\")]",
+	},
+	{
+		ID:          "prefer_free_formats",
+		Group:       "Video Format",
+		Method:      "PreferFreeFormats",
+		UnsetMethod: "UnsetPreferFreeFormats",
+		Flag:        "--prefer-free-formats",
+		Flags:       []string{"--prefer-free-formats"},
+		ConfigPath:  "$[?(@.flag==\"--prefer-free-formats\")]",
+	},
+	{
+		ID:          "prefer_free_formats",
+		Group:       "Video Format",
+		Method:      "NoPreferFreeFormats",
+		UnsetMethod: "UnsetPreferFreeFormats",
+		Flag:        "--no-prefer-free-formats",
+		Flags:       []string{"--no-prefer-free-formats"},
+		ConfigPath:  "$[?(@.flag==\"--no-prefer-free-formats\")]",
+	},
+	{
+		ID:          "check_formats",
+		Group:       "Video Format",
+		Method:      "CheckFormats",
+		UnsetMethod: "UnsetCheckFormats",
+		Flag:        "--check-formats",
+		Flags:       []string{"--check-formats"},
+		ConfigPath:  "$[?(@.flag==\"--check-formats\")]",
+	},
+	{
+		ID:          "check_formats",
+		Group:       "Video Format",
+		Method:      "CheckAllFormats",
+		UnsetMethod: "UnsetCheckAllFormats",
+		Flag:        "--check-all-formats",
+		Flags:       []string{"--check-all-formats"},
+		ConfigPath:  "$[?(@.flag==\"--check-all-formats\")]",
+	},
+	{
+		ID:          "check_formats",
+		Group:       "Video Format",
+		Method:      "NoCheckFormats",
+		UnsetMethod: "UnsetCheckFormats",
+		Flag:        "--no-check-formats",
+		Flags:       []string{"--no-check-formats"},
+		ConfigPath:  "$[?(@.flag==\"--no-check-formats\")]",
+	},
+	{
+		ID:          "listformats",
+		Group:       "Video Format",
+		Method:      "ListFormats",
+		UnsetMethod: "UnsetListFormats",
+		Flag:        "--list-formats",
+		Flags:       []string{"-F", "--list-formats"},
+		ConfigPath:  "$[?(@.flag==\"--list-formats\")]",
+	},
+	{
+		ID:          "listformats_table",
+		Group:       "Video Format",
+		Method:      "ListFormatsAsTable",
+		UnsetMethod: "UnsetListFormatsAsTable",
+		Flag:        "--list-formats-as-table",
+		Flags:       []string{"--list-formats-as-table"},
+		ConfigPath:  "$[?(@.flag==\"--list-formats-as-table\")]",
+	},
+	{
+		ID:          "listformats_table",
+		Group:       "Video Format",
+		Method:      "ListFormatsOld",
+		UnsetMethod: "UnsetListFormatsOld",
+		Flag:        "--list-formats-old",
+		Flags:       []string{"--list-formats-old", "--no-list-formats-as-table"},
+		ConfigPath:  "$[?(@.flag==\"--list-formats-old\")]",
+	},
+	{
+		ID:          "merge_output_format",
+		Group:       "Video Format",
+		Method:      "MergeOutputFormat",
+		UnsetMethod: "UnsetMergeOutputFormat",
+		Flag:        "--merge-output-format",
+		Flags:       []string{"--merge-output-format"},
+		ConfigPath:  "$[?(@.flag==\"--merge-output-format\")]",
+	},
+	{
+		ID:          "writesubtitles",
+		Group:       "Subtitle",
+		Method:      "WriteSubs",
+		UnsetMethod: "UnsetWriteSubs",
+		Flag:        "--write-subs",
+		Flags:       []string{"--write-subs", "--write-srt"},
+		ConfigPath:  "$[?(@.flag==\"--write-subs\")]",
+	},
+	{
+		ID:          "writesubtitles",
+		Group:       "Subtitle",
+		Method:      "NoWriteSubs",
+		UnsetMethod: "UnsetWriteSubs",
+		Flag:        "--no-write-subs",
+		Flags:       []string{"--no-write-subs", "--no-write-srt"},
+		ConfigPath:  "$[?(@.flag==\"--no-write-subs\")]",
+	},
+	{
+		ID:          "writeautomaticsub",
+		Group:       "Subtitle",
+		Method:      "WriteAutoSubs",
+		UnsetMethod: "UnsetWriteAutoSubs",
+		Flag:        "--write-auto-subs",
+		Flags:       []string{"--write-auto-subs", "--write-automatic-subs"},
+		ConfigPath:  "$[?(@.flag==\"--write-auto-subs\")]",
+	},
+	{
+		ID:          "writeautomaticsub",
+		Group:       "Subtitle",
+		Method:      "NoWriteAutoSubs",
+		UnsetMethod: "UnsetWriteAutoSubs",
+		Flag:        "--no-write-auto-subs",
+		Flags:       []string{"--no-write-auto-subs", "--no-write-automatic-subs"},
+		ConfigPath:  "$[?(@.flag==\"--no-write-auto-subs\")]",
+	},
+	{
+		ID:          "allsubtitles",
+		Group:       "Subtitle",
+		Method:      "AllSubs",
+		UnsetMethod: "UnsetAllSubs",
+		Flag:        "--all-subs",
+		Flags:       []string{"--all-subs"},
+		ConfigPath:  "$[?(@.flag==\"--all-subs\")]",
+	},
+	{
+		ID:          "listsubtitles",
+		Group:       "Subtitle",
+		Method:      "ListSubs",
+		UnsetMethod: "UnsetListSubs",
+		Flag:        "--list-subs",
+		Flags:       []string{"--list-subs"},
+		ConfigPath:  "$[?(@.flag==\"--list-subs\")]",
+	},
+	{
+		ID:          "subtitlesformat",
+		Group:       "Subtitle",
+		Method:      "SubFormat",
+		UnsetMethod: "UnsetSubFormat",
+		Flag:        "--sub-format",
+		Flags:       []string{"--sub-format"},
+		ConfigPath:  "$[?(@.flag==\"--sub-format\")]",
+	},
+	{
+		ID:          "subtitleslangs",
+		Group:       "Subtitle",
+		Method:      "SubLangs",
+		UnsetMethod: "UnsetSubLangs",
+		Flag:        "--sub-langs",
+		Flags:       []string{"--sub-langs", "--srt-langs"},
+		ConfigPath:  "$[?(@.flag==\"--sub-langs\")]",
+	},
+	{
+		ID:          "username",
+		Group:       "Authentication",
+		Method:      "Username",
+		UnsetMethod: "UnsetUsername",
+		Flag:        "--username",
+		Flags:       []string{"-u", "--username"},
+		ConfigPath:  "$[?(@.flag==\"--username\")]",
+	},
+	{
+		ID:          "password",
+		Group:       "Authentication",
+		Method:      "Password",
+		UnsetMethod: "UnsetPassword",
+		Flag:        "--password",
+		Flags:       []string{"-p", "--password"},
+		ConfigPath:  "$[?(@.flag==\"--password\")]",
+	},
+	{
+		ID:          "twofactor",
+		Group:       "Authentication",
+		Method:      "TwoFactor",
+		UnsetMethod: "UnsetTwoFactor",
+		Flag:        "--twofactor",
+		Flags:       []string{"-2", "--twofactor"},
+		ConfigPath:  "$[?(@.flag==\"--twofactor\")]",
+	},
+	{
+		ID:          "usenetrc",
+		Group:       "Authentication",
+		Method:      "Netrc",
+		UnsetMethod: "UnsetNetrc",
+		Flag:        "--netrc",
+		Flags:       []string{"-n", "--netrc"},
+		ConfigPath:  "$[?(@.flag==\"--netrc\")]",
+	},
+	{
+		ID:          "netrc_location",
+		Group:       "Authentication",
+		Method:      "NetrcLocation",
+		UnsetMethod: "UnsetNetrcLocation",
+		Flag:        "--netrc-location",
+		Flags:       []string{"--netrc-location"},
+		ConfigPath:  "$[?(@.flag==\"--netrc-location\")]",
+	},
+	{
+		ID:          "netrc_cmd",
+		Group:       "Authentication",
+		Method:      "NetrcCmd",
+		UnsetMethod: "UnsetNetrcCmd",
+		Flag:        "--netrc-cmd",
+		Flags:       []string{"--netrc-cmd"},
+		ConfigPath:  "$[?(@.flag==\"--netrc-cmd\")]",
+	},
+	{
+		ID:          "videopassword",
+		Group:       "Authentication",
+		Method:      "VideoPassword",
+		UnsetMethod: "UnsetVideoPassword",
+		Flag:        "--video-password",
+		Flags:       []string{"--video-password"},
+		ConfigPath:  "$[?(@.flag==\"--video-password\")]",
+	},
+	{
+		ID:          "ap_mso",
+		Group:       "Authentication",
+		Method:      "ApMSO",
+		UnsetMethod: "UnsetApMSO",
+		Flag:        "--ap-mso",
+		Flags:       []string{"--ap-mso"},
+		ConfigPath:  "$[?(@.flag==\"--ap-mso\")]",
+	},
+	{
+		ID:          "ap_username",
+		Group:       "Authentication",
+		Method:      "ApUsername",
+		UnsetMethod: "UnsetApUsername",
+		Flag:        "--ap-username",
+		Flags:       []string{"--ap-username"},
+		ConfigPath:  "$[?(@.flag==\"--ap-username\")]",
+	},
+	{
+		ID:          "ap_password",
+		Group:       "Authentication",
+		Method:      "ApPassword",
+		UnsetMethod: "UnsetApPassword",
+		Flag:        "--ap-password",
+		Flags:       []string{"--ap-password"},
+		ConfigPath:  "$[?(@.flag==\"--ap-password\")]",
+	},
+	{
+		ID:          "ap_list_mso",
+		Group:       "Authentication",
+		Method:      "ApListMSO",
+		UnsetMethod: "UnsetApListMSO",
+		Flag:        "--ap-list-mso",
+		Flags:       []string{"--ap-list-mso"},
+		ConfigPath:  "$[?(@.flag==\"--ap-list-mso\")]",
+	},
+	{
+		ID:          "client_certificate",
+		Group:       "Authentication",
+		Method:      "ClientCertificate",
+		UnsetMethod: "UnsetClientCertificate",
+		Flag:        "--client-certificate",
+		Flags:       []string{"--client-certificate"},
+		ConfigPath:  "$[?(@.flag==\"--client-certificate\")]",
+	},
+	{
+		ID:          "client_certificate_key",
+		Group:       "Authentication",
+		Method:      "ClientCertificateKey",
+		UnsetMethod: "UnsetClientCertificateKey",
+		Flag:        "--client-certificate-key",
+		Flags:       []string{"--client-certificate-key"},
+		ConfigPath:  "$[?(@.flag==\"--client-certificate-key\")]",
+	},
+	{
+		ID:          "client_certificate_password",
+		Group:       "Authentication",
+		Method:      "ClientCertificatePassword",
+		UnsetMethod: "UnsetClientCertificatePassword",
+		Flag:        "--client-certificate-password",
+		Flags:       []string{"--client-certificate-password"},
+		ConfigPath:  "$[?(@.flag==\"--client-certificate-password\")]",
+	},
+	{
+		ID:          "extractaudio",
+		Group:       "Post-Processing",
+		Method:      "ExtractAudio",
+		UnsetMethod: "UnsetExtractAudio",
+		Flag:        "--extract-audio",
+		Flags:       []string{"-x", "--extract-audio"},
+		ConfigPath:  "$[?(@.flag==\"--extract-audio\")]",
+	},
+	{
+		ID:          "audioformat",
+		Group:       "Post-Processing",
+		Method:      "AudioFormat",
+		UnsetMethod: "UnsetAudioFormat",
+		Flag:        "--audio-format",
+		Flags:       []string{"--audio-format"},
+		ConfigPath:  "$[?(@.flag==\"--audio-format\")]",
+	},
+	{
+		ID:          "audioquality",
+		Group:       "Post-Processing",
+		Method:      "AudioQuality",
+		UnsetMethod: "UnsetAudioQuality",
+		Flag:        "--audio-quality",
+		Flags:       []string{"--audio-quality"},
+		ConfigPath:  "$[?(@.flag==\"--audio-quality\")]",
+	},
+	{
+		ID:          "remuxvideo",
+		Group:       "Post-Processing",
+		Method:      "RemuxVideo",
+		UnsetMethod: "UnsetRemuxVideo",
+		Flag:        "--remux-video",
+		Flags:       []string{"--remux-video"},
+		ConfigPath:  "$[?(@.flag==\"--remux-video\")]",
+	},
+	{
+		ID:          "recodevideo",
+		Group:       "Post-Processing",
+		Method:      "RecodeVideo",
+		UnsetMethod: "UnsetRecodeVideo",
+		Flag:        "--recode-video",
+		Flags:       []string{"--recode-video"},
+		ConfigPath:  "$[?(@.flag==\"--recode-video\")]",
+	},
+	{
+		ID:          "postprocessor_args",
+		Group:       "Post-Processing",
+		Method:      "PostProcessorArgs",
+		UnsetMethod: "UnsetPostProcessorArgs",
+		Flag:        "--postprocessor-args",
+		Flags:       []string{"--postprocessor-args", "--ppa"},
+		ConfigPath:  "$[?(@.flag==\"--postprocessor-args\")]",
+	},
+	{
+		ID:          "keepvideo",
+		Group:       "Post-Processing",
+		Method:      "KeepVideo",
+		UnsetMethod: "UnsetKeepVideo",
+		Flag:        "--keep-video",
+		Flags:       []string{"-k", "--keep-video"},
+		ConfigPath:  "$[?(@.flag==\"--keep-video\")]",
+	},
+	{
+		ID:          "keepvideo",
+		Group:       "Post-Processing",
+		Method:      "NoKeepVideo",
+		UnsetMethod: "UnsetKeepVideo",
+		Flag:        "--no-keep-video",
+		Flags:       []string{"--no-keep-video"},
+		ConfigPath:  "$[?(@.flag==\"--no-keep-video\")]",
+	},
+	{
+		ID:          "nopostoverwrites",
+		Group:       "Post-Processing",
+		Method:      "PostOverwrites",
+		UnsetMethod: "UnsetPostOverwrites",
+		Flag:        "--post-overwrites",
+		Flags:       []string{"--post-overwrites"},
+		ConfigPath:  "$[?(@.flag==\"--post-overwrites\")]",
+	},
+	{
+		ID:          "nopostoverwrites",
+		Group:       "Post-Processing",
+		Method:      "NoPostOverwrites",
+		UnsetMethod: "UnsetPostOverwrites",
+		Flag:        "--no-post-overwrites",
+		Flags:       []string{"--no-post-overwrites"},
+		ConfigPath:  "$[?(@.flag==\"--no-post-overwrites\")]",
+	},
+	{
+		ID:          "embedsubtitles",
+		Group:       "Post-Processing",
+		Method:      "EmbedSubs",
+		UnsetMethod: "UnsetEmbedSubs",
+		Flag:        "--embed-subs",
+		Flags:       []string{"--embed-subs"},
+		ConfigPath:  "$[?(@.flag==\"--embed-subs\")]",
+	},
+	{
+		ID:          "embedsubtitles",
+		Group:       "Post-Processing",
+		Method:      "NoEmbedSubs",
+		UnsetMethod: "UnsetEmbedSubs",
+		Flag:        "--no-embed-subs",
+		Flags:       []string{"--no-embed-subs"},
+		ConfigPath:  "$[?(@.flag==\"--no-embed-subs\")]",
+	},
+	{
+		ID:          "embedthumbnail",
+		Group:       "Post-Processing",
+		Method:      "EmbedThumbnail",
+		UnsetMethod: "UnsetEmbedThumbnail",
+		Flag:        "--embed-thumbnail",
+		Flags:       []string{"--embed-thumbnail"},
+		ConfigPath:  "$[?(@.flag==\"--embed-thumbnail\")]",
+	},
+	{
+		ID:          "embedthumbnail",
+		Group:       "Post-Processing",
+		Method:      "NoEmbedThumbnail",
+		UnsetMethod: "UnsetEmbedThumbnail",
+		Flag:        "--no-embed-thumbnail",
+		Flags:       []string{"--no-embed-thumbnail"},
+		ConfigPath:  "$[?(@.flag==\"--no-embed-thumbnail\")]",
+	},
+	{
+		ID:          "addmetadata",
+		Group:       "Post-Processing",
+		Method:      "EmbedMetadata",
+		UnsetMethod: "UnsetEmbedMetadata",
+		Flag:        "--embed-metadata",
+		Flags:       []string{"--embed-metadata", "--add-metadata"},
+		ConfigPath:  "$[?(@.flag==\"--embed-metadata\")]",
+	},
+	{
+		ID:          "addmetadata",
+		Group:       "Post-Processing",
+		Method:      "NoEmbedMetadata",
+		UnsetMethod: "UnsetEmbedMetadata",
+		Flag:        "--no-embed-metadata",
+		Flags:       []string{"--no-embed-metadata", "--no-add-metadata"},
+		ConfigPath:  "$[?(@.flag==\"--no-embed-metadata\")]",
+	},
+	{
+		ID:          "addchapters",
+		Group:       "Post-Processing",
+		Method:      "EmbedChapters",
+		UnsetMethod: "UnsetEmbedChapters",
+		Flag:        "--embed-chapters",
+		Flags:       []string{"--embed-chapters", "--add-chapters"},
+		ConfigPath:  "$[?(@.flag==\"--embed-chapters\")]",
+	},
+	{
+		ID:          "addchapters",
+		Group:       "Post-Processing",
+		Method:      "NoEmbedChapters",
+		UnsetMethod: "UnsetEmbedChapters",
+		Flag:        "--no-embed-chapters",
+		Flags:       []string{"--no-embed-chapters", "--no-add-chapters"},
+		ConfigPath:  "$[?(@.flag==\"--no-embed-chapters\")]",
+	},
+	{
+		ID:          "embed_infojson",
+		Group:       "Post-Processing",
+		Method:      "EmbedInfoJSON",
+		UnsetMethod: "UnsetEmbedInfoJSON",
+		Flag:        "--embed-info-json",
+		Flags:       []string{"--embed-info-json"},
+		ConfigPath:  "$[?(@.flag==\"--embed-info-json\")]",
+	},
+	{
+		ID:          "embed_infojson",
+		Group:       "Post-Processing",
+		Method:      "NoEmbedInfoJSON",
+		UnsetMethod: "UnsetEmbedInfoJSON",
+		Flag:        "--no-embed-info-json",
+		Flags:       []string{"--no-embed-info-json"},
+		ConfigPath:  "$[?(@.flag==\"--no-embed-info-json\")]",
+	},
+	{
+		ID:          "metafromtitle",
+		Group:       "Post-Processing",
+		Method:      "MetadataFromTitle",
+		UnsetMethod: "UnsetMetadataFromTitle",
+		Flag:        "--metadata-from-title",
+		Flags:       []string{"--metadata-from-title"},
+		ConfigPath:  "$[?(@.flag==\"--metadata-from-title\")]",
+	},
+	{
+		ID:          "parse_metadata",
+		Group:       "Post-Processing",
+		Method:      "ParseMetadata",
+		UnsetMethod: "UnsetParseMetadata",
+		Flag:        "--parse-metadata",
+		Flags:       []string{"--parse-metadata"},
+		ConfigPath:  "$[?(@.flag==\"--parse-metadata\")]",
+	},
+	{
+		ID:          "parse_metadata",
+		Group:       "Post-Processing",
+		Method:      "ReplaceInMetadata",
+		UnsetMethod: "UnsetReplaceInMetadata",
+		Flag:        "--replace-in-metadata",
+		Flags:       []string{"--replace-in-metadata"},
+		ConfigPath:  "$[?(@.flag==\"--replace-in-metadata\")]",
+	},
+	{
+		ID:          "xattrs",
+		Group:       "Post-Processing",
+		Method:      "Xattrs",
+		UnsetMethod: "UnsetXattrs",
+		Flag:        "--xattrs",
+		Flags:       []string{"--xattrs", "--xattr"},
+		ConfigPath:  "$[?(@.flag==\"--xattrs\")]",
+	},
+	{
+		ID:          "concat_playlist",
+		Group:       "Post-Processing",
+		Method:      "ConcatPlaylist",
+		UnsetMethod: "UnsetConcatPlaylist",
+		Flag:        "--concat-playlist",
+		Flags:       []string{"--concat-playlist"},
+		ConfigPath:  "$[?(@.flag==\"--concat-playlist\")]",
+	},
+	{
+		ID:          "fixup",
+		Group:       "Post-Processing",
+		Method:      "Fixup",
+		UnsetMethod: "UnsetFixup",
+		Flag:        "--fixup",
+		Flags:       []string{"--fixup"},
+		ConfigPath:  "$[?(@.flag==\"--fixup\")]",
+	},
+	{
+		ID:          "prefer_ffmpeg",
+		Group:       "Post-Processing",
+		Method:      "PreferAVConv",
+		UnsetMethod: "UnsetPreferAVConv",
+		Flag:        "--prefer-avconv",
+		Flags:       []string{"--prefer-avconv", "--no-prefer-ffmpeg"},
+		ConfigPath:  "$[?(@.flag==\"--prefer-avconv\")]",
+	},
+	{
+		ID:          "prefer_ffmpeg",
+		Group:       "Post-Processing",
+		Method:      "PreferFFmpeg",
+		UnsetMethod: "UnsetPreferFFmpeg",
+		Flag:        "--prefer-ffmpeg",
+		Flags:       []string{"--prefer-ffmpeg", "--no-prefer-avconv"},
+		ConfigPath:  "$[?(@.flag==\"--prefer-ffmpeg\")]",
+	},
+	{
+		ID:          "ffmpeg_location",
+		Group:       "Post-Processing",
+		Method:      "FFmpegLocation",
+		UnsetMethod: "UnsetFFmpegLocation",
+		Flag:        "--ffmpeg-location",
+		Flags:       []string{"--ffmpeg-location", "--avconv-location"},
+		ConfigPath:  "$[?(@.flag==\"--ffmpeg-location\")]",
+	},
+	{
+		ID:          "exec_cmd",
+		Group:       "Post-Processing",
+		Method:      "Exec",
+		UnsetMethod: "UnsetExec",
+		Flag:        "--exec",
+		Flags:       []string{"--exec"},
+		ConfigPath:  "$[?(@.flag==\"--exec\")]",
+	},
+	{
+		ID:          "exec_cmd",
+		Group:       "Post-Processing",
+		Method:      "NoExec",
+		UnsetMethod: "UnsetExec",
+		Flag:        "--no-exec",
+		Flags:       []string{"--no-exec"},
+		ConfigPath:  "$[?(@.flag==\"--no-exec\")]",
+	},
+	{
+		ID:          "exec_before_dl_cmd",
+		Group:       "Post-Processing",
+		Method:      "ExecBeforeDownload",
+		UnsetMethod: "UnsetExecBeforeDownload",
+		Flag:        "--exec-before-download",
+		Flags:       []string{"--exec-before-download"},
+		ConfigPath:  "$[?(@.flag==\"--exec-before-download\")]",
+	},
+	{
+		ID:          "exec_before_dl_cmd",
+		Group:       "Post-Processing",
+		Method:      "NoExecBeforeDownload",
+		UnsetMethod: "UnsetExecBeforeDownload",
+		Flag:        "--no-exec-before-download",
+		Flags:       []string{"--no-exec-before-download"},
+		ConfigPath:  "$[?(@.flag==\"--no-exec-before-download\")]",
+	},
+	{
+		ID:          "convertsubtitles",
+		Group:       "Post-Processing",
+		Method:      "ConvertSubs",
+		UnsetMethod: "UnsetConvertSubs",
+		Flag:        "--convert-subs",
+		Flags:       []string{"--convert-subs", "--convert-sub", "--convert-subtitles"},
+		ConfigPath:  "$[?(@.flag==\"--convert-subs\")]",
+	},
+	{
+		ID:          "convertthumbnails",
+		Group:       "Post-Processing",
+		Method:      "ConvertThumbnails",
+		UnsetMethod: "UnsetConvertThumbnails",
+		Flag:        "--convert-thumbnails",
+		Flags:       []string{"--convert-thumbnails"},
+		ConfigPath:  "$[?(@.flag==\"--convert-thumbnails\")]",
+	},
+	{
+		ID:          "split_chapters",
+		Group:       "Post-Processing",
+		Method:      "SplitChapters",
+		UnsetMethod: "UnsetSplitChapters",
+		Flag:        "--split-chapters",
+		Flags:       []string{"--split-chapters", "--split-tracks"},
+		ConfigPath:  "$[?(@.flag==\"--split-chapters\")]",
+	},
+	{
+		ID:          "split_chapters",
+		Group:       "Post-Processing",
+		Method:      "NoSplitChapters",
+		UnsetMethod: "UnsetSplitChapters",
+		Flag:        "--no-split-chapters",
+		Flags:       []string{"--no-split-chapters", "--no-split-tracks"},
+		ConfigPath:  "$[?(@.flag==\"--no-split-chapters\")]",
+	},
+	{
+		ID:          "remove_chapters",
+		Group:       "Post-Processing",
+		Method:      "RemoveChapters",
+		UnsetMethod: "UnsetRemoveChapters",
+		Flag:        "--remove-chapters",
+		Flags:       []string{"--remove-chapters"},
+		ConfigPath:  "$[?(@.flag==\"--remove-chapters\")]",
+	},
+	{
+		ID:          "remove_chapters",
+		Group:       "Post-Processing",
+		Method:      "NoRemoveChapters",
+		UnsetMethod: "UnsetRemoveChapters",
+		Flag:        "--no-remove-chapters",
+		Flags:       []string{"--no-remove-chapters"},
+		ConfigPath:  "$[?(@.flag==\"--no-remove-chapters\")]",
+	},
+	{
+		ID:          "force_keyframes_at_cuts",
+		Group:       "Post-Processing",
+		Method:      "ForceKeyframesAtCuts",
+		UnsetMethod: "UnsetForceKeyframesAtCuts",
+		Flag:        "--force-keyframes-at-cuts",
+		Flags:       []string{"--force-keyframes-at-cuts"},
+		ConfigPath:  "$[?(@.flag==\"--force-keyframes-at-cuts\")]",
+	},
+	{
+		ID:          "force_keyframes_at_cuts",
+		Group:       "Post-Processing",
+		Method:      "NoForceKeyframesAtCuts",
+		UnsetMethod: "UnsetForceKeyframesAtCuts",
+		Flag:        "--no-force-keyframes-at-cuts",
+		Flags:       []string{"--no-force-keyframes-at-cuts"},
+		ConfigPath:  "$[?(@.flag==\"--no-force-keyframes-at-cuts\")]",
+	},
+	{
+		ID:          "add_postprocessors",
+		Group:       "Post-Processing",
+		Method:      "UsePostProcessor",
+		UnsetMethod: "UnsetUsePostProcessor",
+		Flag:        "--use-postprocessor",
+		Flags:       []string{"--use-postprocessor"},
+		ConfigPath:  "$[?(@.flag==\"--use-postprocessor\")]",
+	},
+	{
+		ID:          "sponsorblock_mark",
+		Group:       "SponsorBlock",
+		Method:      "SponsorblockMark",
+		UnsetMethod: "UnsetSponsorblockMark",
+		Flag:        "--sponsorblock-mark",
+		Flags:       []string{"--sponsorblock-mark"},
+		ConfigPath:  "$[?(@.flag==\"--sponsorblock-mark\")]",
+	},
+	{
+		ID:          "sponsorblock_remove",
+		Group:       "SponsorBlock",
+		Method:      "SponsorblockRemove",
+		UnsetMethod: "UnsetSponsorblockRemove",
+		Flag:        "--sponsorblock-remove",
+		Flags:       []string{"--sponsorblock-remove"},
+		ConfigPath:  "$[?(@.flag==\"--sponsorblock-remove\")]",
+	},
+	{
+		ID:          "sponsorblock_chapter_title",
+		Group:       "SponsorBlock",
+		Method:      "SponsorblockChapterTitle",
+		UnsetMethod: "UnsetSponsorblockChapterTitle",
+		Flag:        "--sponsorblock-chapter-title",
+		Flags:       []string{"--sponsorblock-chapter-title"},
+		ConfigPath:  "$[?(@.flag==\"--sponsorblock-chapter-title\")]",
+	},
+	{
+		ID:          "no_sponsorblock",
+		Group:       "SponsorBlock",
+		Method:      "NoSponsorblock",
+		UnsetMethod: "UnsetSponsorblock",
+		Flag:        "--no-sponsorblock",
+		Flags:       []string{"--no-sponsorblock"},
+		ConfigPath:  "$[?(@.flag==\"--no-sponsorblock\")]",
+	},
+	{
+		ID:          "sponsorblock_api",
+		Group:       "SponsorBlock",
+		Method:      "SponsorblockAPI",
+		UnsetMethod: "UnsetSponsorblockAPI",
+		Flag:        "--sponsorblock-api",
+		Flags:       []string{"--sponsorblock-api"},
+		ConfigPath:  "$[?(@.flag==\"--sponsorblock-api\")]",
+	},
+	{
+		ID:          "sponskrub",
+		Group:       "SponsorBlock",
+		Method:      "Sponskrub",
+		UnsetMethod: "UnsetSponskrub",
+		Flag:        "--sponskrub",
+		Flags:       []string{"--sponskrub"},
+		ConfigPath:  "$[?(@.flag==\"--sponskrub\")]",
+	},
+	{
+		ID:          "sponskrub",
+		Group:       "SponsorBlock",
+		Method:      "NoSponskrub",
+		UnsetMethod: "UnsetSponskrub",
+		Flag:        "--no-sponskrub",
+		Flags:       []string{"--no-sponskrub"},
+		ConfigPath:  "$[?(@.flag==\"--no-sponskrub\")]",
+	},
+	{
+		ID:          "sponskrub_cut",
+		Group:       "SponsorBlock",
+		Method:      "SponskrubCut",
+		UnsetMethod: "UnsetSponskrubCut",
+		Flag:        "--sponskrub-cut",
+		Flags:       []string{"--sponskrub-cut"},
+		ConfigPath:  "$[?(@.flag==\"--sponskrub-cut\")]",
+	},
+	{
+		ID:          "sponskrub_cut",
+		Group:       "SponsorBlock",
+		Method:      "NoSponskrubCut",
+		UnsetMethod: "UnsetSponskrubCut",
+		Flag:        "--no-sponskrub-cut",
+		Flags:       []string{"--no-sponskrub-cut"},
+		ConfigPath:  "$[?(@.flag==\"--no-sponskrub-cut\")]",
+	},
+	{
+		ID:          "sponskrub_force",
+		Group:       "SponsorBlock",
+		Method:      "SponskrubForce",
+		UnsetMethod: "UnsetSponskrubForce",
+		Flag:        "--sponskrub-force",
+		Flags:       []string{"--sponskrub-force"},
+		ConfigPath:  "$[?(@.flag==\"--sponskrub-force\")]",
+	},
+	{
+		ID:          "sponskrub_force",
+		Group:       "SponsorBlock",
+		Method:      "NoSponskrubForce",
+		UnsetMethod: "UnsetSponskrubForce",
+		Flag:        "--no-sponskrub-force",
+		Flags:       []string{"--no-sponskrub-force"},
+		ConfigPath:  "$[?(@.flag==\"--no-sponskrub-force\")]",
+	},
+	{
+		ID:          "sponskrub_path",
+		Group:       "SponsorBlock",
+		Method:      "SponskrubLocation",
+		UnsetMethod: "UnsetSponskrubLocation",
+		Flag:        "--sponskrub-location",
+		Flags:       []string{"--sponskrub-location"},
+		ConfigPath:  "$[?(@.flag==\"--sponskrub-location\")]",
+	},
+	{
+		ID:          "sponskrub_args",
+		Group:       "SponsorBlock",
+		Method:      "SponskrubArgs",
+		UnsetMethod: "UnsetSponskrubArgs",
+		Flag:        "--sponskrub-args",
+		Flags:       []string{"--sponskrub-args"},
+		ConfigPath:  "$[?(@.flag==\"--sponskrub-args\")]",
+	},
+	{
+		ID:          "extractor_retries",
+		Group:       "Extractor",
+		Method:      "ExtractorRetries",
+		UnsetMethod: "UnsetExtractorRetries",
+		Flag:        "--extractor-retries",
+		Flags:       []string{"--extractor-retries"},
+		ConfigPath:  "$[?(@.flag==\"--extractor-retries\")]",
+	},
+	{
+		ID:          "dynamic_mpd",
+		Group:       "Extractor",
+		Method:      "AllowDynamicMPD",
+		UnsetMethod: "UnsetAllowDynamicMPD",
+		Flag:        "--allow-dynamic-mpd",
+		Flags:       []string{"--allow-dynamic-mpd", "--no-ignore-dynamic-mpd"},
+		ConfigPath:  "$[?(@.flag==\"--allow-dynamic-mpd\")]",
+	},
+	{
+		ID:          "dynamic_mpd",
+		Group:       "Extractor",
+		Method:      "IgnoreDynamicMPD",
+		UnsetMethod: "UnsetIgnoreDynamicMPD",
+		Flag:        "--ignore-dynamic-mpd",
+		Flags:       []string{"--ignore-dynamic-mpd", "--no-allow-dynamic-mpd"},
+		ConfigPath:  "$[?(@.flag==\"--ignore-dynamic-mpd\")]",
+	},
+	{
+		ID:          "hls_split_discontinuity",
+		Group:       "Extractor",
+		Method:      "HLSSplitDiscontinuity",
+		UnsetMethod: "UnsetHLSSplitDiscontinuity",
+		Flag:        "--hls-split-discontinuity",
+		Flags:       []string{"--hls-split-discontinuity"},
+		ConfigPath:  "$[?(@.flag==\"--hls-split-discontinuity\")]",
+	},
+	{
+		ID:          "hls_split_discontinuity",
+		Group:       "Extractor",
+		Method:      "NoHLSSplitDiscontinuity",
+		UnsetMethod: "UnsetHLSSplitDiscontinuity",
+		Flag:        "--no-hls-split-discontinuity",
+		Flags:       []string{"--no-hls-split-discontinuity"},
+		ConfigPath:  "$[?(@.flag==\"--no-hls-split-discontinuity\")]",
+	},
+	{
+		ID:          "extractor_args",
+		Group:       "Extractor",
+		Method:      "ExtractorArgs",
+		UnsetMethod: "UnsetExtractorArgs",
+		Flag:        "--extractor-args",
+		Flags:       []string{"--extractor-args"},
+		ConfigPath:  "$[?(@.flag==\"--extractor-args\")]",
+	},
+	{
+		ID:          "youtube_include_dash_manifest",
+		Group:       "Extractor",
+		Method:      "YoutubeIncludeDashManifest",
+		UnsetMethod: "UnsetYoutubeIncludeDashManifest",
+		Flag:        "--youtube-include-dash-manifest",
+		Flags:       []string{"--youtube-include-dash-manifest", "--no-youtube-skip-dash-manifest"},
+		ConfigPath:  "$[?(@.flag==\"--youtube-include-dash-manifest\")]",
+	},
+	{
+		ID:          "youtube_include_dash_manifest",
+		Group:       "Extractor",
+		Method:      "YoutubeSkipDashManifest",
+		UnsetMethod: "UnsetYoutubeSkipDashManifest",
+		Flag:        "--youtube-skip-dash-manifest",
+		Flags:       []string{"--youtube-skip-dash-manifest", "--no-youtube-include-dash-manifest"},
+		ConfigPath:  "$[?(@.flag==\"--youtube-skip-dash-manifest\")]",
+	},
+	{
+		ID:          "youtube_include_hls_manifest",
+		Group:       "Extractor",
+		Method:      "YoutubeIncludeHLSManifest",
+		UnsetMethod: "UnsetYoutubeIncludeHLSManifest",
+		Flag:        "--youtube-include-hls-manifest",
+		Flags:       []string{"--youtube-include-hls-manifest", "--no-youtube-skip-hls-manifest"},
+		ConfigPath:  "$[?(@.flag==\"--youtube-include-hls-manifest\")]",
+	},
+	{
+		ID:          "youtube_include_hls_manifest",
+		Group:       "Extractor",
+		Method:      "YoutubeSkipHLSManifest",
+		UnsetMethod: "UnsetYoutubeSkipHLSManifest",
+		Flag:        "--youtube-skip-hls-manifest",
+		Flags:       []string{"--youtube-skip-hls-manifest", "--no-youtube-include-hls-manifest"},
+		ConfigPath:  "$[?(@.flag==\"--youtube-skip-hls-manifest\")]",
+	},
+}
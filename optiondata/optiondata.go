@@ -6,6 +6,19 @@
 // package are generated via cmd/codegen, and may change at any time.
 package optiondata
 
+import (
+	_ "embed"
+	"slices"
+)
+
+// FormMetadataJSON contains per-option UI metadata (label, help, input type,
+// choices, group, advanced/hidden, etc), as JSON, which can be used to automatically
+// build forms for download options. Fields are keyed by the same ID, builder
+// method, and flag as [Mapping].
+//
+//go:embed form.gen.json
+var FormMetadataJSON []byte
+
 // OptionGroup is a group of options (e.g. general, verbosity, etc).
 type OptionGroup struct {
 	// Name of the option group.
@@ -64,3 +77,58 @@ type OptionURL struct {
 	// URL is the link to the documentation for the option.
 	URL string `json:"url"`
 }
+
+// OptionMapping connects the different surfaces that an option is exposed through:
+// the yt-dlp ID/"dest", the go-ytdlp builder method(s), the cli flag(s), and the
+// flag config. Mappings are generated via cmd/codegen, along with the builder
+// methods.
+type OptionMapping struct {
+	// ID is the yt-dlp identifier ("dest") for the option, which is also the
+	// [github.com/lrstanley/go-ytdlp.Flag.ID] used when the option is set. May
+	// be empty for executable options.
+	ID string `json:"id,omitempty"`
+	// Group is the name of the option group the option belongs to.
+	Group string `json:"group"`
+	// Method is the name of the builder method on [github.com/lrstanley/go-ytdlp.Command].
+	Method string `json:"method"`
+	// UnsetMethod is the name of the builder method which unsets the option. Empty
+	// if the option is executable, or if no unset method removes this option's ID
+	// (e.g. when it shares an unset method with another option).
+	UnsetMethod string `json:"unset_method,omitempty"`
+	// Flag is the default cli flag for the option.
+	Flag string `json:"flag"`
+	// Flags are all cli flags (short and long) for the option.
+	Flags []string `json:"flags"`
+	// ConfigPath is the JSONPath of the option's entries within the JSON encoding
+	// of [github.com/lrstanley/go-ytdlp.Command.GetFlagConfig], matched by flag
+	// (e.g. `$[?(@.flag=="--format-sort")]`), with any arguments under "args".
+	ConfigPath string `json:"config_path"`
+}
+
+// Mapping returns the mapping between yt-dlp IDs, builder methods, and cli flags,
+// for all options. The returned data should not be modified.
+func Mapping() []*OptionMapping {
+	return mappings
+}
+
+// MappingByMethod returns the mapping for the provided builder method name (e.g.
+// "FormatSort"), or nil if not found.
+func MappingByMethod(method string) *OptionMapping {
+	for _, m := range mappings {
+		if m.Method == method {
+			return m
+		}
+	}
+	return nil
+}
+
+// MappingByFlag returns the mapping for the provided cli flag (short or long,
+// e.g. "-S" or "--format-sort"), or nil if not found.
+func MappingByFlag(flag string) *OptionMapping {
+	for _, m := range mappings {
+		if slices.Contains(m.Flags, flag) {
+			return m
+		}
+	}
+	return nil
+}
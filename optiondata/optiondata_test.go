@@ -0,0 +1,76 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package optiondata
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMapping(t *testing.T) {
+	if len(Mapping()) != len(Options) {
+		t.Fatalf("expected %d mappings, got %d", len(Options), len(Mapping()))
+	}
+
+	m := MappingByFlag("-S")
+	if m == nil {
+		t.Fatal("expected mapping for -S")
+	}
+
+	if m.Method != "FormatSort" || m.UnsetMethod != "UnsetFormatSort" || m.ID != "format_sort" {
+		t.Fatalf("unexpected mapping: %#v", m)
+	}
+
+	if m.ConfigPath != `$[?(@.flag=="--format-sort")]` {
+		t.Fatalf("unexpected config path: %q", m.ConfigPath)
+	}
+
+	if m = MappingByMethod("NoUpdate"); m == nil || m.UnsetMethod != "UnsetUpdate" {
+		t.Fatalf("unexpected mapping for NoUpdate: %#v", m)
+	}
+
+	if m = MappingByMethod("Version"); m == nil || m.UnsetMethod != "" {
+		t.Fatalf("unexpected mapping for Version: %#v", m)
+	}
+
+	unset := map[string]string{}
+	for _, m := range Mapping() {
+		if m.UnsetMethod == "" {
+			continue
+		}
+
+		if id, ok := unset[m.UnsetMethod]; ok && id != m.ID {
+			t.Fatalf("unset method %q maps to both %q and %q", m.UnsetMethod, id, m.ID)
+		}
+		unset[m.UnsetMethod] = m.ID
+	}
+}
+
+func TestFormMetadataJSON(t *testing.T) {
+	var fields []struct {
+		Method string `json:"method"`
+		Flag   string `json:"flag"`
+		Input  string `json:"input"`
+	}
+
+	if err := json.Unmarshal(FormMetadataJSON, &fields); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(fields) != len(Options) {
+		t.Fatalf("expected %d fields, got %d", len(Options), len(fields))
+	}
+
+	for _, f := range fields {
+		m := MappingByMethod(f.Method)
+		if m == nil || m.Flag != f.Flag {
+			t.Fatalf("form metadata for %q is out of sync with option data", f.Method)
+		}
+
+		if f.Input == "" {
+			t.Fatalf("expected input type for %q", f.Method)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"fmt"
+	"slices"
+)
+
+// PathType is the type of file that a path provided via [Command.Paths] applies
+// to.
+type PathType string
+
+const (
+	PathHome                PathType = "home"
+	PathTemp                PathType = "temp"
+	PathSubtitle            PathType = "subtitle"
+	PathThumbnail           PathType = "thumbnail"
+	PathDescription         PathType = "description"
+	PathAnnotation          PathType = "annotation"
+	PathInfoJSON            PathType = "infojson"
+	PathLink                PathType = "link"
+	PathChapter             PathType = "chapter"
+	PathPlaylistThumbnail   PathType = "pl_thumbnail"
+	PathPlaylistDescription PathType = "pl_description"
+	PathPlaylistInfoJSON    PathType = "pl_infojson"
+	PathPlaylistVideo       PathType = "pl_video"
+)
+
+// PathTypes are all of the path types known to yt-dlp.
+var PathTypes = []PathType{
+	PathHome,
+	PathTemp,
+	PathSubtitle,
+	PathThumbnail,
+	PathDescription,
+	PathAnnotation,
+	PathInfoJSON,
+	PathLink,
+	PathChapter,
+	PathPlaylistThumbnail,
+	PathPlaylistDescription,
+	PathPlaylistInfoJSON,
+	PathPlaylistVideo,
+}
+
+// PathsBuilder builds the (repeated) "--paths TYPE:PATH" flags. Create one with
+// [Paths], and apply it with [Command.SetPaths].
+type PathsBuilder struct {
+	types []PathType
+	paths map[PathType]string
+}
+
+// Paths returns a new [PathsBuilder], e.g.:
+//
+//	ytdlp.New().SetPaths(ytdlp.Paths().Home("/downloads").Temp("/tmp/ytdlp"))
+func Paths() *PathsBuilder {
+	return &PathsBuilder{paths: map[PathType]string{}}
+}
+
+// Set sets the path for the provided type. Setting the same type more than once
+// replaces the previous path.
+func (p *PathsBuilder) Set(typ PathType, path string) *PathsBuilder {
+	if _, ok := p.paths[typ]; !ok {
+		p.types = append(p.types, typ)
+	}
+
+	p.paths[typ] = path
+	return p
+}
+
+// Home sets the home path, which all other (relative) paths and output templates
+// are relative to.
+func (p *PathsBuilder) Home(path string) *PathsBuilder {
+	return p.Set(PathHome, path)
+}
+
+// Temp sets the path where intermediary files are stored, while downloading.
+func (p *PathsBuilder) Temp(path string) *PathsBuilder {
+	return p.Set(PathTemp, path)
+}
+
+// Subtitle sets the path where subtitles are written.
+func (p *PathsBuilder) Subtitle(path string) *PathsBuilder {
+	return p.Set(PathSubtitle, path)
+}
+
+// Thumbnail sets the path where thumbnails are written.
+func (p *PathsBuilder) Thumbnail(path string) *PathsBuilder {
+	return p.Set(PathThumbnail, path)
+}
+
+// Description sets the path where video descriptions are written.
+func (p *PathsBuilder) Description(path string) *PathsBuilder {
+	return p.Set(PathDescription, path)
+}
+
+// InfoJSON sets the path where info JSON files are written.
+func (p *PathsBuilder) InfoJSON(path string) *PathsBuilder {
+	return p.Set(PathInfoJSON, path)
+}
+
+// Chapter sets the path where split chapters are written.
+func (p *PathsBuilder) Chapter(path string) *PathsBuilder {
+	return p.Set(PathChapter, path)
+}
+
+// Validate returns an error if any of the provided types are unknown to yt-dlp,
+// or any of the paths are empty.
+func (p *PathsBuilder) Validate() error {
+	for _, typ := range p.types {
+		if !slices.Contains(PathTypes, typ) {
+			return fmt.Errorf("unknown path type %q", typ)
+		}
+
+		if p.paths[typ] == "" {
+			return fmt.Errorf("path for type %q is empty", typ)
+		}
+	}
+
+	return nil
+}
+
+// Args returns the "TYPE:PATH" arguments for each of the paths, in the order
+// they were first set.
+func (p *PathsBuilder) Args() []string {
+	args := make([]string, 0, len(p.types))
+
+	for _, typ := range p.types {
+		args = append(args, string(typ)+":"+p.paths[typ])
+	}
+
+	return args
+}
+
+// SetPaths replaces any paths previously set via [Command.Paths] with the paths
+// in the provided [PathsBuilder]. If the builder is invalid (see
+// [PathsBuilder.Validate]), the error is returned when the command is invoked.
+func (c *Command) SetPaths(p *PathsBuilder) *Command {
+	c.UnsetPaths()
+
+	if p == nil {
+		return c
+	}
+
+	if err := p.Validate(); err != nil {
+		c.addFlag(&Flag{
+			ID:   "paths",
+			Flag: "--paths",
+			Args: []string{},
+			err:  fmt.Errorf("unable to set paths: %w", err),
+		})
+		return c
+	}
+
+	for _, arg := range p.Args() {
+		c.Paths(arg)
+	}
+
+	return c
+}
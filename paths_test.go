@@ -0,0 +1,57 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"os"
+	"slices"
+	"testing"
+)
+
+func TestPaths_SetPaths(t *testing.T) {
+	t.Parallel()
+
+	cmd := New().
+		SetExecutable(os.Args[0]). // Avoid resolving yt-dlp.
+		Paths("/old").
+		SetPaths(Paths().Home("/downloads").Temp("/tmp/ytdlp").Subtitle("subs").Home("/media"))
+
+	var args []string
+	for _, f := range cmd.getFlagsByID("paths") {
+		args = append(args, f.Raw()...)
+	}
+
+	expected := []string{"--paths", "home:/media", "--paths", "temp:/tmp/ytdlp", "--paths", "subtitle:subs"}
+
+	if !slices.Equal(args, expected) {
+		t.Fatalf("expected %q, got %q", expected, args)
+	}
+
+	if cmd.buildCommand(context.Background()).Err != nil {
+		t.Fatalf("expected no error, got %v", cmd.buildCommand(context.Background()).Err)
+	}
+}
+
+func TestPaths_Invalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []*PathsBuilder{
+		Paths().Set("invalid", "/foo"),
+		Paths().Home(""),
+	}
+
+	for _, p := range tests {
+		if err := p.Validate(); err == nil {
+			t.Fatalf("expected error for %q", p.Args())
+		}
+
+		cmd := New().SetExecutable("yt-dlp").SetPaths(p)
+
+		if cmd.buildCommand(context.Background()).Err == nil {
+			t.Fatalf("expected error when building command for %q", p.Args())
+		}
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// playlistIndexRemap is the --parse-metadata value which replaces playlist_index
+// with the position of the video in the download queue.
+const playlistIndexRemap = "%(playlist_autonumber)s:%(playlist_index)s"
+
+// ContiguousPlaylistIndex remaps the playlist_index field (e.g. as used in output
+// templates like "%(playlist_index)s - %(title)s.%(ext)s") to the position of the
+// video within the videos being downloaded, rather than within the playlist, so
+// numbered filenames stay contiguous when only some of the playlist is downloaded
+// (e.g. with [Command.PlaylistItems], or a match filter). This is done using
+// [Command.ParseMetadata], so applies to all output templates, including those
+// of [Command.PrintToFile] and similar. The number is zero-padded according to
+// the length of the playlist, the same as playlist_index. autonumber is already
+// contiguous within each invocation, so isn't remapped.
+//
+// To filter entries in Go (e.g. with [FilterEntries]), download the playlist URL
+// with [Command.PlaylistItems] and [PlaylistItemsSpec], instead of the URLs of
+// each entry, so the entries are numbered as part of the playlist.
+//   - See [Command.UnsetContiguousPlaylistIndex], for disabling it.
+func (c *Command) ContiguousPlaylistIndex() *Command {
+	c.UnsetContiguousPlaylistIndex()
+	return c.ParseMetadata(playlistIndexRemap)
+}
+
+// UnsetContiguousPlaylistIndex disables remapping of playlist_index, previously
+// enabled with [Command.ContiguousPlaylistIndex]. Other flags set with
+// [Command.ParseMetadata] are kept.
+func (c *Command) UnsetContiguousPlaylistIndex() *Command {
+	c.mu.Lock()
+	c.flags = slices.DeleteFunc(c.flags, func(f *Flag) bool {
+		return f.ID == "parse_metadata" && slices.Equal(f.Args, []string{playlistIndexRemap})
+	})
+	c.mu.Unlock()
+
+	return c
+}
+
+// PlaylistItemsSpec returns the item spec (for [Command.PlaylistItems]) which
+// selects the provided entries of a playlist, by their playlist index, with
+// consecutive indexes collapsed into ranges (e.g. "1:3,7,9:10"). Entries must
+// belong to the same (non-nested) playlist, and those without a playlist index
+// are skipped. Returns an empty string if no entries have a playlist index.
+func PlaylistItemsSpec(entries []*ExtractedInfo) string {
+	var indexes []int
+
+	for _, entry := range entries {
+		if entry != nil && entry.PlaylistIndex != nil {
+			indexes = append(indexes, *entry.PlaylistIndex)
+		}
+	}
+
+	slices.Sort(indexes)
+	indexes = slices.Compact(indexes)
+
+	var items []string
+
+	for i := 0; i < len(indexes); {
+		j := i
+		for j+1 < len(indexes) && indexes[j+1] == indexes[j]+1 {
+			j++
+		}
+
+		if i == j {
+			items = append(items, strconv.Itoa(indexes[i]))
+		} else {
+			items = append(items, strconv.Itoa(indexes[i])+":"+strconv.Itoa(indexes[j]))
+		}
+
+		i = j + 1
+	}
+
+	return strings.Join(items, ",")
+}
@@ -0,0 +1,50 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCommand_ContiguousPlaylistIndex(t *testing.T) {
+	t.Parallel()
+
+	cmd := New().
+		ParseMetadata("title:%(artist)s - %(title)s").
+		ContiguousPlaylistIndex().
+		ContiguousPlaylistIndex()
+
+	flags := cmd.getFlagsByID("parse_metadata")
+	if len(flags) != 2 || !slices.Equal(flags[1].Args, []string{"%(playlist_autonumber)s:%(playlist_index)s"}) {
+		t.Fatalf("expected playlist index remap to be set once, got %v", flags)
+	}
+
+	cmd.UnsetContiguousPlaylistIndex()
+
+	flags = cmd.getFlagsByID("parse_metadata")
+	if len(flags) != 1 || flags[0].Args[0] != "title:%(artist)s - %(title)s" {
+		t.Fatalf("expected other parse metadata flags to be kept, got %v", flags)
+	}
+}
+
+func TestPlaylistItemsSpec(t *testing.T) {
+	t.Parallel()
+
+	var entries []*ExtractedInfo
+	for _, i := range []int{9, 1, 2, 3, 7, 10, 3} {
+		entries = append(entries, &ExtractedInfo{PlaylistIndex: &i})
+	}
+
+	entries = append(entries, &ExtractedInfo{}, nil)
+
+	if spec := PlaylistItemsSpec(entries); spec != "1:3,7,9:10" {
+		t.Fatalf("expected %q, got %q", "1:3,7,9:10", spec)
+	}
+
+	if spec := PlaylistItemsSpec(nil); spec != "" {
+		t.Fatalf("expected empty spec, got %q", spec)
+	}
+}
@@ -0,0 +1,362 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+const (
+	pluginsDir      = "plugins"        // Directory within [CacheDir] where plugins are installed.
+	pluginNamespace = "yt_dlp_plugins" // Namespace package which all yt-dlp plugins provide.
+	pluginMarker    = ".go-ytdlp"      // File marking plugins installed with [InstallPlugin].
+)
+
+// Plugin is a yt-dlp plugin package installed with [InstallPlugin].
+type Plugin struct {
+	// Name is the name of the plugin package (its directory within [PluginDir]).
+	Name string `json:"name"`
+
+	// Dir is the directory the plugin package is installed in.
+	Dir string `json:"dir"`
+
+	// Extractors are the names of the extractor modules provided by the plugin.
+	Extractors []string `json:"extractors,omitempty"`
+
+	// PostProcessors are the names of the postprocessor modules provided by the
+	// plugin.
+	PostProcessors []string `json:"postprocessors,omitempty"`
+}
+
+// PluginDir returns the directory which plugins installed with [InstallPlugin]
+// are stored in ("plugins" within [CacheDir]). It may not exist yet.
+func PluginDir() (string, error) {
+	dir, err := CacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, pluginsDir), nil
+}
+
+// InstallPlugin installs a yt-dlp plugin package into [PluginDir], from source,
+// which is either a URL (e.g. a GitHub release asset, or the source archive of a
+// repository), or the path to a local file. source must be a zip archive (or a
+// wheel, which is a zip archive) containing the "yt_dlp_plugins" namespace
+// package, either at its root (as with wheels), or within a single directory (as
+// with GitHub source archives). The name of the plugin is the distribution name
+// for wheels, the directory containing "yt_dlp_plugins" if nested, or otherwise
+// the name of the archive. Any existing plugin with the same name is replaced.
+//
+// Once installed, all commands automatically include the plugin directory with
+// --plugin-dirs, unless [Command.PluginDirs] is set, or safe mode is enabled
+// (see [Command.SafeMode]). Note that yt-dlp only loads extractor plugins from
+// directories passed with --plugin-dirs. Of the options, DownloadURLTimeout,
+// Retries, Backoff, Client and GitHubToken are supported. opts may be nil to use
+// the defaults.
+func InstallPlugin(ctx context.Context, source string, opts *InstallOptions) (*Plugin, error) {
+	if opts == nil {
+		opts = &InstallOptions{}
+	}
+
+	dir, err := PluginDir()
+	if err != nil {
+		return nil, err
+	}
+
+	if err = os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("unable to create plugin directory: %w", err)
+	}
+
+	archive := source
+	name := source
+
+	if u, perr := url.Parse(source); perr == nil && (u.Scheme == "http" || u.Scheme == "https") {
+		name = path.Base(u.Path)
+
+		tmp, err := os.CreateTemp(dir, ".download-*.tmp")
+		if err != nil {
+			return nil, fmt.Errorf("unable to create temporary plugin file: %w", err)
+		}
+		_ = tmp.Close()
+
+		archive = tmp.Name()
+		defer os.Remove(archive)
+
+		if err = opts.download(ctx, source, archive, 0o600); err != nil {
+			return nil, fmt.Errorf("unable to download plugin %q: %w", source, err)
+		}
+	}
+
+	zr, err := zip.OpenReader(archive)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open plugin archive %q: %w", source, err)
+	}
+	defer zr.Close()
+
+	root, ok := pluginArchiveRoot(&zr.Reader)
+	if !ok {
+		return nil, fmt.Errorf("plugin archive %q doesn't contain a %s package", source, pluginNamespace)
+	}
+
+	name = pluginName(name, root)
+	if name == "" || name == "." || strings.HasPrefix(name, ".") {
+		return nil, fmt.Errorf("unable to determine name of plugin %q", source)
+	}
+
+	staging, err := os.MkdirTemp(dir, ".install-*.tmp")
+	if err != nil {
+		return nil, fmt.Errorf("unable to create temporary plugin directory: %w", err)
+	}
+	defer os.RemoveAll(staging)
+
+	if err = extractPlugin(&zr.Reader, root, staging); err != nil {
+		return nil, fmt.Errorf("unable to extract plugin %q: %w", source, err)
+	}
+
+	if err = os.WriteFile(filepath.Join(staging, pluginMarker), []byte(source+"\n"), 0o600); err != nil {
+		return nil, fmt.Errorf("unable to mark plugin %q as installed: %w", source, err)
+	}
+
+	dest := filepath.Join(dir, name)
+
+	if err = os.RemoveAll(dest); err != nil {
+		return nil, fmt.Errorf("unable to remove existing plugin %q: %w", name, err)
+	}
+
+	if err = os.Rename(staging, dest); err != nil {
+		return nil, fmt.Errorf("unable to install plugin %q: %w", name, err)
+	}
+
+	return readPlugin(dest)
+}
+
+// ListInstalledPlugins returns the plugins installed with [InstallPlugin], sorted
+// by name. Other directories within [PluginDir] are ignored.
+func ListInstalledPlugins() ([]*Plugin, error) {
+	dir, err := PluginDir()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("unable to read plugin directory: %w", err)
+	}
+
+	var plugins []*Plugin
+
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		plugin, err := readPlugin(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			continue // Not a plugin.
+		}
+
+		plugins = append(plugins, plugin)
+	}
+
+	return plugins, nil
+}
+
+// RemovePlugin removes a plugin installed with [InstallPlugin]. It's not an
+// error if the plugin isn't installed.
+func RemovePlugin(name string) error {
+	dir, err := PluginDir()
+	if err != nil {
+		return err
+	}
+
+	if name == "" || strings.ContainsAny(name, `/\`) || strings.HasPrefix(name, ".") {
+		return fmt.Errorf("invalid plugin name %q", name)
+	}
+
+	if err = os.RemoveAll(filepath.Join(dir, name)); err != nil {
+		return fmt.Errorf("unable to remove plugin %q: %w", name, err)
+	}
+
+	return nil
+}
+
+// readPlugin reads the plugin installed in dir, which must have been installed
+// with [InstallPlugin].
+func readPlugin(dir string) (*Plugin, error) {
+	for _, name := range []string{pluginNamespace, pluginMarker} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			return nil, fmt.Errorf("unable to read plugin: %w", err)
+		}
+	}
+
+	plugin := &Plugin{Name: filepath.Base(dir), Dir: dir}
+	plugin.Extractors = pluginModules(filepath.Join(dir, pluginNamespace, "extractor"))
+	plugin.PostProcessors = pluginModules(filepath.Join(dir, pluginNamespace, "postprocessor"))
+
+	return plugin, nil
+}
+
+// pluginModules returns the names of the python modules in dir, excluding
+// private modules (e.g. "__init__").
+func pluginModules(dir string) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	var modules []string
+
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), ".py")
+		if entry.IsDir() {
+			name, ok = entry.Name(), true
+		}
+
+		if ok && !strings.HasPrefix(name, "_") {
+			modules = append(modules, name)
+		}
+	}
+
+	return modules
+}
+
+// pluginArchiveRoot returns the directory within the archive which contains the
+// "yt_dlp_plugins" package (empty if at the root of the archive).
+func pluginArchiveRoot(zr *zip.Reader) (string, bool) {
+	var roots []string
+
+	for _, f := range zr.File {
+		parts := strings.Split(strings.TrimSuffix(f.Name, "/"), "/")
+
+		i := slices.Index(parts, pluginNamespace)
+		if i < 0 || i > 1 {
+			continue
+		}
+
+		root := strings.Join(parts[:i], "/")
+		if !slices.Contains(roots, root) {
+			roots = append(roots, root)
+		}
+	}
+
+	if len(roots) != 1 {
+		return "", false
+	}
+
+	return roots[0], true
+}
+
+// pluginName returns the name of the plugin, from the name of its archive, and
+// the directory within the archive containing the "yt_dlp_plugins" package.
+func pluginName(archive, root string) string {
+	if root != "" {
+		return root
+	}
+
+	base := filepath.Base(archive)
+
+	if name, ok := strings.CutSuffix(base, ".whl"); ok {
+		name, _, _ = strings.Cut(name, "-")
+		return name
+	}
+
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
+
+// extractPlugin extracts the "yt_dlp_plugins" package (and any files alongside
+// it) from the root directory of the archive into dest.
+func extractPlugin(zr *zip.Reader, root, dest string) error {
+	prefix := ""
+	if root != "" {
+		prefix = root + "/"
+	}
+
+	for _, f := range zr.File {
+		name, ok := strings.CutPrefix(f.Name, prefix)
+		if !ok || name == "" || !strings.HasPrefix(name, pluginNamespace+"/") {
+			continue
+		}
+
+		target := filepath.Join(dest, filepath.FromSlash(name))
+		if !strings.HasPrefix(target, filepath.Clean(dest)+string(os.PathSeparator)) {
+			return fmt.Errorf("invalid file path in archive: %q", f.Name)
+		}
+
+		if f.FileInfo().IsDir() {
+			if err := os.MkdirAll(target, 0o750); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		if err := os.MkdirAll(filepath.Dir(target), 0o750); err != nil {
+			return err
+		}
+
+		if err := extractZipFile(f, target); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// extractZipFile extracts a single file from a zip archive to target.
+func extractZipFile(f *zip.File, target string) (err error) {
+	rc, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	out, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o640)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(out, rc) //nolint:gosec
+	return errors.Join(err, out.Close())
+}
+
+// pluginDirsFlag returns the flag which adds [PluginDir] to the plugin
+// directories of yt-dlp, or nil if flags already configure them, yt-dlp is only
+// invoked for its version, or no plugins were installed with [InstallPlugin].
+func pluginDirsFlag(flags []*Flag) *Flag {
+	for _, f := range flags {
+		if f.ID == "plugin_dirs" || f.Flag == "--version" || (f.ID == rawFlagID && isPluginDirsFlag(f.Flag)) {
+			return nil
+		}
+	}
+
+	plugins, err := ListInstalledPlugins()
+	if err != nil || len(plugins) == 0 {
+		return nil
+	}
+
+	return &Flag{ID: "plugin_dirs", Flag: "--plugin-dirs", Args: []string{filepath.Dir(plugins[0].Dir)}}
+}
+
+// isPluginDirsFlag returns true if the raw flag configures the plugin
+// directories.
+func isPluginDirsFlag(flag string) bool {
+	name, _, _ := strings.Cut(flag, "=")
+	return name == "--plugin-dirs" || name == "--no-plugin-dirs"
+}
@@ -0,0 +1,158 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// newPluginArchive returns a zip archive containing the provided files.
+func newPluginArchive(t *testing.T, files ...string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+
+	zw := zip.NewWriter(&buf)
+	for _, name := range files {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err = w.Write([]byte("# " + name + "\n")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func TestInstallPlugin(t *testing.T) { //nolint:paralleltest
+	if err := SetCacheDir(t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = SetCacheDir("") })
+
+	ctx := context.Background()
+
+	dir, err := PluginDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Plugins which weren't installed with InstallPlugin are ignored.
+	err = os.MkdirAll(filepath.Join(dir, "manual", pluginNamespace, "extractor"), 0o750)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	args := New().SetExecutable("yt-dlp").buildCommand(ctx).Args[1:]
+	if len(args) != 0 {
+		t.Fatalf("expected no plugin dirs without plugins: %q", args)
+	}
+
+	// Wheels have the package at their root.
+	wheel := filepath.Join(t.TempDir(), "yt_dlp_foo-1.0.0-py3-none-any.whl")
+
+	err = os.WriteFile(wheel, newPluginArchive(t,
+		"yt_dlp_plugins/extractor/foo.py",
+		"yt_dlp_plugins/extractor/_private.py",
+		"yt_dlp_plugins/postprocessor/foo_pp.py",
+		"yt_dlp_foo-1.0.0.dist-info/METADATA",
+	), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	plugin, err := InstallPlugin(ctx, wheel, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if plugin.Name != "yt_dlp_foo" ||
+		!slices.Equal(plugin.Extractors, []string{"foo"}) ||
+		!slices.Equal(plugin.PostProcessors, []string{"foo_pp"}) {
+		t.Fatalf("unexpected plugin: %+v", plugin)
+	}
+
+	// Source archives have the package within a single directory.
+	archive := newPluginArchive(t,
+		"yt-dlp-bar-main/README.md",
+		"yt-dlp-bar-main/yt_dlp_plugins/extractor/bar.py",
+	)
+
+	client := &http.Client{Transport: roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(bytes.NewReader(archive)), Request: r}, nil
+	})}
+
+	plugin, err = InstallPlugin(ctx, "https://example.invalid/archive/refs/heads/main.zip", &InstallOptions{Client: client})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if plugin.Name != "yt-dlp-bar-main" || !slices.Equal(plugin.Extractors, []string{"bar"}) {
+		t.Fatalf("unexpected plugin: %+v", plugin)
+	}
+
+	if _, err = os.Stat(filepath.Join(plugin.Dir, "README.md")); !os.IsNotExist(err) {
+		t.Fatalf("expected only the plugin package to be extracted, got %v", err)
+	}
+
+	plugins, err := ListInstalledPlugins()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(plugins) != 2 || plugins[0].Name != "yt-dlp-bar-main" || plugins[1].Name != "yt_dlp_foo" {
+		t.Fatalf("unexpected installed plugins: %+v", plugins)
+	}
+
+	args = New().SetExecutable("yt-dlp").buildCommand(ctx).Args[1:]
+	if !slices.Equal(args, []string{"--plugin-dirs", dir}) {
+		t.Fatalf("expected managed plugin dir to be used: %q", args)
+	}
+
+	args = New().SetExecutable("yt-dlp").PluginDirs("/other").buildCommand(ctx).Args[1:]
+	if !slices.Equal(args, []string{"--plugin-dirs", "/other"}) {
+		t.Fatalf("expected explicit plugin dir to take precedence: %q", args)
+	}
+
+	if args = New().SetExecutable("yt-dlp").BuildVersionCommand(ctx).Args[1:]; slices.Contains(args, "--plugin-dirs") {
+		t.Fatalf("expected plugins not to be loaded when checking the version: %q", args)
+	}
+
+	if args = New().SetExecutable("yt-dlp").SafeMode().buildCommand(ctx).Args[1:]; slices.Contains(args, "--plugin-dirs") {
+		t.Fatalf("expected plugins not to be loaded in safe mode: %q", args)
+	}
+
+	bad := filepath.Join(t.TempDir(), "bad.zip")
+	if err = os.WriteFile(bad, newPluginArchive(t, "README.md"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = InstallPlugin(ctx, bad, nil); err == nil {
+		t.Fatal("expected error for archive without plugins")
+	}
+
+	if err = RemovePlugin("yt_dlp_foo"); err != nil {
+		t.Fatal(err)
+	}
+
+	if plugins, err = ListInstalledPlugins(); err != nil || len(plugins) != 1 {
+		t.Fatalf("expected 1 plugin after removal, got %+v: %v", plugins, err)
+	}
+}
@@ -0,0 +1,196 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// PodcastFeedOptions are the options used to generate a podcast feed with
+// [WritePodcastFeed].
+type PodcastFeedOptions struct {
+	// BaseURL is the URL which the downloaded files are served from. Enclosure
+	// URLs are generated by appending the (escaped) file name to BaseURL.
+	BaseURL string
+
+	// Files maps entry IDs (see [ExtractedInfo.ID]) to the paths of the downloaded
+	// audio files. Entries without a file are excluded from the feed.
+	Files map[string]string
+
+	// Optional overrides for the channel metadata, which otherwise default to the
+	// metadata of the playlist/channel.
+	Title       string
+	Description string
+	Link        string
+	ImageURL    string
+	Author      string
+	Language    string
+}
+
+type podcastRSS struct {
+	XMLName xml.Name       `xml:"rss"`
+	Version string         `xml:"version,attr"`
+	ITunes  string         `xml:"xmlns:itunes,attr"`
+	Channel podcastChannel `xml:"channel"`
+}
+
+type podcastChannel struct {
+	Title       string         `xml:"title"`
+	Link        string         `xml:"link,omitempty"`
+	Description string         `xml:"description"`
+	Language    string         `xml:"language,omitempty"`
+	Author      string         `xml:"itunes:author,omitempty"`
+	Image       *podcastImage  `xml:"itunes:image,omitempty"`
+	Items       []*podcastItem `xml:"item"`
+}
+
+type podcastImage struct {
+	Href string `xml:"href,attr"`
+}
+
+type podcastItem struct {
+	Title       string           `xml:"title"`
+	Description string           `xml:"description,omitempty"`
+	Link        string           `xml:"link,omitempty"`
+	GUID        podcastGUID      `xml:"guid"`
+	PubDate     string           `xml:"pubDate,omitempty"`
+	Duration    string           `xml:"itunes:duration,omitempty"`
+	Enclosure   podcastEnclosure `xml:"enclosure"`
+}
+
+type podcastGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type podcastEnclosure struct {
+	URL    string `xml:"url,attr"`
+	Length int64  `xml:"length,attr"`
+	Type   string `xml:"type,attr"`
+}
+
+// WritePodcastFeed generates a podcast RSS feed from a playlist/channel (e.g. the
+// results of [Command.DumpSingleJSON]) and the audio files downloaded for its
+// entries, writing it to w. Nested playlists are flattened, and entries without
+// a downloaded file are skipped.
+func WritePodcastFeed(w io.Writer, info *ExtractedInfo, opts *PodcastFeedOptions) error {
+	if info == nil {
+		return errors.New("no extracted info provided")
+	}
+
+	if opts == nil || opts.BaseURL == "" {
+		return errors.New("base URL is required to generate podcast feed")
+	}
+
+	channel := podcastChannel{
+		Title:       firstNonEmpty(opts.Title, derefString(info.Title), derefString(info.PlaylistTitle), info.ID),
+		Link:        firstNonEmpty(opts.Link, derefString(info.WebpageURL)),
+		Description: firstNonEmpty(opts.Description, derefString(info.Description)),
+		Language:    opts.Language,
+		Author:      firstNonEmpty(opts.Author, derefString(info.Channel), derefString(info.Uploader)),
+	}
+
+	if image := firstNonEmpty(opts.ImageURL, derefString(info.Thumbnail)); image != "" {
+		channel.Image = &podcastImage{Href: image}
+	}
+
+	entries := FilterEntries(info, func(entry *ExtractedInfo) bool {
+		return opts.Files[entry.ID] != ""
+	})
+
+	for _, entry := range entries {
+		item, err := podcastFeedItem(entry, opts.BaseURL, opts.Files[entry.ID])
+		if err != nil {
+			return err
+		}
+
+		channel.Items = append(channel.Items, item)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return fmt.Errorf("unable to write podcast feed: %w", err)
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+
+	err := enc.Encode(&podcastRSS{
+		Version: "2.0",
+		ITunes:  "http://www.itunes.com/dtds/podcast-1.0.dtd",
+		Channel: channel,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to write podcast feed: %w", err)
+	}
+
+	return nil
+}
+
+// podcastMIMETypes are the enclosure MIME types of common audio extensions, as
+// the system MIME database (used for other extensions) often lacks them, or maps
+// them to video types.
+var podcastMIMETypes = map[string]string{
+	".m4a":  "audio/mp4",
+	".mp3":  "audio/mpeg",
+	".ogg":  "audio/ogg",
+	".opus": "audio/ogg",
+	".webm": "audio/webm",
+}
+
+func podcastFeedItem(entry *ExtractedInfo, baseURL, path string) (*podcastItem, error) {
+	stat, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to stat podcast file for %q: %w", entry.ID, err)
+	}
+
+	mimeType := podcastMIMETypes[strings.ToLower(filepath.Ext(path))]
+	if mimeType == "" {
+		mimeType = mime.TypeByExtension(filepath.Ext(path))
+	}
+	if mimeType == "" {
+		mimeType = "audio/mpeg"
+	}
+
+	item := &podcastItem{
+		Title:       firstNonEmpty(derefString(entry.Title), entry.ID),
+		Description: derefString(entry.Description),
+		Link:        firstNonEmpty(derefString(entry.WebpageURL), derefString(entry.URL)),
+		GUID:        podcastGUID{Value: entry.ID},
+		Enclosure: podcastEnclosure{
+			URL:    strings.TrimSuffix(baseURL, "/") + "/" + url.PathEscape(filepath.Base(path)),
+			Length: stat.Size(),
+			Type:   mimeType,
+		},
+	}
+
+	if uploaded, ok := entryUploadTime(entry); ok {
+		item.PubDate = uploaded.UTC().Format(time.RFC1123Z)
+	}
+
+	if entry.Duration != nil {
+		d := time.Duration(*entry.Duration) * time.Second
+		item.Duration = fmt.Sprintf("%02d:%02d:%02d", int(d.Hours()), int(d.Minutes())%60, int(d.Seconds())%60) //nolint:gomnd
+	}
+
+	return item, nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
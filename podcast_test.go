@@ -0,0 +1,103 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestWritePodcastFeed(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, "Episode 1 [abc].mp3")
+
+	if err := os.WriteFile(file, []byte("12345"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	info := &ExtractedInfo{
+		Type:  ExtractedTypePlaylist,
+		ID:    "PL123",
+		Title: ptr("My Channel & Friends"),
+		Entries: []*ExtractedInfo{
+			{
+				ID:         "abc",
+				Title:      ptr("Episode 1"),
+				Duration:   ptr(3725.0),
+				UploadDate: ptr("20240102"),
+				WebpageURL: ptr("https://example.com/watch?v=abc"),
+			},
+			{ID: "def", Title: ptr("Not downloaded")},
+		},
+	}
+
+	var buf bytes.Buffer
+
+	err := WritePodcastFeed(&buf, info, &PodcastFeedOptions{
+		BaseURL: "https://cdn.example.com/podcast/",
+		Files:   map[string]string{"abc": file},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	out := buf.String()
+
+	for _, expected := range []string{
+		`<title>My Channel &amp; Friends</title>`,
+		`<title>Episode 1</title>`,
+		`<guid isPermaLink="false">abc</guid>`,
+		`<pubDate>Tue, 02 Jan 2024 00:00:00 +0000</pubDate>`,
+		`<itunes:duration>01:02:05</itunes:duration>`,
+		`<enclosure url="https://cdn.example.com/podcast/Episode%201%20%5Babc%5D.mp3" length="5" type="audio/mpeg"></enclosure>`,
+	} {
+		if !strings.Contains(out, expected) {
+			t.Fatalf("expected feed to contain %q, got:\n%s", expected, out)
+		}
+	}
+
+	if strings.Contains(out, "Not downloaded") {
+		t.Fatal("expected entries without files to be skipped")
+	}
+
+	if err = WritePodcastFeed(&buf, info, &PodcastFeedOptions{}); err == nil {
+		t.Fatal("expected error without base URL")
+	}
+}
+
+func TestPodcastFeedItem_MIMEType(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	for ext, want := range map[string]string{
+		".m4a":  "audio/mp4",
+		".OPUS": "audio/ogg",
+		".ogg":  "audio/ogg",
+		".webm": "audio/webm",
+		".mp3":  "audio/mpeg",
+		".xyz":  "audio/mpeg",
+	} {
+		file := filepath.Join(dir, "episode"+ext)
+
+		if err := os.WriteFile(file, nil, 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		item, err := podcastFeedItem(&ExtractedInfo{ID: "abc"}, "https://example.com", file)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if item.Enclosure.Type != want {
+			t.Fatalf("expected type %q for %q, got %q", want, ext, item.Enclosure.Type)
+		}
+	}
+}
@@ -0,0 +1,371 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// ErrPresetNotFound is returned by a [PresetStore] when a preset doesn't exist.
+var ErrPresetNotFound = errors.New("preset not found")
+
+// presetFlagID is the ID used for the placeholder flag added when a preset fails
+// to apply, so the error is returned when the command is invoked.
+const presetFlagID = ":preset"
+
+// Preset is a named set of flags, which can be persisted (see [PresetStore]) and
+// applied to new commands (see [Command.WithPreset]), e.g. for user-configurable
+// quality presets.
+type Preset struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description,omitempty"`
+	Flags       []*Flag `json:"flags"`
+}
+
+// NewPreset returns a new preset with a copy of all flags currently set on the
+// command.
+func NewPreset(name string, cmd *Command) *Preset {
+	cmd.mu.RLock()
+	defer cmd.mu.RUnlock()
+
+	p := &Preset{Name: name, Flags: make([]*Flag, 0, len(cmd.flags))}
+
+	for _, f := range cmd.flags {
+		p.Flags = append(p.Flags, f.Clone())
+	}
+
+	return p
+}
+
+// Validate returns an error if the preset is invalid.
+func (p *Preset) Validate() error {
+	if p.Name == "" {
+		return errors.New("preset name is required")
+	}
+
+	for _, f := range p.Flags {
+		if f == nil || f.ID == "" || f.Flag == "" {
+			return fmt.Errorf("preset %q has an invalid flag", p.Name)
+		}
+
+		if f.ID == rawFlagID {
+			if err := validateRawFlag(f.Flag); err != nil {
+				return fmt.Errorf("preset %q: %w", p.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ApplyPreset applies all flags from the preset to the command. Boolean flags
+// which are already set are replaced. If the preset is invalid, the error will be
+// returned when the command is invoked.
+func (c *Command) ApplyPreset(p *Preset) *Command {
+	if p == nil {
+		return c
+	}
+
+	if err := p.Validate(); err != nil {
+		c.addFlag(&Flag{
+			ID:   presetFlagID,
+			Flag: presetFlagID,
+			Args: []string{},
+			err:  fmt.Errorf("unable to apply preset: %w", err),
+		})
+		return c
+	}
+
+	for _, f := range p.Flags {
+		c.addFlag(f.Clone())
+	}
+
+	return c
+}
+
+// WithPreset applies the named preset from the default preset store (see
+// [SetPresetStore]) to the command. If no store is configured, or the preset
+// cannot be loaded, the error will be returned when the command is invoked.
+func (c *Command) WithPreset(name string) *Command {
+	var p *Preset
+	var err error
+
+	if store := presetStore.Load(); store != nil {
+		p, err = (*store).Get(name)
+	} else {
+		err = errors.New("no preset store configured")
+	}
+
+	if err != nil {
+		c.addFlag(&Flag{
+			ID:   presetFlagID,
+			Flag: presetFlagID,
+			Args: []string{},
+			err:  fmt.Errorf("unable to load preset %q: %w", name, err),
+		})
+		return c
+	}
+
+	return c.ApplyPreset(p)
+}
+
+// PresetStore persists named presets. Implementations must be safe for concurrent
+// use. See [NewFilePresetStore] and [NewMemoryPresetStore].
+type PresetStore interface {
+	// Get returns the preset with the provided name, or [ErrPresetNotFound].
+	Get(name string) (*Preset, error)
+
+	// Put creates or replaces a preset.
+	Put(p *Preset) error
+
+	// Delete removes the preset with the provided name, if it exists.
+	Delete(name string) error
+
+	// List returns all presets, sorted by name.
+	List() ([]*Preset, error)
+}
+
+var presetStore atomic.Pointer[PresetStore]
+
+// SetPresetStore sets the store used by [Command.WithPreset]. Pass nil to unset it.
+func SetPresetStore(store PresetStore) {
+	if store == nil {
+		presetStore.Store(nil)
+		return
+	}
+
+	presetStore.Store(&store)
+}
+
+// MemoryPresetStore is an in-memory [PresetStore].
+type MemoryPresetStore struct {
+	mu      sync.RWMutex
+	presets map[string]*Preset
+}
+
+var _ PresetStore = (*MemoryPresetStore)(nil)
+
+// NewMemoryPresetStore returns a new in-memory preset store, with the provided
+// presets.
+func NewMemoryPresetStore(presets ...*Preset) *MemoryPresetStore {
+	s := &MemoryPresetStore{presets: make(map[string]*Preset, len(presets))}
+
+	for _, p := range presets {
+		s.presets[p.Name] = clonePreset(p)
+	}
+
+	return s
+}
+
+// Get implements [PresetStore].
+func (s *MemoryPresetStore) Get(name string) (*Preset, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	p, ok := s.presets[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrPresetNotFound, name)
+	}
+
+	return clonePreset(p), nil
+}
+
+// Put implements [PresetStore].
+func (s *MemoryPresetStore) Put(p *Preset) error {
+	if err := p.Validate(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.presets[p.Name] = clonePreset(p)
+	s.mu.Unlock()
+
+	return nil
+}
+
+// Delete implements [PresetStore].
+func (s *MemoryPresetStore) Delete(name string) error {
+	s.mu.Lock()
+	delete(s.presets, name)
+	s.mu.Unlock()
+
+	return nil
+}
+
+// List implements [PresetStore].
+func (s *MemoryPresetStore) List() ([]*Preset, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	presets := make([]*Preset, 0, len(s.presets))
+
+	for _, p := range s.presets {
+		presets = append(presets, clonePreset(p))
+	}
+
+	sort.Slice(presets, func(i, j int) bool {
+		return presets[i].Name < presets[j].Name
+	})
+
+	return presets, nil
+}
+
+// FilePresetStore is a [PresetStore] which persists presets to a JSON file on disk.
+// The file is re-read on every access, so changes made by other processes are
+// picked up.
+type FilePresetStore struct {
+	mu   sync.Mutex
+	path string
+}
+
+var _ PresetStore = (*FilePresetStore)(nil)
+
+// NewFilePresetStore returns a new preset store backed by the JSON file at path.
+// The file (and its parent directory) is created on the first [FilePresetStore.Put].
+func NewFilePresetStore(path string) *FilePresetStore {
+	return &FilePresetStore{path: path}
+}
+
+// load reads all presets from disk. The lock must be held.
+func (s *FilePresetStore) load() ([]*Preset, error) {
+	b, err := os.ReadFile(s.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("unable to read presets: %w", err)
+	}
+
+	var presets []*Preset
+
+	if err = json.Unmarshal(b, &presets); err != nil {
+		return nil, fmt.Errorf("unable to decode presets %q: %w", s.path, err)
+	}
+
+	return presets, nil
+}
+
+// save atomically writes all presets to disk. The lock must be held.
+func (s *FilePresetStore) save(presets []*Preset) error {
+	sort.Slice(presets, func(i, j int) bool {
+		return presets[i].Name < presets[j].Name
+	})
+
+	b, err := json.MarshalIndent(presets, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to encode presets: %w", err)
+	}
+
+	if err = os.MkdirAll(filepath.Dir(s.path), 0o750); err != nil {
+		return fmt.Errorf("unable to create preset directory: %w", err)
+	}
+
+	if err = writeFileAtomic(s.path, b, 0o600); err != nil {
+		return fmt.Errorf("unable to write presets: %w", err)
+	}
+
+	return nil
+}
+
+// Get implements [PresetStore].
+func (s *FilePresetStore) Get(name string) (*Preset, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	presets, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, p := range presets {
+		if p.Name == name {
+			return p, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %q", ErrPresetNotFound, name)
+}
+
+// Put implements [PresetStore].
+func (s *FilePresetStore) Put(p *Preset) error {
+	if err := p.Validate(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	presets, err := s.load()
+	if err != nil {
+		return err
+	}
+
+	presets = slices.DeleteFunc(presets, func(pp *Preset) bool {
+		return pp.Name == p.Name
+	})
+
+	return s.save(append(presets, clonePreset(p)))
+}
+
+// Delete implements [PresetStore].
+func (s *FilePresetStore) Delete(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	presets, err := s.load()
+	if err != nil {
+		return err
+	}
+
+	n := len(presets)
+
+	presets = slices.DeleteFunc(presets, func(p *Preset) bool {
+		return p.Name == name
+	})
+
+	if len(presets) == n {
+		return nil
+	}
+
+	return s.save(presets)
+}
+
+// List implements [PresetStore].
+func (s *FilePresetStore) List() ([]*Preset, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	presets, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(presets, func(i, j int) bool {
+		return presets[i].Name < presets[j].Name
+	})
+
+	return presets, nil
+}
+
+// clonePreset returns a deep copy of the preset.
+func clonePreset(p *Preset) *Preset {
+	cp := &Preset{Name: p.Name, Description: p.Description, Flags: make([]*Flag, len(p.Flags))}
+
+	for i, f := range p.Flags {
+		cp.Flags[i] = f.Clone()
+		cp.Flags[i].Args = slices.Clone(f.Args)
+	}
+
+	return cp
+}
@@ -0,0 +1,85 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestFilePresetStore(t *testing.T) {
+	t.Parallel()
+
+	store := NewFilePresetStore(filepath.Join(t.TempDir(), "sub", "presets.json"))
+
+	if _, err := store.Get("audio-high"); !errors.Is(err, ErrPresetNotFound) {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+
+	p := NewPreset("audio-high", New().ExtractAudio().AudioQuality("0").AddRawFlags("--foo=bar"))
+	p.Description = "Best quality audio"
+
+	if err := store.Put(p); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := store.Put(NewPreset("", New())); err == nil {
+		t.Fatal("expected error for preset without name")
+	}
+
+	if err := store.Put(NewPreset("video", New().Format("bv*+ba"))); err != nil {
+		t.Fatal(err)
+	}
+
+	// Re-open, to ensure it's persisted.
+	store = NewFilePresetStore(store.path)
+
+	presets, err := store.List()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(presets) != 2 || presets[0].Name != "audio-high" || presets[1].Name != "video" {
+		t.Fatalf("unexpected presets: %+v", presets)
+	}
+
+	got := New().ApplyPreset(presets[0])
+
+	want := []string{"--extract-audio", "--audio-quality", "0", "--foo=bar"}
+	if args := got.buildCommand(context.Background()).Args[1:]; !slices.Equal(args, want) {
+		t.Fatalf("expected args %v, got %v", want, args)
+	}
+
+	if err = store.Delete("video"); err != nil {
+		t.Fatal(err)
+	}
+
+	if presets, _ = store.List(); len(presets) != 1 {
+		t.Fatalf("expected 1 preset after delete, got %d", len(presets))
+	}
+}
+
+func TestCommand_WithPreset(t *testing.T) { //nolint:paralleltest
+	cmd := New().SetExecutable("yt-dlp").WithPreset("audio-high")
+	if err := cmd.buildCommand(context.Background()).Err; err == nil {
+		t.Fatal("expected error without preset store")
+	}
+
+	SetPresetStore(NewMemoryPresetStore(NewPreset("audio-high", New().ExtractAudio())))
+	t.Cleanup(func() { SetPresetStore(nil) })
+
+	cmd = New().SetExecutable("yt-dlp").WithPreset("audio-high")
+	if args := cmd.buildCommand(context.Background()).Args[1:]; !slices.Equal(args, []string{"--extract-audio"}) {
+		t.Fatalf("unexpected args: %v", args)
+	}
+
+	cmd = New().SetExecutable("yt-dlp").WithPreset("missing")
+	if err := cmd.buildCommand(context.Background()).Err; !errors.Is(err, ErrPresetNotFound) {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
@@ -0,0 +1,107 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"fmt"
+)
+
+// IOPriorityClass is the IO scheduling class of a process (Linux only, see
+// ioprio_set(2)).
+type IOPriorityClass int
+
+const (
+	// IOPriorityDefault leaves the IO scheduling class unchanged.
+	IOPriorityDefault IOPriorityClass = iota
+
+	// IOPriorityRealtime always gets first access to the disk. Requires elevated
+	// privileges.
+	IOPriorityRealtime
+
+	// IOPriorityBestEffort is the default scheduling class, where
+	// [ProcessPriority.IOLevel] determines the priority within the class.
+	IOPriorityBestEffort
+
+	// IOPriorityIdle only gets disk access when no other process needs it.
+	IOPriorityIdle
+)
+
+// ProcessPriority is the scheduling priority of the yt-dlp process, and any child
+// processes it spawns (e.g. ffmpeg), which is useful to prevent background jobs
+// from impacting foreground workloads on shared hosts. See
+// [Command.SetProcessPriority].
+type ProcessPriority struct {
+	// Nice is the niceness of the process, between -20 (highest priority) and 19
+	// (lowest priority). 0 leaves the priority unchanged, and negative values
+	// usually require elevated privileges. On Windows, this is mapped to the
+	// closest priority class (e.g. 1-9 is below normal, 10+ is idle).
+	Nice int
+
+	// IOClass is the IO scheduling class (Linux only).
+	IOClass IOPriorityClass
+
+	// IOLevel is the priority within [IOPriorityRealtime] and
+	// [IOPriorityBestEffort], between 0 (highest priority) and 7 (lowest
+	// priority). Ignored for other classes.
+	IOLevel int
+}
+
+// Validate returns an error if the priority is invalid.
+func (p *ProcessPriority) Validate() error {
+	if p.Nice < -20 || p.Nice > 19 {
+		return fmt.Errorf("invalid niceness %d: must be between -20 and 19", p.Nice)
+	}
+
+	if p.IOClass < IOPriorityDefault || p.IOClass > IOPriorityIdle {
+		return fmt.Errorf("invalid io priority class %d", p.IOClass)
+	}
+
+	if p.IOLevel < 0 || p.IOLevel > 7 {
+		return fmt.Errorf("invalid io priority level %d: must be between 0 and 7", p.IOLevel)
+	}
+
+	return nil
+}
+
+// windowsPriorityClass returns the Windows process creation flag for the
+// priority class closest to the niceness, or 0 if unchanged.
+func (p *ProcessPriority) windowsPriorityClass() uint32 {
+	switch {
+	case p == nil || p.Nice == 0:
+		return 0
+	case p.Nice >= 10: //nolint:gomnd
+		return 0x00000040 // IDLE_PRIORITY_CLASS.
+	case p.Nice > 0:
+		return 0x00004000 // BELOW_NORMAL_PRIORITY_CLASS.
+	case p.Nice <= -10: //nolint:gomnd
+		return 0x00000080 // HIGH_PRIORITY_CLASS.
+	default:
+		return 0x00008000 // ABOVE_NORMAL_PRIORITY_CLASS.
+	}
+}
+
+// SetProcessPriority sets the scheduling priority of the yt-dlp process (and any
+// child processes, e.g. ffmpeg). On Linux and Windows, the priority is applied
+// before yt-dlp is started, and if it cannot be applied (e.g. due to missing
+// privileges), yt-dlp isn't started and an error is returned. On other unix
+// systems, the niceness is applied to the process group right after yt-dlp is
+// started, so child processes spawned before then keep the default priority, and
+// if it cannot be applied, yt-dlp keeps running, and the error is returned once
+// it exits. If the priority is invalid, the error is returned when the command is
+// invoked. Pass nil to unset it.
+func (c *Command) SetProcessPriority(p *ProcessPriority) *Command {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if p == nil {
+		c.priority = nil
+		return c
+	}
+
+	pp := *p
+	c.priority = &pp
+
+	return c
+}
@@ -0,0 +1,84 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+//go:build linux
+
+package ytdlp
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+	"syscall"
+)
+
+const (
+	ioprioWhoProcess = 1  // IOPRIO_WHO_PROCESS.
+	ioprioClassShift = 13 // IOPRIO_CLASS_SHIFT.
+)
+
+// startProcess starts the command with the process priority (if any) applied
+// before exec, so it's inherited by yt-dlp, and any child processes it spawns
+// (e.g. ffmpeg). On Linux, niceness and IO priority are attributes of each thread,
+// which are inherited when forking, so they're set on a dedicated OS thread which
+// starts the command. The thread is never unlocked, so it's terminated (or left
+// idle, if it's the main thread) once the command has started, as its priority
+// can't always be restored.
+func startProcess(cmd *exec.Cmd, p *ProcessPriority) error {
+	if p == nil || (p.Nice == 0 && p.IOClass == IOPriorityDefault) {
+		return cmd.Start()
+	}
+
+	errs := make(chan error, 1)
+
+	go func() {
+		runtime.LockOSThread()
+
+		tid := syscall.Gettid()
+
+		if p.Nice != 0 {
+			if err := syscall.Setpriority(syscall.PRIO_PROCESS, tid, p.Nice); err != nil {
+				errs <- fmt.Errorf("unable to set process priority: unable to set niceness: %w", err)
+				return
+			}
+		}
+
+		if err := setIOPriority(tid, p.IOClass, p.IOLevel); err != nil {
+			errs <- fmt.Errorf("unable to set process priority: unable to set io priority: %w", err)
+			return
+		}
+
+		errs <- cmd.Start()
+	}()
+
+	return <-errs
+}
+
+// setIOPriority sets the IO scheduling class and level of the thread.
+func setIOPriority(tid int, class IOPriorityClass, level int) error {
+	if class == IOPriorityDefault {
+		return nil
+	}
+
+	if class == IOPriorityIdle {
+		level = 0
+	}
+
+	_, _, errno := syscall.Syscall(
+		syscall.SYS_IOPRIO_SET,
+		ioprioWhoProcess,
+		uintptr(tid),
+		uintptr(int(class)<<ioprioClassShift|level),
+	)
+	if errno != 0 {
+		return errno
+	}
+
+	return nil
+}
+
+// applyPriority is a no-op, as the priority is applied by startProcess.
+func (c *Command) applyPriority(_ *exec.Cmd, _ *ProcessPriority) error {
+	return nil
+}
@@ -0,0 +1,18 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+//go:build !linux
+
+package ytdlp
+
+import (
+	"os/exec"
+)
+
+// startProcess starts the command. The process priority (if any) is applied
+// on creation on Windows (see applySyscall), or right after the command has
+// started on other platforms (see applyPriority).
+func startProcess(cmd *exec.Cmd, _ *ProcessPriority) error {
+	return cmd.Start()
+}
@@ -0,0 +1,51 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestCommand_SetProcessPriority(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS != "linux" {
+		t.Skip("requires linux")
+	}
+
+	// Field 19 of /proc/<pid>/stat is the niceness, which must already be applied
+	// to yt-dlp, and child processes it spawns immediately.
+	bin := writeFakeYtdlp(t, "#!/bin/sh\ncut -d' ' -f19 /proc/$$/stat\nsh -c 'cut -d\" \" -f19 /proc/$$/stat'\n")
+
+	result, err := New().
+		SetExecutable(bin).
+		SetProcessPriority(&ProcessPriority{Nice: 10, IOClass: IOPriorityIdle}).
+		Run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Fields(result.Stdout)[0] != "10" || strings.Fields(result.Stdout)[1] != "10" {
+		t.Fatalf("expected niceness 10 for yt-dlp and its child process, got %q", result.Stdout)
+	}
+
+	// The priority doesn't leak to later invocations.
+	result, err = New().SetExecutable(bin).Run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Fields(result.Stdout)[0] != "0" {
+		t.Fatalf("expected default niceness, got %q", result.Stdout)
+	}
+
+	_, err = New().SetExecutable(bin).SetProcessPriority(&ProcessPriority{Nice: 20}).Run(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "invalid niceness") {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
@@ -0,0 +1,30 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+//go:build unix && !linux
+
+package ytdlp
+
+import (
+	"fmt"
+	"os/exec"
+	"syscall"
+)
+
+// applyPriority applies the niceness (if any) to the process group of the
+// started command. Niceness is an attribute of the whole process on these
+// platforms, so it can't be applied before exec without also applying it to the
+// current process, and child processes spawned before it's applied (e.g. ffmpeg)
+// keep the default priority. IO scheduling classes are only supported on Linux.
+func (c *Command) applyPriority(cmd *exec.Cmd, p *ProcessPriority) error {
+	if p == nil || p.Nice == 0 {
+		return nil
+	}
+
+	if err := syscall.Setpriority(syscall.PRIO_PGRP, cmd.Process.Pid, p.Nice); err != nil {
+		return fmt.Errorf("unable to set niceness: %w", err)
+	}
+
+	return nil
+}
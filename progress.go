@@ -5,16 +5,30 @@
 package ytdlp
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"net/url"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 )
 
+// progressPrefix is the start of the prefix of progress lines, which is followed
+// by a random token unique to each command, see [Command.SetProgressPrefix].
 var progressPrefix = []byte("progress:")
 
+// newProgressPrefix returns a new random prefix for progress lines.
+func newProgressPrefix() string {
+	b := make([]byte, 8) //nolint:gomnd
+	_, _ = rand.Read(b)
+
+	return string(progressPrefix) + hex.EncodeToString(b) + ":"
+}
+
 const progressFormat = "%()j"
 
 type progressData struct {
@@ -28,18 +42,34 @@ type progressData struct {
 		TmpFilename        string         `json:"tmpfilename,omitempty"`
 		FragmentIndex      int            `json:"fragment_index,omitempty"`
 		FragmentCount      int            `json:"fragment_count,omitempty"`
+		Speed              float64        `json:"speed,omitempty"`   // Bytes per second.
+		ETA                float64        `json:"eta,omitempty"`     // Seconds.
+		Elapsed            float64        `json:"elapsed,omitempty"` // Seconds.
+		Postprocessor      string         `json:"postprocessor,omitempty"`
 		// There are technically other fields, but these are the important ones.
 	} `json:"progress"`
 	AutoNumber      int `json:"autonumber,omitempty"`
 	VideoAutoNumber int `json:"video_autonumber,omitempty"`
 }
 
+// progressSmoothing is the weight given to the latest speed sample when
+// calculating [ProgressUpdate.SmoothedSpeed] (an exponential moving average).
+const progressSmoothing = 0.3
+
+// progressRate tracks the smoothed download speed of a single download.
+type progressRate struct {
+	bytes    int
+	at       time.Time
+	smoothed float64
+}
+
 type progressHandler struct {
 	fn ProgressCallbackFunc
 
 	mu       sync.Mutex
-	started  map[string]time.Time // Used to track multiple independent downloads.
-	finished map[string]time.Time // Used to track multiple independent downloads.
+	started  map[string]time.Time     // Used to track multiple independent downloads.
+	finished map[string]time.Time     // Used to track multiple independent downloads.
+	rates    map[string]*progressRate // Used to track multiple independent downloads.
 }
 
 func newProgressHandler(fn ProgressCallbackFunc) *progressHandler {
@@ -47,34 +77,81 @@ func newProgressHandler(fn ProgressCallbackFunc) *progressHandler {
 		fn:       fn,
 		started:  make(map[string]time.Time),
 		finished: make(map[string]time.Time),
+		rates:    make(map[string]*progressRate),
 	}
 	return h
 }
 
-func (h *progressHandler) parse(raw json.RawMessage) {
+// smooth updates the smoothed speed of the download, using the speed reported by
+// yt-dlp if available, otherwise the speed since the previous update. Must be
+// called with the lock held.
+func (h *progressHandler) smooth(uuid string, update *ProgressUpdate, now time.Time) {
+	rate, ok := h.rates[uuid]
+	if !ok {
+		rate = &progressRate{}
+		h.rates[uuid] = rate
+	}
+
+	speed := update.Speed
+
+	if speed <= 0 && !rate.at.IsZero() && now.After(rate.at) && update.DownloadedBytes >= rate.bytes {
+		speed = float64(update.DownloadedBytes-rate.bytes) / now.Sub(rate.at).Seconds()
+	}
+
+	rate.bytes = update.DownloadedBytes
+	rate.at = now
+
+	if speed > 0 {
+		if rate.smoothed == 0 {
+			rate.smoothed = speed
+		} else {
+			rate.smoothed = progressSmoothing*speed + (1-progressSmoothing)*rate.smoothed
+		}
+	}
+
+	update.SmoothedSpeed = rate.smoothed
+}
+
+// parse parses the raw progress data, and invokes the progress function. inputs
+// are the inputs of the invocation, used to populate [ProgressUpdate.InputURL].
+// If the progress function panics, the recovered panic is returned.
+func (h *progressHandler) parse(raw json.RawMessage, inputs []string) *ErrCallbackPanic {
 	data := &progressData{}
 
 	err := json.Unmarshal(raw, data)
 	if err != nil {
-		return
+		return nil
 	}
 
 	cleanJSON(data)
 
 	update := ProgressUpdate{
 		Info:            data.Info,
+		InputURL:        matchInputURL(data.Info, inputs),
 		Status:          data.Progress.Status,
 		TotalBytes:      data.Progress.TotalBytes,
 		DownloadedBytes: data.Progress.DownloadedBytes,
 		FragmentIndex:   data.Progress.FragmentIndex,
 		FragmentCount:   data.Progress.FragmentCount,
 		Filename:        data.Progress.Filename,
+		Speed:           data.Progress.Speed,
+		ReportedETA:     time.Duration(data.Progress.ETA * float64(time.Second)),
+		Elapsed:         time.Duration(data.Progress.Elapsed * float64(time.Second)),
+		tmpFilename:     data.Progress.TmpFilename,
 	}
 
 	if update.TotalBytes == 0 {
 		update.TotalBytes = int(data.Progress.TotalBytesEstimate)
 	}
 
+	// Post-processor hooks report their own statuses ("started", "processing" and
+	// "finished"), which shouldn't be confused with those of downloads.
+	if data.Progress.Postprocessor != "" {
+		update.Status = ProgressStatusPostProcessing
+		update.PostProcessor = data.Progress.Postprocessor
+		update.PostProcessorStatus = PostProcessorStatus(data.Progress.Status)
+	}
+
 	if update.Filename == "" {
 		if data.Progress.TmpFilename != "" {
 			update.Filename = data.Progress.TmpFilename
@@ -87,21 +164,71 @@ func (h *progressHandler) parse(raw json.RawMessage) {
 
 	var ok bool
 
+	now := time.Now()
+
 	h.mu.Lock()
 	update.Started, ok = h.started[uuid]
 	if !ok {
-		update.Started = time.Now()
+		update.Started = now
 		h.started[uuid] = update.Started
 	}
 
+	if update.Status == ProgressStatusDownloading {
+		h.smooth(uuid, &update, now)
+	}
+
 	update.Finished, ok = h.finished[uuid]
-	if !ok && update.Status.IsCompletedType() {
+	if !ok && (update.Status.IsCompletedType() || update.PostProcessorStatus == PostProcessorStatusFinished) {
 		update.Finished = time.Now()
 		h.finished[uuid] = update.Finished
 	}
 	h.mu.Unlock()
 
-	h.fn(update)
+	return callSafely("progress", func() { h.fn(update) })
+}
+
+// matchInputURL returns the input which the video was extracted from, by
+// comparing the original URL, then the webpage URL of the video, against the
+// inputs. If there is only a single input, it's always returned.
+func matchInputURL(info *ExtractedInfo, inputs []string) string {
+	if len(inputs) == 1 {
+		return inputs[0]
+	}
+
+	if info == nil || len(inputs) == 0 {
+		return ""
+	}
+
+	for _, u := range []*string{info.OriginalURL, info.WebpageURL} {
+		if u == nil || *u == "" {
+			continue
+		}
+
+		if slices.Contains(inputs, *u) {
+			return *u
+		}
+
+		normalized := normalizeInputURL(*u)
+		for _, input := range inputs {
+			if normalizeInputURL(input) == normalized {
+				return input
+			}
+		}
+	}
+
+	return ""
+}
+
+// normalizeInputURL normalizes a URL for comparison, ignoring the scheme, case
+// of the host, "www." prefix and trailing slashes.
+func normalizeInputURL(raw string) string {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || u.Host == "" {
+		return strings.TrimSuffix(strings.TrimSpace(raw), "/")
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(u.Host), "www.")
+	return host + strings.TrimSuffix(u.EscapedPath(), "/") + "?" + u.RawQuery
 }
 
 // ProgressStatus is the status of the download progress.
@@ -114,11 +241,20 @@ func (s ProgressStatus) IsCompletedType() bool {
 const (
 	ProgressStatusStarting       ProgressStatus = "starting"
 	ProgressStatusDownloading    ProgressStatus = "downloading"
-	ProgressStatusPostProcessing ProgressStatus = "post_processing"
+	ProgressStatusPostProcessing ProgressStatus = "post_processing" // See [ProgressUpdate.PostProcessor].
 	ProgressStatusError          ProgressStatus = "error"
 	ProgressStatusFinished       ProgressStatus = "finished"
 )
 
+// PostProcessorStatus is the status of a single post-processing stage.
+type PostProcessorStatus string
+
+const (
+	PostProcessorStatusStarted    PostProcessorStatus = "started"
+	PostProcessorStatusProcessing PostProcessorStatus = "processing"
+	PostProcessorStatusFinished   PostProcessorStatus = "finished"
+)
+
 // ProgressCallbackFunc is a callback function that is called when (if) we receive
 // progress updates from yt-dlp.
 type ProgressCallbackFunc func(update ProgressUpdate)
@@ -127,6 +263,13 @@ type ProgressCallbackFunc func(update ProgressUpdate)
 type ProgressUpdate struct {
 	Info *ExtractedInfo `json:"info"`
 
+	// InputURL is the input (as passed to [Command.Run]) which the video was
+	// extracted from, correlated using the original and webpage URLs of the
+	// video. This is useful when multiple inputs are passed, as redirects and
+	// playlists mean the URL of the video may differ from the input. Empty if it
+	// couldn't be determined.
+	InputURL string `json:"input_url,omitempty"`
+
 	// Status is the current status of the download.
 	Status ProgressStatus `json:"status"`
 	// TotalBytes is the total number of bytes in the download. If yt-dlp is unable
@@ -139,6 +282,27 @@ type ProgressUpdate struct {
 	// FragmentCount is the total number of fragments in the download.
 	FragmentCount int `json:"fragment_count,omitempty"`
 
+	// Speed is the current download speed in bytes per second, as reported by
+	// yt-dlp. If yt-dlp is unable to determine the speed, this will be 0.
+	Speed float64 `json:"speed,omitempty"`
+	// SmoothedSpeed is an exponential moving average of the download speed in
+	// bytes per second (using the speed since the previous update when yt-dlp
+	// doesn't report one), which is more suitable for display than Speed.
+	SmoothedSpeed float64 `json:"smoothed_speed,omitempty"`
+	// ReportedETA is the estimated time remaining, as reported by yt-dlp. If yt-dlp
+	// is unable to determine the ETA, this will be 0. See also [ProgressUpdate.ETA].
+	ReportedETA time.Duration `json:"eta,omitempty"`
+	// Elapsed is the time elapsed since the download started, as reported by yt-dlp.
+	Elapsed time.Duration `json:"elapsed,omitempty"`
+
+	// PostProcessor is the name of the post-processor (e.g. "Merger", "FFmpegVideoConvertor",
+	// "EmbedThumbnail" or "MoveFiles"), when Status is [ProgressStatusPostProcessing].
+	// Started and Finished are then tracked for each post-processor separately.
+	PostProcessor string `json:"postprocessor,omitempty"`
+	// PostProcessorStatus is the status of the post-processor, when Status is
+	// [ProgressStatusPostProcessing].
+	PostProcessorStatus PostProcessorStatus `json:"postprocessor_status,omitempty"`
+
 	// Filename is the filename of the video being downloaded, if available. Note that
 	// this is not necessarily the same as the destination file, as post-processing
 	// may merge multiple files into one.
@@ -149,6 +313,8 @@ type ProgressUpdate struct {
 	// Finished is the time the download finished. If the download is still in progress,
 	// this will be zero. You can validate with IsZero().
 	Finished time.Time `json:"finished,omitempty"`
+
+	tmpFilename string // Temporary file being written to, if any.
 }
 
 func (p *ProgressUpdate) uuid() string {
@@ -165,6 +331,10 @@ func (p *ProgressUpdate) uuid() string {
 		unique = append(unique, strconv.Itoa(*p.Info.PlaylistIndex))
 	}
 
+	if p.PostProcessor != "" {
+		unique = append(unique, p.PostProcessor)
+	}
+
 	return strings.Join(unique, ":")
 }
 
@@ -178,8 +348,13 @@ func (p *ProgressUpdate) Duration() time.Duration {
 }
 
 // ETA returns the estimated time until the download is complete. If the download is
-// complete, or hasn't started yet, it will return 0.
+// complete, or hasn't started yet, it will return 0. The ETA reported by yt-dlp is
+// used if available (see [ProgressUpdate.ReportedETA]), otherwise it's estimated
+// from the time since the download started.
 func (p *ProgressUpdate) ETA() time.Duration {
+	if p.ReportedETA > 0 && !p.Status.IsCompletedType() {
+		return p.ReportedETA
+	}
 	perc := p.Percent()
 	if perc == 0 || perc == 100 {
 		return 0
@@ -207,21 +382,79 @@ func (p *ProgressUpdate) PercentString() string {
 // ProgressFunc can be used to register a callback function that will be called when
 // yt-dlp sends progress updates. The callback function will be called with any information
 // that yt-dlp is able to provide, including sending separate updates for each file, playlist,
-// etc that may be downloaded.
+// etc that may be downloaded, and for each post-processing stage (e.g. merging formats,
+// or embedding thumbnails), see [ProgressStatusPostProcessing].
 //   - See [Command.UnsetProgressFunc], for unsetting the progress function.
 func (c *Command) ProgressFunc(frequency time.Duration, fn ProgressCallbackFunc) *Command {
 	if frequency < 100*time.Millisecond {
 		frequency = 100 * time.Millisecond
 	}
 
+	c.setProgressFlags(frequency)
+
+	c.mu.Lock()
+	c.progress = newProgressHandler(fn)
+	c.mu.Unlock()
+
+	return c
+}
+
+// setProgressFlags sets the flags required for yt-dlp to send progress updates
+// that can be parsed.
+func (c *Command) setProgressFlags(frequency time.Duration) {
+	c.mu.Lock()
+	if c.progressPrefix == "" {
+		c.progressPrefix = newProgressPrefix()
+	}
+	prefix := c.progressPrefix
+	c.mu.Unlock()
+
 	c.Progress().
 		ProgressDelta(frequency.Seconds()).
-		ProgressTemplate(string(progressPrefix) + progressFormat).
+		ProgressTemplate(progressTemplate("download", prefix)).
+		ProgressTemplate(progressTemplate("postprocess", prefix)).
 		Newline()
+}
 
+// progressTemplate returns the progress template for the provided type, which
+// prints progress lines with prefix.
+func progressTemplate(typ, prefix string) string {
+	return typ + ":" + escapeTemplate(prefix) + progressFormat
+}
+
+// SetProgressPrefix sets the prefix of the progress lines which yt-dlp prints
+// for go-ytdlp to parse (see [Command.ProgressFunc]), which are also filtered
+// out of the output of the invocation (e.g. [Result.Stdout]). By default, each
+// command (including copies from [Command.Clone]) uses a unique random prefix
+// (starting with "progress:"), so output from other flags (e.g. [Command.Print])
+// is never mistaken for progress. The prefix must not contain newlines. Any
+// progress flags already set are updated. Pass an empty string to use a new
+// random prefix.
+func (c *Command) SetProgressPrefix(prefix string) *Command {
 	c.mu.Lock()
-	c.progress = newProgressHandler(fn)
-	c.mu.Unlock()
+	defer c.mu.Unlock()
+
+	c.customProgressPrefix = prefix != ""
+
+	if prefix == "" {
+		prefix = newProgressPrefix()
+	}
+
+	if c.progressPrefix != "" {
+		for i, f := range c.flags {
+			if f.ID != "progress_template" || len(f.Args) == 0 {
+				continue
+			}
+
+			for _, typ := range []string{"download", "postprocess"} {
+				if f.Args[0] == progressTemplate(typ, c.progressPrefix) {
+					c.flags[i] = &Flag{ID: f.ID, Flag: f.Flag, Args: []string{progressTemplate(typ, prefix)}}
+				}
+			}
+		}
+	}
+
+	c.progressPrefix = prefix
 
 	return c
 }
@@ -235,3 +468,68 @@ func (c *Command) UnsetProgressFunc() *Command {
 
 	return c
 }
+
+// progressChanSize is the buffer size of channels returned by [Command.ProgressChan].
+const progressChanSize = 64
+
+// ProgressChan is the same as [Command.ProgressFunc], however progress updates are
+// delivered on the returned channel, which works naturally with select loops and
+// fan-in from multiple commands. The channel is buffered, and if the receiver
+// falls behind, the oldest pending updates are dropped, so yt-dlp is never
+// blocked. The channel is shared by all invocations of the command, and is only
+// closed when cancel is called, which also unsets the progress function (unless
+// it has since been replaced). cancel is safe to call multiple times.
+func (c *Command) ProgressChan(frequency time.Duration) (updates <-chan ProgressUpdate, cancel func()) {
+	ch := make(chan ProgressUpdate, progressChanSize)
+
+	var mu sync.Mutex
+	var closed bool
+
+	if frequency < 100*time.Millisecond {
+		frequency = 100 * time.Millisecond
+	}
+
+	c.setProgressFlags(frequency)
+
+	handler := newProgressHandler(func(update ProgressUpdate) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if closed {
+			return
+		}
+
+		for {
+			select {
+			case ch <- update:
+				return
+			default:
+			}
+
+			// Full, so drop the oldest pending update.
+			select {
+			case <-ch:
+			default:
+			}
+		}
+	})
+
+	c.mu.Lock()
+	c.progress = handler
+	c.mu.Unlock()
+
+	return ch, func() {
+		c.mu.Lock()
+		if c.progress == handler {
+			c.progress = nil
+		}
+		c.mu.Unlock()
+
+		mu.Lock()
+		if !closed {
+			closed = true
+			close(ch)
+		}
+		mu.Unlock()
+	}
+}
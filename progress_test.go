@@ -0,0 +1,258 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"encoding/json"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProgressHandler_Speed(t *testing.T) {
+	t.Parallel()
+
+	var updates []ProgressUpdate
+
+	h := newProgressHandler(func(update ProgressUpdate) {
+		updates = append(updates, update)
+	})
+
+	for _, raw := range []string{
+		`{"info": {"id": "abc"}, "progress": {"status": "downloading", "filename": "abc.mp4", "total_bytes": 1000, "downloaded_bytes": 100, "speed": 100, "eta": 9, "elapsed": 1.5}}`,
+		`{"info": {"id": "abc"}, "progress": {"status": "downloading", "filename": "abc.mp4", "total_bytes": 1000, "downloaded_bytes": 300, "speed": 200, "eta": 3.5, "elapsed": 2.5}}`,
+		`{"info": {"id": "abc"}, "progress": {"status": "finished", "filename": "abc.mp4", "total_bytes": 1000, "downloaded_bytes": 1000, "elapsed": 5}}`,
+	} {
+		if p := h.parse(json.RawMessage(raw), nil); p != nil {
+			t.Fatal(p)
+		}
+	}
+
+	if len(updates) != 3 {
+		t.Fatalf("expected 3 updates, got %d", len(updates))
+	}
+
+	if updates[0].Speed != 100 || updates[0].SmoothedSpeed != 100 || updates[0].Elapsed != 1500*time.Millisecond {
+		t.Fatalf("unexpected first update: %+v", updates[0])
+	}
+
+	if updates[1].SmoothedSpeed != 130 {
+		t.Fatalf("expected smoothed speed of 130, got %f", updates[1].SmoothedSpeed)
+	}
+
+	if updates[1].ReportedETA != 3500*time.Millisecond || updates[1].ETA() != updates[1].ReportedETA {
+		t.Fatalf("expected reported eta of 3.5s, got %s (%s)", updates[1].ReportedETA, updates[1].ETA())
+	}
+
+	if updates[2].ETA() != 0 || updates[2].Elapsed != 5*time.Second {
+		t.Fatalf("unexpected final update: %+v", updates[2])
+	}
+}
+
+func TestProgressHandler_PostProcessing(t *testing.T) {
+	t.Parallel()
+
+	var updates []ProgressUpdate
+
+	h := newProgressHandler(func(update ProgressUpdate) {
+		updates = append(updates, update)
+	})
+
+	for _, raw := range []string{
+		`{"info": {"id": "abc", "filename": "abc.mp4"}, "progress": {"status": "finished", "filename": "abc.f137.mp4", "total_bytes": 10, "downloaded_bytes": 10}}`,
+		`{"info": {"id": "abc", "filename": "abc.mp4"}, "progress": {"status": "started", "postprocessor": "Merger"}}`,
+		`{"info": {"id": "abc", "filename": "abc.mp4"}, "progress": {"status": "finished", "postprocessor": "Merger"}}`,
+		`{"info": {"id": "abc", "filename": "abc.mp4"}, "progress": {"status": "started", "postprocessor": "MoveFiles"}}`,
+	} {
+		if p := h.parse(json.RawMessage(raw), nil); p != nil {
+			t.Fatal(p)
+		}
+	}
+
+	if len(updates) != 4 {
+		t.Fatalf("expected 4 updates, got %d", len(updates))
+	}
+
+	if updates[0].Status != ProgressStatusFinished || updates[0].PostProcessor != "" {
+		t.Fatalf("unexpected download update: %+v", updates[0])
+	}
+
+	for i, expected := range []struct {
+		name   string
+		status PostProcessorStatus
+		done   bool
+	}{
+		{name: "Merger", status: PostProcessorStatusStarted},
+		{name: "Merger", status: PostProcessorStatusFinished, done: true},
+		{name: "MoveFiles", status: PostProcessorStatusStarted},
+	} {
+		update := updates[i+1]
+
+		if update.Status != ProgressStatusPostProcessing || update.PostProcessor != expected.name ||
+			update.PostProcessorStatus != expected.status || update.Finished.IsZero() == expected.done {
+			t.Fatalf("unexpected post-processing update %d: %+v", i, update)
+		}
+	}
+
+	if !updates[1].Started.Equal(updates[2].Started) || updates[3].Started.Before(updates[2].Started) {
+		t.Fatal("expected post-processors to be tracked separately")
+	}
+}
+
+func TestCommand_ProgressChan(t *testing.T) {
+	t.Parallel()
+
+	// More updates than the channel can buffer.
+	script := "#!/bin/sh\n" + fakeProgressPreamble
+	for n := 0; n < progressChanSize+10; n++ {
+		script += `echo "$PROGRESS"'{"info":{"id":"abc","_type":"video"},"progress":{"status":"downloading","downloaded_bytes":` +
+			strconv.Itoa(n) + `,"filename":"abc.mp4"}}'` + "\n"
+	}
+
+	bin := writeFakeYtdlp(t, script)
+
+	cmd := New().SetExecutable(bin)
+	updates, cancel := cmd.ProgressChan(time.Second)
+
+	if _, err := cmd.Run(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	cancel()
+	cancel()
+
+	var received []ProgressUpdate
+	for update := range updates {
+		received = append(received, update)
+	}
+
+	if len(received) != progressChanSize {
+		t.Fatalf("expected %d updates, got %d", progressChanSize, len(received))
+	}
+
+	if last := received[len(received)-1]; last.DownloadedBytes != progressChanSize+9 {
+		t.Fatalf("expected oldest updates to be dropped, got last update with %d bytes", last.DownloadedBytes)
+	}
+
+	cmd.mu.RLock()
+	defer cmd.mu.RUnlock()
+
+	if cmd.progress != nil {
+		t.Fatal("expected progress function to be unset")
+	}
+}
+
+func TestProgressHandler_InputURL(t *testing.T) {
+	t.Parallel()
+
+	var updates []ProgressUpdate
+
+	h := newProgressHandler(func(update ProgressUpdate) {
+		updates = append(updates, update)
+	})
+
+	inputs := []string{
+		"https://www.youtube.com/playlist?list=PL123",
+		"https://youtu.be/def",
+		"https://example.com/video/",
+	}
+
+	for _, raw := range []string{
+		`{"info": {"id": "abc", "original_url": "https://www.youtube.com/playlist?list=PL123", "webpage_url": "https://www.youtube.com/watch?v=abc"}, "progress": {"status": "downloading", "filename": "abc.mp4"}}`,
+		`{"info": {"id": "def", "original_url": "https://youtu.be/def", "webpage_url": "https://www.youtube.com/watch?v=def"}, "progress": {"status": "downloading", "filename": "def.mp4"}}`,
+		`{"info": {"id": "ghi", "webpage_url": "http://EXAMPLE.com/video"}, "progress": {"status": "downloading", "filename": "ghi.mp4"}}`,
+		`{"info": {"id": "jkl", "webpage_url": "https://example.com/other"}, "progress": {"status": "downloading", "filename": "jkl.mp4"}}`,
+	} {
+		if p := h.parse(json.RawMessage(raw), inputs); p != nil {
+			t.Fatal(p)
+		}
+	}
+
+	expected := []string{inputs[0], inputs[1], inputs[2], ""}
+
+	for i, update := range updates {
+		if update.InputURL != expected[i] {
+			t.Fatalf("update %d: expected input url %q, got %q", i, expected[i], update.InputURL)
+		}
+	}
+
+	if p := h.parse(json.RawMessage(`{"info": {"id": "abc"}, "progress": {"status": "downloading"}}`), inputs[:1]); p != nil {
+		t.Fatal(p)
+	}
+
+	if updates[len(updates)-1].InputURL != inputs[0] {
+		t.Fatalf("expected single input to always match, got %q", updates[len(updates)-1].InputURL)
+	}
+}
+
+func TestCommand_SetProgressPrefix(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("requires a posix shell")
+	}
+
+	cmd1 := New().ProgressFunc(time.Second, func(_ ProgressUpdate) {})
+	cmd2 := New().ProgressFunc(time.Second, func(_ ProgressUpdate) {})
+
+	if cmd1.progressPrefix == cmd2.progressPrefix || !strings.HasPrefix(cmd1.progressPrefix, string(progressPrefix)) {
+		t.Fatalf("expected unique progress prefixes, got %q and %q", cmd1.progressPrefix, cmd2.progressPrefix)
+	}
+
+	clone := cmd1.Clone()
+	if clone.progressPrefix == cmd1.progressPrefix || !strings.HasPrefix(clone.progressPrefix, string(progressPrefix)) {
+		t.Fatalf("expected clone to use a new progress prefix, got %q", clone.progressPrefix)
+	}
+
+	flags := clone.getFlagsByID("progress_template")
+	if len(flags) != 2 || flags[0].Args[0] != progressTemplate("download", clone.progressPrefix) {
+		t.Fatalf("expected cloned progress templates to use the new prefix, got %v", flags)
+	}
+
+	if cmd1.getFlagsByID("progress_template")[0].Args[0] != progressTemplate("download", cmd1.progressPrefix) {
+		t.Fatal("expected original progress templates to be unchanged")
+	}
+
+	cmd1.SetProgressPrefix("custom%:")
+
+	flags = cmd1.getFlagsByID("progress_template")
+	if len(flags) != 2 || flags[0].Args[0] != "download:custom%%:"+progressFormat ||
+		flags[1].Args[0] != "postprocess:custom%%:"+progressFormat {
+		t.Fatalf("expected progress templates to use the new prefix, got %v", flags)
+	}
+
+	if cmd1.Clone().progressPrefix != "custom%:" {
+		t.Fatal("expected custom progress prefix to be cloned")
+	}
+
+	script := "#!/bin/sh\n" +
+		`echo 'custom%:{"info":{"id":"abc","_type":"video"},"progress":{"status":"downloading","filename":"abc.mp4"}}'` + "\n" +
+		`echo 'progress:printed by the user'` + "\n" +
+		`echo 'custom%:not json'` + "\n"
+
+	bin := writeFakeYtdlp(t, script)
+
+	var updates int
+
+	result, err := New().
+		SetExecutable(bin).
+		SetProgressPrefix("custom%:").
+		ProgressFunc(time.Second, func(_ ProgressUpdate) { updates++ }).
+		Run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if updates != 1 {
+		t.Fatalf("expected 1 progress update, got %d", updates)
+	}
+
+	if result.Stdout != "progress:printed by the user" {
+		t.Fatalf("expected progress lines to be filtered from stdout, got %q", result.Stdout)
+	}
+}
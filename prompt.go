@@ -0,0 +1,88 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"io"
+	"regexp"
+	"sync"
+)
+
+// PromptCallbackFunc is invoked when yt-dlp interactively prompts for input, e.g.
+// for an account password (when using [Command.Username] without [Command.Password]),
+// or a two-factor authentication code. prompt is the text yt-dlp displayed, e.g.
+// "Type 2-step verification code and press [Return]:". The returned response is
+// written to yt-dlp's stdin. If an error is returned, yt-dlp's stdin is closed,
+// which will typically cause yt-dlp to fail.
+type PromptCallbackFunc func(ctx context.Context, prompt string) (response string, err error)
+
+// rePrompt matches an (unterminated) line which yt-dlp is waiting on input for.
+var rePrompt = regexp.MustCompile(`(?i)(?:\[return\]|password|code|otp|pin)\s*:\s*$`)
+
+// isPrompt returns true if the provided partial line looks like a prompt.
+func isPrompt(line []byte) bool {
+	return rePrompt.Match(line)
+}
+
+// promptHandler responds to prompts detected on stdout/stderr, by writing the
+// responses from the callback to the stdin of the process.
+type promptHandler struct {
+	ctx    context.Context //nolint:containedctx
+	fn     PromptCallbackFunc
+	mu     sync.Mutex
+	stdin  io.WriteCloser
+	panics *callbackPanics
+}
+
+func (h *promptHandler) handle(prompt string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.stdin == nil {
+		return
+	}
+
+	var response string
+	var err error
+
+	if perr := callSafely("prompt", func() { response, err = h.fn(h.ctx, prompt) }); perr != nil {
+		h.panics.add(perr)
+		err = perr
+	}
+
+	if err == nil {
+		_, err = io.WriteString(h.stdin, response+"\n")
+	}
+
+	if err != nil {
+		_ = h.stdin.Close()
+		h.stdin = nil
+	}
+}
+
+// PromptFunc can be used to register a callback function that will be called when
+// yt-dlp interactively prompts for input, rather than the invocation hanging (or
+// failing) while waiting for input that will never arrive. Note that if the process
+// has a controlling terminal, yt-dlp may read passwords from it directly, bypassing
+// the callback.
+//   - See [Command.UnsetPromptFunc], for unsetting the prompt function.
+func (c *Command) PromptFunc(fn PromptCallbackFunc) *Command {
+	c.mu.Lock()
+	c.promptFn = fn
+	c.mu.Unlock()
+
+	return c
+}
+
+// UnsetPromptFunc can be used to unset the prompt function that was previously set
+// with [Command.PromptFunc].
+func (c *Command) UnsetPromptFunc() *Command {
+	c.mu.Lock()
+	c.promptFn = nil
+	c.mu.Unlock()
+
+	return c
+}
@@ -0,0 +1,67 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestPrompt_IsPrompt(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]bool{
+		"Type 2-step verification code and press [Return]: ": true,
+		"Type account password and press [Return]: ":         true,
+		"Password:":                   true,
+		"[youtube] abc: Downloading ": false,
+		"[download] Destination: foo": false,
+		"":                            false,
+	}
+
+	for line, expected := range tests {
+		if isPrompt([]byte(line)) != expected {
+			t.Fatalf("expected isPrompt(%q) to be %v", line, expected)
+		}
+	}
+}
+
+func TestPrompt_PromptFunc(t *testing.T) {
+	t.Parallel()
+
+	bin := writeFakeYtdlp(t, "#!/bin/sh\nprintf 'Type 2-step verification code and press [Return]: ' >&2\nread code\necho \"code=$code\"\n")
+
+	var prompts []string
+
+	result, err := New().
+		SetExecutable(bin).
+		PromptFunc(func(_ context.Context, prompt string) (string, error) {
+			prompts = append(prompts, prompt)
+			return "123456", nil
+		}).
+		Run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(prompts) != 1 || prompts[0] != "Type 2-step verification code and press [Return]:" {
+		t.Fatalf("unexpected prompts: %q", prompts)
+	}
+
+	if result.Stdout != "code=123456" {
+		t.Fatalf("expected response to be written to stdin, got stdout %q", result.Stdout)
+	}
+
+	_, err = New().
+		SetExecutable(bin).
+		PromptFunc(func(_ context.Context, _ string) (string, error) {
+			return "", errors.New("no code available")
+		}).
+		Run(context.Background())
+	if err != nil {
+		t.Fatalf("expected closed stdin to be handled by the process, got %v", err)
+	}
+}
@@ -0,0 +1,118 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"regexp"
+	"strings"
+)
+
+// FormatDownload records which format was downloaded for a video, and how. When
+// multiple formats are merged (e.g. "137+140"), there is one FormatDownload per
+// format. See [Result.FormatDownloads].
+type FormatDownload struct {
+	// VideoID is the ID of the video the format belongs to.
+	VideoID string `json:"video_id"`
+
+	// FormatID is the ID of the downloaded format.
+	FormatID string `json:"format_id"`
+
+	// Downloader is the downloader used for the format (e.g. "http", "hlsnative",
+	// "dashsegments", "ffmpeg", or "aria2c"). Only available when yt-dlp is
+	// invoked with [Command.Verbose], otherwise empty.
+	Downloader string `json:"downloader,omitempty"`
+
+	// Filename is the destination file of the format, if known.
+	Filename string `json:"filename,omitempty"`
+}
+
+var (
+	// "[info] <id>: Downloading 1 format(s): 137+140".
+	reFormatSelection = regexp.MustCompile(`^\[info\] (.+): Downloading \d+ format\(s\): (.+)$`)
+	// `[debug] Invoking http downloader on "https://..."`.
+	reDownloaderInvoked = regexp.MustCompile(`^\[debug\] Invoking (\S+) downloader on `)
+	// "[download] Destination: <file>".
+	reDownloadDestination = regexp.MustCompile(`^\[download\] Destination: (.+)$`)
+	// "[info] Writing video subtitles to: <file>", which are downloaded like formats.
+	reSubtitleDestination = regexp.MustCompile(`^\[info\] Writing video subtitles to: (.+)$`)
+)
+
+// parseFormatDownloads parses which formats were downloaded (and with which
+// downloader), from the output logs of an invocation.
+func parseFormatDownloads(logs []*ResultLog) (downloads []*FormatDownload) {
+	var pending []*FormatDownload // Selected formats which haven't started downloading yet.
+	var current *FormatDownload
+	var subtitle string // Subtitle file currently being downloaded, which should be ignored.
+
+	next := func() *FormatDownload {
+		if len(pending) == 0 {
+			return nil
+		}
+
+		current, pending = pending[0], pending[1:]
+		downloads = append(downloads, current)
+
+		return current
+	}
+
+	for _, log := range logs {
+		if log.JSON != nil {
+			continue
+		}
+
+		line := strings.TrimSpace(log.Line)
+
+		if m := reFormatSelection.FindStringSubmatch(line); m != nil {
+			pending, current = nil, nil
+
+			for _, group := range strings.Split(m[2], ",") {
+				for _, id := range strings.Split(strings.TrimSpace(group), "+") {
+					pending = append(pending, &FormatDownload{VideoID: m[1], FormatID: id})
+				}
+			}
+
+			continue
+		}
+
+		if m := reSubtitleDestination.FindStringSubmatch(line); m != nil {
+			subtitle = m[1]
+			continue
+		}
+
+		if m := reDownloaderInvoked.FindStringSubmatch(line); m != nil {
+			if subtitle != "" {
+				continue
+			}
+
+			// Downloader is always logged before the destination.
+			if f := next(); f != nil {
+				f.Downloader = m[1]
+			}
+
+			continue
+		}
+
+		if m := reDownloadDestination.FindStringSubmatch(line); m != nil {
+			if subtitle != "" {
+				if m[1] == subtitle {
+					subtitle = ""
+				}
+
+				continue
+			}
+
+			f := current
+			if f == nil || f.Filename != "" {
+				f = next()
+			}
+
+			if f != nil {
+				f.Filename = m[1]
+			}
+		}
+	}
+
+	return downloads
+}
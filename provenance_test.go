@@ -0,0 +1,53 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseFormatDownloads(t *testing.T) {
+	t.Parallel()
+
+	verbose := `[youtube] abc: Downloading webpage
+[info] abc: Downloading subtitles: en
+[info] abc: Downloading 1 format(s): 137+140
+[info] Writing video subtitles to: video.en.vtt
+[debug] Invoking http downloader on "https://example.com/subs"
+[download] Destination: video.en.vtt
+[debug] Invoking dashsegments downloader on "https://example.com/137"
+[download] Destination: video.f137.mp4
+[download] 100% of 10.00MiB
+[debug] Invoking http downloader on "https://example.com/140"
+[download] Destination: video.f140.m4a
+[Merger] Merging formats into "video.mp4"`
+
+	quiet := `[info] def: Downloading 1 format(s): 18
+[download] Destination: other.mp4`
+
+	var logs []*ResultLog
+	for _, line := range strings.Split(verbose+"\n"+quiet, "\n") {
+		logs = append(logs, &ResultLog{Line: line})
+	}
+
+	expected := []FormatDownload{
+		{VideoID: "abc", FormatID: "137", Downloader: "dashsegments", Filename: "video.f137.mp4"},
+		{VideoID: "abc", FormatID: "140", Downloader: "http", Filename: "video.f140.m4a"},
+		{VideoID: "def", FormatID: "18", Filename: "other.mp4"},
+	}
+
+	downloads := parseFormatDownloads(logs)
+
+	if len(downloads) != len(expected) {
+		t.Fatalf("expected %d downloads, got %d", len(expected), len(downloads))
+	}
+
+	for i, d := range downloads {
+		if *d != expected[i] {
+			t.Fatalf("unexpected download %d: got %+v, expected %+v", i, *d, expected[i])
+		}
+	}
+}
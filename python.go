@@ -0,0 +1,107 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+)
+
+// pythonModule configures invoking yt-dlp via "python -m yt_dlp".
+type pythonModule struct {
+	python string // Python interpreter.
+	repo   string // yt-dlp source checkout, added to PYTHONPATH.
+}
+
+// SetPythonModuleMode invokes yt-dlp as "python -m yt_dlp" rather than using the
+// yt-dlp executable, which is useful for testing patched yt-dlp source trees
+// (e.g. when developing extractors) through the same API. pythonPath is the
+// Python interpreter to use (defaulting to "python3", or "python" on Windows, if
+// empty). If repoPath is provided, it should be the root of a yt-dlp source
+// checkout (containing the "yt_dlp" package), which is prepended to PYTHONPATH.
+// Takes precedence over [Command.SetExecutable].
+//   - See [Command.UnsetPythonModuleMode], for disabling it.
+func (c *Command) SetPythonModuleMode(pythonPath, repoPath string) *Command {
+	if pythonPath == "" {
+		pythonPath = "python3"
+
+		if runtime.GOOS == "windows" {
+			pythonPath = "python"
+		}
+	}
+
+	c.mu.Lock()
+	c.python = &pythonModule{python: pythonPath, repo: repoPath}
+	c.mu.Unlock()
+
+	return c
+}
+
+// UnsetPythonModuleMode disables invoking yt-dlp as a Python module, previously
+// enabled with [Command.SetPythonModuleMode].
+func (c *Command) UnsetPythonModuleMode() *Command {
+	c.mu.Lock()
+	c.python = nil
+	c.mu.Unlock()
+
+	return c
+}
+
+// args returns the arguments to pass to the interpreter, before any yt-dlp args.
+func (p *pythonModule) args() []string {
+	return []string{"-m", "yt_dlp"}
+}
+
+// validate ensures the source checkout (if any) contains the yt_dlp package.
+func (p *pythonModule) validate() error {
+	if p.repo == "" {
+		return nil
+	}
+
+	_, err := os.Stat(filepath.Join(p.repo, "yt_dlp", "__main__.py"))
+	if err != nil {
+		return fmt.Errorf("unable to use python module mode: %q is not a yt-dlp source checkout: %w", p.repo, err)
+	}
+
+	return nil
+}
+
+// environ returns the environment for the invocation, with the source checkout
+// (if any) prepended to PYTHONPATH. env is the environment explicitly set on the
+// command, and if empty, the environment of the current process is used, as it
+// would otherwise be inherited.
+func (p *pythonModule) environ(env map[string]string) []string {
+	var out []string
+
+	if len(env) > 0 {
+		for k, v := range env {
+			out = append(out, k+"="+v)
+		}
+	} else {
+		out = os.Environ()
+	}
+
+	if p.repo == "" {
+		return out
+	}
+
+	repo, err := filepath.Abs(p.repo)
+	if err != nil {
+		repo = p.repo
+	}
+
+	current, _ := lookupEnv(env, "PYTHONPATH")
+	if len(env) == 0 {
+		current = os.Getenv("PYTHONPATH")
+	}
+
+	if current != "" {
+		repo += string(os.PathListSeparator) + current
+	}
+
+	return append(out, "PYTHONPATH="+repo)
+}
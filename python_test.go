@@ -0,0 +1,59 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCommand_SetPythonModuleMode(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	repo := filepath.Join(dir, "yt-dlp")
+
+	python := writeFakeExecutable(t, dir, "python", "#!/bin/sh\necho \"$PYTHONPATH\"\necho \"$@\"\n")
+
+	if err := os.MkdirAll(filepath.Join(repo, "yt_dlp"), 0o750); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(repo, "yt_dlp", "__main__.py"), nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := New().
+		SetExecutable("/nonexistent/yt-dlp").
+		SetEnvVar("PYTHONPATH", "/other").
+		SetPythonModuleMode(python, repo).
+		NoColors()
+
+	result, err := cmd.Run(context.Background(), "https://example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := repo + string(os.PathListSeparator) + "/other\n-m yt_dlp --no-colors https://example.com"
+	if result.Stdout != expected {
+		t.Fatalf("expected stdout %q, got %q", expected, result.Stdout)
+	}
+
+	_, err = New().SetPythonModuleMode(python, dir).Run(context.Background())
+	if err == nil {
+		t.Fatal("expected error for invalid source checkout")
+	}
+
+	result, err = cmd.UnsetPythonModuleMode().SetExecutable(python).Run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Stdout != "/other\n--no-colors" {
+		t.Fatalf("expected python module mode to be disabled, got %q", result.Stdout)
+	}
+}
@@ -0,0 +1,70 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// runRecorded invokes the provided command (which is modified), with yt-dlp
+// printing each of the templates (which must output JSON, e.g. "%(.{id})j") to
+// a temporary file, returning the decoded records for which keep returns true.
+// Records are returned even if the invocation fails.
+func runRecorded[T any](ctx context.Context, cmd *Command, templates []string, keep func(r *T) bool, args ...string) (*Result, []*T, error) {
+	f, err := os.CreateTemp("", "go-ytdlp-records-*.jsonl")
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to create records file: %w", err)
+	}
+	_ = f.Close()
+	defer os.Remove(f.Name()) //nolint:errcheck
+
+	for _, tmpl := range templates {
+		cmd.printToFileInternal(tmpl, f.Name())
+	}
+
+	result, err := cmd.Run(ctx, args...)
+
+	records, rerr := readRecords(f.Name(), keep)
+	if rerr != nil && err == nil {
+		err = rerr
+	}
+
+	return result, records, err
+}
+
+// readRecords reads all records from the provided JSON lines file, skipping
+// lines which can't be decoded, or for which keep returns false.
+func readRecords[T any](path string, keep func(r *T) bool) ([]*T, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read records: %w", err)
+	}
+	defer f.Close()
+
+	var records []*T
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1024*1024) //nolint:gomnd
+
+	for scanner.Scan() {
+		r := new(T)
+
+		if err = json.Unmarshal(scanner.Bytes(), r); err != nil || !keep(r) {
+			continue
+		}
+
+		records = append(records, r)
+	}
+
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read records: %w", err)
+	}
+
+	return records, nil
+}
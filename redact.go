@@ -0,0 +1,81 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// secretHeaders are the (upper-case) names of headers whose values are always
+// redacted from logs. Other headers are only redacted if they are "X-" prefixed
+// and look like a secret (e.g. "X-Api-Key").
+var secretHeaders = []string{"AUTHORIZATION", "PROXY-AUTHORIZATION", "COOKIE", "SET-COOKIE"}
+
+// reHeader matches headers within log lines, in the formats used by yt-dlp
+// (e.g. "header: Set-Cookie: a=b", "send: b'...\r\nCookie: a=b\r\n...'",
+// "'Authorization:Bearer abc'" and "{'Cookie': 'a=b'}"). The value ends at an
+// escaped line break, or a quote.
+var reHeader = regexp.MustCompile(`(^|[\s'"{,]|\\n)([A-Za-z][A-Za-z0-9-]*)('?\s*:\s*'?)((?:\\[^rn]|[^\\'"])*)`)
+
+// SetHeaderRedaction sets whether the values of sensitive headers (e.g.
+// Authorization and Cookie) are redacted (replaced with [MaskedValue]) from log
+// lines captured in results and passed to [Command.LogFunc], when the headers
+// may be logged by yt-dlp, i.e. with [Command.PrintTraffic] or
+// [Command.Verbose]. Enabled by default. Output written to writers set with
+// [Command.SetStdout] and similar is never redacted.
+func (c *Command) SetHeaderRedaction(enabled bool) *Command {
+	c.mu.Lock()
+	c.noHeaderRedaction = !enabled
+	c.mu.Unlock()
+
+	return c
+}
+
+// mayLogHeaders returns true if yt-dlp may log headers, based on the flags of
+// the command.
+func (c *Command) mayLogHeaders() bool {
+	if c.getFlagsByID("debug_printtraffic") != nil || c.getFlagsByID("verbose") != nil {
+		return true
+	}
+
+	for _, f := range c.getFlagsByID(rawFlagID) {
+		if slices.Contains([]string{"-v", "--verbose", "--print-traffic", "--dump-headers"}, f.Flag) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isSecretHeader returns true if the value of the header should be redacted.
+func isSecretHeader(name string) bool {
+	name = strings.ToUpper(name)
+	return slices.Contains(secretHeaders, name) || (strings.HasPrefix(name, "X-") && isSecretName(name))
+}
+
+// redactHeaders replaces the values of sensitive headers in the log line with
+// [MaskedValue].
+func redactHeaders(line string) string {
+	if !strings.Contains(line, ":") {
+		return line
+	}
+
+	return reHeader.ReplaceAllStringFunc(line, func(match string) string {
+		m := reHeader.FindStringSubmatch(match)
+
+		if !isSecretHeader(m[2]) {
+			// The value may contain other headers, e.g. "header: Cookie: a=b".
+			return m[1] + m[2] + m[3] + redactHeaders(m[4])
+		}
+
+		if m[4] == "" {
+			return match
+		}
+
+		return m[1] + m[2] + m[3] + MaskedValue
+	})
+}
@@ -0,0 +1,67 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestRedactHeaders(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		`header: Set-Cookie: YSC=abc; path=/; domain=.youtube.com`:                                                                   `header: Set-Cookie: xxxxx`,
+		`send: b'GET /watch HTTP/1.1\r\nHost: www.youtube.com\r\nCookie: SID=abc\r\nAuthorization: Bearer abc\r\n\r\n'`:              `send: b'GET /watch HTTP/1.1\r\nHost: www.youtube.com\r\nCookie: xxxxx\r\nAuthorization: xxxxx\r\n\r\n'`,
+		`[debug] Command-line config: ['--add-headers', 'Authorization:Bearer abc', '--add-headers', 'Referer:https://example.com']`: `[debug] Command-line config: ['--add-headers', 'Authorization:xxxxx', '--add-headers', 'Referer:https://example.com']`,
+		`[debug] HTTP headers: {'Cookie': 'SID=abc', 'X-Api-Key': 'abc', 'Accept': '*/*'}`:                                           `[debug] HTTP headers: {'Cookie': 'xxxxx', 'X-Api-Key': 'xxxxx', 'Accept': '*/*'}`,
+		`[youtube] abc: Downloading webpage`: `[youtube] abc: Downloading webpage`,
+		`[youtube] KEY: Downloading webpage`: `[youtube] KEY: Downloading webpage`,
+	}
+
+	for in, want := range tests {
+		if got := redactHeaders(in); got != want {
+			t.Fatalf("unexpected redaction of %q:\nwant: %q\ngot:  %q", in, want, got)
+		}
+	}
+}
+
+func TestCommand_SetHeaderRedaction(t *testing.T) {
+	t.Parallel()
+
+	bin := writeFakeYtdlp(t, "#!/bin/sh\necho 'header: Set-Cookie: SID=secret' >&2\n")
+
+	var logged []string
+
+	res, err := New().SetExecutable(bin).PrintTraffic().LogFunc(func(log ResultLog) {
+		logged = append(logged, log.Line)
+	}).Run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(res.Stderr, "secret") || strings.Join(logged, "\n") != "header: Set-Cookie: "+MaskedValue {
+		t.Fatalf("expected cookie to be redacted: %q (logged: %q)", res.Stderr, logged)
+	}
+
+	res, err = New().SetExecutable(bin).PrintTraffic().SetHeaderRedaction(false).Run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(res.Stderr, "secret") {
+		t.Fatalf("expected cookie not to be redacted when disabled: %q", res.Stderr)
+	}
+
+	res, err = New().SetExecutable(bin).Run(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(res.Stderr, "secret") {
+		t.Fatalf("expected no redaction without traffic or verbose logging: %q", res.Stderr)
+	}
+}
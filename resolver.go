@@ -0,0 +1,77 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// Resolver resolves (and optionally installs) the yt-dlp executable, caching the
+// result. By default, all commands share a process-wide resolver (which is also
+// used by [Install]). Commands can use a separate resolver with
+// [Command.SetResolver], which allows using multiple yt-dlp executables within a
+// single process (e.g. stable and nightly, or per-tenant installs), using a
+// different [InstallOptions.CacheDir] for each.
+//
+// Resolver is safe for concurrent use.
+type Resolver struct {
+	opts  InstallOptions
+	mu    sync.Mutex // Ensures only one install invocation is running at a time.
+	cache atomic.Pointer[ResolvedInstall]
+}
+
+// NewResolver returns a new resolver, which uses the provided options when
+// resolving and installing. opts may be nil to use the defaults.
+func NewResolver(opts *InstallOptions) *Resolver {
+	rs := &Resolver{}
+
+	if opts != nil {
+		rs.opts = *opts
+	}
+
+	return rs
+}
+
+// Install is the same as [Install], using the options of the resolver, and caching
+// the resolved install in the resolver, rather than process-wide.
+func (rs *Resolver) Install(ctx context.Context) (*ResolvedInstall, error) {
+	return rs.install(ctx, &rs.opts)
+}
+
+// Resolve returns the install cached by [Resolver.Install], or resolves the yt-dlp
+// executable (from [InstallOptions.CacheDir] first, then PATH), without
+// downloading it.
+func (rs *Resolver) Resolve(ctx context.Context) (*ResolvedInstall, error) {
+	return rs.resolveExecutable(ctx, rs.opts.CacheDir, rs.opts.Version, true, false)
+}
+
+// Reset clears the resolved install cached in the resolver, so the executable is
+// resolved again on next use (e.g. after it was upgraded).
+func (rs *Resolver) Reset() {
+	rs.cache.Store(nil)
+}
+
+// resolve is the same as [Resolver.Resolve], using the process-wide resolver if
+// rs is nil.
+func (rs *Resolver) resolve(ctx context.Context) (*ResolvedInstall, error) {
+	if rs == nil {
+		rs = defaultResolver
+	}
+
+	return rs.Resolve(ctx)
+}
+
+// SetResolver sets the resolver used to resolve the yt-dlp executable, when one
+// isn't explicitly set with [Command.SetExecutable]. Pass nil to use the
+// process-wide resolver (the default), which is shared with [Install].
+func (c *Command) SetResolver(rs *Resolver) *Command {
+	c.mu.Lock()
+	c.resolver = rs
+	c.mu.Unlock()
+
+	return c
+}
@@ -0,0 +1,94 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCommand_SetResolver(t *testing.T) {
+	t.Parallel()
+
+	newResolver := func(version string) *Resolver {
+		dir := t.TempDir()
+
+		writeFakeExecutable(t, dir, "yt-dlp", "#!/bin/sh\necho "+version+"\n")
+
+		return NewResolver(&InstallOptions{CacheDir: dir, DisableDownload: true, AllowVersionMismatch: true})
+	}
+
+	stable := newResolver("2000.01.01")
+	nightly := newResolver("2000.01.02")
+
+	for _, rs := range []*Resolver{stable, nightly} {
+		r, err := rs.Install(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !r.FromCache || r.Executable != filepath.Join(rs.opts.CacheDir, "yt-dlp") {
+			t.Fatalf("unexpected resolved install: %+v", r)
+		}
+
+		cmd := New().SetResolver(rs).Clone().buildCommand(context.Background())
+		if cmd.Err != nil || cmd.Path != r.Executable {
+			t.Fatalf("expected command to use %q, got %q (err: %v)", r.Executable, cmd.Path, cmd.Err)
+		}
+	}
+
+	r, err := nightly.Resolve(context.Background())
+	if err != nil || r.Version != "2000.01.02" {
+		t.Fatalf("unexpected resolved nightly install: %+v (err: %v)", r, err)
+	}
+
+	if defaultResolver.cache.Load() == r {
+		t.Fatal("expected process-wide resolver to not be affected")
+	}
+
+	// Removing the executable should only affect resolution once the cache is reset.
+	if err = os.Remove(r.Executable); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = nightly.Resolve(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	nightly.Reset()
+
+	if r, err = nightly.Resolve(context.Background()); err == nil && r.Executable == filepath.Join(nightly.opts.CacheDir, "yt-dlp") {
+		t.Fatal("expected executable to be resolved again after reset")
+	}
+}
+
+func TestResolver_InstallVersion(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	for _, version := range []string{Version, "2000.01.02"} {
+		writeFakeExecutable(t, dir, "yt-dlp-"+version, "#!/bin/sh\necho "+version+"\n")
+	}
+
+	rs := NewResolver(&InstallOptions{CacheDir: dir, DisableDownload: true})
+
+	r, err := rs.Install(context.Background())
+	if err != nil || r.Version != Version {
+		t.Fatalf("unexpected resolved install: %+v (err: %v)", r, err)
+	}
+
+	// A specific version must not return the previously cached install.
+	r, err = rs.install(context.Background(), &InstallOptions{CacheDir: dir, DisableDownload: true, Version: "2000.01.02"})
+	if err != nil || r.Version != "2000.01.02" || r.Executable != filepath.Join(dir, "yt-dlp-2000.01.02") {
+		t.Fatalf("expected requested version to be installed, got %+v (err: %v)", r, err)
+	}
+
+	if _, err = rs.install(context.Background(), &InstallOptions{CacheDir: dir, DisableDownload: true, Version: "2000.01.03"}); err == nil {
+		t.Fatal("expected error for version which isn't installed")
+	}
+}
@@ -7,11 +7,16 @@ package ytdlp
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"maps"
 	"reflect"
 	"slices"
 	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 	"unicode"
 )
@@ -25,8 +30,34 @@ type Result struct {
 	Executable string `json:"executable"`
 
 	// Args are the arguments that were passed to yt-dlp, excluding the executable.
+	// Secrets are replaced with [MaskedValue], see [Command.DryRun].
 	Args []string `json:"args"`
 
+	// Flags are the flags that were set on the command when it was invoked, with
+	// secrets replaced with [MaskedValue]. Flags added by go-ytdlp itself for the
+	// invocation (e.g. to record downloaded files) aren't included. See also
+	// [Result.Command].
+	Flags []*Flag `json:"flags"`
+
+	// SafeMode is true if the command was invoked in safe mode (see
+	// [Command.SafeMode]).
+	SafeMode bool `json:"safe_mode,omitempty"`
+
+	// DroppedFlags are the flags which were set on the command, but dropped due to
+	// the duplicate strategy set with [Command.SetDuplicateStrategy].
+	DroppedFlags []*Flag `json:"dropped_flags,omitempty"`
+
+	// Inputs are the additional arguments (commonly URLs) that were passed when
+	// the command was invoked.
+	Inputs []string `json:"inputs,omitempty"`
+
+	// WorkDir is the working directory that was set on the command, if any.
+	WorkDir string `json:"work_dir,omitempty"`
+
+	// Env are the environment variables that were explicitly set on the command,
+	// with secrets replaced with [MaskedValue].
+	Env map[string]string `json:"env,omitempty"`
+
 	// ExitCode is the exit code of the yt-dlp process.
 	ExitCode int `json:"exit_code"`
 
@@ -39,6 +70,44 @@ type Result struct {
 	// OutputLogs are the stdout/stderr logs, sorted by timestamp, and any JSON
 	// parsed (if configured with [Command.PrintJson]).
 	OutputLogs []*ResultLog `json:"output_logs"`
+
+	// StdoutTruncated is true if stdout exceeded the limit configured with
+	// [Command.SetMaxCaptureBytes], and some output lines were discarded.
+	StdoutTruncated bool `json:"stdout_truncated,omitempty"`
+
+	// StderrTruncated is true if stderr exceeded the limit configured with
+	// [Command.SetMaxCaptureBytes], and some output lines were discarded.
+	StderrTruncated bool `json:"stderr_truncated,omitempty"`
+
+	// Attempts is the number of attempts made, when a retry policy was set with
+	// [Command.SetRetryPolicy]. The result is from the final attempt.
+	Attempts int `json:"attempts,omitempty"`
+
+	// IPFamily is the IP family that was forced for the final attempt, when
+	// [RetryPolicy.IPFamilyFallback] is enabled.
+	IPFamily IPFamily `json:"ip_family,omitempty"`
+
+	// FormatDownloads are the formats that were downloaded (including each of the
+	// formats which were merged), parsed from the output logs. The downloader used
+	// for each format is only available when invoked with [Command.Verbose].
+	FormatDownloads []*FormatDownload `json:"format_downloads,omitempty"`
+
+	// SponsorBlock are the results of verifying SponsorBlock segment removal for
+	// each downloaded file, when enabled with [Command.VerifySponsorBlock].
+	SponsorBlock []*SponsorBlockVerification `json:"sponsorblock,omitempty"`
+
+	// FFmpegFallback describes how the invocation was changed as ffmpeg couldn't
+	// be resolved, when enabled with [Command.SetFFmpegFallback].
+	FFmpegFallback *FFmpegFallback `json:"ffmpeg_fallback,omitempty"`
+
+	// CallbackPanics are any panics recovered from user-provided callbacks (e.g.
+	// [Command.ProgressFunc]) during the invocation, which don't otherwise stop
+	// the invocation.
+	CallbackPanics []*ErrCallbackPanic `json:"-"`
+
+	files           []*ResultFile           // See [Result.Files].
+	duration        time.Duration           // Duration of the final attempt.
+	bytesByCategory map[BytesCategory]int64 // See [Command.TrackBandwidth].
 }
 
 func (r *Result) asString(stdout, stderr, timestamps, maskJSON, exitCode bool) string {
@@ -56,6 +125,14 @@ func (r *Result) asString(stdout, stderr, timestamps, maskJSON, exitCode bool) s
 		out = append(out, l.asString(timestamps, maskJSON))
 	}
 
+	if stdout && r.StdoutTruncated {
+		out = append(out, "<stdout truncated>")
+	}
+
+	if stderr && r.StderrTruncated {
+		out = append(out, "<stderr truncated>")
+	}
+
 	if exitCode {
 		out = append(out, fmt.Sprintf("exit code: %d", r.ExitCode))
 	}
@@ -67,6 +144,101 @@ func (r *Result) String() string {
 	return r.asString(true, true, true, true, true)
 }
 
+// Command reconstructs a [Command] from the result, with the same executable,
+// flags, working directory, environment variables and safe mode as the
+// invocation that produced the result. This is useful to reproduce a failed run
+// when debugging, e.g. by calling [Command.Run] with [Result.Inputs]. Secrets
+// are masked in results, so an error is returned if any flag or environment
+// variable contains [MaskedValue], rather than invoking yt-dlp with it.
+func (r *Result) Command() (*Command, error) {
+	if r.Executable == "" {
+		return nil, errors.New("result does not contain the executable that was invoked")
+	}
+
+	// Results serialized by older versions of go-ytdlp won't contain the flags.
+	if r.Flags == nil && len(r.Args) > len(r.Inputs) {
+		return nil, errors.New("result does not contain the flags that were invoked")
+	}
+
+	for _, f := range r.Flags {
+		if strings.Contains(f.Flag, MaskedValue) || slices.ContainsFunc(f.Args, func(arg string) bool {
+			return strings.Contains(arg, MaskedValue)
+		}) {
+			return nil, fmt.Errorf("flag %q contains a masked secret, so can't be reconstructed", f.Flag)
+		}
+	}
+
+	for k, v := range r.Env {
+		if strings.Contains(v, MaskedValue) {
+			return nil, fmt.Errorf("env var %q contains a masked secret, so can't be reconstructed", k)
+		}
+	}
+
+	cmd := New().
+		SetExecutable(r.Executable).
+		SetWorkDir(r.WorkDir)
+
+	if r.SafeMode {
+		cmd.SafeMode()
+	}
+
+	for k, v := range r.Env {
+		cmd.SetEnvVar(k, v)
+	}
+
+	for _, f := range r.Flags {
+		cmd.flags = append(cmd.flags, f.Clone())
+	}
+
+	return cmd, nil
+}
+
+// CLIString returns the invocation that produced the result as a shell-quoted
+// string (including the working directory and environment variables, if any were
+// set), which can be pasted into a POSIX shell.
+func (r *Result) CLIString() string {
+	var out []string
+
+	if r.WorkDir != "" {
+		out = append(out, "cd", shellQuote(r.WorkDir), "&&")
+	}
+
+	keys := make([]string, 0, len(r.Env))
+	for k := range r.Env {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	for _, k := range keys {
+		out = append(out, k+"="+shellQuote(r.Env[k]))
+	}
+
+	out = append(out, shellQuote(r.Executable))
+
+	for _, arg := range r.Args {
+		out = append(out, shellQuote(arg))
+	}
+
+	return strings.Join(out, " ")
+}
+
+// shellQuote quotes s for use in a POSIX shell, if necessary.
+func shellQuote(s string) string {
+	if s == "" {
+		return "''"
+	}
+
+	safe := strings.IndexFunc(s, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || strings.ContainsRune("_@%+=:,./-", r))
+	}) == -1
+
+	if safe {
+		return s
+	}
+
+	return "'" + strings.ReplaceAll(s, "'", `'"'"'`) + "'"
+}
+
 func (r *Result) decorateError(err error) error {
 	if err == nil {
 		return nil
@@ -101,8 +273,117 @@ func (r *Result) GetExtractedInfo() (info []*ExtractedInfo, err error) {
 	return info, nil
 }
 
+// MergeResults merges the results of multiple invocations of the same command
+// (e.g. batches, see [Command.SetBatchSplit], or separate attempts) into a single
+// result, in the order provided:
+//   - Executable, Flags, WorkDir, Env and similar are from the first result.
+//   - FFmpegFallback is from the first result which has one.
+//   - Inputs are concatenated, and Args are the flags of the first result,
+//     followed by all inputs.
+//   - ExitCode is the first non-zero exit code, if any.
+//   - Stdout and Stderr are joined with newlines.
+//   - OutputLogs are concatenated and sorted by timestamp (re-sequenced, so
+//     sequences remain unique), so [Result.GetExtractedInfo] returns the
+//     extracted info of all results.
+//   - Attempts are summed, as are the bytes recorded by [Command.TrackBandwidth].
+//   - Files, format downloads, SponsorBlock verifications and callback panics
+//     are concatenated.
+//
+// nil results are ignored. Returns nil if there are no results. The provided
+// results aren't modified.
+func MergeResults(results ...*Result) *Result {
+	results = slices.DeleteFunc(slices.Clone(results), func(r *Result) bool { return r == nil })
+	if len(results) == 0 {
+		return nil
+	}
+
+	first := results[0]
+
+	merged := &Result{
+		Executable:   first.Executable,
+		Flags:        first.Flags,
+		DroppedFlags: first.DroppedFlags,
+		SafeMode:     first.SafeMode,
+		WorkDir:      first.WorkDir,
+		IPFamily:     first.IPFamily,
+	}
+
+	var stdout, stderr []string
+
+	for _, r := range results {
+		merged.Inputs = append(merged.Inputs, r.Inputs...)
+
+		if merged.ExitCode == 0 {
+			merged.ExitCode = r.ExitCode
+		}
+
+		if r.Stdout != "" {
+			stdout = append(stdout, r.Stdout)
+		}
+
+		if r.Stderr != "" {
+			stderr = append(stderr, r.Stderr)
+		}
+
+		for _, l := range r.OutputLogs {
+			lc := *l
+			merged.OutputLogs = append(merged.OutputLogs, &lc)
+		}
+
+		merged.StdoutTruncated = merged.StdoutTruncated || r.StdoutTruncated
+		merged.StderrTruncated = merged.StderrTruncated || r.StderrTruncated
+		merged.Attempts += r.Attempts
+		merged.FormatDownloads = append(merged.FormatDownloads, r.FormatDownloads...)
+		merged.SponsorBlock = append(merged.SponsorBlock, r.SponsorBlock...)
+
+		if merged.FFmpegFallback == nil {
+			merged.FFmpegFallback = r.FFmpegFallback
+		}
+
+		merged.CallbackPanics = append(merged.CallbackPanics, r.CallbackPanics...)
+		merged.files = append(merged.files, r.files...)
+		merged.duration += r.duration
+
+		if r.bytesByCategory != nil {
+			if merged.bytesByCategory == nil {
+				merged.bytesByCategory = make(map[BytesCategory]int64, len(r.bytesByCategory))
+			}
+
+			for k, v := range r.bytesByCategory {
+				merged.bytesByCategory[k] += v
+			}
+		}
+	}
+
+	// Args are the flags of the first invocation, followed by all inputs.
+	merged.Args = slices.Clone(first.Args)
+	if n := len(first.Args) - len(first.Inputs); n >= 0 && slices.Equal(first.Args[n:], first.Inputs) {
+		merged.Args = append(merged.Args[:n], merged.Inputs...)
+	}
+
+	if first.Env != nil {
+		merged.Env = maps.Clone(first.Env)
+	}
+
+	merged.Stdout = strings.Join(stdout, "\n")
+	merged.Stderr = strings.Join(stderr, "\n")
+
+	// Logs of separate invocations are interleaved by timestamp, and re-sequenced
+	// so sequences remain unique.
+	slices.SortStableFunc(merged.OutputLogs, func(a, b *ResultLog) int {
+		return a.Timestamp.Compare(b.Timestamp)
+	})
+
+	for i, l := range merged.OutputLogs {
+		l.Sequence = uint64(i) + 1 //nolint:gosec
+	}
+
+	return merged
+}
+
 type ResultLog struct {
 	Timestamp time.Time        `json:"timestamp"`
+	Sequence  uint64           `json:"sequence"` // Monotonic (and unique) ordering of lines, across both stdout and stderr.
 	Line      string           `json:"line"`
 	JSON      *json.RawMessage `json:"json,omitempty"` // May be nil if the log line wasn't valid JSON.
 	Pipe      string           `json:"pipe"`           // stdout or stderr.
@@ -126,51 +407,174 @@ func (r *ResultLog) String() string {
 	return r.asString(true, true)
 }
 
+// teeWriter writes to w, and also to tee (if set), ignoring any errors from tee.
+// mu is shared between all tee writers of the same invocation, so the same tee
+// can be used for multiple pipes.
+type teeWriter struct {
+	w   io.Writer
+	tee io.Writer
+	mu  *sync.Mutex
+}
+
+func (w *teeWriter) Write(p []byte) (n int, err error) {
+	if w.tee != nil {
+		w.mu.Lock()
+		_, _ = w.tee.Write(p)
+		w.mu.Unlock()
+	}
+
+	return w.w.Write(p)
+}
+
 type timestampWriter struct {
 	checkJSON bool   // Whether to check if the log lines are valid JSON.
+	stripANSI bool   // Whether to strip ANSI escape sequences from log lines.
+	redact    bool   // Whether to redact sensitive headers from log lines.
 	pipe      string // stdout or stderr.
 
 	buf            bytes.Buffer
 	lastWriteStart time.Time
+	lastWriteSeq   uint64
 	results        []*ResultLog
 
-	progress *progressHandler
+	// seq is shared between all writers of the same invocation, to provide a
+	// stable ordering of lines across writers.
+	seq *atomic.Uint64
+
+	maxBytes  int64 // Maximum number of bytes to capture, 0 being unlimited.
+	captured  int64 // Number of bytes captured so far.
+	truncated bool  // Whether maxBytes was exceeded, after which all lines are discarded.
+	discard   bool  // Whether the current line is being discarded due to maxBytes.
+
+	progress       *progressHandler
+	progressPrefix []byte   // Prefix of progress lines, which are parsed, then discarded.
+	inputs         []string // Inputs of the invocation, see [ProgressUpdate.InputURL].
+	log            *logHandler
+	prompt         *promptHandler
+	prompted       bool // Whether the prompt handler was already invoked for the current line.
+	panics         *callbackPanics
+	faults         FaultInjector
 }
 
 func (w *timestampWriter) Write(p []byte) (n int, err error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	if w.seq == nil {
+		w.seq = &atomic.Uint64{}
+	}
+
 	if w.lastWriteStart.IsZero() {
 		w.lastWriteStart = time.Now()
+		w.lastWriteSeq = w.seq.Add(1)
 	}
 
 	if i := bytes.IndexByte(p, '\n'); i >= 0 {
-		w.buf.Write(p[:i+1])
+		w.buffer(p[:i+1])
 		w.flush()
 
 		_, err = w.Write(p[i+1:]) // Recursively write the rest of the buffer, in case it contains multiple lines.
 		return len(p), err
 	}
 
-	return w.buf.Write(p)
+	w.buffer(p)
+
+	if w.prompt != nil && !w.prompted && !w.discard && isPrompt(w.buf.Bytes()) {
+		w.prompted = true
+		w.prompt.handle(StripANSI(strings.TrimSpace(w.buf.String())))
+	}
+
+	return len(p), nil
+}
+
+// buffer writes p to the current line buffer, unless the line would exceed the
+// configured capture limit, in which case the line (and every line after it) is
+// discarded. Progress lines are never discarded, as they aren't retained in the
+// results.
+func (w *timestampWriter) buffer(p []byte) {
+	if w.discard {
+		return
+	}
+
+	w.buf.Write(p)
+
+	if w.maxBytes <= 0 || (!w.truncated && w.captured+int64(w.buf.Len()) <= w.maxBytes) {
+		return
+	}
+
+	if len(w.progressPrefix) > 0 {
+		line := w.buf.Bytes()
+
+		if bytes.HasPrefix(line, w.progressPrefix) || bytes.HasPrefix(w.progressPrefix, line) {
+			return
+		}
+	}
+
+	w.discard = true
+	w.truncated = true
+	w.buf.Reset()
 }
 
 func (w *timestampWriter) flush() {
+	w.prompted = false
+
+	if w.discard {
+		w.discard = false
+		w.lastWriteStart = time.Time{}
+		return
+	}
+
 	if w.buf.Len() == 0 {
 		return
 	}
 
 	line := bytes.TrimRightFunc(w.buf.Bytes(), unicode.IsSpace)
 
+	if w.stripANSI {
+		line = []byte(StripANSI(string(line)))
+	}
+
+	if w.faults != nil {
+		// Fault injectors don't know the prefix of the command, so progress lines
+		// are passed with the default prefix instead.
+		input := string(line)
+		isProgress := len(w.progressPrefix) > 0 && bytes.HasPrefix(line, w.progressPrefix)
+
+		if isProgress {
+			input = string(progressPrefix) + string(line[len(w.progressPrefix):])
+		}
+
+		injected, keep := w.faults.InjectLine(w.pipe, input)
+		if !keep {
+			w.lastWriteStart = time.Time{}
+			w.buf.Reset()
+			return
+		}
+
+		if v, ok := strings.CutPrefix(injected, string(progressPrefix)); ok && isProgress {
+			injected = string(w.progressPrefix) + v
+		}
+
+		line = []byte(injected)
+	}
+
+	if w.redact {
+		line = []byte(redactHeaders(string(line)))
+	}
+
 	result := &ResultLog{
 		Timestamp: w.lastWriteStart,
+		Sequence:  w.lastWriteSeq,
 		Line:      string(line),
 		Pipe:      w.pipe,
 	}
 
-	if v, ok := bytes.CutPrefix(line, progressPrefix); ok && w.progress != nil {
+	if v, ok := bytes.CutPrefix(line, w.progressPrefix); ok && len(w.progressPrefix) > 0 {
 		var raw json.RawMessage
 
-		if err := json.Unmarshal(v, &raw); err == nil {
-			w.progress.parse(raw)
+		if err := json.Unmarshal(v, &raw); err == nil && w.progress != nil {
+			w.panics.add(w.progress.parse(raw, w.inputs))
 		}
 		goto reset
 	}
@@ -184,13 +588,19 @@ func (w *timestampWriter) flush() {
 	}
 
 	w.results = append(w.results, result)
+	w.captured += int64(len(line))
+
+	if w.log != nil {
+		w.panics.add(w.log.handle(result))
+	}
 reset:
 	w.lastWriteStart = time.Time{}
 	w.buf.Reset()
 }
 
 // mergeResults merges the results from this writer with the results from another writer
-// (or multiple writers). The results are sorted by timestamp.
+// (or multiple writers). The results are sorted by sequence (which follows the same
+// order as the timestamps, but is unique).
 func (w *timestampWriter) mergeResults(otherWriters ...*timestampWriter) []*ResultLog {
 	w.flush()
 
@@ -200,9 +610,9 @@ func (w *timestampWriter) mergeResults(otherWriters ...*timestampWriter) []*Resu
 		results = append(results, other.results...)
 	}
 
-	// Sort results by timestamp.
+	// Sort results by sequence.
 	sort.Slice(results, func(i, j int) bool {
-		return results[i].Timestamp.Before(results[j].Timestamp)
+		return results[i].Sequence < results[j].Sequence
 	})
 
 	return results
@@ -482,6 +892,10 @@ type ExtractedInfo struct {
 	// it's missing)
 	WebpageURL *string `json:"webpage_url,omitempty"`
 
+	// OriginalURL contains the URL originally passed to yt-dlp, which the video
+	// was extracted from (e.g. the URL of the playlist it's part of).
+	OriginalURL *string `json:"original_url,omitempty"`
+
 	// Categories contains a list of categories that the video falls in, for example
 	// ["Sports", "Berlin"].
 	Categories []string `json:"categories,omitempty"`
@@ -0,0 +1,296 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTimestampWriter_MaxBytes(t *testing.T) {
+	w := &timestampWriter{pipe: "stdout", maxBytes: 10}
+
+	_, _ = w.Write([]byte("hello\nthis line is too long"))
+	_, _ = w.Write([]byte(" to be captured\nabc\n"))
+	_, _ = w.Write([]byte("defgh\n"))
+
+	if !w.truncated {
+		t.Fatal("expected writer to be truncated")
+	}
+
+	if got := w.String(); got != "hello" {
+		t.Fatalf("expected captured output to be %q, got %q", "hello", got)
+	}
+}
+
+func TestTimestampWriter_MaxBytesLongThenShort(t *testing.T) {
+	w := &timestampWriter{pipe: "stdout", maxBytes: 10}
+
+	_, _ = w.Write([]byte("this line is too long\n"))
+	_, _ = w.Write([]byte("a\nb\n"))
+
+	if !w.truncated {
+		t.Fatal("expected writer to be truncated")
+	}
+
+	if len(w.results) != 0 {
+		t.Fatalf("expected no lines to be captured after truncation, got %d", len(w.results))
+	}
+}
+
+func TestTimestampWriter_MaxBytesProgress(t *testing.T) {
+	var updates int
+
+	w := &timestampWriter{
+		pipe:           "stdout",
+		maxBytes:       5,
+		progress:       newProgressHandler(func(_ ProgressUpdate) { updates++ }),
+		progressPrefix: progressPrefix,
+	}
+
+	_, _ = w.Write([]byte(string(progressPrefix) + `{"info":{"id":"foo"},"progress":{"status":"downloading"}}` + "\n"))
+	_, _ = w.Write([]byte(strings.Repeat("a", 10) + "\n"))
+	_, _ = w.Write([]byte(string(progressPrefix) + `{"info":{"id":"foo"},"progress":{"status":"finished"}}` + "\n"))
+
+	if updates != 2 {
+		t.Fatalf("expected 2 progress updates, got %d", updates)
+	}
+
+	if !w.truncated {
+		t.Fatal("expected writer to be truncated")
+	}
+}
+
+func TestTimestampWriter_MergeResults(t *testing.T) {
+	seq := &atomic.Uint64{}
+
+	stdout := &timestampWriter{pipe: "stdout", seq: seq}
+	stderr := &timestampWriter{pipe: "stderr", seq: seq}
+
+	_, _ = stdout.Write([]byte("1\n"))
+	_, _ = stderr.Write([]byte("2\n"))
+	_, _ = stdout.Write([]byte("3"))
+	_, _ = stderr.Write([]byte("4\n"))
+	_, _ = stdout.Write([]byte("\n"))
+
+	results := stdout.mergeResults(stderr)
+
+	var lines []string
+	for i, r := range results {
+		if i > 0 && r.Sequence <= results[i-1].Sequence {
+			t.Fatalf("expected sequence to be strictly increasing, got %d after %d", r.Sequence, results[i-1].Sequence)
+		}
+
+		lines = append(lines, r.Line)
+	}
+
+	if got := strings.Join(lines, ","); got != "1,2,3,4" {
+		t.Fatalf("expected lines in order 1,2,3,4, got %s", got)
+	}
+}
+
+func TestResult_Command(t *testing.T) {
+	r := &Result{
+		Executable: "/usr/bin/yt-dlp",
+		Args:       []string{"--output", "%(title)s [%(id)s].%(ext)s", "--no-progress", "https://example.com/it's"},
+		Flags: []*Flag{
+			{ID: "outtmpl", Flag: "--output", Args: []string{"%(title)s [%(id)s].%(ext)s"}},
+			{ID: "noprogress", Flag: "--no-progress"},
+		},
+		Inputs:  []string{"https://example.com/it's"},
+		WorkDir: "/tmp",
+		Env:     map[string]string{"FOO": "bar baz"},
+	}
+
+	want := `cd /tmp && FOO='bar baz' /usr/bin/yt-dlp --output '%(title)s [%(id)s].%(ext)s' --no-progress 'https://example.com/it'"'"'s'`
+	if got := r.CLIString(); got != want {
+		t.Fatalf("expected CLI string to be:\n%s\ngot:\n%s", want, got)
+	}
+
+	cmd, err := r.Command()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ecmd := cmd.buildCommand(context.Background(), r.Inputs...)
+
+	if !slices.Equal(ecmd.Args[1:], r.Args) {
+		t.Fatalf("expected args to be %#v, got %#v", r.Args, ecmd.Args[1:])
+	}
+
+	if ecmd.Dir != r.WorkDir {
+		t.Fatalf("expected workdir to be %q, got %q", r.WorkDir, ecmd.Dir)
+	}
+
+	if _, err = (&Result{Executable: "yt-dlp", Args: []string{"--foo"}}).Command(); err == nil {
+		t.Fatal("expected error when flags are missing")
+	}
+
+	masked := []*Result{
+		{Executable: "yt-dlp", Flags: []*Flag{{ID: "password", Flag: "--password", Args: []string{MaskedValue}}}},
+		{Executable: "yt-dlp", Flags: []*Flag{{ID: rawFlagID, Flag: "--password=" + MaskedValue}}},
+		{Executable: "yt-dlp", Flags: []*Flag{}, Env: map[string]string{"API_TOKEN": MaskedValue}},
+	}
+
+	for _, r := range masked {
+		if _, err = r.Command(); err == nil {
+			t.Fatalf("expected error for masked secret: %+v", r)
+		}
+	}
+}
+
+func TestResult_Command_Run(t *testing.T) {
+	t.Parallel()
+
+	bin := writeFakeYtdlp(t, "#!/bin/sh\necho \"$*\"\n")
+
+	// printToFileInternal is added by go-ytdlp when recording downloaded files.
+	result, err := New().
+		SetExecutable(bin).
+		SetWorkDir(t.TempDir()).
+		SafeMode().
+		NoProgress().
+		printToFileInternal("%(id)s", filepath.Join(t.TempDir(), "records.jsonl")).
+		Run(context.Background(), "https://example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !result.SafeMode || len(result.Flags) != 1 || result.Flags[0].ID != "noprogress" {
+		t.Fatalf("expected safe mode, and internal flags to be excluded: %v, %+v", result.SafeMode, result.Flags)
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded := &Result{}
+	if err = json.Unmarshal(data, decoded); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd, err := decoded.Command()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	args := cmd.buildCommand(context.Background(), decoded.Inputs...).Args[1:]
+	if !slices.Contains(args, "--simulate") || slices.Contains(args, "--print-to-file") {
+		t.Fatalf("expected safe mode without internal flags, got %q", args)
+	}
+}
+
+func TestMergeResults(t *testing.T) {
+	now := time.Now()
+	info := func(id string) *json.RawMessage {
+		raw := json.RawMessage(`{"_type":"video","id":"` + id + `","extractor":"generic"}`)
+		return &raw
+	}
+
+	a := &Result{
+		Executable: "yt-dlp",
+		Args:       []string{"--simulate", "a"},
+		Inputs:     []string{"a"},
+		Env:        map[string]string{"LANG": "C"},
+		Stdout:     "a",
+		Attempts:   1,
+		OutputLogs: []*ResultLog{
+			{Timestamp: now, Sequence: 1, Line: "a", Pipe: "stdout", JSON: info("a")},
+			{Timestamp: now.Add(2 * time.Second), Sequence: 2, Line: "a done", Pipe: "stdout"},
+		},
+		bytesByCategory: map[BytesCategory]int64{BytesVideo: 10},
+	}
+
+	b := &Result{
+		Executable: "yt-dlp",
+		Args:       []string{"--simulate", "b"},
+		Inputs:     []string{"b"},
+		ExitCode:   1,
+		Stderr:     "ERROR: b",
+		Attempts:   2,
+		OutputLogs: []*ResultLog{
+			{Timestamp: now.Add(time.Second), Sequence: 1, Line: "b", Pipe: "stdout", JSON: info("b")},
+		},
+		bytesByCategory: map[BytesCategory]int64{BytesVideo: 5, BytesAudio: 1},
+	}
+
+	merged := MergeResults(a, nil, b)
+
+	if !slices.Equal(merged.Args, []string{"--simulate", "a", "b"}) || !slices.Equal(merged.Inputs, []string{"a", "b"}) {
+		t.Fatalf("unexpected args/inputs: %v/%v", merged.Args, merged.Inputs)
+	}
+
+	if merged.ExitCode != 1 || merged.Attempts != 3 || merged.Stdout != "a" || merged.Stderr != "ERROR: b" {
+		t.Fatalf("unexpected merged result: %+v", merged)
+	}
+
+	var lines []string
+	for i, l := range merged.OutputLogs {
+		if l.Sequence != uint64(i)+1 {
+			t.Fatalf("expected logs to be re-sequenced, got %d at %d", l.Sequence, i)
+		}
+
+		lines = append(lines, l.Line)
+	}
+
+	if !slices.Equal(lines, []string{"a", "b", "a done"}) {
+		t.Fatalf("expected logs sorted by timestamp, got %v", lines)
+	}
+
+	if a.OutputLogs[1].Sequence != 2 {
+		t.Fatal("expected provided results to not be modified")
+	}
+
+	extracted, err := merged.GetExtractedInfo()
+	if err != nil || len(extracted) != 2 || extracted[0].ID != "a" || extracted[1].ID != "b" {
+		t.Fatalf("unexpected extracted info: %v: %v", err, extracted)
+	}
+
+	if merged.bytesByCategory[BytesVideo] != 15 || merged.bytesByCategory[BytesAudio] != 1 {
+		t.Fatalf("unexpected bytes: %v", merged.bytesByCategory)
+	}
+
+	if MergeResults() != nil || MergeResults(nil) != nil {
+		t.Fatal("expected nil for no results")
+	}
+}
+
+func TestResult_MaskedSecrets(t *testing.T) {
+	t.Parallel()
+
+	bin := writeFakeYtdlp(t, "#!/bin/sh\nexit 0\n")
+
+	result, err := New().
+		SetExecutable(bin).
+		SetEnvVar("API_TOKEN", "abc123").
+		Username("user").
+		Password("hunter2").
+		AddHeaders("Authorization:Bearer abc123").
+		AddRawFlags("--video-password=hunter2").
+		Run(context.Background(), "https://example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := json.Marshal(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(string(b), "hunter2") || strings.Contains(string(b), "abc123") {
+		t.Fatalf("expected secrets to be masked: %s", b)
+	}
+
+	if !slices.Contains(result.Args, "user") || result.Env["API_TOKEN"] != MaskedValue {
+		t.Fatalf("unexpected args/env: %q/%v", result.Args, result.Env)
+	}
+}
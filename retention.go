@@ -0,0 +1,189 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+)
+
+// RetentionMode controls whether [Workspace.ApplyRetention] removes files.
+type RetentionMode int
+
+const (
+	RetentionDryRun RetentionMode = iota // Only report what would be removed.
+	RetentionDelete                      // Remove files (and their index entries).
+)
+
+// RetentionReason is the reason a file was selected for removal.
+type RetentionReason string
+
+const (
+	RetentionReasonMaxAge       RetentionReason = "max_age"
+	RetentionReasonKeepLast     RetentionReason = "keep_last"
+	RetentionReasonMaxTotalSize RetentionReason = "max_total_size"
+)
+
+// RetentionPolicy is a policy for removing old downloads from a workspace. Any
+// limit which is 0 is disabled. Age and ordering is based on when files were
+// added to the index (see [IndexEntry.Added]).
+type RetentionPolicy struct {
+	// MaxAge removes files which were added longer than MaxAge ago.
+	MaxAge time.Duration
+
+	// KeepLastPerPlaylist keeps only the N most recently added files for each
+	// playlist. Files which weren't downloaded as part of a playlist are unaffected.
+	KeepLastPerPlaylist int
+
+	// MaxTotalBytes removes the oldest files, until the total size of all remaining
+	// files is at most MaxTotalBytes. Applied after all other limits.
+	MaxTotalBytes int64
+}
+
+// RetentionItem is a single file selected for removal.
+type RetentionItem struct {
+	Entry  *IndexEntry     `json:"entry"`
+	Bytes  int64           `json:"bytes"`
+	Reason RetentionReason `json:"reason"`
+}
+
+// RetentionReport is the report of what was (or would be, with [RetentionDryRun])
+// removed by [Workspace.ApplyRetention].
+type RetentionReport struct {
+	DryRun     bool             `json:"dry_run"`
+	Removed    []*RetentionItem `json:"removed"`
+	FreedBytes int64            `json:"freed_bytes"`
+
+	// Skipped are files which were selected for removal, but are outside of the
+	// workspace directory (e.g. downloaded with an absolute output template), so
+	// are never removed, and are kept in the index.
+	Skipped []*RetentionItem `json:"skipped,omitempty"`
+}
+
+// ApplyRetention applies the retention policy to the indexed files within the
+// workspace. With [RetentionDryRun], nothing is removed, and the report contains
+// what would have been removed. With [RetentionDelete], the files are removed from
+// disk and from the index. Files outside of the workspace directory are never
+// removed, and are reported as skipped. If some files fail to be removed, the report only
+// contains the files which were removed, along with a joined error.
+func (w *Workspace) ApplyRetention(policy *RetentionPolicy, mode RetentionMode) (*RetentionReport, error) {
+	if policy == nil {
+		return nil, errors.New("no retention policy provided")
+	}
+
+	type candidate struct {
+		entry  *IndexEntry
+		size   int64
+		reason RetentionReason
+	}
+
+	var candidates []*candidate //nolint:prealloc
+
+	for _, entry := range w.Entries() {
+		var size int64
+
+		if stat, err := os.Stat(w.Path(entry)); err == nil {
+			size = stat.Size()
+		}
+
+		candidates = append(candidates, &candidate{entry: entry, size: size})
+	}
+
+	// Oldest first.
+	slices.SortStableFunc(candidates, func(a, b *candidate) int {
+		return a.entry.Added.Compare(b.entry.Added)
+	})
+
+	now := time.Now()
+
+	if policy.MaxAge > 0 {
+		for _, c := range candidates {
+			if now.Sub(c.entry.Added) > policy.MaxAge {
+				c.reason = RetentionReasonMaxAge
+			}
+		}
+	}
+
+	if policy.KeepLastPerPlaylist > 0 {
+		kept := make(map[string]int)
+
+		for i := len(candidates) - 1; i >= 0; i-- {
+			c := candidates[i]
+
+			if c.reason != "" || c.entry.PlaylistID == "" {
+				continue
+			}
+
+			kept[c.entry.PlaylistID]++
+			if kept[c.entry.PlaylistID] > policy.KeepLastPerPlaylist {
+				c.reason = RetentionReasonKeepLast
+			}
+		}
+	}
+
+	if policy.MaxTotalBytes > 0 {
+		var total int64
+
+		for _, c := range candidates {
+			if c.reason == "" {
+				total += c.size
+			}
+		}
+
+		for _, c := range candidates {
+			if total <= policy.MaxTotalBytes {
+				break
+			}
+
+			if c.reason == "" {
+				c.reason = RetentionReasonMaxTotalSize
+				total -= c.size
+			}
+		}
+	}
+
+	report := &RetentionReport{DryRun: mode == RetentionDryRun}
+	var errs []error
+
+	for _, c := range candidates {
+		if c.reason == "" {
+			continue
+		}
+
+		if !w.contains(w.Path(c.entry)) {
+			report.Skipped = append(report.Skipped, &RetentionItem{Entry: c.entry, Bytes: c.size, Reason: c.reason})
+			continue
+		}
+
+		if mode == RetentionDelete {
+			err := os.Remove(w.Path(c.entry))
+			if err != nil && !errors.Is(err, os.ErrNotExist) {
+				errs = append(errs, fmt.Errorf("unable to remove %q: %w", c.entry.Path, err))
+				continue
+			}
+		}
+
+		report.Removed = append(report.Removed, &RetentionItem{Entry: c.entry, Bytes: c.size, Reason: c.reason})
+		report.FreedBytes += c.size
+	}
+
+	if mode == RetentionDelete && len(report.Removed) > 0 {
+		w.mu.Lock()
+		for _, item := range report.Removed {
+			delete(w.index, item.Entry.Path)
+		}
+		err := w.save()
+		w.mu.Unlock()
+
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return report, errors.Join(errs...)
+}
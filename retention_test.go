@@ -0,0 +1,147 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWorkspace_ApplyRetention(t *testing.T) {
+	t.Parallel()
+
+	ws, err := OpenWorkspace(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+
+	entries := []*IndexEntry{
+		{Path: "ancient.mp4", Added: now.Add(-90 * 24 * time.Hour)},
+		{Path: "pl-1.mp4", PlaylistID: "PL", Added: now.Add(-5 * time.Hour)},
+		{Path: "pl-2.mp4", PlaylistID: "PL", Added: now.Add(-4 * time.Hour)},
+		{Path: "pl-3.mp4", PlaylistID: "PL", Added: now.Add(-3 * time.Hour)},
+		{Path: "big.mp4", Added: now.Add(-2 * time.Hour)},
+		{Path: "new.mp4", Added: now.Add(-1 * time.Hour)},
+	}
+
+	sizes := map[string]int{"ancient.mp4": 1, "pl-1.mp4": 1, "pl-2.mp4": 1, "pl-3.mp4": 1, "big.mp4": 100, "new.mp4": 10}
+
+	for _, e := range entries {
+		if err = os.WriteFile(filepath.Join(ws.Dir(), e.Path), []byte(strings.Repeat("x", sizes[e.Path])), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err = ws.addEntries(entries...); err != nil {
+		t.Fatal(err)
+	}
+
+	policy := &RetentionPolicy{
+		MaxAge:              30 * 24 * time.Hour,
+		KeepLastPerPlaylist: 2,
+		MaxTotalBytes:       50,
+	}
+
+	expected := map[string]RetentionReason{
+		"ancient.mp4": RetentionReasonMaxAge,
+		"pl-1.mp4":    RetentionReasonKeepLast,
+		"pl-2.mp4":    RetentionReasonMaxTotalSize, // Oldest remaining.
+		"pl-3.mp4":    RetentionReasonMaxTotalSize,
+		"big.mp4":     RetentionReasonMaxTotalSize,
+	}
+
+	report, err := ws.ApplyRetention(policy, RetentionDryRun)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	check := func(report *RetentionReport) {
+		t.Helper()
+
+		if len(report.Removed) != len(expected) || report.FreedBytes != 104 {
+			t.Fatalf("expected %d removals freeing 104 bytes, got %d freeing %d", len(expected), len(report.Removed), report.FreedBytes)
+		}
+
+		for _, item := range report.Removed {
+			if expected[item.Entry.Path] != item.Reason {
+				t.Fatalf("expected %q to be removed due to %q, got %q", item.Entry.Path, expected[item.Entry.Path], item.Reason)
+			}
+		}
+	}
+
+	check(report)
+
+	if !report.DryRun || len(ws.Entries()) != len(entries) {
+		t.Fatal("expected dry run to not remove anything")
+	}
+
+	report, err = ws.ApplyRetention(policy, RetentionDelete)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	check(report)
+
+	if remaining := ws.Entries(); len(remaining) != 1 || remaining[0].Path != "new.mp4" {
+		t.Fatalf("expected only new.mp4 to remain, got %d entries", len(remaining))
+	}
+
+	if _, err = os.Stat(filepath.Join(ws.Dir(), "big.mp4")); !os.IsNotExist(err) {
+		t.Fatal("expected big.mp4 to be removed from disk")
+	}
+}
+
+func TestWorkspace_ApplyRetention_Outside(t *testing.T) {
+	t.Parallel()
+
+	ws, err := OpenWorkspace(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	outside := filepath.Join(t.TempDir(), "outside.mp4")
+	if err = os.WriteFile(outside, []byte("x"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	old := time.Now().Add(-90 * 24 * time.Hour)
+
+	entries := []*IndexEntry{
+		{Path: outside, Added: old},
+		{Path: "../" + filepath.Base(outside), Added: old},
+	}
+
+	// Symlinked directory within the workspace, pointing outside of it (symlinks
+	// may require elevated privileges on Windows).
+	if os.Symlink(filepath.Dir(outside), filepath.Join(ws.Dir(), "link")) == nil {
+		entries = append(entries, &IndexEntry{Path: "link/outside.mp4", Added: old})
+	}
+
+	if err = ws.addEntries(entries...); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := ws.ApplyRetention(&RetentionPolicy{MaxAge: time.Hour}, RetentionDelete)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(report.Removed) != 0 || len(report.Skipped) != len(entries) {
+		t.Fatalf("expected all files to be skipped, got %d removed and %d skipped", len(report.Removed), len(report.Skipped))
+	}
+
+	if _, err = os.Stat(outside); err != nil {
+		t.Fatalf("expected file outside of the workspace to be kept: %v", err)
+	}
+
+	if len(ws.Entries()) != len(entries) {
+		t.Fatal("expected skipped files to be kept in the index")
+	}
+}
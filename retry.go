@@ -0,0 +1,175 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"math/rand"
+	"time"
+)
+
+// IPFamily is the IP address family that yt-dlp was forced to use.
+type IPFamily string
+
+const (
+	IPFamilyAny  IPFamily = ""     // No preference (yt-dlp/OS default).
+	IPFamilyIPv4 IPFamily = "ipv4" // Forced with --force-ipv4.
+	IPFamilyIPv6 IPFamily = "ipv6" // Forced with --force-ipv6.
+)
+
+// BackoffFunc returns how long to wait before the provided attempt (starting at
+// 2, as the first attempt is never delayed).
+type BackoffFunc func(attempt int) time.Duration
+
+// ExponentialBackoff returns a [BackoffFunc] which doubles the delay after each
+// attempt, starting at initial and capped at maxDelay, with up to 20% random
+// jitter added to avoid many invocations retrying in lockstep.
+func ExponentialBackoff(initial, maxDelay time.Duration) BackoffFunc {
+	return func(attempt int) time.Duration {
+		delay := initial
+
+		for i := 2; i < attempt && delay < maxDelay; i++ {
+			delay *= 2
+		}
+
+		if delay > maxDelay {
+			delay = maxDelay
+		}
+
+		if delay <= 0 {
+			return 0
+		}
+
+		return delay + time.Duration(rand.Int63n(int64(delay)/5+1)) //nolint:gosec,gomnd
+	}
+}
+
+// IsTransientError returns true when the invocation failed with a non-zero exit
+// code (see [IsExitCodeError]) for a reason which may succeed if retried, i.e.
+// excluding errors like [ErrPrivateVideo], [ErrGeoRestricted], [ErrAgeRestricted],
+// [ErrDRMProtected] and [ErrUnsupportedURL]. Can be used as [RetryPolicy.RetryOn].
+func IsTransientError(err error) bool {
+	return IsExitCodeError(err) &&
+		!IsPrivateVideoError(err) &&
+		!IsGeoRestrictedError(err) &&
+		!IsAgeRestrictedError(err) &&
+		!IsDRMProtectedError(err) &&
+		!IsUnsupportedURLError(err)
+}
+
+// RetryPolicy controls how [Command.Run] retries failed invocations. By default,
+// only invocations which fail with a non-zero exit code (see [IsExitCodeError])
+// are retried, and never once the context has been cancelled.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first. Values
+	// less than 2 disable retrying.
+	MaxAttempts int
+
+	// Backoff returns how long to wait before each subsequent attempt, e.g.
+	// [ExponentialBackoff]. If nil, attempts are retried immediately. If the
+	// context is cancelled while waiting, [ErrCanceled] is returned with the
+	// results of the last attempt.
+	Backoff BackoffFunc
+
+	// RetryOn decides whether an invocation which failed with the provided error
+	// should be retried, e.g. [IsTransientError] or [IsRateLimitedError]. If nil,
+	// [IsExitCodeError] is used.
+	RetryOn func(err error) bool
+
+	// IPFamilyFallback toggles [Command.ForceIPv4] and [Command.ForceIPv6] on
+	// subsequent attempts (alternating, starting with IPv4), which often resolves
+	// network failures on dual-stack hosts. The family used by the final attempt
+	// is recorded in [Result.IPFamily]. Ignored if the command already has an IP
+	// family or source address configured.
+	IPFamilyFallback bool
+}
+
+// SetRetryPolicy sets the retry policy used by [Command.Run]. Pass nil to disable
+// retries (the default).
+func (c *Command) SetRetryPolicy(policy *RetryPolicy) *Command {
+	c.mu.Lock()
+	if policy == nil {
+		c.retry = nil
+	} else {
+		p := *policy
+		c.retry = &p
+	}
+	c.mu.Unlock()
+
+	return c
+}
+
+// attemptFamily returns the IP family to use for the provided attempt (starting
+// at 1).
+func (p *RetryPolicy) attemptFamily(attempt int) IPFamily {
+	if !p.IPFamilyFallback || attempt < 2 {
+		return IPFamilyAny
+	}
+
+	if attempt%2 == 0 {
+		return IPFamilyIPv4
+	}
+
+	return IPFamilyIPv6
+}
+
+// runWithRetry invokes the command until it succeeds, or the retry policy is
+// exhausted, returning the results of the last attempt.
+func (c *Command) runWithRetry(ctx context.Context, policy *RetryPolicy, args ...string) (result *Result, err error) {
+	fallback := policy.IPFamilyFallback && len(c.getFlagsByID("source_address")) == 0
+
+	for attempt := 1; ; attempt++ {
+		cmd := c
+		family := IPFamilyAny
+
+		if fallback {
+			family = policy.attemptFamily(attempt)
+		}
+
+		if family != IPFamilyAny {
+			cmd = c.clone()
+
+			if family == IPFamilyIPv4 {
+				cmd.ForceIPv4()
+			} else {
+				cmd.ForceIPv6()
+			}
+		}
+
+		result, err = cmd.runWithResult(ctx, cmd.buildCommand(ctx, args...), args...)
+		if result != nil {
+			result.Attempts = attempt
+			result.IPFamily = family
+		}
+
+		if err == nil || attempt >= policy.MaxAttempts || ctx.Err() != nil || !policy.retryOn(err) {
+			return result, err
+		}
+
+		if policy.Backoff == nil {
+			continue
+		}
+
+		if delay := policy.Backoff(attempt + 1); delay > 0 {
+			timer := time.NewTimer(delay)
+
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return result, &ErrCanceled{wrapped: ctx.Err(), result: result}
+			case <-timer.C:
+			}
+		}
+	}
+}
+
+// retryOn returns true if the provided error should be retried.
+func (p *RetryPolicy) retryOn(err error) bool {
+	if p.RetryOn != nil {
+		return p.RetryOn(err)
+	}
+
+	return IsExitCodeError(err)
+}
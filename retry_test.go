@@ -0,0 +1,116 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestRetry_IPFamilyFallback(t *testing.T) {
+	t.Parallel()
+
+	// Only succeeds when forced to use IPv6.
+	bin := writeFakeYtdlp(t, "#!/bin/sh\nfor a in \"$@\"; do [ \"$a\" = \"--force-ipv6\" ] && exit 0; done\nexit 1\n")
+
+	cmd := New().SetExecutable(bin).SetRetryPolicy(&RetryPolicy{MaxAttempts: 3, IPFamilyFallback: true})
+
+	result, err := cmd.Run(context.Background(), "https://example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.Attempts != 3 || result.IPFamily != IPFamilyIPv6 {
+		t.Fatalf("expected success on attempt 3 with ipv6, got attempt %d with %q", result.Attempts, result.IPFamily)
+	}
+
+	if !slices.Equal(result.Args, []string{"--force-ipv6", "https://example.com"}) {
+		t.Fatalf("unexpected args: %q", result.Args)
+	}
+
+	if len(cmd.getFlagsByID("source_address")) != 0 {
+		t.Fatal("expected command to not be modified")
+	}
+
+	result, err = New().SetExecutable(bin).SetRetryPolicy(&RetryPolicy{MaxAttempts: 2, IPFamilyFallback: true}).Run(context.Background())
+	if !IsExitCodeError(err) {
+		t.Fatalf("expected exit code error, got %v", err)
+	}
+
+	if result.Attempts != 2 || result.IPFamily != IPFamilyIPv4 {
+		t.Fatalf("expected failure on attempt 2 with ipv4, got attempt %d with %q", result.Attempts, result.IPFamily)
+	}
+
+	// Explicit IP family should never be overridden.
+	result, err = New().SetExecutable(bin).ForceIPv4().SetRetryPolicy(&RetryPolicy{MaxAttempts: 3, IPFamilyFallback: true}).Run(context.Background())
+	if err == nil || result.Attempts != 3 || result.IPFamily != IPFamilyAny {
+		t.Fatalf("expected all attempts to fail without fallback, got attempt %d with %q: %v", result.Attempts, result.IPFamily, err)
+	}
+}
+
+func TestRetry_BackoffAndRetryOn(t *testing.T) {
+	t.Parallel()
+
+	bin := writeFakeYtdlp(t, "#!/bin/sh\necho 'ERROR: [youtube] abc: Private video. Sign in if you have been granted access' >&2\nexit 1\n")
+
+	var delays []int
+
+	policy := &RetryPolicy{
+		MaxAttempts: 3,
+		Backoff: func(attempt int) time.Duration {
+			delays = append(delays, attempt)
+			return time.Millisecond
+		},
+	}
+
+	result, err := New().SetExecutable(bin).SetRetryPolicy(policy).Run(context.Background())
+	if !IsPrivateVideoError(err) || result.Attempts != 3 {
+		t.Fatalf("expected private video error after 3 attempts, got attempt %d: %v", result.Attempts, err)
+	}
+
+	if !slices.Equal(delays, []int{2, 3}) {
+		t.Fatalf("expected backoff before attempts 2 and 3, got %v", delays)
+	}
+
+	policy.RetryOn = IsTransientError
+
+	result, err = New().SetExecutable(bin).SetRetryPolicy(policy).Run(context.Background())
+	if !IsPrivateVideoError(err) || result.Attempts != 1 {
+		t.Fatalf("expected private video error to not be retried, got attempt %d: %v", result.Attempts, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	policy = &RetryPolicy{MaxAttempts: 3, Backoff: ExponentialBackoff(time.Hour, time.Hour)}
+
+	result, err = New().SetExecutable(bin).SetRetryPolicy(policy).Run(ctx)
+	if !IsCanceledError(err) || result.Attempts != 1 {
+		t.Fatalf("expected cancellation during backoff after 1 attempt, got attempt %d: %v", result.Attempts, err)
+	}
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	t.Parallel()
+
+	backoff := ExponentialBackoff(100*time.Millisecond, time.Second)
+
+	for attempt, expected := range map[int]time.Duration{
+		2: 100 * time.Millisecond,
+		3: 200 * time.Millisecond,
+		4: 400 * time.Millisecond,
+		5: 800 * time.Millisecond,
+		6: time.Second,
+		9: time.Second,
+	} {
+		delay := backoff(attempt)
+
+		if delay < expected || delay > expected+expected/5 {
+			t.Fatalf("attempt %d: expected delay within 20%% of %s, got %s", attempt, expected, delay)
+		}
+	}
+}
@@ -0,0 +1,269 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/lrstanley/go-ytdlp/optiondata"
+)
+
+// safeMode is the process-wide safe mode toggle, see [SetSafeMode].
+var safeMode atomic.Bool
+
+// unsafeFlags are the IDs of flags which are always rejected in safe mode (when
+// they have arguments, including inline arguments, e.g. "--exec=cmd"), as they execute arbitrary commands, code or executables
+// (even when simulating), load arbitrary configuration, or update yt-dlp.
+var unsafeFlags = []string{
+	"add_postprocessors",
+	"config_locations",
+	"exec_before_dl_cmd",
+	"exec_cmd",
+	"external_downloader_args",
+	"ffmpeg_location",
+	"netrc_cmd",
+	"plugin_dirs",
+	"update_self",
+}
+
+// unsafeBoolFlags are boolean flags which are always rejected in safe mode, as
+// they replace the yt-dlp executable, or delete files outside of the working
+// directory. They are matched by flag (including any aliases, e.g. -U), as they
+// share IDs with the flags which disable them (e.g. --no-update).
+var unsafeBoolFlags = []string{
+	"--update",
+	"--rm-cache-dir",
+}
+
+// pathFlags are the IDs of flags which write to a path provided as an argument,
+// in safe mode. The value is the index of the argument containing the path.
+var pathFlags = map[string]int{
+	"cachedir":         0,
+	"cookiefile":       0,
+	"download_archive": 0,
+	"outtmpl":          0,
+	"paths":            0,
+	"print_to_file":    1,
+}
+
+// safeOptions maps all cli flags (short and long) known to yt-dlp, to their
+// option data.
+var safeOptions = sync.OnceValue(func() map[string]*optiondata.Option {
+	options := make(map[string]*optiondata.Option)
+	for _, o := range optiondata.Options {
+		for _, flag := range slices.Concat(o.ShortFlags, o.LongFlags) {
+			options[flag] = o
+		}
+	}
+
+	// --help isn't included in the option data, as it's handled by optparse
+	// itself (and used by [Command.Capabilities]).
+	options["--help"] = &optiondata.Option{Name: "help", LongFlags: []string{"--help"}, ShortFlags: []string{"-h"}, Executable: true}
+	options["-h"] = options["--help"]
+
+	return options
+})
+
+// rePathType matches the optional "TYPES:" prefix of --output and --paths.
+var rePathType = regexp.MustCompile(`^[a-z_,]{2,}:`)
+
+// SetSafeMode enables (or disables) safe mode for all commands, regardless of
+// whether it was enabled for them with [Command.SafeMode]. See
+// [Command.SafeMode] for details.
+func SetSafeMode(enabled bool) {
+	safeMode.Store(enabled)
+}
+
+// SafeMode enables safe mode for the command, which is intended for preview
+// endpoints and untrusted environments. In safe mode, --simulate,
+// --skip-download and --ignore-config are always passed to yt-dlp (replacing
+// --no-simulate), and invocations fail with [ErrUnsafeFlag] before yt-dlp is
+// started if any input (e.g. URL) starts with "-", or if any flag:
+//   - executes commands, code or arbitrary executables, or loads configuration
+//     (e.g. [Command.Exec], [Command.UsePostProcessor], [Command.FFmpegLocation],
+//     or [Command.Downloader] with a path).
+//   - updates yt-dlp, or removes its cache directory (e.g. [Command.Update]).
+//   - was added with [Command.AddRawFlags], as these can't be validated.
+//   - isn't known to yt-dlp, doesn't belong to its ID, or has the wrong number
+//     of arguments (e.g. when provided with [Command.SetFlagConfig]). Flags are
+//     validated by what yt-dlp would parse, including inline values (e.g.
+//     "--exec=cmd").
+//   - writes to a path outside of the working directory (see
+//     [Command.SetWorkDir]), or the current directory if not set.
+//
+// Files written by flags which go-ytdlp adds itself (e.g. to record downloaded
+// files for [Command.VerifyIntegrity]) are allowed. Safe mode can also be enabled for all commands
+// with [SetSafeMode].
+//   - See [Command.UnsetSafeMode], for disabling it.
+func (c *Command) SafeMode() *Command {
+	c.mu.Lock()
+	c.safeMode = true
+	c.mu.Unlock()
+
+	return c
+}
+
+// UnsetSafeMode disables safe mode for the command, previously enabled with
+// [Command.SafeMode]. Safe mode remains enabled if it was enabled with
+// [SetSafeMode].
+func (c *Command) UnsetSafeMode() *Command {
+	c.mu.Lock()
+	c.safeMode = false
+	c.mu.Unlock()
+
+	return c
+}
+
+// ErrUnsafeFlag is returned when a flag which isn't allowed in safe mode is
+// used (see [Command.SafeMode]).
+type ErrUnsafeFlag struct {
+	Flag   *Flag
+	Reason string
+}
+
+func (e *ErrUnsafeFlag) Error() string {
+	return fmt.Sprintf("flag %q is not allowed in safe mode: %s", e.Flag.Flag, e.Reason)
+}
+
+// IsUnsafeFlagError returns true when a flag which isn't allowed in safe mode
+// is used.
+func IsUnsafeFlagError(err error) bool {
+	var e *ErrUnsafeFlag
+	return errors.As(err, &e)
+}
+
+// applySafeMode validates the flags against the working directory dir (empty
+// for the current directory), returning the flags with --simulate,
+// --skip-download and --ignore-config injected.
+func applySafeMode(flags []*Flag, dir string) ([]*Flag, error) {
+	if dir == "" {
+		dir = "."
+	}
+
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("unable to resolve working directory: %w", err)
+	}
+
+	out := make([]*Flag, 0, len(flags)+3) //nolint:gomnd
+
+	for _, f := range flags {
+		if f.err != nil {
+			out = append(out, f) // Returned when the command is invoked.
+			continue
+		}
+
+		if f.ID == rawFlagID {
+			return nil, &ErrUnsafeFlag{Flag: f, Reason: "raw flags can't be validated"}
+		}
+
+		// Validate what yt-dlp would actually parse from the flag, rather than
+		// trusting the ID, as flags can be provided with [Command.SetFlagConfig].
+		name, args := parseFlag(f)
+
+		o, ok := safeOptions()[name]
+		switch {
+		case !ok:
+			return nil, &ErrUnsafeFlag{Flag: f, Reason: "unknown flags can't be validated"}
+		case o.ID != f.ID:
+			return nil, &ErrUnsafeFlag{Flag: f, Reason: fmt.Sprintf("flag doesn't belong to ID %q", f.ID)}
+		case len(args) != o.NArgs:
+			return nil, &ErrUnsafeFlag{Flag: f, Reason: fmt.Sprintf("expected %d argument(s), got %d", o.NArgs, len(args))}
+		case slices.Contains(unsafeFlags, o.ID) && len(args) > 0:
+			return nil, &ErrUnsafeFlag{Flag: f, Reason: "executes commands, loads configuration, or updates yt-dlp"}
+		case slices.ContainsFunc(unsafeBoolFlags, func(flag string) bool { return slices.Contains(o.LongFlags, flag) }):
+			return nil, &ErrUnsafeFlag{Flag: f, Reason: "replaces the yt-dlp executable, or removes files outside of the working directory"}
+		case o.ID == "external_downloader" && slices.ContainsFunc(args, func(arg string) bool { return strings.ContainsAny(arg, `/\`) }):
+			return nil, &ErrUnsafeFlag{Flag: f, Reason: "executes an arbitrary external downloader"}
+		case o.ID == "simulate" || o.ID == "skip_download" || o.ID == "ignoreconfig":
+			continue // Injected below.
+		}
+
+		if i, ok := pathFlags[o.ID]; ok && i < len(args) && !f.internal {
+			if reason := unsafePath(o.ID, args[i], dir); reason != "" {
+				return nil, &ErrUnsafeFlag{Flag: f, Reason: reason}
+			}
+		}
+
+		out = append(out, f)
+	}
+
+	return append(
+		out,
+		&Flag{ID: "simulate", Flag: "--simulate", Args: nil},
+		&Flag{ID: "skip_download", Flag: "--skip-download", Args: nil},
+		&Flag{ID: "ignoreconfig", Flag: "--ignore-config", Args: nil},
+	), nil
+}
+
+// checkSafeInputs returns an [ErrUnsafeFlag] if any of the inputs (e.g. URLs)
+// passed to yt-dlp would be parsed as a flag, in safe mode.
+func checkSafeInputs(inputs []string) error {
+	for _, input := range inputs {
+		if strings.HasPrefix(input, "-") {
+			return &ErrUnsafeFlag{
+				Flag:   &Flag{ID: "", Flag: input, Args: nil},
+				Reason: "inputs can't start with \"-\", as they would be parsed as flags",
+			}
+		}
+	}
+
+	return nil
+}
+
+// parseFlag returns the name of the flag (e.g. "--exec"), and the args yt-dlp
+// would parse for it, including any inline value of long flags (e.g.
+// "--exec=cmd").
+func parseFlag(f *Flag) (name string, args []string) {
+	if !strings.HasPrefix(f.Flag, "--") {
+		return f.Flag, f.Args
+	}
+
+	name, value, ok := strings.Cut(f.Flag, "=")
+	if !ok {
+		return name, f.Args
+	}
+
+	return name, append([]string{value}, f.Args...)
+}
+
+// unsafePath returns the reason the path argument of the flag with the provided
+// ID isn't allowed in safe mode, if any.
+func unsafePath(id, path, dir string) string {
+	switch id {
+	case "outtmpl", "paths":
+		path = rePathType.ReplaceAllString(path, "")
+	case "print_to_file":
+		path = strings.ReplaceAll(path, "%%", "%")
+	}
+
+	// yt-dlp expands "~" and environment variables in most paths.
+	if strings.HasPrefix(path, "~") || strings.Contains(path, "$") {
+		return fmt.Sprintf("path %q may expand outside of the working directory", path)
+	}
+
+	if path == "-" {
+		return "" // stdout.
+	}
+
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+
+	path = filepath.Clean(path)
+
+	if rel, err := filepath.Rel(dir, path); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return ""
+	}
+
+	return fmt.Sprintf("path %q is outside of the working directory %q", path, dir)
+}
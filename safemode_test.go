@@ -0,0 +1,129 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestCommand_SafeMode(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	bin := filepath.Join(dir, "yt-dlp")
+
+	result, err := New().
+		SetExecutable(bin).
+		SetWorkDir(dir).
+		SafeMode().
+		NoSimulate().
+		Output("videos/%(title)s.%(ext)s").
+		Paths("temp:"+filepath.Join(dir, "tmp")).
+		printToFileInternal("%(id)s", filepath.Join(os.TempDir(), "go-ytdlp-files-1.txt")).
+		DryRun(context.Background(), "https://example.com/watch?v=abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if slices.Contains(result.Args, "--no-simulate") ||
+		!slices.Contains(result.Args, "--simulate") ||
+		!slices.Contains(result.Args, "--skip-download") ||
+		!slices.Contains(result.Args, "--ignore-config") {
+		t.Fatalf("expected --simulate, --skip-download and --ignore-config to be enforced, got %v", result.Args)
+	}
+
+	// Inputs which would be parsed as flags.
+	_, err = New().SetExecutable(bin).SetWorkDir(dir).SafeMode().DryRun(context.Background(), "--exec", "touch /tmp/pwned", "https://example.com")
+	if !IsUnsafeFlagError(err) {
+		t.Fatalf("flag input: expected unsafe flag error, got %v", err)
+	}
+
+	tests := []struct {
+		name string
+		cmd  *Command
+	}{
+		{name: "exec", cmd: New().Exec("rm -rf {}")},
+		{name: "config", cmd: New().ConfigLocations("/etc/yt-dlp.conf")},
+		{name: "raw", cmd: New().AddRawFlags("--simulate")},
+		{name: "absolute-output", cmd: New().Output("/etc/%(title)s.%(ext)s")},
+		{name: "relative-output", cmd: New().Output("../%(title)s.%(ext)s")},
+		{name: "typed-paths", cmd: New().Paths("home:/var")},
+		{name: "home-cookies", cmd: New().Cookies("~/cookies.txt")},
+		{name: "env-archive", cmd: New().DownloadArchive("$HOME/archive.txt")},
+		{name: "print-to-file", cmd: New().PrintToFile("%(id)s", filepath.Join(os.TempDir(), "ids.txt"))},
+		{name: "print-to-file-internal-name", cmd: New().PrintToFile("%(id)s", filepath.Join(os.TempDir(), "go-ytdlp-files-2.txt"))},
+		{name: "postprocessor", cmd: New().UsePostProcessor("Exec:exec_cmd=id;when=pre_process")},
+		{name: "rm-cache-dir", cmd: New().RmCacheDir()},
+		{name: "ffmpeg-location", cmd: New().FFmpegLocation("/tmp/ffmpeg")},
+		{name: "downloader-path", cmd: New().Downloader("/tmp/aria2c")},
+		{name: "downloader-args", cmd: New().DownloaderArgs("aria2c:--on-download-complete=/tmp/x")},
+		{name: "inline-exec", cmd: New().SetFlagConfig([]*Flag{{ID: "exec_cmd", Flag: "--exec=touch /tmp/pwned"}})},
+		{name: "inline-netrc-cmd", cmd: New().SetFlagConfig([]*Flag{{ID: "netrc_cmd", Flag: "--netrc-cmd=id"}})},
+		{name: "inline-output", cmd: New().SetFlagConfig([]*Flag{{ID: "outtmpl", Flag: "--output=/etc/evil"}})},
+		{name: "mismatched-id", cmd: New().SetFlagConfig([]*Flag{{ID: "whatever", Flag: "--exec", Args: []string{"touch /tmp/pwned"}}})},
+		{name: "mismatched-bool-id", cmd: New().SetFlagConfig([]*Flag{{ID: "quiet", Flag: "--config-locations", Args: []string{"/tmp/x.conf"}}})},
+		{name: "unknown-flag", cmd: New().SetFlagConfig([]*Flag{{ID: "", Flag: "--not-a-real-flag"}})},
+		{name: "short-inline", cmd: New().SetFlagConfig([]*Flag{{ID: "outtmpl", Flag: "-o/etc/evil"}})},
+		{name: "smuggled-args", cmd: New().SetFlagConfig([]*Flag{{ID: "quiet", Flag: "--quiet", Args: []string{"--exec", "id"}}})},
+		{name: "short-update", cmd: New().SetFlagConfig([]*Flag{{ID: "update_self", Flag: "-U"}})},
+	}
+
+	for _, tt := range tests {
+		_, err = tt.cmd.SetExecutable(bin).SetWorkDir(dir).SafeMode().DryRun(context.Background())
+		if !IsUnsafeFlagError(err) {
+			t.Fatalf("%s: expected unsafe flag error, got %v", tt.name, err)
+		}
+	}
+
+	// Updating replaces the yt-dlp executable.
+	if _, err = New().SetExecutable(bin).SetWorkDir(dir).SafeMode().Update(context.Background()); !IsUnsafeFlagError(err) {
+		t.Fatalf("update: expected unsafe flag error, got %v", err)
+	}
+
+	if _, err = New().SetExecutable(bin).SetWorkDir(dir).SafeMode().UpdateTo(context.Background(), "nightly"); !IsUnsafeFlagError(err) {
+		t.Fatalf("update-to: expected unsafe flag error, got %v", err)
+	}
+
+	// Flags which only disable behavior are allowed.
+	if _, err = New().SetExecutable(bin).SafeMode().NoExec().NoCacheDir().NoUpdate().Downloader("native").DryRun(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	// Inline values are validated the same as args.
+	_, err = New().
+		SetExecutable(bin).
+		SetWorkDir(dir).
+		SafeMode().
+		SetFlagConfig([]*Flag{{ID: "outtmpl", Flag: "--output=videos/%(title)s.%(ext)s"}}).
+		DryRun(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSetSafeMode(t *testing.T) { //nolint:paralleltest
+	SetSafeMode(true)
+	t.Cleanup(func() { SetSafeMode(false) })
+
+	cmd := New().SetExecutable(filepath.Join(t.TempDir(), "yt-dlp")).Exec("echo {}")
+
+	if _, err := cmd.DryRun(context.Background()); !IsUnsafeFlagError(err) {
+		t.Fatalf("expected unsafe flag error, got %v", err)
+	}
+
+	if _, err := cmd.UnsetSafeMode().DryRun(context.Background()); !IsUnsafeFlagError(err) {
+		t.Fatalf("expected global safe mode to take precedence, got %v", err)
+	}
+
+	SetSafeMode(false)
+
+	if _, err := cmd.DryRun(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,117 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// SearchProvider is the search "pseudo-URL" prefix that yt-dlp uses to invoke
+// search-based extractors (e.g. "ytsearch5:some query").
+type SearchProvider string
+
+const (
+	SearchYouTube      SearchProvider = "ytsearch"
+	SearchYouTubeDate  SearchProvider = "ytsearchdate" // Newest videos first.
+	SearchSoundCloud   SearchProvider = "scsearch"
+	SearchGoogleVideo  SearchProvider = "gvsearch"
+	SearchBilibili     SearchProvider = "bilisearch"
+	SearchNicoNico     SearchProvider = "nicosearch"
+	SearchNicoNicoDate SearchProvider = "nicosearchdate" // Newest videos first.
+	SearchYahooScreen  SearchProvider = "yvsearch"
+)
+
+// SearchAllResults can be passed to [Search] to request all results, rather than
+// a specific number of results.
+const SearchAllResults = -1
+
+// SearchURL returns the yt-dlp search pseudo-URL for the provided query, e.g.
+// "ytsearch5:some query". If n is 0, yt-dlp's default of 1 result is used, and
+// if n is [SearchAllResults], all results are requested (which may be slow, and
+// is capped by yt-dlp/the provider).
+func (p SearchProvider) SearchURL(query string, n int) (string, error) {
+	if p == "" {
+		return "", errors.New("search provider is empty")
+	}
+
+	query = sanitizeSearchQuery(query)
+	if query == "" {
+		return "", errors.New("search query is empty")
+	}
+
+	switch {
+	case n == SearchAllResults:
+		return string(p) + "all:" + query, nil
+	case n == 0:
+		n = 1
+	case n < 0:
+		return "", fmt.Errorf("invalid number of search results: %d", n)
+	}
+
+	return string(p) + strconv.Itoa(n) + ":" + query, nil
+}
+
+// sanitizeSearchQuery removes any control characters (including newlines, which
+// would otherwise be interpreted as multiple inputs in some cases), and collapses
+// repeated whitespace.
+func sanitizeSearchQuery(query string) string {
+	query = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return ' '
+		}
+		return r
+	}, query)
+
+	return strings.Join(strings.Fields(query), " ")
+}
+
+// Search invokes yt-dlp with the search provider and query (see [SearchProvider.SearchURL]),
+// using flat extraction, and returns the resulting entries. Note that because flat
+// extraction is used, some fields in the returned entries may not be populated
+// (e.g. formats). Any flags already set on the command are also used, and the
+// command itself is not modified.
+func (c *Command) Search(ctx context.Context, provider SearchProvider, query string, n int) ([]*ExtractedInfo, error) {
+	url, err := provider.SearchURL(query, n)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := c.clone().
+		FlatPlaylist().
+		DumpSingleJSON().
+		Run(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+
+	infos, err := result.GetExtractedInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []*ExtractedInfo
+
+	for _, info := range infos {
+		if info.Type != ExtractedTypePlaylist {
+			entries = append(entries, info)
+			continue
+		}
+
+		entries = append(entries, info.Entries...)
+	}
+
+	return entries, nil
+}
+
+// Search is the same as [Command.Search], using a new command with no additional
+// flags.
+func Search(ctx context.Context, provider SearchProvider, query string, n int) ([]*ExtractedInfo, error) {
+	return New().Search(ctx, provider, query, n)
+}
@@ -0,0 +1,43 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import "testing"
+
+func TestSearch_SearchURL(t *testing.T) {
+	tests := []struct {
+		provider SearchProvider
+		query    string
+		n        int
+		want     string
+		wantErr  bool
+	}{
+		{provider: SearchYouTube, query: "foo bar", n: 5, want: "ytsearch5:foo bar"},
+		{provider: SearchSoundCloud, query: "foo", n: 0, want: "scsearch1:foo"},
+		{provider: SearchYouTubeDate, query: "foo", n: SearchAllResults, want: "ytsearchdateall:foo"},
+		{provider: SearchYouTube, query: "  foo\n\tbar\x00 ", n: 2, want: "ytsearch2:foo bar"},
+		{provider: SearchYouTube, query: " \n ", n: 1, wantErr: true},
+		{provider: SearchYouTube, query: "foo", n: -5, wantErr: true},
+		{provider: "", query: "foo", n: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.provider.SearchURL(tt.query, tt.n)
+		if tt.wantErr {
+			if err == nil {
+				t.Fatalf("expected error for %q/%q/%d, got %q", tt.provider, tt.query, tt.n, got)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if got != tt.want {
+			t.Fatalf("expected %q, got %q", tt.want, got)
+		}
+	}
+}
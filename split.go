@@ -0,0 +1,75 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"fmt"
+)
+
+// BatchSplit configures splitting of invocations with many inputs (e.g. URLs)
+// across multiple yt-dlp processes, see [Command.SetBatchSplit].
+type BatchSplit struct {
+	// Size is the maximum number of inputs passed to each yt-dlp process.
+	// Invocations with up to Size inputs aren't split.
+	Size int
+
+	// Concurrency is the number of yt-dlp processes to run at once. 0 means 1
+	// (batches are invoked sequentially).
+	Concurrency int
+}
+
+// Validate returns an error if the batch split configuration is invalid.
+func (s *BatchSplit) Validate() error {
+	if s.Size < 1 {
+		return fmt.Errorf("invalid batch size: %d", s.Size)
+	}
+
+	if s.Concurrency < 0 {
+		return fmt.Errorf("invalid batch concurrency: %d", s.Concurrency)
+	}
+
+	return nil
+}
+
+// SetBatchSplit configures [Command.Run] to split invocations with more than
+// [BatchSplit.Size] inputs into batches, each invoked by a separate yt-dlp
+// process, which avoids OS argument length limits, and limits the impact of a
+// single process crashing. The results of all batches are merged into a single
+// result (with [Result.Inputs] in the same order as provided), and the errors of
+// all failed batches are joined. All other options (e.g. retries) apply to each
+// batch individually. Pass nil to disable splitting (the default).
+func (c *Command) SetBatchSplit(split *BatchSplit) *Command {
+	c.mu.Lock()
+	if split == nil {
+		c.split = nil
+	} else {
+		s := *split
+		c.split = &s
+	}
+	c.mu.Unlock()
+
+	return c
+}
+
+// runSplit invokes the command for each batch of inputs, according to split.
+func (c *Command) runSplit(ctx context.Context, split *BatchSplit, inputs ...string) (*Result, error) {
+	if err := split.Validate(); err != nil {
+		return nil, err
+	}
+
+	cmd := c.clone().SetBatchSplit(nil)
+
+	var batches [][]string
+	for i := 0; i < len(inputs); i += split.Size {
+		batches = append(batches, inputs[i:min(i+split.Size, len(inputs))])
+	}
+
+	results, err := runPool(ctx, cmd, split.Concurrency, batches, func(i int) string {
+		return fmt.Sprintf("batch %d", i+1)
+	})
+
+	return MergeResults(results...), err
+}
@@ -0,0 +1,67 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestCommand_SetBatchSplit(t *testing.T) {
+	t.Parallel()
+
+	// Echo the args, and fail for the "bad" input.
+	bin := writeFakeYtdlp(t, "#!/bin/sh\necho \"$@\"\nfor a in \"$@\"; do [ \"$a\" = bad ] && exit 1; done\nexit 0\n")
+
+	inputs := []string{"a", "b", "c", "d", "e"}
+
+	for _, concurrency := range []int{0, 2} {
+		cmd := New().SetExecutable(bin).Simulate().SetBatchSplit(&BatchSplit{Size: 2, Concurrency: concurrency})
+
+		result, err := cmd.Run(context.Background(), inputs...)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !slices.Equal(result.Inputs, inputs) || !slices.Equal(result.Args, []string{"--simulate", "a", "b", "c", "d", "e"}) {
+			t.Fatalf("unexpected inputs/args: %v/%v", result.Inputs, result.Args)
+		}
+
+		lines := strings.Split(result.Stdout, "\n")
+		slices.Sort(lines)
+
+		if !slices.Equal(lines, []string{"--simulate a b", "--simulate c d", "--simulate e"}) {
+			t.Fatalf("expected 3 batches, got %q", result.Stdout)
+		}
+
+		for i, l := range result.OutputLogs {
+			if l.Sequence != uint64(i)+1 {
+				t.Fatalf("expected logs to be re-sequenced, got %d at %d", l.Sequence, i)
+			}
+		}
+	}
+
+	// Inputs within the batch size aren't split.
+	result, err := New().SetExecutable(bin).SetBatchSplit(&BatchSplit{Size: 2}).Run(context.Background(), "a", "b")
+	if err != nil || result.Stdout != "a b" {
+		t.Fatalf("unexpected result: %v: %+v", err, result)
+	}
+
+	// Failed batches don't stop the others.
+	result, err = New().SetExecutable(bin).SetBatchSplit(&BatchSplit{Size: 1}).Run(context.Background(), "a", "bad", "c")
+	if !IsExitCodeError(err) || !strings.Contains(err.Error(), "batch 2") {
+		t.Fatalf("expected exit code error for batch 2, got %v", err)
+	}
+
+	if result.ExitCode != 1 || result.Stdout != "a\nbad\nc" {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+
+	if _, err = New().SetExecutable(bin).SetBatchSplit(&BatchSplit{Size: 0}).Run(context.Background(), "a"); err == nil {
+		t.Fatal("expected validation error")
+	}
+}
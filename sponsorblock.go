@@ -0,0 +1,306 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// DefaultSponsorBlockTolerance is the default tolerance used by
+// [Command.VerifySponsorBlock].
+const DefaultSponsorBlockTolerance = time.Second
+
+// sponsorBlockCategories are the SponsorBlock categories which can be removed
+// with [Command.SponsorblockRemove] (poi_highlight and chapter can only be marked).
+var sponsorBlockCategories = []string{
+	"sponsor", "intro", "outro", "selfpromo", "preview", "filler", "interaction", "music_offtopic", "hook",
+}
+
+// sponsorBlockTemplates are the --print-to-file templates used to record the
+// original duration of each video (before any segments are removed, as yt-dlp
+// updates the duration afterwards), and the SponsorBlock segments of each file.
+const (
+	sponsorBlockVideoTemplate = "video:%(.{id,duration})j"
+	sponsorBlockMoveTemplate  = "after_move:%(.{id,filepath,sponsorblock_chapters})j"
+)
+
+// SponsorBlockSegment is a single SponsorBlock segment, as returned by the
+// SponsorBlock API.
+type SponsorBlockSegment struct {
+	Category string  `json:"category"`
+	Title    string  `json:"title,omitempty"`
+	Type     string  `json:"type,omitempty"`
+	Start    float64 `json:"start_time"` // In seconds.
+	End      float64 `json:"end_time"`   // In seconds.
+}
+
+// Duration returns the duration of the segment.
+func (s *SponsorBlockSegment) Duration() time.Duration {
+	return time.Duration((s.End - s.Start) * float64(time.Second))
+}
+
+// SponsorBlockVerification is the result of verifying that the SponsorBlock
+// segments were removed from a single downloaded file. See
+// [Command.VerifySponsorBlock].
+type SponsorBlockVerification struct {
+	// ID is the ID of the video.
+	ID string `json:"id"`
+
+	// Path is the path to the final file.
+	Path string `json:"path"`
+
+	// RemovedSegments are the segments which should have been removed from the
+	// file, based on the categories provided to [Command.SponsorblockRemove].
+	RemovedSegments []*SponsorBlockSegment `json:"removed_segments,omitempty"`
+
+	// OriginalDuration is the duration of the video, before any segments were
+	// removed.
+	OriginalDuration time.Duration `json:"original_duration"`
+
+	// ExpectedDuration is the original duration, minus the (merged) duration of
+	// all removed segments.
+	ExpectedDuration time.Duration `json:"expected_duration"`
+
+	// ActualDuration is the duration of the file, as reported by ffprobe.
+	ActualDuration time.Duration `json:"actual_duration"`
+
+	// OK is true if the actual duration is within the configured tolerance of the
+	// expected duration.
+	OK bool `json:"ok"`
+}
+
+// ErrSponsorBlockMismatch is returned when the duration of one or more files
+// doesn't match the expected duration after SponsorBlock segments were removed.
+// See [Command.VerifySponsorBlock].
+type ErrSponsorBlockMismatch struct {
+	Verifications []*SponsorBlockVerification // Only those which failed.
+	Tolerance     time.Duration
+}
+
+func (e *ErrSponsorBlockMismatch) Error() string {
+	out := make([]string, 0, len(e.Verifications))
+
+	for _, v := range e.Verifications {
+		out = append(out, fmt.Sprintf(
+			"%q (expected %s, got %s)",
+			v.Path,
+			v.ExpectedDuration.Round(time.Millisecond),
+			v.ActualDuration.Round(time.Millisecond),
+		))
+	}
+
+	return fmt.Sprintf(
+		"sponsorblock segment removal mismatch (tolerance %s): %s",
+		e.Tolerance,
+		strings.Join(out, ", "),
+	)
+}
+
+// IsSponsorBlockMismatchError returns true when the duration of one or more files
+// doesn't match the expected duration after SponsorBlock segments were removed.
+func IsSponsorBlockMismatchError(err error) bool {
+	var e *ErrSponsorBlockMismatch
+	return errors.As(err, &e)
+}
+
+// VerifySponsorBlock enables verification of SponsorBlock segment removal (see
+// [Command.SponsorblockRemove]). After yt-dlp exits successfully, the duration
+// of each downloaded file is checked with ffprobe, and must be within tolerance
+// of the original duration minus the removed segments. If tolerance is <= 0,
+// [DefaultSponsorBlockTolerance] is used. The results are available via
+// [Result.SponsorBlock], and [ErrSponsorBlockMismatch] is returned if any file
+// fails verification.
+//
+// ffprobe is resolved from [Command.FFmpegLocation] if set, otherwise from PATH.
+// Verification is skipped if [Command.SponsorblockRemove] isn't set. Note that
+// chapters removed with [Command.RemoveChapters] aren't accounted for.
+//   - See [Command.UnsetVerifySponsorBlock], for disabling verification.
+func (c *Command) VerifySponsorBlock(tolerance time.Duration) *Command {
+	if tolerance <= 0 {
+		tolerance = DefaultSponsorBlockTolerance
+	}
+
+	c.mu.Lock()
+	c.sponsorBlockTolerance = tolerance
+	c.mu.Unlock()
+
+	return c
+}
+
+// UnsetVerifySponsorBlock disables SponsorBlock verification, previously enabled
+// with [Command.VerifySponsorBlock].
+func (c *Command) UnsetVerifySponsorBlock() *Command {
+	c.mu.Lock()
+	c.sponsorBlockTolerance = 0
+	c.mu.Unlock()
+
+	return c
+}
+
+// sponsorBlockRemoved returns the SponsorBlock categories which will be removed,
+// based on all --sponsorblock-remove flags (which yt-dlp applies cumulatively).
+func (c *Command) sponsorBlockRemoved() map[string]bool {
+	removed := make(map[string]bool)
+
+	for _, f := range c.getFlagsByID("sponsorblock_remove") {
+		if len(f.Args) == 0 {
+			continue
+		}
+
+		for _, cat := range strings.Split(f.Args[0], ",") {
+			cat = strings.TrimSpace(cat)
+
+			switch cat {
+			case "all":
+				for _, v := range sponsorBlockCategories {
+					removed[v] = true
+				}
+			case "default":
+				for _, v := range sponsorBlockCategories {
+					removed[v] = true
+				}
+				delete(removed, "filler") // "default" is an alias for "all,-filler".
+			case "":
+			default:
+				if strings.HasPrefix(cat, "-") {
+					delete(removed, cat[1:])
+					continue
+				}
+
+				removed[cat] = true
+			}
+		}
+	}
+
+	return removed
+}
+
+// sponsorBlockRecord is a single line written by yt-dlp using the SponsorBlock
+// templates.
+type sponsorBlockRecord struct {
+	ID       string                 `json:"id"`
+	Filepath string                 `json:"filepath"`
+	Duration float64                `json:"duration"`
+	Segments []*SponsorBlockSegment `json:"sponsorblock_chapters"`
+}
+
+// runVerifySponsorBlock invokes yt-dlp, recording the original duration and
+// SponsorBlock segments of each video, and verifies each resulting file.
+func (c *Command) runVerifySponsorBlock(ctx context.Context, tolerance time.Duration, args ...string) (*Result, error) {
+	result, records, err := runRecorded(
+		ctx,
+		c.clone().UnsetVerifySponsorBlock(),
+		[]string{sponsorBlockVideoTemplate, sponsorBlockMoveTemplate},
+		func(r *sponsorBlockRecord) bool { return r.ID != "" },
+		args...,
+	)
+	if err != nil {
+		return result, err
+	}
+
+	ffprobe, err := c.resolveFFprobe()
+	if err != nil {
+		return result, fmt.Errorf("unable to resolve ffprobe: %w", err)
+	}
+
+	c.mu.RLock()
+	workDir := c.directory
+	c.mu.RUnlock()
+
+	removed := c.sponsorBlockRemoved()
+	durations := make(map[string]float64)
+	mismatch := &ErrSponsorBlockMismatch{Tolerance: tolerance}
+
+	for _, r := range records {
+		if r.Filepath == "" {
+			if r.Duration > 0 {
+				durations[r.ID] = r.Duration
+			}
+			continue
+		}
+
+		v := &SponsorBlockVerification{
+			ID:               r.ID,
+			Path:             r.Filepath,
+			OriginalDuration: time.Duration(durations[r.ID] * float64(time.Second)),
+		}
+
+		for _, s := range r.Segments {
+			if removed[s.Category] && s.End > s.Start {
+				v.RemovedSegments = append(v.RemovedSegments, s)
+			}
+		}
+
+		v.ExpectedDuration = v.OriginalDuration - mergedDuration(v.RemovedSegments)
+
+		path := r.Filepath
+		if !filepath.IsAbs(path) && workDir != "" {
+			path = filepath.Join(workDir, path)
+		}
+
+		probe, _, err := probeFile(ctx, ffprobe, path)
+		if err != nil {
+			return result, fmt.Errorf("unable to probe %q: %w", r.Filepath, err)
+		}
+
+		v.ActualDuration = probe.duration()
+
+		diff := v.ActualDuration - v.ExpectedDuration
+		if diff < 0 {
+			diff = -diff
+		}
+
+		v.OK = v.OriginalDuration > 0 && v.ActualDuration > 0 && diff <= tolerance
+		if !v.OK {
+			mismatch.Verifications = append(mismatch.Verifications, v)
+		}
+
+		result.SponsorBlock = append(result.SponsorBlock, v)
+	}
+
+	if len(mismatch.Verifications) > 0 {
+		return result, mismatch
+	}
+
+	return result, nil
+}
+
+// mergedDuration returns the total duration of the provided segments, with any
+// overlapping segments merged.
+func mergedDuration(segments []*SponsorBlockSegment) time.Duration {
+	sorted := slices.Clone(segments)
+	slices.SortFunc(sorted, func(a, b *SponsorBlockSegment) int {
+		switch {
+		case a.Start < b.Start:
+			return -1
+		case a.Start > b.Start:
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	var total, start, end float64
+
+	for i, s := range sorted {
+		if i > 0 && s.Start <= end {
+			end = max(end, s.End)
+			continue
+		}
+
+		total += end - start
+		start, end = s.Start, s.End
+	}
+
+	total += end - start
+
+	return time.Duration(total * float64(time.Second))
+}
@@ -0,0 +1,125 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// fakeSponsorBlockScript emulates yt-dlp downloading two files with SponsorBlock
+// segments removed, writing the video and after_move records to the
+// --print-to-file file. The duration of each file is its contents.
+const fakeSponsorBlockScript = `#!/bin/sh
+while [ $# -gt 0 ]; do
+	case "$1" in
+		--print-to-file) pfile="$3"; shift; shift ;;
+	esac
+	shift
+done
+segments='[{"category":"sponsor","start_time":10,"end_time":20},{"category":"intro","start_time":15,"end_time":25},{"category":"filler","start_time":50,"end_time":60},{"category":"poi_highlight","start_time":70,"end_time":70}]'
+echo 85.4 > a.mp4
+echo 90 > b.mp4
+echo '{"id": "a", "duration": 100}' >> "$pfile"
+echo '{"id": "a", "filepath": "a.mp4", "sponsorblock_chapters": '"$segments"'}' >> "$pfile"
+echo '{"id": "b", "duration": 100}' >> "$pfile"
+echo '{"id": "b", "filepath": "b.mp4", "sponsorblock_chapters": '"$segments"'}' >> "$pfile"
+`
+
+// fakeFFprobeScript emulates ffprobe, reporting the contents of the file as its
+// duration, or an error if the file contains "corrupt".
+const fakeFFprobeScript = `#!/bin/sh
+for f; do :; done
+read -r d < "$f"
+if [ "$d" = corrupt ]; then
+	echo "$f: moov atom not found" >&2
+	exit 1
+fi
+echo '{"format": {"format_name": "mp4", "duration": "'"$d"'"}}'
+`
+
+func TestCommand_VerifySponsorBlock(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	bin := writeFakeExecutable(t, dir, "yt-dlp.sh", fakeSponsorBlockScript)
+
+	writeFakeExecutable(t, dir, "ffprobe", fakeFFprobeScript)
+
+	cmd := New().
+		SetExecutable(bin).
+		SetWorkDir(dir).
+		FFmpegLocation(dir).
+		SponsorblockRemove("default").
+		VerifySponsorBlock(time.Second)
+
+	result, err := cmd.Run(context.Background(), "https://example.com")
+
+	var mismatch *ErrSponsorBlockMismatch
+	if !errors.As(err, &mismatch) {
+		t.Fatalf("expected sponsorblock mismatch error, got %v", err)
+	}
+
+	if len(mismatch.Verifications) != 1 || mismatch.Verifications[0].ID != "b" {
+		t.Fatalf("expected only %q to fail verification, got %v", "b", mismatch.Verifications)
+	}
+
+	if len(result.SponsorBlock) != 2 {
+		t.Fatalf("expected 2 verifications, got %d", len(result.SponsorBlock))
+	}
+
+	v := result.SponsorBlock[0]
+
+	if !v.OK || v.ID != "a" {
+		t.Fatalf("expected %q to pass verification, got %+v", "a", v)
+	}
+
+	if len(v.RemovedSegments) != 2 {
+		t.Fatalf("expected 2 removed segments, got %d", len(v.RemovedSegments))
+	}
+
+	if v.OriginalDuration != 100*time.Second || v.ExpectedDuration != 85*time.Second {
+		t.Fatalf("expected original/expected duration of 100s/85s, got %s/%s", v.OriginalDuration, v.ExpectedDuration)
+	}
+
+	if len(cmd.getFlagsByID("print_to_file")) != 0 {
+		t.Fatal("expected command to not be modified")
+	}
+}
+
+func TestCommand_sponsorBlockRemoved(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		cats     []string
+		expected []string
+	}{
+		{cats: []string{"sponsor,intro"}, expected: []string{"sponsor", "intro"}},
+		{cats: []string{"all,-preview"}, expected: []string{"sponsor", "intro", "outro", "selfpromo", "filler", "interaction", "music_offtopic", "hook"}},
+		{cats: []string{"default"}, expected: []string{"sponsor", "intro", "outro", "selfpromo", "preview", "interaction", "music_offtopic", "hook"}},
+		{cats: []string{"sponsor", "filler,-sponsor"}, expected: []string{"filler"}},
+	}
+
+	for _, tt := range tests {
+		cmd := New()
+		for _, c := range tt.cats {
+			cmd.SponsorblockRemove(c)
+		}
+
+		removed := cmd.sponsorBlockRemoved()
+
+		if len(removed) != len(tt.expected) {
+			t.Fatalf("%v: expected %v, got %v", tt.cats, tt.expected, removed)
+		}
+
+		for _, c := range tt.expected {
+			if !removed[c] {
+				t.Fatalf("%v: expected %q to be removed, got %v", tt.cats, c, removed)
+			}
+		}
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// RunOutcome is the outcome of an invocation, as recorded in [RunStats].
+type RunOutcome string
+
+const (
+	RunOutcomeSuccess  RunOutcome = "success"
+	RunOutcomeFailed   RunOutcome = "failed"
+	RunOutcomeCanceled RunOutcome = "canceled"
+)
+
+// RunStats is anonymized metadata about a single invocation of [Command.Run],
+// which is passed to the [StatsRecorder] set with [Command.SetStatsRecorder]. It
+// intentionally doesn't contain any URLs, video IDs, titles or file paths.
+type RunStats struct {
+	// Extractors are the extractors used (e.g. "youtube" or "generic"), in the
+	// order they were first used.
+	Extractors []string `json:"extractors,omitempty"`
+
+	// Outcome is the outcome of the invocation.
+	Outcome RunOutcome `json:"outcome"`
+
+	// ErrorKind is the kind of error returned by the invocation (e.g.
+	// "rate_limited" or "exit_code"), if any.
+	ErrorKind string `json:"error_kind,omitempty"`
+
+	// ExitCode is the exit code of yt-dlp.
+	ExitCode int `json:"exit_code"`
+
+	// Attempts is the number of attempts made (see [Command.SetRetryPolicy]).
+	Attempts int `json:"attempts"`
+
+	// Duration is the total duration of the invocation, including any retries and
+	// verification.
+	Duration time.Duration `json:"duration"`
+
+	// Bytes is the number of bytes downloaded. If [Command.TrackBandwidth] is
+	// enabled, this is the sum of BytesByCategory, otherwise it's approximated
+	// from the download summary lines of yt-dlp, and will be 0 if downloads
+	// weren't reported (e.g. when using [Command.Quiet] or [Command.ProgressFunc]).
+	Bytes int64 `json:"bytes"`
+
+	// BytesByCategory is the number of bytes downloaded by category, when
+	// [Command.TrackBandwidth] is enabled.
+	BytesByCategory map[BytesCategory]int64 `json:"bytes_by_category,omitempty"`
+}
+
+// StatsRecorder receives anonymized metadata about each invocation of
+// [Command.Run] (and functions which use it), which can be used to build local
+// dashboards or metrics. go-ytdlp never sends any statistics anywhere itself.
+// RecordRun is called synchronously once the invocation completes, so
+// implementations should avoid blocking, and must be safe for concurrent use if
+// shared between commands.
+type StatsRecorder interface {
+	RecordRun(stats *RunStats)
+}
+
+// StatsRecorderFunc is an adapter to allow the use of ordinary functions as a
+// [StatsRecorder].
+type StatsRecorderFunc func(stats *RunStats)
+
+// RecordRun calls fn(stats).
+func (fn StatsRecorderFunc) RecordRun(stats *RunStats) {
+	fn(stats)
+}
+
+// SetStatsRecorder sets the recorder which receives anonymized metadata about
+// each invocation of the command. Any panics in the recorder are recovered, and
+// recorded in [Result.CallbackPanics]. Pass nil to unset it (the default).
+func (c *Command) SetStatsRecorder(r StatsRecorder) *Command {
+	c.mu.Lock()
+	c.stats = r
+	c.mu.Unlock()
+
+	return c
+}
+
+var (
+	// "[youtube] Extracting URL: https://...".
+	reExtractingURL = regexp.MustCompile(`^\[([^\]]+)\] Extracting URL: `)
+	// "[download] 100% of   10.00MiB in 00:00:05 at 2.00MiB/s".
+	reDownloadSummary = regexp.MustCompile(`^\[download\] 100(?:\.0)?% of ~?\s*([\d.]+)([KMGTP]?i?B)\b`)
+)
+
+// errorKind returns the kind of the provided error, for [RunStats.ErrorKind].
+func errorKind(err error) string {
+	switch {
+	case err == nil:
+		return ""
+	case IsCanceledError(err):
+		return "canceled"
+	case IsGeoRestrictedError(err):
+		return "geo_restricted"
+	case IsPrivateVideoError(err):
+		return "private_video"
+	case IsAgeRestrictedError(err):
+		return "age_restricted"
+	case IsRateLimitedError(err):
+		return "rate_limited"
+	case IsDRMProtectedError(err):
+		return "drm_protected"
+	case IsUnsupportedURLError(err):
+		return "unsupported_url"
+	case IsJSRuntimeRequiredError(err):
+		return "js_runtime_required"
+	case IsMisconfigError(err):
+		return "misconfig"
+	case IsParsingError(err):
+		return "parsing"
+	case IsExitCodeError(err):
+		return "exit_code"
+	default:
+		return "unknown"
+	}
+}
+
+// Stats returns anonymized metadata about the invocation, the same as is passed
+// to the [StatsRecorder] set with [Command.SetStatsRecorder]. Note that the
+// outcome is only based on the exit code, and the duration only covers the final
+// attempt (see [Command.SetRetryPolicy]).
+func (r *Result) Stats() *RunStats {
+	stats := &RunStats{
+		Outcome:  RunOutcomeSuccess,
+		ExitCode: r.ExitCode,
+		Attempts: max(r.Attempts, 1),
+		Duration: r.duration,
+	}
+
+	if r.ExitCode != 0 {
+		stats.Outcome = RunOutcomeFailed
+		stats.ErrorKind = "exit_code"
+	}
+
+	for _, log := range r.OutputLogs {
+		line := strings.TrimSpace(log.Line)
+
+		if m := reExtractingURL.FindStringSubmatch(line); m != nil {
+			if !slices.Contains(stats.Extractors, m[1]) {
+				stats.Extractors = append(stats.Extractors, m[1])
+			}
+			continue
+		}
+
+		if m := reDownloadSummary.FindStringSubmatch(line); m != nil && r.bytesByCategory == nil {
+			if size, err := ParseByteSize(m[1] + m[2]); err == nil {
+				stats.Bytes += int64(size)
+			}
+		}
+	}
+
+	if r.bytesByCategory != nil {
+		stats.BytesByCategory = maps.Clone(r.bytesByCategory)
+
+		for _, n := range r.bytesByCategory {
+			stats.Bytes += n
+		}
+	}
+
+	return stats
+}
+
+// newRunStats builds the stats of an invocation of [Command.Run] from its results.
+func newRunStats(started time.Time, result *Result, err error) *RunStats {
+	stats := &RunStats{Attempts: 1}
+	if result != nil {
+		stats = result.Stats()
+	}
+
+	stats.Duration = time.Since(started)
+	stats.ErrorKind = errorKind(err)
+
+	switch {
+	case IsCanceledError(err):
+		stats.Outcome = RunOutcomeCanceled
+	case err != nil:
+		stats.Outcome = RunOutcomeFailed
+	default:
+		stats.Outcome = RunOutcomeSuccess
+	}
+
+	return stats
+}
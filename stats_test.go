@@ -0,0 +1,77 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+const fakeStatsScript = `#!/bin/sh
+echo "[youtube] Extracting URL: https://www.youtube.com/watch?v=abc"
+echo "[youtube] abc: Downloading webpage"
+echo "[download] 100% of   10.00MiB in 00:00:05 at 2.00MiB/s"
+echo "[generic] Extracting URL: https://example.com/video.mp4"
+echo "[youtube] Extracting URL: https://www.youtube.com/watch?v=def"
+echo "[download] 100% of ~ 512.00KiB in 00:00:01 at 512.00KiB/s"
+exit $1
+`
+
+func TestCommand_SetStatsRecorder(t *testing.T) {
+	t.Parallel()
+
+	bin := writeFakeYtdlp(t, fakeStatsScript)
+
+	var recorded []*RunStats
+
+	cmd := New().
+		SetExecutable(bin).
+		SetStatsRecorder(StatsRecorderFunc(func(stats *RunStats) {
+			recorded = append(recorded, stats)
+		}))
+
+	if _, err := cmd.Run(context.Background(), "0"); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := cmd.Run(context.Background(), "1"); !IsExitCodeError(err) {
+		t.Fatalf("expected exit code error, got %v", err)
+	}
+
+	if len(recorded) != 2 {
+		t.Fatalf("expected 2 recorded runs, got %d", len(recorded))
+	}
+
+	stats := recorded[0]
+
+	if stats.Outcome != RunOutcomeSuccess || stats.ErrorKind != "" || stats.Attempts != 1 || stats.Duration <= 0 {
+		t.Fatalf("unexpected stats: %+v", stats)
+	}
+
+	if !slices.Equal(stats.Extractors, []string{"youtube", "generic"}) {
+		t.Fatalf("unexpected extractors: %q", stats.Extractors)
+	}
+
+	if expected := int64(10*1024*1024 + 512*1024); stats.Bytes != expected {
+		t.Fatalf("expected %d bytes, got %d", expected, stats.Bytes)
+	}
+
+	if recorded[1].Outcome != RunOutcomeFailed || recorded[1].ErrorKind != "exit_code" || recorded[1].ExitCode != 1 {
+		t.Fatalf("unexpected stats for failed run: %+v", recorded[1])
+	}
+
+	result, err := New().
+		SetExecutable(bin).
+		SetStatsRecorder(StatsRecorderFunc(func(_ *RunStats) { panic("boom") })).
+		Run(context.Background(), "0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(result.CallbackPanics) != 1 || result.CallbackPanics[0].Callback != "stats" {
+		t.Fatalf("expected recovered stats panic, got %v", result.CallbackPanics)
+	}
+}
@@ -0,0 +1,152 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// throughputSampleInterval is the progress update frequency used when throughput
+// sampling is enabled, but no progress function is set.
+const throughputSampleInterval = 250 * time.Millisecond
+
+// ThroughputSample is a point-in-time sample of the number of bytes downloaded.
+type ThroughputSample struct {
+	Time            time.Time `json:"time"`
+	DownloadedBytes int       `json:"downloaded_bytes"`
+}
+
+// ThroughputSamples are the samples for a single download, ordered from oldest
+// to newest.
+type ThroughputSamples []ThroughputSample
+
+// Rates returns the download speed (in bytes per second) between each pair of
+// consecutive samples, which is useful for drawing speed graphs. The returned
+// slice has one less entry than the number of samples.
+func (s ThroughputSamples) Rates() []float64 {
+	if len(s) < 2 { //nolint:gomnd
+		return nil
+	}
+
+	rates := make([]float64, 0, len(s)-1)
+
+	for i := 1; i < len(s); i++ {
+		elapsed := s[i].Time.Sub(s[i-1].Time).Seconds()
+		if elapsed <= 0 {
+			rates = append(rates, 0)
+			continue
+		}
+
+		rates = append(rates, float64(s[i].DownloadedBytes-s[i-1].DownloadedBytes)/elapsed)
+	}
+
+	return rates
+}
+
+// Rate returns the average download speed (in bytes per second) across all of
+// the samples, or 0 if there aren't enough samples.
+func (s ThroughputSamples) Rate() float64 {
+	if len(s) < 2 { //nolint:gomnd
+		return 0
+	}
+
+	elapsed := s[len(s)-1].Time.Sub(s[0].Time).Seconds()
+	if elapsed <= 0 {
+		return 0
+	}
+
+	return float64(s[len(s)-1].DownloadedBytes-s[0].DownloadedBytes) / elapsed
+}
+
+// sampleRing is a fixed-size ring buffer of samples.
+type sampleRing struct {
+	samples []ThroughputSample
+	next    int
+	full    bool
+}
+
+func (r *sampleRing) add(sample ThroughputSample) {
+	r.samples[r.next] = sample
+	r.next = (r.next + 1) % len(r.samples)
+
+	if r.next == 0 {
+		r.full = true
+	}
+}
+
+func (r *sampleRing) ordered() ThroughputSamples {
+	if !r.full {
+		return slices.Clone(r.samples[:r.next])
+	}
+
+	return append(slices.Clone(r.samples[r.next:]), r.samples[:r.next]...)
+}
+
+// throughputRecorder records samples for each download (keyed by filename).
+type throughputRecorder struct {
+	size int
+
+	mu        sync.RWMutex
+	downloads map[string]*sampleRing
+	order     []string
+}
+
+func newThroughputRecorder(size int) *throughputRecorder {
+	return &throughputRecorder{
+		size:      size,
+		downloads: make(map[string]*sampleRing),
+	}
+}
+
+func (t *throughputRecorder) record(update ProgressUpdate) {
+	if update.Status != ProgressStatusDownloading && update.Status != ProgressStatusFinished {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	ring, ok := t.downloads[update.Filename]
+	if !ok {
+		ring = &sampleRing{samples: make([]ThroughputSample, t.size)}
+		t.downloads[update.Filename] = ring
+		t.order = append(t.order, update.Filename)
+	}
+
+	ring.add(ThroughputSample{Time: time.Now(), DownloadedBytes: update.DownloadedBytes})
+}
+
+func (t *throughputRecorder) samples(filename string) ThroughputSamples {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	ring, ok := t.downloads[filename]
+	if !ok {
+		return nil
+	}
+
+	return ring.ordered()
+}
+
+func (t *throughputRecorder) filenames() []string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	return slices.Clone(t.order)
+}
+
+// SetThroughputSamples enables recording of up to n of the most recent
+// (timestamp, downloaded bytes) samples for each download, for invocations
+// started with [Command.Start]. Samples are accessible via [RunHandle.Throughput].
+// A value of 0 disables recording (the default).
+func (c *Command) SetThroughputSamples(n int) *Command {
+	c.mu.Lock()
+	c.throughputSamples = max(n, 0)
+	c.mu.Unlock()
+
+	return c
+}
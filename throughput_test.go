@@ -0,0 +1,85 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestThroughput_Samples(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	ring := &sampleRing{samples: make([]ThroughputSample, 3)}
+
+	for i := range 5 {
+		ring.add(ThroughputSample{Time: now.Add(time.Duration(i) * time.Second), DownloadedBytes: i * 100})
+	}
+
+	samples := ring.ordered()
+
+	var bytes []int
+	for _, s := range samples {
+		bytes = append(bytes, s.DownloadedBytes)
+	}
+
+	if !slices.Equal(bytes, []int{200, 300, 400}) {
+		t.Fatalf("expected the 3 most recent samples, got %v", bytes)
+	}
+
+	if !slices.Equal(samples.Rates(), []float64{100, 100}) {
+		t.Fatalf("unexpected rates: %v", samples.Rates())
+	}
+
+	if samples.Rate() != 100 {
+		t.Fatalf("expected rate of 100, got %v", samples.Rate())
+	}
+
+	if ThroughputSamples(nil).Rate() != 0 || ThroughputSamples(nil).Rates() != nil {
+		t.Fatal("expected no rate without samples")
+	}
+}
+
+func TestRunHandle_Throughput(t *testing.T) {
+	t.Parallel()
+
+	script := "#!/bin/sh\n" + fakeProgressPreamble
+	for _, n := range []int{0, 100, 200, 300} {
+		script += `echo "$PROGRESS"'{"info":{"id":"abc","_type":"video"},"progress":{"status":"downloading","downloaded_bytes":` +
+			strconv.Itoa(n) + `,"filename":"abc.mp4"}}'` + "\n"
+	}
+
+	bin := writeFakeYtdlp(t, script)
+
+	var updates int
+
+	h := New().
+		SetExecutable(bin).
+		ProgressFunc(time.Second, func(_ ProgressUpdate) { updates++ }).
+		SetThroughputSamples(2).
+		Start(context.Background())
+
+	if _, err := h.Wait(); err != nil {
+		t.Fatal(err)
+	}
+
+	if updates != 4 {
+		t.Fatalf("expected existing progress function to receive 4 updates, got %d", updates)
+	}
+
+	if !slices.Equal(h.Downloads(), []string{"abc.mp4"}) {
+		t.Fatalf("unexpected downloads: %q", h.Downloads())
+	}
+
+	samples := h.Throughput("abc.mp4")
+	if len(samples) != 2 || samples[0].DownloadedBytes != 200 || samples[1].DownloadedBytes != 300 {
+		t.Fatalf("unexpected samples: %v", samples)
+	}
+}
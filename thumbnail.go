@@ -0,0 +1,217 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os/exec"
+)
+
+// ThumbnailFormat is the image format that a thumbnail should be converted to.
+type ThumbnailFormat string
+
+const (
+	ThumbnailFormatOriginal ThumbnailFormat = ""    // No conversion.
+	ThumbnailFormatJPG      ThumbnailFormat = "jpg" // Requires ffmpeg.
+	ThumbnailFormatPNG      ThumbnailFormat = "png" // Requires ffmpeg.
+)
+
+// ThumbnailPreference are the options used to select (and optionally convert)
+// a thumbnail with [FetchThumbnail].
+type ThumbnailPreference struct {
+	// ID is the exact thumbnail ID to fetch. If empty, the best thumbnail will
+	// be selected.
+	ID string
+
+	// MaxWidth and MaxHeight limit the selected thumbnail to the provided
+	// dimensions, if the dimensions of the thumbnail are known. 0 means no limit.
+	MaxWidth  int
+	MaxHeight int
+
+	// Format is the format the thumbnail should be converted to, which requires
+	// ffmpeg. Defaults to [ThumbnailFormatOriginal].
+	Format ThumbnailFormat
+
+	// FFmpegLocation is the path to the ffmpeg executable (or its containing
+	// directory), resolved the same way as [Command.FFmpegLocation]. If empty, it
+	// will be resolved from PATH.
+	FFmpegLocation string
+
+	// Client is the HTTP client used to fetch the thumbnail. Defaults to a client
+	// with a reasonable timeout.
+	Client *http.Client
+}
+
+// selectThumbnail returns the best thumbnail from info, according to pref. yt-dlp
+// returns thumbnails ordered from worst to best, with preference taking priority.
+func selectThumbnail(info *ExtractedInfo, pref *ThumbnailPreference) (*ExtractedThumbnail, error) {
+	if info == nil {
+		return nil, errors.New("no extracted info provided")
+	}
+
+	var best *ExtractedThumbnail
+
+	for _, thumb := range info.Thumbnails {
+		if thumb == nil || thumb.URL == "" {
+			continue
+		}
+
+		if pref.ID != "" {
+			if thumb.ID != nil && *thumb.ID == pref.ID {
+				return thumb, nil
+			}
+			continue
+		}
+
+		if pref.MaxWidth > 0 && thumb.Width != nil && *thumb.Width > pref.MaxWidth {
+			continue
+		}
+
+		if pref.MaxHeight > 0 && thumb.Height != nil && *thumb.Height > pref.MaxHeight {
+			continue
+		}
+
+		if best == nil || thumbnailPreference(thumb) >= thumbnailPreference(best) {
+			best = thumb
+		}
+	}
+
+	if pref.ID != "" {
+		return nil, fmt.Errorf("thumbnail with id %q not found", pref.ID)
+	}
+
+	if best == nil && info.Thumbnail != nil && pref.MaxWidth == 0 && pref.MaxHeight == 0 {
+		best = &ExtractedThumbnail{URL: *info.Thumbnail}
+	}
+
+	if best == nil {
+		return nil, errors.New("no suitable thumbnail found")
+	}
+
+	return best, nil
+}
+
+func thumbnailPreference(thumb *ExtractedThumbnail) int {
+	if thumb.Preference == nil {
+		return -1
+	}
+	return *thumb.Preference
+}
+
+// FetchThumbnail downloads the best thumbnail (according to pref, which can be nil)
+// from the provided extracted info, and writes it to w. The thumbnail is fetched
+// directly, without invoking yt-dlp, using the HTTP headers that yt-dlp provided
+// for the thumbnail (or video).
+func FetchThumbnail(ctx context.Context, info *ExtractedInfo, pref *ThumbnailPreference, w io.Writer) error {
+	if pref == nil {
+		pref = &ThumbnailPreference{}
+	}
+
+	thumb, err := selectThumbnail(info, pref)
+	if err != nil {
+		return err
+	}
+
+	headers := thumb.HTTPHeaders
+	if headers == nil && info.ExtractedFormat != nil {
+		headers = info.HTTPHeaders
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, thumb.URL, http.NoBody)
+	if err != nil {
+		return fmt.Errorf("unable to fetch thumbnail: request creation: %w", err)
+	}
+
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	client := pref.Client
+	if client == nil {
+		client = &http.Client{Timeout: downloadTimeout}
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("unable to fetch thumbnail: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unable to fetch thumbnail: bad status: %s", resp.Status)
+	}
+
+	if pref.Format == ThumbnailFormatOriginal {
+		_, err = io.Copy(w, resp.Body)
+		if err != nil {
+			return fmt.Errorf("unable to fetch thumbnail: streaming data: %w", err)
+		}
+		return nil
+	}
+
+	return convertThumbnail(ctx, pref, resp.Body, w)
+}
+
+// convertThumbnail converts the image from r into the format in pref (using ffmpeg),
+// writing the results to w.
+func convertThumbnail(ctx context.Context, pref *ThumbnailPreference, r io.Reader, w io.Writer) error {
+	var codec string
+
+	switch pref.Format {
+	case ThumbnailFormatJPG:
+		codec = "mjpeg"
+	case ThumbnailFormatPNG:
+		codec = "png"
+	default:
+		return fmt.Errorf("unsupported thumbnail format: %q", pref.Format)
+	}
+
+	resolver := New()
+	if pref.FFmpegLocation != "" {
+		resolver.FFmpegLocation(pref.FFmpegLocation)
+	}
+
+	ffmpeg, err := resolver.resolveFFmpegExecutable("ffmpeg")
+	if err != nil {
+		return fmt.Errorf("unable to convert thumbnail: %w", err)
+	}
+
+	var stderr bytes.Buffer
+
+	cmd := exec.CommandContext( //nolint:gosec
+		ctx,
+		ffmpeg,
+		"-hide_banner", "-loglevel", "error",
+		"-i", "pipe:0",
+		"-frames:v", "1",
+		"-f", "image2pipe",
+		"-c:v", codec,
+		"pipe:1",
+	)
+	cmd.Stdin = r
+	cmd.Stdout = w
+	cmd.Stderr = &stderr
+
+	if err = cmd.Run(); err != nil {
+		return fmt.Errorf("unable to convert thumbnail: %w: %s", err, bytes.TrimSpace(stderr.Bytes()))
+	}
+
+	return nil
+}
+
+// FetchThumbnailFile is the same as [FetchThumbnail], but writes the thumbnail
+// to the provided file path. The file is only created if the thumbnail was
+// successfully fetched (and converted), and its permissions follow the process
+// umask, the same as files downloaded by yt-dlp.
+func FetchThumbnailFile(ctx context.Context, info *ExtractedInfo, pref *ThumbnailPreference, path string) error {
+	return writeFileAtomicFunc(path, 0o666, func(w io.Writer) error { //nolint:gomnd
+		return FetchThumbnail(ctx, info, pref, w)
+	})
+}
@@ -0,0 +1,79 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestThumbnail_Select(t *testing.T) {
+	info := &ExtractedInfo{
+		Thumbnails: []*ExtractedThumbnail{
+			{ID: ptr("0"), URL: "https://example.com/0.jpg", Preference: ptr(-10), Width: ptr(1920), Height: ptr(1080)},
+			{ID: ptr("1"), URL: "https://example.com/1.jpg", Preference: ptr(-5), Width: ptr(320), Height: ptr(180)},
+			{ID: ptr("2"), URL: "https://example.com/2.jpg", Preference: ptr(-1), Width: ptr(1280), Height: ptr(720)},
+		},
+	}
+
+	tests := []struct {
+		pref *ThumbnailPreference
+		want string
+	}{
+		{pref: &ThumbnailPreference{}, want: "2"},
+		{pref: &ThumbnailPreference{ID: "0"}, want: "0"},
+		{pref: &ThumbnailPreference{MaxWidth: 640}, want: "1"},
+	}
+
+	for _, tt := range tests {
+		thumb, err := selectThumbnail(info, tt.pref)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if *thumb.ID != tt.want {
+			t.Fatalf("expected thumbnail %q, got %q", tt.want, *thumb.ID)
+		}
+	}
+
+	if _, err := selectThumbnail(info, &ThumbnailPreference{ID: "missing"}); err == nil {
+		t.Fatal("expected error for missing thumbnail id")
+	}
+}
+
+func TestThumbnail_Fetch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Referer") != "https://example.com/" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write([]byte("image-data"))
+	}))
+	defer srv.Close()
+
+	info := &ExtractedInfo{
+		Thumbnails: []*ExtractedThumbnail{
+			{URL: srv.URL, HTTPHeaders: map[string]string{"Referer": "https://example.com/"}},
+		},
+	}
+
+	var buf bytes.Buffer
+
+	err := FetchThumbnail(context.Background(), info, nil, &buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if buf.String() != "image-data" {
+		t.Fatalf("expected thumbnail data to be %q, got %q", "image-data", buf.String())
+	}
+}
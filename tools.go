@@ -0,0 +1,605 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// InstallSpec describes how to install an additional helper executable (e.g.
+// rtmpdump or mkvmerge), registered with [RegisterTool].
+type InstallSpec struct {
+	// Version of the tool, which is used to name the cached executable, so
+	// changing the version results in a new download.
+	Version string
+
+	// Executable is the name of the executable to look up on PATH, without any
+	// extension (e.g. ".exe"). Defaults to the tool name.
+	Executable string
+
+	// URLs are the download URLs of the executable, keyed by "<GOOS>_<GOARCH>"
+	// (e.g. "linux_amd64"). URLs must be direct executables, or zip archives
+	// (see ArchivePaths).
+	// On musl-based Linux systems (e.g. Alpine), "linux_<GOARCH>_musl" (e.g.
+	// "linux_amd64_musl") is preferred, if present. If there is no build for the
+	// current platform, builds which run under emulation are used instead
+	// ("windows_amd64" on windows/arm64, "darwin_amd64" on darwin/arm64), as
+	// are static builds for Linux ("linux_static").
+	URLs map[string]string
+
+	// SHA256 are the hex-encoded SHA-256 checksums of the executables, keyed the
+	// same as URLs. Downloads without a checksum (from SHA256 or ChecksumURLs)
+	// fail, unless [InstallOptions.DisableChecksum] is set.
+	SHA256 map[string]string
+
+	// ChecksumURLs are the URLs of the checksum files published by the project
+	// (in the format of sha256sum, e.g. "SHA256SUMS", or containing only the
+	// checksum), keyed the same as URLs. They're downloaded before the executable
+	// is moved into the cache, and used for platforms without an entry in SHA256.
+	// The checksum of the executable is looked up by the file name in its URL.
+	ChecksumURLs map[string]string
+
+	// ArchivePaths are the slash-separated paths of the executable within the
+	// downloaded zip archive, keyed the same as URLs, for platforms where the
+	// executable is only published within an archive. Checksums are of the
+	// archive itself.
+	ArchivePaths map[string]string
+}
+
+var (
+	toolsMu sync.RWMutex
+	tools   = map[string]*registeredTool{}
+)
+
+type registeredTool struct {
+	name string
+	spec InstallSpec
+
+	mu       sync.Mutex // Ensures only one install invocation is running at a time.
+	resolved *ResolvedInstall
+}
+
+// RegisterTool registers an additional helper executable, which go-ytdlp can
+// then resolve (from the cache, or PATH) and install (download, verify and cache)
+// with [InstallTool], the same way yt-dlp itself is managed. Registering a tool
+// with the same name replaces the previous registration.
+func RegisterTool(name string, spec InstallSpec) error {
+	if name == "" || strings.ContainsAny(name, `/\`) || strings.Trim(name, ".") == "" {
+		return fmt.Errorf("invalid tool name %q", name)
+	}
+
+	if spec.Version == "" {
+		return fmt.Errorf("tool %q: version is required", name)
+	}
+
+	if spec.Executable == "" {
+		spec.Executable = name
+	}
+
+	toolsMu.Lock()
+	tools[name] = &registeredTool{name: name, spec: spec}
+	toolsMu.Unlock()
+
+	return nil
+}
+
+// RegisteredTools returns the names of all tools registered with [RegisterTool].
+func RegisteredTools() []string {
+	toolsMu.RLock()
+	defer toolsMu.RUnlock()
+
+	names := make([]string, 0, len(tools))
+	for name := range tools {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+func getTool(name string) (*registeredTool, error) {
+	toolsMu.RLock()
+	defer toolsMu.RUnlock()
+
+	t, ok := tools[name]
+	if !ok {
+		return nil, fmt.Errorf("tool %q is not registered", name)
+	}
+
+	return t, nil
+}
+
+// cachedName returns the filename of the tool in the cache directory.
+func (t *registeredTool) cachedName() string {
+	name := t.spec.Executable + "-" + t.spec.Version
+
+	if runtime.GOOS == "windows" {
+		name += ".exe"
+	}
+
+	return name
+}
+
+// resolve resolves the tool from the cache directory (first), or PATH (second).
+func (t *registeredTool) resolve(cacheDir string) (*ResolvedInstall, error) {
+	if cacheDir != "" {
+		bin := filepath.Join(cacheDir, t.cachedName())
+
+		if _, ok := findExecutable(bin); ok {
+			emitInstallEvent(&InstallEvent{Type: InstallEventResolvedFromCache, Path: bin, Version: t.spec.Version})
+			return &ResolvedInstall{Executable: bin, Version: t.spec.Version, FromCache: true}, nil
+		}
+	}
+
+	bin, err := LookupExecutable(t.spec.Executable)
+	if err != nil {
+		return nil, fmt.Errorf("unable to resolve %s executable: %w", t.name, err)
+	}
+
+	emitInstallEvent(&InstallEvent{Type: InstallEventResolvedFromPath, Path: bin})
+	return &ResolvedInstall{Executable: bin}, nil
+}
+
+// toolCacheDir returns the cache directory for tools.
+func toolCacheDir(opts *InstallOptions) (string, error) {
+	if opts.CacheDir != "" {
+		return opts.CacheDir, nil
+	}
+
+	dir, err := CacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "tools"), nil
+}
+
+// ResolveTool resolves a tool registered with [RegisterTool] from the go-ytdlp
+// cache (see [InstallOptions.CacheDir], which defaults to "tools" within
+// [CacheDir]), or PATH, without downloading it. The version of executables
+// resolved from PATH is unknown.
+func ResolveTool(name string, opts *InstallOptions) (*ResolvedInstall, error) {
+	t, err := getTool(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts == nil {
+		opts = &InstallOptions{}
+	}
+
+	t.mu.Lock()
+	resolved := t.resolved
+	t.mu.Unlock()
+
+	if resolved != nil {
+		return resolved, nil
+	}
+
+	dir, err := toolCacheDir(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.resolve(dir)
+}
+
+// InstallTool resolves a tool registered with [RegisterTool] (see [ResolveTool]),
+// and if not found, downloads it for the current platform, verifies its checksum,
+// and stores it in the go-ytdlp cache. The resolved install is cached for the
+// lifetime of the process. Of the options, DisableDownload, DisableChecksum,
+// DownloadURL, DownloadURLTimeout, Retries, Backoff, Client, GitHubToken and
+// CacheDir are supported.
+func InstallTool(ctx context.Context, name string, opts *InstallOptions) (*ResolvedInstall, error) {
+	t, err := getTool(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.install(ctx, opts)
+}
+
+// install resolves the tool, and if not found, downloads it. See [InstallTool].
+func (t *registeredTool) install(ctx context.Context, opts *InstallOptions) (*ResolvedInstall, error) {
+	if opts == nil {
+		opts = &InstallOptions{}
+	}
+
+	name := t.name
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.resolved != nil {
+		return t.resolved, nil
+	}
+
+	dir, err := toolCacheDir(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	resolved, err := t.resolve(dir)
+	if err == nil {
+		t.resolved = resolved
+		return resolved, nil
+	}
+
+	if opts.DisableDownload {
+		return nil, fmt.Errorf("%s executable not found, and downloading is disabled", name)
+	}
+
+	platforms := toolPlatforms(runtime.GOOS, runtime.GOARCH, isMuslLibc())
+	platform := platforms[0]
+
+	for _, p := range platforms {
+		if _, ok := t.spec.URLs[p]; ok {
+			platform = p
+			break
+		}
+	}
+
+	url := opts.DownloadURL
+	if url == "" {
+		url = t.spec.URLs[platform]
+	}
+
+	if url == "" {
+		var supported []string
+		for k := range t.spec.URLs {
+			supported = append(supported, strings.Replace(k, "_", "/", 1))
+		}
+
+		sort.Strings(supported)
+
+		return nil, &ErrUnsupportedPlatform{Name: name, Platform: runtime.GOOS + "/" + runtime.GOARCH, Supported: supported}
+	}
+
+	sum := t.spec.SHA256[platform]
+	if sum == "" && t.spec.ChecksumURLs[platform] == "" && !opts.DisableChecksum {
+		return nil, fmt.Errorf("unable to install %s: no checksum for %s", name, platform)
+	}
+
+	if err = os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("unable to install %s: unable to create tool cache directory: %w", name, err)
+	}
+
+	bin := filepath.Join(dir, t.cachedName())
+
+	if sum == "" && !opts.DisableChecksum {
+		sumsURL := t.spec.ChecksumURLs[platform]
+
+		if err = opts.download(ctx, sumsURL, bin+".sums.tmp", 0o640); err != nil { //nolint:gomnd
+			return nil, fmt.Errorf("unable to install %s: %w", name, err)
+		}
+
+		sum, err = checksumFromFile(bin+".sums.tmp", path.Base(strings.SplitN(url, "?", 2)[0]))
+		_ = os.Remove(bin + ".sums.tmp")
+
+		if err != nil {
+			return nil, fmt.Errorf("unable to install %s: %w", name, err)
+		}
+	}
+
+	member := t.spec.ArchivePaths[platform]
+
+	download := bin + ".tmp"
+	if member != "" {
+		download = bin + ".zip.tmp"
+	}
+
+	if err = opts.download(ctx, url, download, 0o750); err != nil { //nolint:gomnd
+		return nil, fmt.Errorf("unable to install %s: %w", name, err)
+	}
+
+	if !opts.DisableChecksum {
+		if err = verifySHA256(download, sum); err != nil {
+			_ = os.Remove(download)
+			emitInstallEvent(&InstallEvent{Type: InstallEventChecksumFailed, Path: download, URL: url, Error: err})
+			return nil, fmt.Errorf("unable to install %s: %w", name, err)
+		}
+
+		emitInstallEvent(&InstallEvent{Type: InstallEventChecksumVerified, Path: download, URL: url})
+	}
+
+	if member != "" {
+		err = extractArchiveMember(download, member, bin+".tmp")
+		_ = os.Remove(download)
+
+		if err != nil {
+			_ = os.Remove(bin + ".tmp")
+			return nil, fmt.Errorf("unable to install %s: %w", name, err)
+		}
+	}
+
+	if err = os.Rename(bin+".tmp", bin); err != nil {
+		return nil, fmt.Errorf("unable to rename %s executable: %w", name, err)
+	}
+
+	t.resolved = &ResolvedInstall{Executable: bin, Version: t.spec.Version, FromCache: true, Downloaded: true}
+	return t.resolved, nil
+}
+
+// InstallAllOptions are the options for [InstallAll].
+type InstallAllOptions struct {
+	// SkipYTDLP skips installing yt-dlp, only installing tools.
+	SkipYTDLP bool
+
+	// YTDLP are the options used to install yt-dlp, see [Install].
+	YTDLP *InstallOptions
+
+	// Tools are the names of the tools registered with [RegisterTool] to install.
+	// If nil, all registered tools are installed. Use an empty (non-nil) slice to
+	// not install any tools.
+	Tools []string
+
+	// ToolOptions are the options used to install each tool, keyed by name, see
+	// [InstallTool]. Tools without options use the defaults.
+	ToolOptions map[string]*InstallOptions
+}
+
+// InstallAllResult is the result of [InstallAll].
+type InstallAllResult struct {
+	// YTDLP is the resolved install of yt-dlp, if installed.
+	YTDLP *ResolvedInstall
+
+	// Tools are the resolved installs of the tools which were installed, keyed by
+	// name.
+	Tools map[string]*ResolvedInstall
+}
+
+// ErrInstall is returned (joined, one per failed install) by [InstallAll],
+// attributing the failure to the executable which failed to install.
+type ErrInstall struct {
+	Tool string // Name of the executable, e.g. "yt-dlp", or the name of the tool registered with [RegisterTool].
+	Err  error
+}
+
+func (e *ErrInstall) Unwrap() error {
+	return e.Err
+}
+
+func (e *ErrInstall) Error() string {
+	return fmt.Sprintf("%s: %v", e.Tool, e.Err)
+}
+
+// IsInstallError returns true when an executable failed to install with
+// [InstallAll].
+func IsInstallError(err error) bool {
+	var e *ErrInstall
+	return errors.As(err, &e)
+}
+
+// InstallAll installs yt-dlp (see [Install]), and the selected tools registered
+// with [RegisterTool] (see [InstallTool]), each with their own options. The
+// executables are installed concurrently (so the hook set with
+// [SetInstallEventHook] may be invoked concurrently). All installs are
+// attempted, even if some fail, and the errors of all failed installs are
+// joined, each as an [ErrInstall] naming the executable. Cancelling ctx stops
+// in-flight downloads, and installs which haven't started yet fail with the
+// error of ctx. opts may be nil to install yt-dlp and all registered tools with
+// the default options.
+func InstallAll(ctx context.Context, opts *InstallAllOptions) (*InstallAllResult, error) {
+	if opts == nil {
+		opts = &InstallAllOptions{}
+	}
+
+	names := opts.Tools
+	if names == nil {
+		names = RegisteredTools()
+	}
+
+	// Index 0 is yt-dlp, followed by the tools.
+	installs := make([]*ResolvedInstall, len(names)+1)
+	errs := make([]error, len(names)+1)
+
+	var g errgroup.Group
+
+	install := func(i int, name string, fn func() (*ResolvedInstall, error)) {
+		g.Go(func() error {
+			err := ctx.Err()
+			if err == nil {
+				installs[i], err = fn()
+			}
+
+			if err != nil {
+				errs[i] = &ErrInstall{Tool: name, Err: err}
+			}
+
+			return errs[i]
+		})
+	}
+
+	if !opts.SkipYTDLP {
+		install(0, "yt-dlp", func() (*ResolvedInstall, error) {
+			return Install(ctx, opts.YTDLP)
+		})
+	}
+
+	for i, name := range names {
+		install(i+1, name, func() (*ResolvedInstall, error) {
+			return InstallTool(ctx, name, opts.ToolOptions[name])
+		})
+	}
+
+	// Wait only returns the first error, all of them are joined below.
+	_ = g.Wait()
+
+	result := &InstallAllResult{YTDLP: installs[0], Tools: make(map[string]*ResolvedInstall)}
+
+	for i, name := range names {
+		if installs[i+1] != nil {
+			result.Tools[name] = installs[i+1]
+		}
+	}
+
+	return result, errors.Join(errs...)
+}
+
+// ResolveAll resolves yt-dlp (using the process-wide resolver, see
+// [Resolver.Resolve]), and all tools registered with [RegisterTool] (see
+// [ResolveTool]), from the go-ytdlp cache or PATH, without downloading anything,
+// which is useful for health checks and startup preflight checks. yt-dlp is
+// always first, followed by the tools sorted by name. Executables which can't be
+// resolved (or for yt-dlp, don't match the expected version) are included with
+// [ResolvedInstall.NeedsInstall] set, rather than returning an error.
+func ResolveAll(ctx context.Context) ([]*ResolvedInstall, error) {
+	var installs []*ResolvedInstall
+
+	expected := defaultResolver.opts.Version
+	if expected == "" {
+		expected = Version
+	}
+
+	resolved, err := defaultResolver.Resolve(ctx)
+	switch {
+	case ctx.Err() != nil:
+		return nil, ctx.Err()
+	case err != nil:
+		resolved = &ResolvedInstall{NeedsInstall: true}
+	case resolved.Version != expected && !defaultResolver.opts.AllowVersionMismatch:
+		r := *resolved
+		r.NeedsInstall = true
+		resolved = &r
+	default:
+		r := *resolved
+		resolved = &r
+	}
+
+	resolved.Name = "yt-dlp"
+	installs = append(installs, resolved)
+
+	for _, name := range RegisteredTools() {
+		resolved, err = ResolveTool(name, nil)
+		if err != nil {
+			resolved = &ResolvedInstall{NeedsInstall: true}
+		} else {
+			r := *resolved
+			resolved = &r
+		}
+
+		resolved.Name = name
+		installs = append(installs, resolved)
+	}
+
+	return installs, nil
+}
+
+// extractArchiveMember extracts the executable at the slash-separated path member
+// within the zip archive to target.
+func extractArchiveMember(archive, member, target string) error {
+	zr, err := zip.OpenReader(archive)
+	if err != nil {
+		return fmt.Errorf("unable to open archive: %w", err)
+	}
+	defer zr.Close()
+
+	for _, f := range zr.File {
+		if f.Name != member || f.FileInfo().IsDir() {
+			continue
+		}
+
+		if err = extractZipFile(f, target); err != nil {
+			return fmt.Errorf("unable to extract %q from archive: %w", member, err)
+		}
+
+		return os.Chmod(target, 0o750) //nolint:gomnd
+	}
+
+	return fmt.Errorf("%q not found in archive", member)
+}
+
+// toolPlatforms returns the keys of [InstallSpec.URLs] which can be used on the
+// provided platform, in order of preference.
+func toolPlatforms(goos, goarch string, musl bool) []string {
+	var platforms []string
+
+	if goos == "linux" && musl {
+		platforms = append(platforms, goos+"_"+goarch+"_musl")
+	}
+
+	platforms = append(platforms, goos+"_"+goarch)
+
+	switch {
+	case goos == "windows" && goarch == "arm64", goos == "darwin" && goarch == "arm64":
+		platforms = append(platforms, goos+"_amd64")
+	case goos == "linux":
+		platforms = append(platforms, "linux_static")
+	}
+
+	return platforms
+}
+
+// isMuslLibc returns true if the system uses musl as its C library (e.g.
+// Alpine), as executables linked against glibc won't run on it.
+func isMuslLibc() bool {
+	if runtime.GOOS != "linux" {
+		return false
+	}
+
+	matches, _ := filepath.Glob("/lib/ld-musl-*.so.1")
+	return len(matches) > 0
+}
+
+// checksumFromFile returns the checksum of the file with the provided name, from
+// a checksum file in the format of sha256sum ("<checksum>  <name>" per line,
+// where the name may be prefixed with "*"), or containing only a checksum.
+func checksumFromFile(sumsPath, name string) (string, error) {
+	b, err := os.ReadFile(sumsPath)
+	if err != nil {
+		return "", fmt.Errorf("unable to read checksum file: %w", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+
+	for _, line := range lines {
+		fields := strings.Fields(line)
+
+		if len(fields) == 1 && len(lines) == 1 {
+			return fields[0], nil
+		}
+
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == name { //nolint:gomnd
+			return fields[0], nil
+		}
+	}
+
+	return "", fmt.Errorf("unable to find checksum for %s", name)
+}
+
+// verifySHA256 verifies the SHA-256 checksum of the file.
+func verifySHA256(path, expected string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	if _, err = io.Copy(hash, f); err != nil {
+		return err
+	}
+
+	if sum := fmt.Sprintf("%x", hash.Sum(nil)); !strings.EqualFold(sum, expected) {
+		return &ErrChecksumMismatch{Path: path, Expected: expected, Actual: sum}
+	}
+
+	return nil
+}
@@ -0,0 +1,475 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestInstallTool(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("requires a posix shell")
+	}
+
+	content := []byte("#!/bin/sh\necho mkvmerge\n")
+	platform := runtime.GOOS + "_" + runtime.GOARCH
+
+	var downloads atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		downloads.Add(1)
+		_, _ = w.Write(content)
+	}))
+	t.Cleanup(srv.Close)
+
+	err := RegisterTool("test-mkvmerge", InstallSpec{
+		Version: "1.0.0",
+		URLs:    map[string]string{platform: srv.URL},
+		SHA256:  map[string]string{platform: fmt.Sprintf("%x", sha256.Sum256(content))},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = RegisterTool("test-badsum", InstallSpec{
+		Version: "1.0.0",
+		URLs:    map[string]string{platform: srv.URL},
+		SHA256:  map[string]string{platform: "deadbeef"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = RegisterTool("../bad", InstallSpec{Version: "1"}); err == nil {
+		t.Fatal("expected error for invalid tool name")
+	}
+
+	if !slices.Contains(RegisteredTools(), "test-mkvmerge") {
+		t.Fatal("expected tool to be registered")
+	}
+
+	opts := &InstallOptions{CacheDir: t.TempDir()}
+
+	if _, err = ResolveTool("test-mkvmerge", opts); err == nil {
+		t.Fatal("expected error resolving tool before install")
+	}
+
+	if _, err = InstallTool(context.Background(), "test-mkvmerge", &InstallOptions{CacheDir: opts.CacheDir, DisableDownload: true}); err == nil {
+		t.Fatal("expected error with downloads disabled")
+	}
+
+	r, err := InstallTool(context.Background(), "test-mkvmerge", opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !r.Downloaded || r.Version != "1.0.0" || downloads.Load() != 1 {
+		t.Fatalf("unexpected install: %+v (downloads: %d)", r, downloads.Load())
+	}
+
+	if resolved, rerr := ResolveTool("test-mkvmerge", opts); rerr != nil || resolved.Executable != r.Executable {
+		t.Fatalf("expected resolved tool to match install: %+v (err: %v)", resolved, rerr)
+	}
+
+	if _, err = InstallTool(context.Background(), "test-mkvmerge", opts); err != nil || downloads.Load() != 1 {
+		t.Fatalf("expected cached install (downloads: %d, err: %v)", downloads.Load(), err)
+	}
+
+	if _, err = InstallTool(context.Background(), "test-badsum", opts); !IsChecksumMismatchError(err) {
+		t.Fatalf("expected checksum error, got %v", err)
+	}
+
+	err = RegisterTool("test-unreachable", InstallSpec{
+		Version: "1.0.0",
+		URLs:    map[string]string{platform: "http://127.0.0.1:0/mkvmerge"},
+		SHA256:  map[string]string{platform: "deadbeef"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = InstallTool(context.Background(), "test-unreachable", opts); err == nil || !strings.Contains(err.Error(), "test-unreachable") {
+		t.Fatalf("expected download error labeled with the tool name, got %v", err)
+	}
+
+	if _, err = InstallTool(context.Background(), "test-missing", opts); err == nil {
+		t.Fatal("expected error for unregistered tool")
+	}
+}
+
+func TestInstallTool_Archive(t *testing.T) {
+	t.Parallel()
+
+	content := []byte("#!/bin/sh\necho aria2c\n")
+	platform := runtime.GOOS + "_" + runtime.GOARCH
+
+	var archive bytes.Buffer
+	zw := zip.NewWriter(&archive)
+
+	for _, name := range []string{"tool-1.0.0/README.txt", "tool-1.0.0/tool"} {
+		w, err := zw.Create(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err = w.Write(content); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(archive.Bytes())
+	}))
+	t.Cleanup(srv.Close)
+
+	for _, member := range []string{"tool-1.0.0/tool", "tool-1.0.0/missing"} {
+		name := "test-archive-" + path.Base(member)
+
+		err := RegisterTool(name, InstallSpec{
+			Version:      "1.0.0",
+			URLs:         map[string]string{platform: srv.URL},
+			SHA256:       map[string]string{platform: fmt.Sprintf("%x", sha256.Sum256(archive.Bytes()))},
+			ArchivePaths: map[string]string{platform: member},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	r, err := InstallTool(context.Background(), "test-archive-tool", &InstallOptions{CacheDir: t.TempDir()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if b, rerr := os.ReadFile(r.Executable); rerr != nil || !bytes.Equal(b, content) {
+		t.Fatalf("expected executable to be extracted from the archive (err: %v)", rerr)
+	}
+
+	if _, err = InstallTool(context.Background(), "test-archive-missing", &InstallOptions{CacheDir: t.TempDir()}); err == nil {
+		t.Fatal("expected error for executable missing from the archive")
+	}
+}
+
+func TestInstallTool_Client(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("requires a posix shell")
+	}
+
+	content := []byte("#!/bin/sh\necho mkvextract\n")
+	platform := runtime.GOOS + "_" + runtime.GOARCH
+
+	var proxied atomic.Int32
+
+	// Acts as a forward proxy, as the tool URL itself isn't resolvable.
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Host == "tools.invalid" {
+			proxied.Add(1)
+		}
+		_, _ = w.Write(content)
+	}))
+	t.Cleanup(proxy.Close)
+
+	err := RegisterTool("test-mkvextract", InstallSpec{
+		Version: "1.0.0",
+		URLs:    map[string]string{platform: "http://tools.invalid/mkvextract"},
+		SHA256:  map[string]string{platform: fmt.Sprintf("%x", sha256.Sum256(content))},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	proxyURL, err := url.Parse(proxy.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := InstallTool(context.Background(), "test-mkvextract", &InstallOptions{
+		CacheDir: t.TempDir(),
+		Client:   &http.Client{Transport: &http.Transport{Proxy: http.ProxyURL(proxyURL)}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !r.Downloaded || proxied.Load() != 1 {
+		t.Fatalf("expected download via proxy, got %+v (proxied: %d)", r, proxied.Load())
+	}
+}
+
+func TestInstallTool_ChecksumURLs(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("requires a posix shell")
+	}
+
+	content := []byte("#!/bin/sh\necho mkvinfo\n")
+	platform := runtime.GOOS + "_" + runtime.GOARCH
+	sum := fmt.Sprintf("%x", sha256.Sum256(content))
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/SHA256SUMS":
+			_, _ = fmt.Fprintf(w, "%x  other\n%s *mkvinfo-"+platform+"\n", sha256.Sum256(nil), sum)
+		case "/mkvinfo.sha256":
+			_, _ = fmt.Fprintln(w, sum)
+		case "/BADSUMS":
+			_, _ = fmt.Fprintln(w, "deadbeef  mkvinfo-"+platform)
+		default:
+			_, _ = w.Write(content)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	for name, sumsURL := range map[string]string{
+		"test-mkvinfo-sums":    srv.URL + "/SHA256SUMS",
+		"test-mkvinfo-single":  srv.URL + "/mkvinfo.sha256",
+		"test-mkvinfo-badsum":  srv.URL + "/BADSUMS",
+		"test-mkvinfo-missing": srv.URL + "/SHA256SUMS",
+	} {
+		binURL := srv.URL + "/mkvinfo-" + platform
+		if name == "test-mkvinfo-missing" {
+			binURL = srv.URL + "/mkvinfo"
+		}
+
+		err := RegisterTool(name, InstallSpec{
+			Version:      "1.0.0",
+			URLs:         map[string]string{platform: binURL},
+			ChecksumURLs: map[string]string{platform: sumsURL},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, name := range []string{"test-mkvinfo-sums", "test-mkvinfo-single"} {
+		r, err := InstallTool(context.Background(), name, &InstallOptions{CacheDir: t.TempDir()})
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+
+		if !r.Downloaded {
+			t.Fatalf("%s: expected download, got %+v", name, r)
+		}
+	}
+
+	if _, err := InstallTool(context.Background(), "test-mkvinfo-badsum", &InstallOptions{CacheDir: t.TempDir()}); !IsChecksumMismatchError(err) {
+		t.Fatalf("expected checksum error, got %v", err)
+	}
+
+	if _, err := InstallTool(context.Background(), "test-mkvinfo-missing", &InstallOptions{CacheDir: t.TempDir()}); err == nil {
+		t.Fatal("expected error for missing checksum")
+	}
+
+	r, err := InstallTool(context.Background(), "test-mkvinfo-missing", &InstallOptions{CacheDir: t.TempDir(), DisableChecksum: true})
+	if err != nil || !r.Downloaded {
+		t.Fatalf("expected download with checksums disabled, got %+v (err: %v)", r, err)
+	}
+}
+
+func TestInstallAll(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("requires a posix shell")
+	}
+
+	content := []byte("#!/bin/sh\necho rtmpdump\n")
+	platform := runtime.GOOS + "_" + runtime.GOARCH
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write(content)
+	}))
+	t.Cleanup(srv.Close)
+
+	for _, name := range []string{"test-rtmpdump", "test-skipped"} {
+		err := RegisterTool(name, InstallSpec{
+			Version: "1.0.0",
+			URLs:    map[string]string{platform: srv.URL},
+			SHA256:  map[string]string{platform: fmt.Sprintf("%x", sha256.Sum256(content))},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	dir := t.TempDir()
+
+	result, err := InstallAll(context.Background(), &InstallAllOptions{
+		SkipYTDLP: true,
+		Tools:     []string{"test-rtmpdump", "test-unregistered"},
+		ToolOptions: map[string]*InstallOptions{
+			"test-rtmpdump": {CacheDir: dir},
+		},
+	})
+	var ierr *ErrInstall
+	if !errors.As(err, &ierr) || ierr.Tool != "test-unregistered" {
+		t.Fatalf("expected install error for unregistered tool, got %v", err)
+	}
+
+	if result.YTDLP != nil || len(result.Tools) != 1 {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+
+	if r := result.Tools["test-rtmpdump"]; r == nil || !r.Downloaded || r.Executable != filepath.Join(dir, "test-rtmpdump-1.0.0") {
+		t.Fatalf("expected tool to be installed with its options: %+v", r)
+	}
+
+	if _, err = ResolveTool("test-skipped", &InstallOptions{CacheDir: dir}); err == nil {
+		t.Fatal("expected unselected tool not to be installed")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result, err = InstallAll(ctx, &InstallAllOptions{
+		SkipYTDLP:   true,
+		Tools:       []string{"test-skipped"},
+		ToolOptions: map[string]*InstallOptions{"test-skipped": {CacheDir: dir}},
+	})
+	if !errors.Is(err, context.Canceled) || !IsInstallError(err) || len(result.Tools) != 0 {
+		t.Fatalf("expected no installs to be started once cancelled, got %+v (err: %v)", result, err)
+	}
+}
+
+func TestInstallAll_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("requires a posix shell")
+	}
+
+	content := []byte("#!/bin/sh\necho tool\n")
+	platform := runtime.GOOS + "_" + runtime.GOARCH
+
+	// Each download is only served once both have been requested, so installing
+	// the tools one at a time would time out.
+	var started sync.WaitGroup
+	started.Add(2)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		started.Done()
+		started.Wait()
+		_, _ = w.Write(content)
+	}))
+	t.Cleanup(srv.Close)
+
+	names := []string{"test-concurrent-a", "test-concurrent-b"}
+	toolOpts := map[string]*InstallOptions{}
+
+	for _, name := range names {
+		err := RegisterTool(name, InstallSpec{
+			Version: "1.0.0",
+			URLs:    map[string]string{platform: srv.URL},
+			SHA256:  map[string]string{platform: fmt.Sprintf("%x", sha256.Sum256(content))},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		toolOpts[name] = &InstallOptions{CacheDir: t.TempDir()}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	result, err := InstallAll(ctx, &InstallAllOptions{SkipYTDLP: true, Tools: names, ToolOptions: toolOpts})
+	if err != nil || len(result.Tools) != 2 {
+		t.Fatalf("expected tools to be installed concurrently, got %+v (err: %v)", result, err)
+	}
+}
+
+func TestResolveAll(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("requires a posix shell")
+	}
+
+	err := RegisterTool("test-resolveall-sh", InstallSpec{Version: "1.0.0", Executable: "sh"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = RegisterTool("test-resolveall-missing", InstallSpec{Version: "1.0.0", Executable: "go-ytdlp-missing-tool"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	installs, err := ResolveAll(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(installs) == 0 || installs[0].Name != "yt-dlp" {
+		t.Fatalf("expected yt-dlp to be first, got %+v", installs)
+	}
+
+	if installs[0].NeedsInstall != (installs[0].Executable == "" || installs[0].Version != Version) {
+		t.Fatalf("unexpected yt-dlp install: %+v", installs[0])
+	}
+
+	byName := make(map[string]*ResolvedInstall)
+	for _, r := range installs {
+		byName[r.Name] = r
+	}
+
+	if r := byName["test-resolveall-sh"]; r == nil || r.NeedsInstall || r.Executable == "" {
+		t.Fatalf("expected tool to be resolved from PATH, got %+v", r)
+	}
+
+	if r := byName["test-resolveall-missing"]; r == nil || !r.NeedsInstall || r.Executable != "" {
+		t.Fatalf("expected missing tool to need install, got %+v", r)
+	}
+}
+
+func TestToolPlatforms(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		goos, goarch string
+		musl         bool
+		want         []string
+	}{
+		{"linux", "amd64", false, []string{"linux_amd64", "linux_static"}},
+		{"linux", "arm64", true, []string{"linux_arm64_musl", "linux_arm64", "linux_static"}},
+		{"linux", "386", false, []string{"linux_386", "linux_static"}},
+		{"windows", "arm64", false, []string{"windows_arm64", "windows_amd64"}},
+		{"darwin", "arm64", false, []string{"darwin_arm64", "darwin_amd64"}},
+		{"windows", "amd64", true, []string{"windows_amd64"}},
+	}
+
+	for _, tt := range tests {
+		if got := toolPlatforms(tt.goos, tt.goarch, tt.musl); !slices.Equal(got, tt.want) {
+			t.Fatalf("toolPlatforms(%q, %q, %v) = %v, want %v", tt.goos, tt.goarch, tt.musl, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,161 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ByteSize is a size in bytes, e.g. for [Command.LimitRateBytes]. yt-dlp uses
+// binary multipliers for sizes, so "1K" is 1024 bytes.
+type ByteSize int64
+
+// Common sizes, using binary multipliers.
+const (
+	Byte     ByteSize = 1
+	Kibibyte          = 1024 * Byte
+	Mebibyte          = 1024 * Kibibyte
+	Gibibyte          = 1024 * Mebibyte
+	Tebibyte          = 1024 * Gibibyte
+	Pebibyte          = 1024 * Tebibyte
+)
+
+// byteSizeUnits are the unit suffixes accepted by yt-dlp for sizes, in order of
+// magnitude.
+var byteSizeUnits = []string{"", "K", "M", "G", "T", "P"}
+
+// ParseByteSize parses a size in the syntax accepted by yt-dlp (e.g. "50K",
+// "4.2M" or "1024"). Units are case-insensitive, and may optionally be suffixed
+// with "B" or "iB" (e.g. "50KiB"), as used in the output of yt-dlp.
+func ParseByteSize(s string) (ByteSize, error) {
+	value := strings.ToUpper(strings.TrimSpace(s))
+	if v, ok := strings.CutSuffix(value, "IB"); ok {
+		value = v
+	} else {
+		value = strings.TrimSuffix(value, "B")
+	}
+
+	exp := 0
+
+	if n := len(value); n > 0 {
+		for i := len(byteSizeUnits) - 1; i > 0; i-- {
+			if value[n-1] == byteSizeUnits[i][0] {
+				exp = i
+				value = strings.TrimSpace(value[:n-1])
+				break
+			}
+		}
+	}
+
+	n, err := strconv.ParseFloat(value, 64)
+	if err != nil || n < 0 || math.IsInf(n, 0) || strings.ContainsAny(value, "+-EN") {
+		return 0, fmt.Errorf("invalid size %q", s)
+	}
+
+	size := n * math.Pow(1024, float64(exp)) //nolint:gomnd
+	if size > math.MaxInt64 {
+		return 0, fmt.Errorf("invalid size %q: out of range", s)
+	}
+
+	return ByteSize(size), nil
+}
+
+// String returns the size in the syntax accepted by yt-dlp, using the largest
+// unit which represents the size exactly (e.g. "50K", or "1536" for 1.5K), so
+// no precision is lost.
+func (b ByteSize) String() string {
+	exp := 0
+
+	for b != 0 && exp < len(byteSizeUnits)-1 && b%Kibibyte == 0 {
+		b /= Kibibyte
+		exp++
+	}
+
+	return strconv.FormatInt(int64(b), 10) + byteSizeUnits[exp]
+}
+
+// durationSeconds returns the duration in (fractional) seconds, as used by
+// yt-dlp.
+func durationSeconds(d time.Duration) float64 {
+	return max(d, 0).Seconds()
+}
+
+// LimitRateBytes is the same as [Command.LimitRate], using a typed size (per
+// second).
+//   - See [Command.UnsetLimitRate], for unsetting the flag.
+func (c *Command) LimitRateBytes(rate ByteSize) *Command {
+	return c.LimitRate(rate.String())
+}
+
+// ThrottledRateBytes is the same as [Command.ThrottledRate], using a typed size
+// (per second).
+//   - See [Command.UnsetThrottledRate], for unsetting the flag.
+func (c *Command) ThrottledRateBytes(rate ByteSize) *Command {
+	return c.ThrottledRate(rate.String())
+}
+
+// BufferSizeBytes is the same as [Command.BufferSize], using a typed size.
+//   - See [Command.UnsetBufferSize], for unsetting the flag.
+func (c *Command) BufferSizeBytes(size ByteSize) *Command {
+	return c.BufferSize(size.String())
+}
+
+// HTTPChunkSizeBytes is the same as [Command.HTTPChunkSize], using a typed size.
+//   - See [Command.UnsetHTTPChunkSize], for unsetting the flag.
+func (c *Command) HTTPChunkSizeBytes(size ByteSize) *Command {
+	return c.HTTPChunkSize(size.String())
+}
+
+// MinFileSizeBytes is the same as [Command.MinFileSize], using a typed size.
+//   - See [Command.UnsetMinFileSize], for unsetting the flag.
+func (c *Command) MinFileSizeBytes(size ByteSize) *Command {
+	return c.MinFileSize(size.String())
+}
+
+// MaxFileSizeBytes is the same as [Command.MaxFileSize], using a typed size.
+//   - See [Command.UnsetMaxFileSize], for unsetting the flag.
+func (c *Command) MaxFileSizeBytes(size ByteSize) *Command {
+	return c.MaxFileSize(size.String())
+}
+
+// SocketTimeoutDuration is the same as [Command.SocketTimeout], using a
+// [time.Duration].
+//   - See [Command.UnsetSocketTimeout], for unsetting the flag.
+func (c *Command) SocketTimeoutDuration(timeout time.Duration) *Command {
+	return c.SocketTimeout(durationSeconds(timeout))
+}
+
+// SleepRequestsDuration is the same as [Command.SleepRequests], using a
+// [time.Duration].
+//   - See [Command.UnsetSleepRequests], for unsetting the flag.
+func (c *Command) SleepRequestsDuration(d time.Duration) *Command {
+	return c.SleepRequests(durationSeconds(d))
+}
+
+// SleepIntervalDuration is the same as [Command.SleepInterval], using a
+// [time.Duration].
+//   - See [Command.UnsetSleepInterval], for unsetting the flag.
+func (c *Command) SleepIntervalDuration(d time.Duration) *Command {
+	return c.SleepInterval(durationSeconds(d))
+}
+
+// MaxSleepIntervalDuration is the same as [Command.MaxSleepInterval], using a
+// [time.Duration].
+//   - See [Command.UnsetMaxSleepInterval], for unsetting the flag.
+func (c *Command) MaxSleepIntervalDuration(d time.Duration) *Command {
+	return c.MaxSleepInterval(durationSeconds(d))
+}
+
+// SleepSubtitlesDuration is the same as [Command.SleepSubtitles], using a
+// [time.Duration]. yt-dlp only supports whole seconds, so the duration is
+// rounded up.
+//   - See [Command.UnsetSleepSubtitles], for unsetting the flag.
+func (c *Command) SleepSubtitlesDuration(d time.Duration) *Command {
+	return c.SleepSubtitles(int(math.Ceil(durationSeconds(d))))
+}
@@ -0,0 +1,92 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestParseByteSize(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		in   string
+		want ByteSize
+		err  bool
+	}{
+		{in: "1024", want: Kibibyte},
+		{in: "50K", want: 50 * Kibibyte},
+		{in: "50k", want: 50 * Kibibyte},
+		{in: "4.2M", want: 4404019},
+		{in: "10.00MiB", want: 10 * Mebibyte},
+		{in: " 2 GB ", want: 2 * Gibibyte},
+		{in: "1T", want: Tebibyte},
+		{in: "", err: true},
+		{in: "K", err: true},
+		{in: "-1K", err: true},
+		{in: "1e3", err: true},
+		{in: "inf", err: true},
+		{in: "50X", err: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseByteSize(tt.in)
+		if (err != nil) != tt.err {
+			t.Fatalf("ParseByteSize(%q): unexpected error: %v", tt.in, err)
+		}
+
+		if got != tt.want {
+			t.Fatalf("ParseByteSize(%q): expected %d, got %d", tt.in, tt.want, got)
+		}
+	}
+}
+
+func TestByteSize_String(t *testing.T) {
+	t.Parallel()
+
+	tests := map[ByteSize]string{
+		0:                     "0",
+		512:                   "512",
+		1536:                  "1536",
+		50 * Kibibyte:         "50K",
+		4 * Mebibyte:          "4M",
+		3 * Gibibyte:          "3G",
+		2048 * Pebibyte:       "2048P",
+		Mebibyte + Kibibyte:   "1025K",
+		Tebibyte + Mebibyte*2: "1048578M",
+	}
+
+	for size, want := range tests {
+		if got := size.String(); got != want {
+			t.Fatalf("expected %d to format as %q, got %q", int64(size), want, got)
+		}
+
+		if parsed, err := ParseByteSize(size.String()); err != nil || parsed != size {
+			t.Fatalf("expected %q to round-trip, got %d (err: %v)", size.String(), parsed, err)
+		}
+	}
+}
+
+func TestCommand_TypedUnits(t *testing.T) {
+	t.Parallel()
+
+	cmd := New().
+		LimitRateBytes(4 * Mebibyte).
+		MinFileSizeBytes(50 * Kibibyte).
+		SocketTimeoutDuration(1500 * time.Millisecond).
+		SleepSubtitlesDuration(1200 * time.Millisecond)
+
+	var args []string
+	for _, f := range cmd.flags {
+		args = append(args, f.Raw()...)
+	}
+
+	want := []string{"--limit-rate", "4M", "--min-filesize", "50K", "--socket-timeout", "1.5", "--sleep-subtitles", "2"}
+	if !slices.Equal(args, want) {
+		t.Fatalf("expected args %q, got %q", want, args)
+	}
+}
@@ -0,0 +1,232 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ReadDownloadArchive reads a yt-dlp download archive (see [Command.DownloadArchive]),
+// returning the set of archived "<extractor> <id>" entries.
+func ReadDownloadArchive(path string) (map[string]bool, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read download archive: %w", err)
+	}
+	defer f.Close()
+
+	archive := make(map[string]bool)
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if line := strings.TrimSpace(scanner.Text()); line != "" {
+			archive[line] = true
+		}
+	}
+
+	if err = scanner.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read download archive: %w", err)
+	}
+
+	return archive, nil
+}
+
+// ArchiveID returns the ID yt-dlp uses for the entry within download archives.
+func (e *IndexEntry) ArchiveID() string {
+	return strings.ToLower(firstNonEmpty(e.ExtractorKey, e.Extractor)) + " " + e.ID
+}
+
+// UpgradeOptions are the options used by [Workspace.FindUpgrades].
+type UpgradeOptions struct {
+	// Archive is the path to a yt-dlp download archive. If set, only entries
+	// recorded in the archive are checked.
+	Archive string
+
+	// SortKeys are the keys used to compare formats (see [ExtractedFormat.Better]).
+	// Defaults to [DefaultFormatSortKeys].
+	SortKeys SortKeys
+}
+
+// UpgradeCandidate is an indexed file, for which a better format is available.
+type UpgradeCandidate struct {
+	Entry *IndexEntry
+
+	// Current is the best component (e.g. the video stream) of the format on disk.
+	Current *ExtractedFormat
+
+	// Available is the best component of the format which would now be selected.
+	Available *ExtractedFormat
+
+	// FormatID is the format which would now be selected, e.g. "313+251".
+	FormatID string
+}
+
+// bestFormatComponent returns the best of the formats (from info) referenced
+// by formatID (e.g. "137+140"), or nil if none of them are offered anymore.
+// The sort keys must already be validated with [ExtractedFormat.Better].
+func bestFormatComponent(info *ExtractedInfo, formatID string, sortKeys SortKeys) *ExtractedFormat {
+	var best *ExtractedFormat
+
+	for _, id := range strings.Split(formatID, "+") {
+		for _, f := range info.Formats {
+			if f == nil || f.FormatID == nil || *f.FormatID != id {
+				continue
+			}
+
+			if better, _ := f.Better(best, sortKeys...); better {
+				best = f
+			}
+		}
+	}
+
+	return best
+}
+
+// FindUpgrades re-extracts the source of each indexed file (using the format
+// selection and other flags of the provided command), and returns the files for
+// which a better format is now available (e.g. a new 4K remaster). Entries whose
+// format is no longer offered by the source can't be compared, and are skipped.
+// Extraction failures are returned as a joined error, along with the candidates
+// from all other entries.
+func (w *Workspace) FindUpgrades(ctx context.Context, cmd *Command, opts *UpgradeOptions) ([]*UpgradeCandidate, error) {
+	if opts == nil {
+		opts = &UpgradeOptions{}
+	}
+
+	// Validate the sort keys, before anything is extracted.
+	if _, err := (&ExtractedFormat{}).Better(nil, opts.SortKeys...); err != nil {
+		return nil, err
+	}
+
+	var archive map[string]bool
+
+	if opts.Archive != "" {
+		var err error
+
+		archive, err = ReadDownloadArchive(opts.Archive)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var candidates []*UpgradeCandidate
+	var errs []error
+
+	for _, entry := range w.Entries() {
+		if entry.URL == "" || entry.FormatID == "" || (archive != nil && !archive[entry.ArchiveID()]) {
+			continue
+		}
+
+		result, err := cmd.clone().
+			UnsetDownloadArchive().
+			SkipDownload().
+			NoPlaylist().
+			DumpJSON().
+			Run(ctx, entry.URL)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("unable to check %q for upgrades: %w", entry.Path, err))
+			continue
+		}
+
+		infos, err := result.GetExtractedInfo()
+		if err != nil || len(infos) == 0 || infos[0].ExtractedFormat == nil || infos[0].FormatID == nil {
+			errs = append(errs, fmt.Errorf("unable to check %q for upgrades: no format info returned", entry.Path))
+			continue
+		}
+
+		info := infos[0]
+
+		if *info.FormatID == entry.FormatID {
+			continue
+		}
+
+		current := bestFormatComponent(info, entry.FormatID, opts.SortKeys)
+		available := bestFormatComponent(info, *info.FormatID, opts.SortKeys)
+
+		if current == nil || available == nil {
+			continue
+		}
+
+		if better, _ := available.Better(current, opts.SortKeys...); !better {
+			continue
+		}
+
+		candidates = append(candidates, &UpgradeCandidate{
+			Entry:     entry,
+			Current:   current,
+			Available: available,
+			FormatID:  *info.FormatID,
+		})
+	}
+
+	return candidates, errors.Join(errs...)
+}
+
+// Upgrade re-downloads the candidate (with the flags of the provided command,
+// but with the format of the candidate, and without any download archive or
+// paths), replacing the existing file. The new file is downloaded into a
+// temporary directory within the workspace, and then atomically moved into the
+// directory of the existing file. If the new file has a different name (e.g. a
+// different extension), the existing file is removed. Returns the updated index
+// entry.
+func (w *Workspace) Upgrade(ctx context.Context, cmd *Command, candidate *UpgradeCandidate) (*IndexEntry, error) {
+	tmp, err := os.MkdirTemp(w.dir, ".go-ytdlp-upgrade-*")
+	if err != nil {
+		return nil, fmt.Errorf("unable to create upgrade directory: %w", err)
+	}
+	defer os.RemoveAll(tmp) //nolint:errcheck
+
+	_, records, err := runRecorded(
+		ctx,
+		cmd.clone().
+			UnsetDownloadArchive().
+			UnsetPaths().
+			UnsetFormat().
+			Format(candidate.FormatID).
+			NoPlaylist().
+			SetWorkDir(tmp),
+		[]string{indexTemplate},
+		(*indexRecord).hasFile,
+		candidate.Entry.URL,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(records) == 0 {
+		return nil, fmt.Errorf("unable to upgrade %q: no file downloaded", candidate.Entry.Path)
+	}
+
+	entry := w.newEntry(records[len(records)-1], tmp)
+	downloaded := w.Path(entry)
+	existing := w.Path(candidate.Entry)
+	target := filepath.Join(filepath.Dir(existing), filepath.Base(downloaded))
+
+	if err = os.Rename(downloaded, target); err != nil {
+		return nil, fmt.Errorf("unable to replace %q: %w", candidate.Entry.Path, err)
+	}
+
+	if target != existing {
+		if err = os.Remove(existing); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, fmt.Errorf("unable to remove %q: %w", candidate.Entry.Path, err)
+		}
+	}
+
+	entry.Path = w.relPath(target)
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	delete(w.index, candidate.Entry.Path)
+	w.index[entry.Path] = entry
+
+	return entry, w.save()
+}
@@ -0,0 +1,94 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// fakeUpgradeScript emulates yt-dlp: with --dump-json it reports that format
+// 22 (720p) is now selected, otherwise it downloads format 22 as an mkv.
+const fakeUpgradeScript = `#!/bin/sh
+while [ $# -gt 0 ]; do
+	case "$1" in
+		--dump-json) echo '{"_type": "video", "id": "abc", "format_id": "22", "formats": [{"format_id": "18", "height": 360, "width": 640}, {"format_id": "22", "height": 720, "width": 1280}]}'; exit 0 ;;
+		--print-to-file) pfile="$3"; shift; shift ;;
+	esac
+	shift
+done
+echo new > "video [abc].mkv"
+echo '{"id": "abc", "extractor": "youtube", "extractor_key": "Youtube", "webpage_url": "https://www.youtube.com/watch?v=abc", "format_id": "22", "filepath": "video [abc].mkv"}' >> "$pfile"
+`
+
+func TestWorkspace_Upgrade(t *testing.T) {
+	t.Parallel()
+
+	bin := writeFakeYtdlp(t, fakeUpgradeScript)
+
+	ws, err := OpenWorkspace(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = os.MkdirAll(filepath.Join(ws.Dir(), "sub"), 0o750); err != nil {
+		t.Fatal(err)
+	}
+
+	if err = os.WriteFile(filepath.Join(ws.Dir(), "sub", "video [abc].mp4"), []byte("old"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	err = ws.addEntries(
+		&IndexEntry{Path: "sub/video [abc].mp4", ID: "abc", ExtractorKey: "Youtube", URL: "https://www.youtube.com/watch?v=abc", FormatID: "18"},
+		&IndexEntry{Path: "other.mp4", ID: "def", ExtractorKey: "Youtube", URL: "https://www.youtube.com/watch?v=def", FormatID: "18"},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	archive := filepath.Join(t.TempDir(), "archive.txt")
+	if err = os.WriteFile(archive, []byte("youtube abc\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := New().SetExecutable(bin)
+
+	candidates, err := ws.FindUpgrades(context.Background(), cmd, &UpgradeOptions{Archive: archive})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(candidates) != 1 || candidates[0].Entry.ID != "abc" || candidates[0].FormatID != "22" {
+		t.Fatalf("unexpected candidates: %+v", candidates)
+	}
+
+	entry, err := ws.Upgrade(context.Background(), cmd, candidates[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if entry.Path != "sub/video [abc].mkv" || entry.FormatID != "22" {
+		t.Fatalf("unexpected upgraded entry: %+v", entry)
+	}
+
+	if _, err = os.Stat(filepath.Join(ws.Dir(), "sub", "video [abc].mp4")); !os.IsNotExist(err) {
+		t.Fatal("expected old file to be removed")
+	}
+
+	if b, _ := os.ReadFile(filepath.Join(ws.Dir(), "sub", "video [abc].mkv")); string(b) != "new\n" {
+		t.Fatalf("expected new file to be moved into place, got %q", b)
+	}
+
+	if _, ok := ws.Lookup("sub/video [abc].mp4"); ok {
+		t.Fatal("expected old entry to be removed from the index")
+	}
+
+	if len(ws.Entries()) != 2 {
+		t.Fatalf("expected 2 index entries, got %d", len(ws.Entries()))
+	}
+}
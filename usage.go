@@ -0,0 +1,112 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// UsageGroup is the disk usage of a group of files (e.g. all files from a single
+// channel).
+type UsageGroup struct {
+	Files int   `json:"files"`
+	Bytes int64 `json:"bytes"`
+}
+
+func (g *UsageGroup) add(size int64) {
+	g.Files++
+	g.Bytes += size
+}
+
+// WorkspaceUsage is the disk usage of the files within a workspace, as returned
+// by [Workspace.Usage]. Groups are keyed by extractor, channel and uploader
+// respectively, with an empty key for files where the value is unknown.
+type WorkspaceUsage struct {
+	Total       UsageGroup             `json:"total"`
+	Missing     int                    `json:"missing"` // Indexed files which no longer exist on disk.
+	ByExtractor map[string]*UsageGroup `json:"by_extractor"`
+	ByChannel   map[string]*UsageGroup `json:"by_channel"`
+	ByUploader  map[string]*UsageGroup `json:"by_uploader"`
+}
+
+// infoSidecar is the subset of fields read from info JSON sidecar files (see
+// [Command.WriteInfoJSON]).
+type infoSidecar struct {
+	Extractor string `json:"extractor"`
+	Channel   string `json:"channel"`
+	Uploader  string `json:"uploader"`
+}
+
+// readInfoSidecar reads the info JSON sidecar for the provided media file, if
+// one exists.
+func readInfoSidecar(path string) (*infoSidecar, bool) {
+	b, err := os.ReadFile(strings.TrimSuffix(path, filepath.Ext(path)) + ".info.json")
+	if err != nil {
+		return nil, false
+	}
+
+	info := &infoSidecar{}
+	if err = json.Unmarshal(b, info); err != nil {
+		return nil, false
+	}
+
+	return info, true
+}
+
+// Usage summarizes the disk usage of all indexed files, grouped by extractor,
+// channel and uploader, which can be used to enforce per-channel retention
+// policies. If the index doesn't contain the channel or uploader of a file (e.g.
+// for files indexed by older versions), they are read from the info JSON sidecar
+// of the file, if one exists.
+func (w *Workspace) Usage() (*WorkspaceUsage, error) {
+	usage := &WorkspaceUsage{
+		ByExtractor: make(map[string]*UsageGroup),
+		ByChannel:   make(map[string]*UsageGroup),
+		ByUploader:  make(map[string]*UsageGroup),
+	}
+
+	group := func(m map[string]*UsageGroup, key string) *UsageGroup {
+		g, ok := m[key]
+		if !ok {
+			g = &UsageGroup{}
+			m[key] = g
+		}
+		return g
+	}
+
+	for _, entry := range w.Entries() {
+		path := w.Path(entry)
+
+		stat, err := os.Stat(path)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				usage.Missing++
+				continue
+			}
+			return nil, fmt.Errorf("unable to stat %q: %w", entry.Path, err)
+		}
+
+		extractor, channel, uploader := entry.Extractor, entry.Channel, entry.Uploader
+
+		if channel == "" && uploader == "" {
+			if info, ok := readInfoSidecar(path); ok {
+				extractor = firstNonEmpty(extractor, info.Extractor)
+				channel, uploader = info.Channel, info.Uploader
+			}
+		}
+
+		usage.Total.add(stat.Size())
+		group(usage.ByExtractor, extractor).add(stat.Size())
+		group(usage.ByChannel, channel).add(stat.Size())
+		group(usage.ByUploader, uploader).add(stat.Size())
+	}
+
+	return usage, nil
+}
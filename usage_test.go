@@ -0,0 +1,64 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWorkspace_Usage(t *testing.T) {
+	t.Parallel()
+
+	ws, err := OpenWorkspace(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string]string{
+		"a.mp4":       "12345",
+		"b.mp4":       "123",
+		"c.mp4":       "1234567890",
+		"c.info.json": `{"extractor": "youtube", "channel": "Channel B", "uploader": "Uploader B"}`,
+	}
+
+	for name, data := range files {
+		if err = os.WriteFile(filepath.Join(ws.Dir(), name), []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	err = ws.addEntries(
+		&IndexEntry{Path: "a.mp4", Extractor: "youtube", Channel: "Channel A", Uploader: "Uploader A"},
+		&IndexEntry{Path: "b.mp4", Extractor: "youtube", Channel: "Channel A", Uploader: "Uploader A"},
+		&IndexEntry{Path: "c.mp4"}, // From sidecar.
+		&IndexEntry{Path: "missing.mp4", Extractor: "vimeo"},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	usage, err := ws.Usage()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if usage.Total.Files != 3 || usage.Total.Bytes != 18 || usage.Missing != 1 {
+		t.Fatalf("unexpected totals: %+v (missing: %d)", usage.Total, usage.Missing)
+	}
+
+	if g := usage.ByChannel["Channel A"]; g == nil || g.Files != 2 || g.Bytes != 8 {
+		t.Fatalf("unexpected usage for Channel A: %+v", g)
+	}
+
+	if g := usage.ByUploader["Uploader B"]; g == nil || g.Files != 1 || g.Bytes != 10 {
+		t.Fatalf("unexpected usage for Uploader B: %+v", g)
+	}
+
+	if g := usage.ByExtractor["youtube"]; g == nil || g.Files != 3 || g.Bytes != 18 {
+		t.Fatalf("unexpected usage for youtube: %+v", g)
+	}
+}
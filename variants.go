@@ -0,0 +1,170 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// FormatVariant is a single format variant to download with
+// [Command.DownloadVariants].
+type FormatVariant struct {
+	// Name is a unique identifier for the variant, e.g. "1080p" or "audio".
+	Name string
+
+	// Format is the format selector for the variant, e.g. "bv*[height<=1080]+ba/b"
+	// or "ba[acodec=opus]". See [Command.Format].
+	Format string
+
+	// Output is the output template for the variant. If empty, and no output
+	// template is set on the command, "%(title)s [%(id)s] [<name>].%(ext)s" is
+	// used, so that variants with the same extension don't overwrite each other.
+	Output string
+}
+
+// VariantResult is the result of downloading a single [FormatVariant].
+type VariantResult struct {
+	Variant FormatVariant
+
+	// Result is the result of the yt-dlp invocation for the variant. May be nil
+	// if the invocation couldn't be started.
+	Result *Result
+
+	// Files are the final paths of the files that were downloaded for the variant
+	// (after any post-processing).
+	Files []string
+
+	// Err is the error (if any) from downloading the variant.
+	Err error
+}
+
+// escapeTemplate escapes s so it can be used literally within an output template.
+func escapeTemplate(s string) string {
+	return strings.ReplaceAll(s, "%", "%%")
+}
+
+// DownloadVariants downloads multiple format variants of the same URL (e.g. a
+// 1080p video, and an audio-only opus file). The URL is only extracted once,
+// with each variant then downloaded in a separate invocation from the extracted
+// information (using [Command.LoadInfoJSON]), which avoids repeated extraction
+// requests. Any flags already set on the command are also used (except for
+// [Command.Format]), and the command itself is not modified.
+//
+// An error is returned if extraction fails. Otherwise, a result is returned for
+// every variant (in the same order), along with a joined error of all of the
+// variants that failed.
+func (c *Command) DownloadVariants(ctx context.Context, url string, variants ...FormatVariant) ([]*VariantResult, error) {
+	if len(variants) == 0 {
+		return nil, errors.New("no format variants provided")
+	}
+
+	seen := make(map[string]bool, len(variants))
+
+	for _, v := range variants {
+		if v.Name == "" || v.Format == "" {
+			return nil, errors.New("format variants must have a name and format")
+		}
+
+		if seen[v.Name] {
+			return nil, fmt.Errorf("duplicate format variant name %q", v.Name)
+		}
+		seen[v.Name] = true
+	}
+
+	infoFile, err := c.extractInfoJSON(ctx, url)
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(infoFile) //nolint:errcheck
+
+	hasOutput := len(c.getFlagsByID("outtmpl")) > 0
+
+	results := make([]*VariantResult, 0, len(variants))
+	var errs []error
+
+	for _, v := range variants {
+		vr := &VariantResult{Variant: v}
+		results = append(results, vr)
+
+		vr.Result, vr.Files, vr.Err = c.downloadVariant(ctx, infoFile, v, hasOutput)
+		if vr.Err != nil {
+			errs = append(errs, fmt.Errorf("variant %q: %w", v.Name, vr.Err))
+		}
+	}
+
+	return results, errors.Join(errs...)
+}
+
+// extractInfoJSON extracts the info for url, and writes it to a temporary file,
+// returning the path to the file.
+func (c *Command) extractInfoJSON(ctx context.Context, url string) (string, error) {
+	result, err := c.clone().
+		UnsetFormat().
+		DumpSingleJSON().
+		Run(ctx, url)
+	if err != nil {
+		return "", err
+	}
+
+	var raw []byte
+
+	for _, l := range result.OutputLogs {
+		if l.JSON != nil {
+			raw = *l.JSON
+		}
+	}
+
+	if raw == nil {
+		return "", errors.New("unable to extract info: no info JSON returned")
+	}
+
+	f, err := os.CreateTemp("", "go-ytdlp-info-*.json")
+	if err != nil {
+		return "", fmt.Errorf("unable to create info JSON file: %w", err)
+	}
+
+	_, err = f.Write(raw)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+
+	if err != nil {
+		_ = os.Remove(f.Name())
+		return "", fmt.Errorf("unable to write info JSON file: %w", err)
+	}
+
+	return f.Name(), nil
+}
+
+// downloadVariant downloads a single variant from the info JSON file, returning
+// the files that were downloaded.
+func (c *Command) downloadVariant(ctx context.Context, infoFile string, v FormatVariant, hasOutput bool) (*Result, []string, error) {
+	cmd := c.clone().
+		UnsetFormat().
+		Format(v.Format).
+		LoadInfoJSON(infoFile)
+
+	switch {
+	case v.Output != "":
+		cmd.UnsetOutput().Output(v.Output)
+	case !hasOutput:
+		cmd.Output("%(title)s [%(id)s] [" + escapeTemplate(v.Name) + "].%(ext)s")
+	}
+
+	result, records, err := runRecorded(ctx, cmd, []string{"after_move:%(filepath)j"}, func(path *string) bool {
+		return *path != ""
+	})
+
+	files := make([]string, 0, len(records))
+	for _, path := range records {
+		files = append(files, *path)
+	}
+
+	return result, files, err
+}
@@ -0,0 +1,82 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// fakeVariantScript emulates yt-dlp: with --dump-single-json it prints the info
+// JSON (counting extractions), otherwise it "downloads" the selected format,
+// writing the resulting path (as JSON) to the --print-to-file file.
+const fakeVariantScript = `#!/bin/sh
+dir=$(dirname "$0")
+fmt=""; out=""; pfile=""; info=""
+while [ $# -gt 0 ]; do
+	case "$1" in
+		--dump-single-json) echo x >> "$dir/extractions"; echo '{"id":"abc","title":"t","_type":"video"}'; exit 0 ;;
+		--format) fmt="$2"; shift ;;
+		--output) out="$2"; shift ;;
+		--load-info-json) info="$2"; shift ;;
+		--print-to-file) pfile="$3"; shift; shift ;;
+	esac
+	shift
+done
+[ -f "$info" ] || exit 2
+[ "$fmt" = "bad" ] && exit 1
+printf '"%s"\n' "$dir/$fmt|$out" >> "$pfile"
+`
+
+func TestCommand_DownloadVariants(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	bin := writeFakeExecutable(t, dir, "yt-dlp.sh", fakeVariantScript)
+
+	results, err := New().SetExecutable(bin).DownloadVariants(
+		context.Background(),
+		"https://example.com/watch?v=abc",
+		FormatVariant{Name: "1080p", Format: "bv*[height<=1080]+ba"},
+		FormatVariant{Name: "audio", Format: "ba", Output: "%(id)s.%(ext)s"},
+		FormatVariant{Name: "broken", Format: "bad"},
+	)
+	if err == nil || !strings.Contains(err.Error(), `variant "broken"`) {
+		t.Fatalf("expected error for broken variant, got %v", err)
+	}
+
+	extractions, _ := os.ReadFile(filepath.Join(dir, "extractions"))
+	if n := strings.Count(string(extractions), "x"); n != 1 {
+		t.Fatalf("expected a single extraction, got %d", n)
+	}
+
+	if len(results) != 3 {
+		t.Fatalf("expected 3 results, got %d", len(results))
+	}
+
+	expected := [][]string{
+		{dir + "/bv*[height<=1080]+ba|%(title)s [%(id)s] [1080p].%(ext)s"},
+		{dir + "/ba|%(id)s.%(ext)s"},
+		nil,
+	}
+
+	for i, r := range results {
+		if !slices.Equal(r.Files, expected[i]) {
+			t.Fatalf("variant %q: expected files %q, got %q", r.Variant.Name, expected[i], r.Files)
+		}
+
+		if (r.Err != nil) != (r.Variant.Name == "broken") {
+			t.Fatalf("variant %q: unexpected error state: %v", r.Variant.Name, r.Err)
+		}
+	}
+
+	if _, err = New().DownloadVariants(context.Background(), "x", FormatVariant{Name: "a", Format: "b"}, FormatVariant{Name: "a", Format: "c"}); err == nil {
+		t.Fatal("expected error for duplicate variant names")
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// versionResolvers are the resolvers used for commands with a specific yt-dlp
+// version (see [Command.SetYtdlpVersion]), keyed by the resolver they are
+// derived from, and the version.
+var versionResolvers sync.Map // versionKey -> *Resolver
+
+type versionKey struct {
+	base    *Resolver
+	version string
+}
+
+// SetYtdlpVersion sets the version of yt-dlp used by the command, which must
+// already be installed in the go-ytdlp cache (e.g. with
+// NewResolver(&InstallOptions{Version: version}).Install). Each version is
+// cached separately, so multiple versions can be installed at once, and used by
+// different commands (e.g. per-tenant). The executable is resolved the same way
+// as the resolver of the command (see [Command.SetResolver]), using its options
+// (e.g. [InstallOptions.CacheDir]), preferring the executable of the exact
+// version, and failing if the resolved executable isn't the exact version. An
+// executable set with [Command.SetExecutable] takes precedence. Pass an empty
+// string to use the version of the resolver (the default).
+func (c *Command) SetYtdlpVersion(version string) *Command {
+	c.mu.Lock()
+	c.ytdlpVersion = version
+	c.mu.Unlock()
+
+	return c
+}
+
+// resolveVersion resolves the yt-dlp executable of the exact version, using the
+// options of the resolver (or the process-wide resolver, if rs is nil). The
+// resolved install is cached, separately from the resolver itself.
+func (rs *Resolver) resolveVersion(ctx context.Context, version string) (*ResolvedInstall, error) {
+	if rs == nil {
+		rs = defaultResolver
+	}
+
+	if r := rs.cache.Load(); r != nil && r.Version == version {
+		return r, nil
+	}
+
+	v, ok := versionResolvers.Load(versionKey{base: rs, version: version})
+	if !ok {
+		opts := rs.opts
+		opts.Version = version
+
+		v, _ = versionResolvers.LoadOrStore(versionKey{base: rs, version: version}, NewResolver(&opts))
+	}
+
+	vr := v.(*Resolver) //nolint:forcetypeassert
+
+	if r := vr.cache.Load(); r != nil {
+		return r, nil
+	}
+
+	r, err := vr.resolveExecutable(ctx, vr.opts.CacheDir, version, false, false)
+	if err != nil {
+		return nil, fmt.Errorf("unable to resolve yt-dlp %s: %w", version, err)
+	}
+
+	if r.Version != version {
+		return nil, fmt.Errorf("yt-dlp %s is not installed (resolved %s at %q)", version, r.Version, r.Executable)
+	}
+
+	vr.cache.Store(r)
+	return r, nil
+}
+
+// CachedVersions returns the versions of yt-dlp installed in the go-ytdlp cache
+// (see [InstallOptions.CacheDir]), sorted oldest first. Executables installed
+// without a version in their name aren't included. opts may be nil to use the
+// defaults.
+func CachedVersions(opts *InstallOptions) ([]string, error) {
+	dir := ""
+	if opts != nil {
+		dir = opts.CacheDir
+	}
+
+	if dir == "" {
+		var err error
+
+		dir, err = CacheDir()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("unable to read yt-dlp executable cache directory: %w", err)
+	}
+
+	var versions []string
+
+	for _, entry := range entries {
+		name := entry.Name()
+
+		if runtime.GOOS == "windows" {
+			var ok bool
+			if name, ok = strings.CutSuffix(name, ".exe"); !ok {
+				continue
+			}
+		}
+
+		version, ok := strings.CutPrefix(name, "yt-dlp-")
+		if !ok || !entry.Type().IsRegular() || version == "" || version[0] < '0' || version[0] > '9' || strings.HasSuffix(version, ".tmp") {
+			continue
+		}
+
+		versions = append(versions, version)
+	}
+
+	sort.Strings(versions)
+
+	return versions, nil
+}
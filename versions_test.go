@@ -0,0 +1,55 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestCommand_SetYtdlpVersion(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	for _, version := range []string{"2000.01.02", "2000.01.01"} {
+		script := "#!/bin/sh\nif [ \"$1\" = --version ]; then echo " + version + "; else echo " + version + " \"$@\"; fi\n"
+		writeFakeExecutable(t, dir, "yt-dlp-"+version, script)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "yt-dlp-2000.01.03.tmp"), nil, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	versions, err := CachedVersions(&InstallOptions{CacheDir: dir})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !slices.Equal(versions, []string{"2000.01.01", "2000.01.02"}) {
+		t.Fatalf("unexpected cached versions: %q", versions)
+	}
+
+	rs := NewResolver(&InstallOptions{CacheDir: dir, DisableDownload: true})
+
+	for _, version := range versions {
+		result, err := New().SetResolver(rs).SetYtdlpVersion(version).Run(context.Background(), "https://example.com")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if result.Stdout != version+" https://example.com" || !strings.HasSuffix(result.Executable, "yt-dlp-"+version) {
+			t.Fatalf("expected yt-dlp %s to be used, got %q (%s)", version, result.Stdout, result.Executable)
+		}
+	}
+
+	if _, err = New().SetResolver(rs).SetYtdlpVersion("2000.01.03").Run(context.Background(), "https://example.com"); err == nil {
+		t.Fatal("expected error for version which isn't installed")
+	}
+}
@@ -0,0 +1,192 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultVODWaitInterval is the default interval between checks of whether a VOD
+// has finished processing, see [VODWaitOptions.Interval].
+const DefaultVODWaitInterval = time.Minute
+
+// IsProcessingVOD returns true if the video was a livestream, which has ended,
+// but whose VOD hasn't finished processing yet ("post_live"). Until processed,
+// only part of the livestream may be available, so downloads (especially of
+// sections, see [Command.DownloadSections]) may be incomplete or fail.
+func (i *ExtractedInfo) IsProcessingVOD() bool {
+	return i.LiveStatus != nil && *i.LiveStatus == ExtractedLiveStatusPostLive
+}
+
+// VODWaitEvent is a progress event emitted while waiting for a VOD to finish
+// processing, see [VODWaitOptions.OnWait].
+type VODWaitEvent struct {
+	// URL is the URL of the video being waited on.
+	URL string
+
+	// LiveStatus is the live status of the video, as of the latest check.
+	LiveStatus ExtractedLiveStatus
+
+	// Attempt is the number of checks performed so far, starting at 1.
+	Attempt int
+
+	// Elapsed is the time spent waiting on the video so far.
+	Elapsed time.Duration
+
+	// Next is the time until the next check.
+	Next time.Duration
+}
+
+// VODWaitOptions configures waiting for the VODs of ended livestreams to finish
+// processing, see [Command.WaitForVOD].
+type VODWaitOptions struct {
+	// Interval is the interval between checks. Defaults to
+	// [DefaultVODWaitInterval].
+	Interval time.Duration
+
+	// MaxWait is the maximum time to wait for each video, after which
+	// [ErrVODNotReady] is returned. 0 means no limit (other than the context).
+	MaxWait time.Duration
+
+	// OnWait is invoked each time a video is still processing, before waiting for
+	// the next check.
+	OnWait func(event VODWaitEvent)
+}
+
+// ErrVODNotReady is returned when the VOD of a livestream didn't finish
+// processing within [VODWaitOptions.MaxWait].
+type ErrVODNotReady struct {
+	URL    string
+	Waited time.Duration
+}
+
+func (e *ErrVODNotReady) Error() string {
+	return fmt.Sprintf("vod of %q still processing after %s", e.URL, e.Waited.Round(time.Second))
+}
+
+// IsVODNotReadyError returns true when the VOD of a livestream didn't finish
+// processing in time.
+func IsVODNotReadyError(err error) bool {
+	var e *ErrVODNotReady
+	return errors.As(err, &e)
+}
+
+// WaitForVOD configures [Command.Run] to first check each of the provided URLs,
+// waiting until any VODs of ended livestreams have finished processing (see
+// [ExtractedInfo.IsProcessingVOD]), before downloading. This is useful with
+// [Command.DownloadSections], as sections of a VOD which is still processing
+// may not be available yet. Unlike [Command.WaitForVideo], which waits for
+// upcoming livestreams to start, this waits for ended livestreams to become
+// fully available. Checks use the same flags as the command (e.g. cookies).
+// opts may be nil to use the defaults.
+//   - See [Command.UnsetWaitForVOD], for disabling it.
+func (c *Command) WaitForVOD(opts *VODWaitOptions) *Command {
+	o := VODWaitOptions{}
+	if opts != nil {
+		o = *opts
+	}
+
+	if o.Interval <= 0 {
+		o.Interval = DefaultVODWaitInterval
+	}
+
+	c.mu.Lock()
+	c.vodWait = &o
+	c.mu.Unlock()
+
+	return c
+}
+
+// UnsetWaitForVOD disables waiting for VODs, previously enabled with
+// [Command.WaitForVOD].
+func (c *Command) UnsetWaitForVOD() *Command {
+	c.mu.Lock()
+	c.vodWait = nil
+	c.mu.Unlock()
+
+	return c
+}
+
+// runWaitForVOD waits for the VODs of all urls to finish processing, then
+// invokes the command.
+func (c *Command) runWaitForVOD(ctx context.Context, opts *VODWaitOptions, urls ...string) (*Result, error) {
+	cmd := c.clone().UnsetWaitForVOD()
+	panics := &callbackPanics{}
+
+	for _, url := range urls {
+		if err := cmd.waitForVOD(ctx, opts, panics, url); err != nil {
+			return nil, err
+		}
+	}
+
+	result, err := cmd.Run(ctx, urls...)
+	if result != nil {
+		result.CallbackPanics = append(result.CallbackPanics, panics.panics...)
+	}
+
+	return result, err
+}
+
+// waitForVOD waits until the VOD of the url is no longer processing, recording
+// any panics of [VODWaitOptions.OnWait] to panics.
+func (c *Command) waitForVOD(ctx context.Context, opts *VODWaitOptions, panics *callbackPanics, url string) error {
+	check := c.clone().
+		SetStatsRecorder(nil).
+		SetBatchSplit(nil).
+		SkipDownload().
+		NoPlaylist().
+		IgnoreNoFormatsError().
+		DumpJSON()
+
+	started := time.Now()
+
+	for attempt := 1; ; attempt++ {
+		result, err := check.Run(ctx, url)
+		if err != nil {
+			return fmt.Errorf("unable to check vod status: %w", err)
+		}
+
+		infos, err := result.GetExtractedInfo()
+		if err != nil {
+			return fmt.Errorf("unable to check vod status: %w", err)
+		}
+
+		if len(infos) == 0 || !infos[0].IsProcessingVOD() {
+			return nil
+		}
+
+		elapsed := time.Since(started)
+		next := opts.Interval
+
+		if opts.MaxWait > 0 {
+			if elapsed >= opts.MaxWait {
+				return &ErrVODNotReady{URL: url, Waited: elapsed}
+			}
+
+			next = min(next, opts.MaxWait-elapsed)
+		}
+
+		if opts.OnWait != nil {
+			event := VODWaitEvent{
+				URL:        url,
+				LiveStatus: *infos[0].LiveStatus,
+				Attempt:    attempt,
+				Elapsed:    elapsed,
+				Next:       next,
+			}
+
+			panics.add(callSafely("vod-wait", func() { opts.OnWait(event) }))
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(next):
+		}
+	}
+}
@@ -0,0 +1,83 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCommand_WaitForVOD(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	// Reports the VOD as processing for the first 2 checks, then downloads it.
+	script := `#!/bin/sh
+case " $* " in
+*" --dump-json "*)
+	n=$(cat "$0.count" 2>/dev/null || echo 0)
+	echo $((n + 1)) > "$0.count"
+	if [ "$n" -lt 2 ]; then status=post_live; else status=was_live; fi
+	echo "{\"_type\": \"video\", \"id\": \"abc\", \"live_status\": \"$status\"}"
+	;;
+*)
+	echo "downloaded $*"
+	;;
+esac
+`
+
+	bin := writeFakeExecutable(t, dir, "yt-dlp.sh", script)
+
+	var events []VODWaitEvent
+
+	result, err := New().SetExecutable(bin).DownloadSections("*10:00-20:00").WaitForVOD(&VODWaitOptions{
+		Interval: 10 * time.Millisecond,
+		OnWait:   func(event VODWaitEvent) { events = append(events, event) },
+	}).Run(context.Background(), "https://example.com/live")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasPrefix(result.Stdout, "downloaded --download-sections") {
+		t.Fatalf("expected download after waiting, got %q", result.Stdout)
+	}
+
+	if len(events) != 2 || events[1].Attempt != 2 || events[1].LiveStatus != ExtractedLiveStatusPostLive || events[1].URL != "https://example.com/live" {
+		t.Fatalf("unexpected wait events: %+v", events)
+	}
+
+	// Panics are recovered, and the download continues.
+	if err = os.Remove(bin + ".count"); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err = New().SetExecutable(bin).WaitForVOD(&VODWaitOptions{
+		Interval: 10 * time.Millisecond,
+		OnWait:   func(_ VODWaitEvent) { panic("buggy callback") },
+	}).Run(context.Background(), "https://example.com/live")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(result.CallbackPanics) != 2 || result.CallbackPanics[0].Callback != "vod-wait" {
+		t.Fatalf("expected 2 vod-wait callback panics, got %v", result.CallbackPanics)
+	}
+
+	// Always processing.
+	if err = os.WriteFile(bin+".count", []byte("-1000\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = New().SetExecutable(bin).WaitForVOD(&VODWaitOptions{
+		Interval: 10 * time.Millisecond,
+		MaxWait:  15 * time.Millisecond,
+	}).Run(context.Background(), "https://example.com/live")
+	if !IsVODNotReadyError(err) {
+		t.Fatalf("expected vod not ready error, got %v", err)
+	}
+}
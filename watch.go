@@ -0,0 +1,267 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultWatchInterval is the default interval between checks of a watched batch
+// file, see [WatchBatchFile].
+const DefaultWatchInterval = 5 * time.Second
+
+// watchArchiveName is the name of the download archive used by [WatchBatchFile]
+// when watching a directory, if the command doesn't set one.
+const watchArchiveName = ".yt-dlp-archive.txt"
+
+// WatchBatchFile monitors a batch file of URLs (see [ReadBatchFile]), or a
+// directory of batch files (all ".txt" files directly within it), every interval
+// (or [DefaultWatchInterval] if <= 0), and downloads any URLs which weren't seen
+// before, including those which already exist when watching starts. Each check
+// with new URLs invokes [Command.Run] once, with a command returned by
+// cmdFactory. Lines which don't end with a newline yet are ignored, as they may
+// still be being written. It blocks until ctx is done, returning ctx.Err(), or
+// reading path fails (a missing path is treated as empty).
+//
+// URLs are deduplicated in memory, and against the download archive of the
+// command (see [Command.DownloadArchive]), so videos aren't downloaded again if
+// watching is restarted. If the command doesn't set a download archive, one is
+// used alongside path (path with an ".archive" suffix, or
+// ".yt-dlp-archive.txt" within the directory).
+//
+// Failed invocations don't stop watching, and their URLs aren't retried until
+// watching is restarted. Use [Command.OnComplete] on the commands returned by
+// cmdFactory to observe results.
+func WatchBatchFile(ctx context.Context, path string, interval time.Duration, cmdFactory func() *Command) error {
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+
+	archive := path + ".archive"
+	if stat, err := os.Stat(path); err == nil && stat.IsDir() {
+		archive = filepath.Join(path, watchArchiveName)
+	}
+
+	seen := make(map[string]bool)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		urls, err := readWatchedBatchFiles(path)
+		if err != nil {
+			return err
+		}
+
+		var pending []string
+
+		for _, u := range urls {
+			if !seen[u] {
+				seen[u] = true
+				pending = append(pending, u)
+			}
+		}
+
+		if len(pending) > 0 {
+			cmd := cmdFactory()
+			if cmd.getFlagsByID("download_archive") == nil {
+				cmd.DownloadArchive(archive)
+			}
+
+			_, _ = cmd.Run(ctx, pending...)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// readWatchedBatchFiles reads the complete lines of the batch file at path, or of
+// all ".txt" batch files within it (sorted by name), if it's a directory.
+func readWatchedBatchFiles(path string) ([]string, error) {
+	stat, err := os.Stat(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("unable to watch batch file: %w", err)
+	}
+
+	files := []string{path}
+
+	if stat.IsDir() {
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, fmt.Errorf("unable to watch batch file directory: %w", err)
+		}
+
+		files = files[:0]
+
+		for _, entry := range entries {
+			if entry.Type().IsRegular() && strings.EqualFold(filepath.Ext(entry.Name()), ".txt") && entry.Name() != watchArchiveName {
+				files = append(files, filepath.Join(path, entry.Name()))
+			}
+		}
+
+		sort.Strings(files)
+	}
+
+	var urls []string
+
+	for _, file := range files {
+		b, err := os.ReadFile(file)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				continue // Removed since listing.
+			}
+
+			return nil, fmt.Errorf("unable to read batch file: %w", err)
+		}
+
+		fileURLs, err := parseBatchFile(b[:bytes.LastIndexByte(b, '\n')+1])
+		if err != nil {
+			return nil, err
+		}
+
+		urls = append(urls, fileURLs...)
+	}
+
+	return urls, nil
+}
+
+const (
+	infoJSONSuffix    = ".info.json" // Suffix of info JSON files, see [WatchInfoJSONDir].
+	infoJSONDoneDir   = "done"       // Directory within the watched directory for processed files.
+	infoJSONFailedDir = "failed"     // Directory within the watched directory for failed files.
+)
+
+// InfoJSONEvent is emitted for each info JSON file processed by
+// [WatchInfoJSONDir].
+type InfoJSONEvent struct {
+	// Path is the path the info JSON file was moved to once processed, within
+	// the "done" or "failed" directory.
+	Path string
+
+	// Info is the extracted info from the file, if it could be parsed.
+	Info *ExtractedInfo
+
+	// Result is the result of the download. May be nil if yt-dlp wasn't invoked.
+	Result *Result
+
+	// Err is the error processing the file, if any.
+	Err error
+}
+
+// WatchInfoJSONDir monitors a directory for info JSON files (files ending with
+// ".info.json", e.g. written with [Command.WriteInfoJSON] by another system),
+// every interval (or [DefaultWatchInterval] if <= 0), and downloads each by
+// invoking [Command.Run] with [Command.LoadInfoJSON], using a command returned by
+// cmdFactory. This allows extraction and downloading to be decoupled. Files which
+// aren't valid JSON yet are skipped until the next check, as they may still be
+// being written.
+//
+// Once processed, each file is moved into the "done" (or "failed", if
+// downloading failed) directory within dir, so it isn't processed again, and fn
+// (if not nil) is invoked with the outcome. If moving a file fails, it's not
+// processed again until watching is restarted. It blocks until ctx is done,
+// returning ctx.Err(), or reading dir fails.
+func WatchInfoJSONDir(ctx context.Context, dir string, interval time.Duration, cmdFactory func() *Command, fn func(event InfoJSONEvent)) error {
+	if interval <= 0 {
+		interval = DefaultWatchInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	stuck := make(map[string]bool) // Processed files which couldn't be moved.
+
+	for {
+		entries, err := os.ReadDir(dir)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("unable to watch info JSON directory: %w", err)
+		}
+
+		for _, entry := range entries {
+			path := filepath.Join(dir, entry.Name())
+
+			if !entry.Type().IsRegular() || !strings.HasSuffix(entry.Name(), infoJSONSuffix) || stuck[path] {
+				continue
+			}
+
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			event, ok := processInfoJSON(ctx, path, cmdFactory)
+			if ok && event.Path == path {
+				stuck[path] = true
+			}
+
+			if ok && fn != nil {
+				_ = callSafely("info-json-watch", func() { fn(event) })
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// processInfoJSON downloads the info JSON file at path, then moves it into the
+// "done" or "failed" directory alongside it. Returns false if the file was
+// skipped, as it isn't complete yet (or was removed).
+func processInfoJSON(ctx context.Context, path string, cmdFactory func() *Command) (InfoJSONEvent, bool) {
+	var event InfoJSONEvent
+
+	b, err := os.ReadFile(path)
+	if err != nil || !json.Valid(b) {
+		return event, false
+	}
+
+	raw := json.RawMessage(b)
+	event.Info, event.Err = ParseExtractedInfo(&raw)
+
+	if event.Err == nil {
+		event.Result, event.Err = cmdFactory().LoadInfoJSON(path).Run(ctx)
+	}
+
+	if ctx.Err() != nil {
+		return event, false // Retried once watching is restarted.
+	}
+
+	dest := filepath.Join(filepath.Dir(path), infoJSONDoneDir)
+	if event.Err != nil {
+		dest = filepath.Join(filepath.Dir(path), infoJSONFailedDir)
+	}
+
+	event.Path = filepath.Join(dest, filepath.Base(path))
+
+	err = os.MkdirAll(dest, 0o750)
+	if err == nil {
+		err = os.Rename(path, event.Path)
+	}
+
+	if err != nil {
+		event.Path = path
+		event.Err = errors.Join(event.Err, fmt.Errorf("unable to move processed info JSON file: %w", err))
+	}
+
+	return event, true
+}
@@ -0,0 +1,239 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWatchBatchFile(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	calls := filepath.Join(dir, "calls")
+
+	bin := writeFakeExecutable(t, dir, "yt-dlp.sh", "#!/bin/sh\necho \"$*\" >> "+calls+"\n")
+
+	watched := filepath.Join(dir, "watched")
+	if err := os.Mkdir(watched, 0o750); err != nil {
+		t.Fatal(err)
+	}
+
+	batch := filepath.Join(watched, "urls.txt")
+	if err := os.WriteFile(batch, []byte("# comment\nhttps://example.com/a\nhttps://example.com/partial"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(filepath.Join(watched, "more.txt"), []byte("https://example.com/b\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- WatchBatchFile(ctx, watched, 10*time.Millisecond, func() *Command {
+			return New().SetExecutable(bin)
+		})
+	}()
+
+	waitCalls := func(n int) []string {
+		t.Helper()
+
+		deadline := time.Now().Add(5 * time.Second)
+		for time.Now().Before(deadline) {
+			b, _ := os.ReadFile(calls)
+			if lines := strings.Split(strings.TrimSpace(string(b)), "\n"); len(b) > 0 && len(lines) >= n {
+				return lines
+			}
+
+			time.Sleep(10 * time.Millisecond)
+		}
+
+		t.Fatalf("timed out waiting for %d invocations", n)
+		return nil
+	}
+
+	lines := waitCalls(1)
+
+	archive := filepath.Join(watched, watchArchiveName)
+	if lines[0] != "--download-archive "+archive+" https://example.com/b https://example.com/a" {
+		t.Fatalf("unexpected first invocation: %q", lines[0])
+	}
+
+	// Completes the partial line, and appends a duplicate.
+	f, err := os.OpenFile(batch, os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = f.WriteString("\nhttps://example.com/a\n"); err != nil {
+		t.Fatal(err)
+	}
+	_ = f.Close()
+
+	lines = waitCalls(2)
+
+	if lines[1] != "--download-archive "+archive+" https://example.com/partial" {
+		t.Fatalf("unexpected second invocation: %q", lines[1])
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+
+	if err = <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context canceled, got %v", err)
+	}
+
+	if lines = waitCalls(2); len(lines) != 2 {
+		t.Fatalf("expected no further invocations, got %q", lines)
+	}
+}
+
+func TestWatchInfoJSONDir(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	script := "#!/bin/sh\ncase \"$*\" in *bad*) exit 1;; esac\necho \"$*\"\n"
+	bin := writeFakeExecutable(t, dir, "yt-dlp.sh", script)
+
+	watched := filepath.Join(dir, "watched")
+	if err := os.Mkdir(watched, 0o750); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, data := range map[string]string{
+		"good.info.json":    `{"_type": "video", "id": "good"}`,
+		"bad.info.json":     `{"_type": "video", "id": "bad"}`,
+		"partial.info.json": `{"_type": "video", "id": "par`,
+		"other.json":        `{}`,
+	} {
+		if err := os.WriteFile(filepath.Join(watched, name), []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events := make(chan InfoJSONEvent, 10)
+	done := make(chan error, 1)
+
+	go func() {
+		done <- WatchInfoJSONDir(ctx, watched, 10*time.Millisecond, func() *Command {
+			return New().SetExecutable(bin)
+		}, func(event InfoJSONEvent) {
+			events <- event
+		})
+	}()
+
+	received := map[string]InfoJSONEvent{}
+
+	for len(received) < 2 {
+		select {
+		case event := <-events:
+			received[event.Info.ID] = event
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for events, got %v", received)
+		}
+	}
+
+	good := received["good"]
+	if good.Err != nil || good.Path != filepath.Join(watched, infoJSONDoneDir, "good.info.json") {
+		t.Fatalf("unexpected event for good file: %+v", good)
+	}
+
+	if expected := "--load-info-json " + filepath.Join(watched, "good.info.json"); strings.TrimSpace(good.Result.Stdout) != expected {
+		t.Fatalf("expected %q, got %q", expected, good.Result.Stdout)
+	}
+
+	bad := received["bad"]
+	if !IsExitCodeError(bad.Err) || bad.Path != filepath.Join(watched, infoJSONFailedDir, "bad.info.json") {
+		t.Fatalf("unexpected event for bad file: %+v", bad)
+	}
+
+	for _, path := range []string{good.Path, bad.Path, filepath.Join(watched, "partial.info.json"), filepath.Join(watched, "other.json")} {
+		if _, err := os.Stat(path); err != nil {
+			t.Fatalf("expected %q to exist: %v", path, err)
+		}
+	}
+
+	// Completing the partial file processes it.
+	if err := os.WriteFile(filepath.Join(watched, "partial.info.json"), []byte(`{"_type": "video", "id": "partial"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case event := <-events:
+		if event.Info.ID != "partial" || event.Err != nil {
+			t.Fatalf("unexpected event for partial file: %+v", event)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for partial file")
+	}
+
+	cancel()
+
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context canceled, got %v", err)
+	}
+}
+
+func TestWatchInfoJSONDir_MoveFailed(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	invocations := filepath.Join(dir, "invocations.txt")
+	bin := writeFakeExecutable(t, dir, "yt-dlp.sh", "#!/bin/sh\necho run >> "+invocations+"\n")
+
+	watched := filepath.Join(dir, "watched")
+	if err := os.Mkdir(watched, 0o750); err != nil {
+		t.Fatal(err)
+	}
+
+	// A file in place of the "done" directory prevents moving processed files.
+	for name, data := range map[string]string{
+		infoJSONDoneDir:  "",
+		"good.info.json": `{"_type": "video", "id": "good"}`,
+	} {
+		if err := os.WriteFile(filepath.Join(watched, name), []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	var events []InfoJSONEvent
+
+	err := WatchInfoJSONDir(ctx, watched, 10*time.Millisecond, func() *Command {
+		return New().SetExecutable(bin)
+	}, func(event InfoJSONEvent) {
+		events = append(events, event)
+	})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected deadline exceeded, got %v", err)
+	}
+
+	if len(events) != 1 || events[0].Err == nil || events[0].Path != filepath.Join(watched, "good.info.json") {
+		t.Fatalf("expected a single event with a move error, got %+v", events)
+	}
+
+	b, err := os.ReadFile(invocations)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n := strings.Count(string(b), "run"); n != 1 {
+		t.Fatalf("expected file to be downloaded once, got %d downloads", n)
+	}
+}
@@ -0,0 +1,277 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// WorkspaceIndexFile is the name of the index file stored within a workspace.
+const WorkspaceIndexFile = ".go-ytdlp-index.json"
+
+// indexTemplate is the --print-to-file template used to record downloaded files.
+const indexTemplate = "after_move:%(.{id,extractor,extractor_key,channel,uploader,playlist_id,webpage_url,original_url,format_id,filepath})j"
+
+// IndexEntry maps a downloaded file back to its source.
+type IndexEntry struct {
+	Path         string    `json:"path"` // Relative to the workspace, unless stored outside of it.
+	ID           string    `json:"id"`
+	Extractor    string    `json:"extractor"`
+	ExtractorKey string    `json:"extractor_key,omitempty"`
+	Channel      string    `json:"channel,omitempty"`
+	Uploader     string    `json:"uploader,omitempty"`
+	PlaylistID   string    `json:"playlist_id,omitempty"`
+	URL          string    `json:"url"`
+	FormatID     string    `json:"format_id,omitempty"`
+	Added        time.Time `json:"added"`
+}
+
+// indexRecord is a single line written by yt-dlp using indexTemplate.
+type indexRecord struct {
+	ID           string `json:"id"`
+	Extractor    string `json:"extractor"`
+	ExtractorKey string `json:"extractor_key"`
+	Channel      string `json:"channel"`
+	Uploader     string `json:"uploader"`
+	PlaylistID   string `json:"playlist_id"`
+	WebpageURL   string `json:"webpage_url"`
+	OriginalURL  string `json:"original_url"`
+	FormatID     string `json:"format_id"`
+	Filepath     string `json:"filepath"`
+}
+
+// Workspace is a directory which downloads are stored in, along with an index
+// (persisted as JSON within the directory, see [WorkspaceIndexFile]) which maps
+// downloaded files back to their source, so later operations (e.g. deletion or
+// re-downloading) can be performed. Workspaces are safe for concurrent use
+// within a single process.
+type Workspace struct {
+	dir string
+
+	mu    sync.RWMutex
+	index map[string]*IndexEntry // Keyed by IndexEntry.Path.
+}
+
+// OpenWorkspace opens (creating if necessary) the workspace at dir, loading any
+// existing index.
+func OpenWorkspace(dir string) (*Workspace, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("unable to resolve workspace dir: %w", err)
+	}
+
+	err = os.MkdirAll(dir, 0o750) //nolint:gomnd
+	if err != nil {
+		return nil, fmt.Errorf("unable to create workspace dir: %w", err)
+	}
+
+	w := &Workspace{dir: dir, index: make(map[string]*IndexEntry)}
+
+	b, err := os.ReadFile(filepath.Join(dir, WorkspaceIndexFile))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("unable to read workspace index: %w", err)
+	}
+
+	if len(b) > 0 {
+		var entries []*IndexEntry
+
+		if err = json.Unmarshal(b, &entries); err != nil {
+			return nil, fmt.Errorf("unable to parse workspace index: %w", err)
+		}
+
+		for _, e := range entries {
+			w.index[e.Path] = e
+		}
+	}
+
+	return w, nil
+}
+
+// Dir returns the absolute path to the workspace directory.
+func (w *Workspace) Dir() string {
+	return w.dir
+}
+
+// relPath returns the index key for the provided path, which may be absolute,
+// or relative to the workspace.
+func (w *Workspace) relPath(path string) string {
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(w.dir, path)
+	}
+
+	rel, err := filepath.Rel(w.dir, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return filepath.Clean(path)
+	}
+
+	return filepath.ToSlash(rel)
+}
+
+// contains returns true if path resolves to a location within the workspace
+// directory, including through symlinked parent directories.
+func (w *Workspace) contains(path string) bool {
+	if !isWithinDir(w.dir, path) {
+		return false
+	}
+
+	dir, err := filepath.EvalSymlinks(w.dir)
+	if err != nil {
+		return false
+	}
+
+	parent, err := filepath.EvalSymlinks(filepath.Dir(path))
+	if err != nil {
+		return errors.Is(err, os.ErrNotExist) // Nothing to resolve (or remove).
+	}
+
+	return isWithinDir(dir, filepath.Join(parent, filepath.Base(path)))
+}
+
+// isWithinDir returns true if path is lexically within dir (both absolute).
+func isWithinDir(dir, path string) bool {
+	rel, err := filepath.Rel(dir, filepath.Clean(path))
+	return err == nil && rel != "." && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// Path returns the absolute path for the provided index entry.
+func (w *Workspace) Path(entry *IndexEntry) string {
+	if filepath.IsAbs(entry.Path) {
+		return entry.Path
+	}
+
+	return filepath.Join(w.dir, filepath.FromSlash(entry.Path))
+}
+
+// Run invokes the provided command within the workspace (as the working directory,
+// so relative output templates are stored within it), recording all downloaded
+// files in the index. The command itself is not modified. The index is updated
+// even if the invocation fails, for any files that were downloaded.
+func (w *Workspace) Run(ctx context.Context, cmd *Command, args ...string) (*Result, error) {
+	result, records, err := runRecorded(ctx, cmd.clone().SetWorkDir(w.dir), []string{indexTemplate}, (*indexRecord).hasFile, args...)
+
+	entries := make([]*IndexEntry, 0, len(records))
+	for _, r := range records {
+		entries = append(entries, w.newEntry(r, w.dir))
+	}
+
+	if ierr := w.addEntries(entries...); ierr != nil && err == nil {
+		err = ierr
+	}
+
+	return result, err
+}
+
+// hasFile returns true if the record is of a downloaded file.
+func (r *indexRecord) hasFile() bool {
+	return r.Filepath != ""
+}
+
+// newEntry converts the record into an index entry, where relative file paths
+// are relative to dir.
+func (w *Workspace) newEntry(r *indexRecord, dir string) *IndexEntry {
+	path := r.Filepath
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+
+	return &IndexEntry{
+		Path:         w.relPath(path),
+		ID:           r.ID,
+		Extractor:    r.Extractor,
+		ExtractorKey: r.ExtractorKey,
+		Channel:      r.Channel,
+		Uploader:     r.Uploader,
+		PlaylistID:   r.PlaylistID,
+		URL:          firstNonEmpty(r.WebpageURL, r.OriginalURL),
+		FormatID:     r.FormatID,
+		Added:        time.Now(),
+	}
+}
+
+// addEntries adds the entries to the index, and saves it.
+func (w *Workspace) addEntries(entries ...*IndexEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, e := range entries {
+		w.index[e.Path] = e
+	}
+
+	return w.save()
+}
+
+// sortedEntries returns all index entries, sorted by path. w.mu must be held.
+func (w *Workspace) sortedEntries() []*IndexEntry {
+	entries := make([]*IndexEntry, 0, len(w.index))
+	for _, e := range w.index {
+		entries = append(entries, e)
+	}
+
+	slices.SortFunc(entries, func(a, b *IndexEntry) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+
+	return entries
+}
+
+// save writes the index to disk. w.mu must be held.
+func (w *Workspace) save() error {
+	b, err := json.MarshalIndent(w.sortedEntries(), "", "    ")
+	if err != nil {
+		return fmt.Errorf("unable to encode workspace index: %w", err)
+	}
+
+	if err = writeFileAtomic(filepath.Join(w.dir, WorkspaceIndexFile), b, 0o600); err != nil {
+		return fmt.Errorf("unable to write workspace index: %w", err)
+	}
+
+	return nil
+}
+
+// Lookup returns the index entry for the provided file path, which may be
+// absolute, or relative to the workspace.
+func (w *Workspace) Lookup(path string) (*IndexEntry, bool) {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	e, ok := w.index[w.relPath(path)]
+	return e, ok
+}
+
+// Entries returns all index entries, sorted by path.
+func (w *Workspace) Entries() []*IndexEntry {
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+
+	return w.sortedEntries()
+}
+
+// Forget removes the entry for the provided file path from the index (without
+// removing the file itself).
+func (w *Workspace) Forget(path string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	key := w.relPath(path)
+	if _, ok := w.index[key]; !ok {
+		return nil
+	}
+
+	delete(w.index, key)
+	return w.save()
+}
@@ -0,0 +1,118 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// fakeIndexScript emulates yt-dlp downloading a single file (relative to the
+// working directory), writing the index record to the --print-to-file file.
+const fakeIndexScript = `#!/bin/sh
+while [ $# -gt 0 ]; do
+	case "$1" in
+		--print-to-file) pfile="$3"; shift; shift ;;
+	esac
+	shift
+done
+echo data > "video [abc].mp4"
+echo '{"id": "abc", "extractor": "youtube", "webpage_url": "https://www.youtube.com/watch?v=abc", "format_id": "137+140", "filepath": "video [abc].mp4"}' >> "$pfile"
+`
+
+func TestWorkspace_Lookup(t *testing.T) {
+	t.Parallel()
+
+	bin := writeFakeYtdlp(t, fakeIndexScript)
+
+	dir := filepath.Join(t.TempDir(), "downloads")
+
+	ws, err := OpenWorkspace(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = ws.Run(context.Background(), New().SetExecutable(bin), "https://www.youtube.com/watch?v=abc"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Re-open, to ensure the index was persisted.
+	ws, err = OpenWorkspace(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range []string{"video [abc].mp4", filepath.Join(dir, "video [abc].mp4")} {
+		entry, ok := ws.Lookup(path)
+		if !ok {
+			t.Fatalf("expected %q to be in the index", path)
+		}
+
+		if entry.ID != "abc" || entry.Extractor != "youtube" || entry.URL != "https://www.youtube.com/watch?v=abc" || entry.FormatID != "137+140" {
+			t.Fatalf("unexpected entry: %+v", entry)
+		}
+
+		if ws.Path(entry) != filepath.Join(dir, "video [abc].mp4") {
+			t.Fatalf("unexpected entry path: %q", ws.Path(entry))
+		}
+	}
+
+	if err = ws.Forget("video [abc].mp4"); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(ws.Entries()) != 0 {
+		t.Fatal("expected entry to be removed from the index")
+	}
+}
+
+func TestWriteFileAtomic_Umask(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("file permissions aren't supported on windows")
+	}
+
+	dir := t.TempDir()
+
+	// Reference file, which has the process umask applied by the OS.
+	ref, err := os.OpenFile(filepath.Join(dir, "ref"), os.O_CREATE|os.O_WRONLY, 0o666)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = ref.Close()
+
+	path := filepath.Join(dir, "file")
+
+	if err = writeFileAtomic(path, []byte("foo"), 0o666); err != nil {
+		t.Fatal(err)
+	}
+
+	want, err := os.Stat(ref.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Mode().Perm() != want.Mode().Perm() {
+		t.Fatalf("expected mode %v, got %v", want.Mode().Perm(), got.Mode().Perm())
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(entries) != 2 {
+		t.Fatalf("expected temporary file to be renamed, got %d entries", len(entries))
+	}
+}
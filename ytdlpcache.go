@@ -0,0 +1,152 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+)
+
+// ytdlpCacheDir is the directory within [CacheDir] used by the default
+// [YtdlpCache].
+const ytdlpCacheDir = "yt-dlp-cache"
+
+// ytdlpCache is the process-wide yt-dlp cache, see [SetYtdlpCache]. Unset (or
+// nil) means the global cache of yt-dlp.
+var ytdlpCache atomic.Pointer[YtdlpCache]
+
+// YtdlpCache is a directory used by yt-dlp (through --cache-dir) to store
+// downloaded information permanently, such as client IDs and signatures. This is
+// separate from the cache of executables managed by [Install].
+//
+// By default, commands use the global cache of yt-dlp (e.g.
+// "$XDG_CACHE_HOME/yt-dlp"). Use [SetYtdlpCache] (e.g. with
+// NewYtdlpCache("")) to have all commands use a library-managed cache within
+// [CacheDir] instead, so the cache is isolated from other applications.
+type YtdlpCache struct {
+	dir string
+}
+
+// NewYtdlpCache returns a yt-dlp cache using the provided directory, e.g. within
+// [ScopedCacheDir]. If dir is empty, the default directory within [CacheDir] is
+// used.
+func NewYtdlpCache(dir string) *YtdlpCache {
+	if dir != "" {
+		dir = filepath.Clean(dir)
+	}
+
+	return &YtdlpCache{dir: dir}
+}
+
+// SetYtdlpCache sets the yt-dlp cache used by all commands (by passing
+// --cache-dir), unless they set one with [Command.CacheDir] or
+// [Command.NoCacheDir]. Pass nil to use the global cache of yt-dlp (the
+// default).
+func SetYtdlpCache(cache *YtdlpCache) {
+	ytdlpCache.Store(cache)
+}
+
+// currentYtdlpCache returns the yt-dlp cache to use for commands, which may be
+// nil.
+func currentYtdlpCache() *YtdlpCache {
+	return ytdlpCache.Load()
+}
+
+// Dir returns the directory of the cache. It may not exist yet, as yt-dlp
+// creates it when necessary.
+func (yc *YtdlpCache) Dir() (string, error) {
+	if yc.dir != "" {
+		return yc.dir, nil
+	}
+
+	dir, err := CacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, ytdlpCacheDir), nil
+}
+
+// Size returns the total size of all files in the cache, in bytes.
+func (yc *YtdlpCache) Size() (int64, error) {
+	dir, err := yc.Dir()
+	if err != nil {
+		return 0, err
+	}
+
+	var size int64
+
+	err = filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		size += info.Size()
+		return nil
+	})
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return 0, fmt.Errorf("unable to get yt-dlp cache size: %w", err)
+	}
+
+	return size, nil
+}
+
+// Clear removes all files in the cache. It shouldn't be called while commands
+// using the cache are running.
+func (yc *YtdlpCache) Clear() error {
+	dir, err := yc.Dir()
+	if err != nil {
+		return err
+	}
+
+	if err = os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("unable to clear yt-dlp cache: %w", err)
+	}
+
+	return nil
+}
+
+// ytdlpCacheFlag returns the flag which sets the cache directory of yt-dlp to
+// the current [YtdlpCache], or nil if flags already configure it, or no cache
+// is set.
+func ytdlpCacheFlag(flags []*Flag) *Flag {
+	for _, f := range flags {
+		if f.ID == "cachedir" || (f.ID == rawFlagID && isCacheDirFlag(f.Flag)) {
+			return nil
+		}
+	}
+
+	cache := currentYtdlpCache()
+	if cache == nil {
+		return nil
+	}
+
+	dir, err := cache.Dir()
+	if err != nil {
+		return nil // Left to yt-dlp.
+	}
+
+	return &Flag{ID: "cachedir", Flag: "--cache-dir", Args: []string{dir}}
+}
+
+// isCacheDirFlag returns true if the raw flag configures the cache directory.
+func isCacheDirFlag(flag string) bool {
+	name, _, _ := strings.Cut(flag, "=")
+	return name == "--cache-dir" || name == "--no-cache-dir"
+}
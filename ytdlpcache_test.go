@@ -0,0 +1,86 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlp
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestYtdlpCache(t *testing.T) { //nolint:paralleltest
+	dir := t.TempDir()
+	t.Setenv(CacheDirEnv, dir)
+
+	// The global cache of yt-dlp is used by default.
+	args := New().SetExecutable("yt-dlp").NoProgress().buildCommand(context.Background(), "https://example.com").Args[1:]
+	if !slices.Equal(args, []string{"--no-progress", "https://example.com"}) {
+		t.Fatalf("expected no cache dir by default: %q", args)
+	}
+
+	SetYtdlpCache(NewYtdlpCache(""))
+	t.Cleanup(func() { SetYtdlpCache(nil) })
+
+	cache := currentYtdlpCache()
+
+	cacheDir, err := cache.Dir()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cacheDir != filepath.Join(dir, ytdlpCacheDir) {
+		t.Fatalf("unexpected default cache dir: %q", cacheDir)
+	}
+
+	if size, err := cache.Size(); err != nil || size != 0 {
+		t.Fatalf("expected empty cache before creation: %d, %v", size, err)
+	}
+
+	if err = os.MkdirAll(filepath.Join(cacheDir, "youtube-sigfuncs"), 0o750); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, data := range map[string]string{"a.json": "12345", "youtube-sigfuncs/b.json": "123"} {
+		if err = os.WriteFile(filepath.Join(cacheDir, name), []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if size, err := cache.Size(); err != nil || size != 8 {
+		t.Fatalf("expected cache size of 8: %d, %v", size, err)
+	}
+
+	args = New().SetExecutable("yt-dlp").NoProgress().buildCommand(context.Background(), "https://example.com").Args[1:]
+	if !slices.Equal(args, []string{"--no-progress", "--cache-dir", cacheDir, "https://example.com"}) {
+		t.Fatalf("expected managed cache dir to be used: %q", args)
+	}
+
+	args = New().SetExecutable("yt-dlp").NoCacheDir().buildCommand(context.Background()).Args[1:]
+	if !slices.Equal(args, []string{"--no-cache-dir"}) {
+		t.Fatalf("expected explicit cache flags to take precedence: %q", args)
+	}
+
+	args = New().SetExecutable("yt-dlp").AddRawFlags("--cache-dir=/other").buildCommand(context.Background()).Args[1:]
+	if !slices.Equal(args, []string{"--cache-dir=/other"}) {
+		t.Fatalf("expected raw cache flags to take precedence: %q", args)
+	}
+
+	if err = cache.Clear(); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = os.Stat(cacheDir); !os.IsNotExist(err) {
+		t.Fatalf("expected cache to be removed: %v", err)
+	}
+
+	SetYtdlpCache(nil)
+
+	args = New().SetExecutable("yt-dlp").buildCommand(context.Background()).Args[1:]
+	if len(args) != 0 {
+		t.Fatalf("expected no cache dir when disabled: %q", args)
+	}
+}
@@ -0,0 +1,268 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+// Package ytdlptest contains helpers for testing applications which use go-ytdlp,
+// without external network access.
+package ytdlptest
+
+import (
+	"bytes"
+	"math/rand"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// MediaFile is a file served by a [MediaServer].
+type MediaFile struct {
+	// Name is the name of the file, which is served at "/<Name>".
+	Name string
+
+	// ContentType is the content type of the file. If empty, it's detected from
+	// the extension of the name, or the data.
+	ContentType string
+
+	// Data is the content of the file.
+	Data []byte
+}
+
+// SampleFiles returns sample media files (video, audio, subtitles and a
+// thumbnail), which yt-dlp downloads as direct links. The content is
+// deterministic, but isn't playable media, so post-processing which requires
+// ffmpeg (e.g. merging or remuxing) won't work.
+func SampleFiles() []*MediaFile {
+	rng := rand.New(rand.NewSource(1)) //nolint:gosec
+
+	random := func(n int) []byte {
+		b := make([]byte, n)
+		_, _ = rng.Read(b)
+		return b
+	}
+
+	return []*MediaFile{
+		{Name: "video.mp4", ContentType: "video/mp4", Data: random(512 * 1024)},
+		{Name: "audio.m4a", ContentType: "audio/mp4", Data: random(128 * 1024)},
+		{Name: "subtitles.vtt", ContentType: "text/vtt", Data: []byte("WEBVTT\n\n00:00.000 --> 00:01.000\nHello world\n")},
+		{Name: "thumbnail.jpg", ContentType: "image/jpeg", Data: random(16 * 1024)},
+	}
+}
+
+// MediaServerOptions are the options for [NewMediaServer].
+type MediaServerOptions struct {
+	// Files are the files to serve. Defaults to [SampleFiles].
+	Files []*MediaFile
+
+	// Latency is the delay before each response is sent.
+	Latency time.Duration
+
+	// Throughput limits the rate each response body is sent at, in bytes per
+	// second. 0 means unlimited.
+	Throughput int64
+}
+
+// Fault is a failure injected into responses of a [MediaServer], see
+// [MediaServer.InjectFault].
+type Fault struct {
+	// Status responds with the status code, rather than the file.
+	Status int
+
+	// DisconnectAfter closes the connection after sending the provided number of
+	// bytes of the response body, simulating a mid-stream disconnect.
+	DisconnectAfter int64
+
+	// Count is the number of requests the fault applies to, after which it's
+	// removed. 0 means all requests.
+	Count int
+}
+
+// MediaRequest is a request received by a [MediaServer].
+type MediaRequest struct {
+	Path    string // Path of the request, e.g. "/video.mp4".
+	Range   string // Range header of the request, if any.
+	Faulted bool   // Whether a fault was injected into the response.
+}
+
+// MediaServer is an HTTP server which serves media files (with support for range
+// requests, so downloads can be resumed), with optional latency and throughput
+// shaping, and failure injection, for testing downloads without external network
+// access.
+type MediaServer struct {
+	*httptest.Server
+
+	opts  MediaServerOptions
+	files map[string]*MediaFile
+
+	mu       sync.Mutex
+	faults   map[string]*Fault
+	requests []MediaRequest
+}
+
+// NewMediaServer starts a new media server, which is closed when the test
+// completes. opts may be nil to use the defaults.
+func NewMediaServer(tb testing.TB, opts *MediaServerOptions) *MediaServer {
+	tb.Helper()
+
+	s := &MediaServer{
+		files:  make(map[string]*MediaFile),
+		faults: make(map[string]*Fault),
+	}
+
+	if opts != nil {
+		s.opts = *opts
+	}
+
+	if s.opts.Files == nil {
+		s.opts.Files = SampleFiles()
+	}
+
+	for _, f := range s.opts.Files {
+		s.files[strings.TrimPrefix(f.Name, "/")] = f
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	tb.Cleanup(s.Close)
+
+	return s
+}
+
+// FileURL returns the URL of the file with the provided name.
+func (s *MediaServer) FileURL(name string) string {
+	return s.URL + "/" + strings.TrimPrefix(name, "/")
+}
+
+// InjectFault injects the fault into responses for the file with the provided
+// name, replacing any previously injected fault for the file.
+func (s *MediaServer) InjectFault(name string, fault Fault) {
+	s.mu.Lock()
+	s.faults[strings.TrimPrefix(name, "/")] = &fault
+	s.mu.Unlock()
+}
+
+// ClearFaults removes all injected faults.
+func (s *MediaServer) ClearFaults() {
+	s.mu.Lock()
+	clear(s.faults)
+	s.mu.Unlock()
+}
+
+// Requests returns all requests received by the server, in order.
+func (s *MediaServer) Requests() []MediaRequest {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]MediaRequest(nil), s.requests...)
+}
+
+// fault returns the fault to inject into the response for the file, if any.
+func (s *MediaServer) fault(r *http.Request, name string) *Fault {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var fault *Fault
+
+	if f, ok := s.faults[name]; ok {
+		fc := *f
+		fault = &fc
+
+		if f.Count > 0 {
+			if f.Count--; f.Count == 0 {
+				delete(s.faults, name)
+			}
+		}
+	}
+
+	s.requests = append(s.requests, MediaRequest{Path: r.URL.Path, Range: r.Header.Get("Range"), Faulted: fault != nil})
+
+	return fault
+}
+
+func (s *MediaServer) serve(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, "/")
+	fault := s.fault(r, name)
+
+	if s.opts.Latency > 0 {
+		select {
+		case <-time.After(s.opts.Latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	file, ok := s.files[name]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	if fault != nil && fault.Status != 0 {
+		w.WriteHeader(fault.Status)
+		return
+	}
+
+	sw := &shapedWriter{ResponseWriter: w, throughput: s.opts.Throughput, remaining: -1}
+	if fault != nil && fault.DisconnectAfter > 0 {
+		sw.remaining = fault.DisconnectAfter
+	}
+
+	if file.ContentType != "" {
+		w.Header().Set("Content-Type", file.ContentType)
+	}
+
+	http.ServeContent(sw, r, name, time.Time{}, bytes.NewReader(file.Data))
+}
+
+// shapedWriter limits the rate of writes, and aborts the connection once
+// remaining bytes have been written (if not negative).
+type shapedWriter struct {
+	http.ResponseWriter
+	throughput int64
+	remaining  int64
+}
+
+func (w *shapedWriter) Write(p []byte) (n int, err error) {
+	for len(p) > 0 {
+		chunk := len(p)
+
+		if w.throughput > 0 {
+			chunk = min(chunk, int(max(w.throughput/20, 1))) //nolint:gomnd
+		}
+
+		if w.remaining >= 0 {
+			chunk = min(chunk, int(w.remaining))
+		}
+
+		if chunk > 0 {
+			var wn int
+
+			wn, err = w.ResponseWriter.Write(p[:chunk])
+			n += wn
+			if err != nil {
+				return n, err
+			}
+
+			p = p[chunk:]
+		}
+
+		if w.remaining >= 0 {
+			w.remaining -= int64(chunk)
+
+			if w.remaining == 0 {
+				if f, ok := w.ResponseWriter.(http.Flusher); ok {
+					f.Flush()
+				}
+
+				panic(http.ErrAbortHandler) // Closes the connection, without completing the response.
+			}
+		}
+
+		if w.throughput > 0 {
+			time.Sleep(time.Duration(int64(chunk) * int64(time.Second) / w.throughput))
+		}
+	}
+
+	return n, nil
+}
@@ -0,0 +1,126 @@
+// Copyright (c) Liam Stanley <liam@liam.sh>. All rights reserved. Use of
+// this source code is governed by the MIT license that can be found in
+// the LICENSE file.
+
+package ytdlptest
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func get(t *testing.T, url, rng string) (*http.Response, []byte, error) {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodGet, url, http.NoBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if rng != "" {
+		req.Header.Set("Range", rng)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	b, err := io.ReadAll(resp.Body)
+	return resp, b, err
+}
+
+func TestMediaServer(t *testing.T) {
+	t.Parallel()
+
+	srv := NewMediaServer(t, nil)
+	files := SampleFiles()
+
+	resp, b, err := get(t, srv.FileURL("video.mp4"), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.Header.Get("Content-Type") != "video/mp4" || !bytes.Equal(b, files[0].Data) {
+		t.Fatalf("unexpected response: %s (%d bytes)", resp.Header.Get("Content-Type"), len(b))
+	}
+
+	resp, b, err = get(t, srv.FileURL("video.mp4"), "bytes=100-")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.StatusCode != http.StatusPartialContent || !bytes.Equal(b, files[0].Data[100:]) {
+		t.Fatalf("unexpected range response: %s (%d bytes)", resp.Status, len(b))
+	}
+
+	if resp, _, _ = get(t, srv.FileURL("missing.mp4"), ""); resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected not found, got %s", resp.Status)
+	}
+
+	requests := srv.Requests()
+	if len(requests) != 3 || requests[1].Path != "/video.mp4" || requests[1].Range != "bytes=100-" {
+		t.Fatalf("unexpected requests: %+v", requests)
+	}
+}
+
+func TestMediaServer_InjectFault(t *testing.T) {
+	t.Parallel()
+
+	srv := NewMediaServer(t, nil)
+
+	srv.InjectFault("audio.m4a", Fault{DisconnectAfter: 1000, Count: 1})
+
+	_, b, err := get(t, srv.FileURL("audio.m4a"), "")
+	if err == nil || len(b) != 1000 {
+		t.Fatalf("expected disconnect after 1000 bytes, got %d bytes: %v", len(b), err)
+	}
+
+	if _, _, err = get(t, srv.FileURL("audio.m4a"), ""); err != nil {
+		t.Fatalf("expected fault to be removed after 1 request: %v", err)
+	}
+
+	srv.InjectFault("audio.m4a", Fault{Status: http.StatusTooManyRequests})
+
+	for range 2 {
+		if resp, _, _ := get(t, srv.FileURL("audio.m4a"), ""); resp.StatusCode != http.StatusTooManyRequests {
+			t.Fatalf("expected injected status, got %s", resp.Status)
+		}
+	}
+
+	srv.ClearFaults()
+
+	if resp, _, _ := get(t, srv.FileURL("audio.m4a"), ""); resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected faults to be cleared, got %s", resp.Status)
+	}
+
+	if requests := srv.Requests(); len(requests) != 5 || !requests[0].Faulted || requests[1].Faulted {
+		t.Fatalf("unexpected requests: %+v", requests)
+	}
+}
+
+func TestMediaServer_Shaping(t *testing.T) {
+	t.Parallel()
+
+	srv := NewMediaServer(t, &MediaServerOptions{
+		Files:      []*MediaFile{{Name: "clip.webm", Data: make([]byte, 10*1024)}},
+		Latency:    50 * time.Millisecond,
+		Throughput: 40 * 1024,
+	})
+
+	started := time.Now()
+
+	_, b, err := get(t, srv.FileURL("clip.webm"), "")
+	if err != nil || len(b) != 10*1024 {
+		t.Fatalf("unexpected response: %d bytes: %v", len(b), err)
+	}
+
+	// 50ms latency, and ~250ms to send 10KiB at 40KiB/s.
+	if elapsed := time.Since(started); elapsed < 250*time.Millisecond {
+		t.Fatalf("expected response to be shaped, took %s", elapsed)
+	}
+}